@@ -0,0 +1,203 @@
+package workerpool
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// adaptiveSmoothing weights the newest run in a strategy's throughput average
+const adaptiveSmoothing = 0.3
+
+// StrategyPerformance is what the Adaptive strategy has learned about one
+// strategy on this pool's workloads
+type StrategyPerformance struct {
+	Runs       int     `json:"runs"`
+	Jobs       int     `json:"jobs"`
+	Throughput float64 `json:"throughput"` // Jobs per second, smoothed over runs
+}
+
+// AdaptiveStats reports the Adaptive strategy's decisions and what it has
+// learned so far
+type AdaptiveStats struct {
+	Decisions   int                            `json:"decisions"`   // Runs dispatched by Adaptive
+	Switches    int                            `json:"switches"`    // Runs moved to another strategy midway
+	LastChoice  string                         `json:"last_choice"` // Strategy chosen for the latest run
+	LastReason  string                         `json:"last_reason"` // Why it was chosen
+	Performance map[string]StrategyPerformance `json:"performance"` // Learned performance by strategy name
+}
+
+// AdaptiveState is the learned performance of the Adaptive strategy. It can
+// be saved, e.g. as JSON, and restored into a new pool with
+// WithAdaptiveState so learning carries over between processes.
+type AdaptiveState struct {
+	Performance map[string]StrategyPerformance `json:"performance"`
+}
+
+// adaptiveLearner picks strategies for Adaptive runs from measured throughput
+type adaptiveLearner struct {
+	perf       map[DistributionStrategy]StrategyPerformance
+	decisions  int
+	switches   int
+	lastChoice DistributionStrategy
+	lastReason string
+	mu         sync.Mutex
+}
+
+// adaptiveCandidates are the strategies Adaptive chooses between. Strategies
+// that change job order, like PriorityBased, are only used when the workload
+// calls for them.
+var adaptiveCandidates = []DistributionStrategy{RoundRobin, Chunked, WorkStealing}
+
+// choose returns the strategy for the next run. Workloads dominated by high
+// priorities always use PriorityBased. Otherwise every candidate is tried
+// once, starting with the heuristic's suggestion, and then the one with the
+// best measured throughput is used.
+func (l *adaptiveLearner) choose(suggested DistributionStrategy) DistributionStrategy {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.decisions++
+	choice, reason := suggested, "priority-heavy workload"
+	if suggested != PriorityBased {
+		choice, reason = l.pickLocked(suggested)
+	}
+	l.lastChoice, l.lastReason = choice, reason
+	return choice
+}
+
+// pickLocked explores untried candidates, then exploits the fastest. Callers must hold l.mu.
+func (l *adaptiveLearner) pickLocked(suggested DistributionStrategy) (DistributionStrategy, string) {
+	if _, tried := l.perf[suggested]; !tried {
+		return suggested, "suggested by workload shape, not yet measured"
+	}
+	for _, s := range adaptiveCandidates {
+		if _, tried := l.perf[s]; !tried {
+			return s, "exploring, not yet measured"
+		}
+	}
+
+	best := suggested
+	for _, s := range adaptiveCandidates {
+		if l.perf[s].Throughput > l.perf[best].Throughput {
+			best = s
+		}
+	}
+	return best, "highest measured throughput"
+}
+
+// observe records how a run dispatched with strategy performed
+func (l *adaptiveLearner) observe(strategy DistributionStrategy, jobs int, elapsed time.Duration) {
+	if jobs == 0 || elapsed <= 0 {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.perf == nil {
+		l.perf = make(map[DistributionStrategy]StrategyPerformance)
+	}
+	throughput := float64(jobs) / elapsed.Seconds()
+	p, seen := l.perf[strategy]
+	if seen {
+		p.Throughput += adaptiveSmoothing * (throughput - p.Throughput)
+	} else {
+		p.Throughput = throughput
+	}
+	p.Runs++
+	p.Jobs += jobs
+	l.perf[strategy] = p
+}
+
+// switched records that the latest run changed strategy midway
+func (l *adaptiveLearner) switched(reason string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.switches++
+	l.lastReason = reason
+}
+
+// stats returns a snapshot of the learner's decisions
+func (l *adaptiveLearner) stats() AdaptiveStats {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	s := AdaptiveStats{
+		Decisions:   l.decisions,
+		Switches:    l.switches,
+		LastReason:  l.lastReason,
+		Performance: l.performanceLocked(),
+	}
+	if l.decisions > 0 {
+		s.LastChoice = l.lastChoice.String()
+	}
+	return s
+}
+
+// performanceLocked returns learned performance keyed by strategy name. Callers must hold l.mu.
+func (l *adaptiveLearner) performanceLocked() map[string]StrategyPerformance {
+	if len(l.perf) == 0 {
+		return nil
+	}
+	perf := make(map[string]StrategyPerformance, len(l.perf))
+	for s, p := range l.perf {
+		perf[s.String()] = p
+	}
+	return perf
+}
+
+// AdaptiveState returns what the Adaptive strategy has learned, for saving
+func (wp *WorkerPool[T, R]) AdaptiveState() AdaptiveState {
+	wp.adaptive.mu.Lock()
+	defer wp.adaptive.mu.Unlock()
+	return AdaptiveState{Performance: wp.adaptive.performanceLocked()}
+}
+
+// WithAdaptiveState restores learned performance saved with AdaptiveState.
+// Entries for unknown strategy names are ignored.
+func (wp *WorkerPool[T, R]) WithAdaptiveState(state AdaptiveState) *WorkerPool[T, R] {
+	wp.adaptive.mu.Lock()
+	defer wp.adaptive.mu.Unlock()
+
+	wp.adaptive.perf = make(map[DistributionStrategy]StrategyPerformance)
+	for name, p := range state.Performance {
+		for s := RoundRobin; s <= FairShare; s++ {
+			if s.String() == name {
+				wp.adaptive.perf[s] = p
+			}
+		}
+	}
+	return wp
+}
+
+// runChunkedThenSteal starts a run chunked, which has the least dispatch
+// overhead, and moves the jobs not started yet to work stealing as soon as
+// stragglers leave workers idle. Jobs dispatched after the switch report
+// "adaptive/work_stealing" as their Result.Strategy.
+func (wp *WorkerPool[T, R]) runChunkedThenSteal(ctx context.Context, jobs []Job[T]) error {
+	unstarted := wp.runChunkedPhase(withStrategy(ctx, Chunked), jobs, true)
+	if len(unstarted) > 0 && ctx.Err() == nil {
+		wp.adaptive.switched("stragglers in chunked phase, switched to work_stealing")
+		return wp.runWorkStealing(ctx, unstarted)
+	}
+
+	wp.closeResults()
+	if ctx.Err() != nil {
+		return cancellationError(ctx)
+	}
+	return nil
+}
+
+// strategyNamed maps a workload analysis result to its strategy
+func strategyNamed(name string) DistributionStrategy {
+	switch name {
+	case "priority_based":
+		return PriorityBased
+	case "chunked":
+		return Chunked
+	case "work_stealing":
+		return WorkStealing
+	default:
+		return RoundRobin
+	}
+}
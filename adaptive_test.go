@@ -0,0 +1,142 @@
+package workerpool
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+func (ts *WorkerPoolTestSuite) TestAdaptiveLearnsAcrossRuns() {
+	config := DefaultConfig()
+	config.NumWorkers = 2
+	config.Strategy = Adaptive
+	pool := NewWithConfig[int, int](config)
+	pool.WithProcessor(func(ctx context.Context, job Job[int]) (int, error) {
+		return job.Data, nil
+	})
+
+	var jobs []Job[int]
+	for i := 0; i < 8; i++ {
+		jobs = append(jobs, Job[int]{ID: fmt.Sprintf("%d", i), Data: i})
+	}
+	pool.AddJobs(jobs)
+
+	// Every candidate is measured once, the suggested one first
+	chosen := make(map[string]bool)
+	for run := 0; run < 3; run++ {
+		_, err := pool.Run()
+		ts.NoError(err)
+		chosen[pool.GetMetrics().Adaptive.LastChoice] = true
+	}
+	ts.Equal(map[string]bool{"round_robin": true, "chunked": true, "work_stealing": true}, chosen)
+
+	// The choice is made from what was learned before the run
+	learned := pool.GetMetrics().Adaptive.Performance
+	ts.Len(learned, 3)
+	_, err := pool.Run()
+	ts.NoError(err)
+	stats := pool.GetMetrics().Adaptive
+	ts.Equal(4, stats.Decisions)
+	ts.Equal("highest measured throughput", stats.LastReason)
+	for _, p := range learned {
+		ts.LessOrEqual(p.Throughput, learned[stats.LastChoice].Throughput)
+	}
+}
+
+func (ts *WorkerPoolTestSuite) TestAdaptiveExploitsFastestStrategy() {
+	var l adaptiveLearner
+	l.observe(RoundRobin, 100, time.Second)
+	l.observe(Chunked, 100, 500*time.Millisecond)
+	l.observe(WorkStealing, 100, 2*time.Second)
+	ts.Equal(Chunked, l.choose(RoundRobin))
+
+	// Priority-heavy workloads keep their ordering guarantees
+	ts.Equal(PriorityBased, l.choose(PriorityBased))
+	ts.Equal("priority-heavy workload", l.stats().LastReason)
+
+	// Throughput is smoothed rather than replaced
+	l.observe(Chunked, 100, 10*time.Second)
+	ts.InDelta(143, l.stats().Performance["chunked"].Throughput, 1)
+}
+
+func (ts *WorkerPoolTestSuite) TestAdaptiveStateRoundTrip() {
+	source := New[int, int]()
+	source.adaptive.observe(WorkStealing, 50, time.Second)
+	data, err := json.Marshal(source.AdaptiveState())
+	ts.NoError(err)
+
+	var state AdaptiveState
+	ts.NoError(json.Unmarshal(data, &state))
+	state.Performance["bogus"] = StrategyPerformance{Runs: 1}
+
+	target := New[int, int]().WithAdaptiveState(state)
+	ts.Equal(map[string]StrategyPerformance{
+		"work_stealing": {Runs: 1, Jobs: 50, Throughput: 50},
+	}, target.AdaptiveState().Performance)
+}
+
+func (ts *WorkerPoolTestSuite) TestAdaptiveSwitchesToStealingOnStragglers() {
+	config := DefaultConfig()
+	config.NumWorkers = 2
+	config.Strategy = Adaptive
+	config.StrategyOptions.AdaptiveSwitching = true
+	pool := NewWithConfig[int, int](config).WithAdaptiveState(AdaptiveState{
+		Performance: map[string]StrategyPerformance{
+			"round_robin":   {Runs: 1, Throughput: 10},
+			"chunked":       {Runs: 1, Throughput: 100},
+			"work_stealing": {Runs: 1, Throughput: 10},
+		},
+	})
+	pool.WithProcessor(func(ctx context.Context, job Job[int]) (int, error) {
+		if job.Data == 0 {
+			time.Sleep(100 * time.Millisecond) // Straggler holding up worker 0's chunk
+		}
+		return job.Data, nil
+	})
+
+	var jobs []Job[int]
+	for i := 0; i < 10; i++ {
+		jobs = append(jobs, Job[int]{ID: fmt.Sprintf("%d", i), Data: i})
+	}
+	pool.AddJobs(jobs)
+
+	results, err := pool.Run()
+	ts.NoError(err)
+	ts.Len(results, 10)
+
+	strategies := make(map[string]string)
+	for _, r := range results {
+		ts.NoError(r.Error)
+		strategies[r.JobID] = r.Strategy
+	}
+	ts.Equal("adaptive/chunked", strategies["0"])
+	for _, id := range []string{"1", "2", "3", "4"} {
+		ts.Equal("adaptive/work_stealing", strategies[id], id)
+	}
+
+	stats := pool.GetMetrics().Adaptive
+	ts.Equal("chunked", stats.LastChoice)
+	ts.Equal(1, stats.Switches)
+	ts.Contains(stats.LastReason, "stragglers")
+}
+
+func (ts *WorkerPoolTestSuite) TestAdaptiveKeepsChunkedWithoutStragglers() {
+	config := DefaultConfig()
+	config.NumWorkers = 2
+	pool := NewWithConfig[int, int](config)
+	pool.WithProcessor(func(ctx context.Context, job Job[int]) (int, error) {
+		time.Sleep(5 * time.Millisecond)
+		return job.Data, nil
+	})
+
+	var jobs []Job[int]
+	for i := 0; i < 4; i++ {
+		jobs = append(jobs, Job[int]{ID: fmt.Sprintf("%d", i), Data: i})
+	}
+	pool.results = make(chan Result[int], len(jobs))
+	unstarted := pool.runChunkedPhase(context.Background(), jobs, true)
+	ts.Empty(unstarted)
+	close(pool.results)
+	ts.Len(pool.results, 4)
+}
@@ -0,0 +1,239 @@
+package workerpool
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// pauseIndefinitely is how long a pause without a duration lasts: until
+// it is resumed, in practice
+const pauseIndefinitely = 100 * 365 * 24 * time.Hour
+
+// AdminPool is what the admin API needs from a pool. Every *WorkerPool
+// implements it, whatever its job and result types.
+type AdminPool interface {
+	Name() string
+	Health() Health
+	GetMetrics() Metrics
+	PendingJobs() []JobSummary
+	FailedJobs() []JobSummary
+	Requeue(jobID string) error
+	ProgressHandler() http.Handler
+	SetBlackout(start, end time.Time)
+	ClearBlackout()
+	Reconfigure(delta ConfigDelta) error
+}
+
+// PoolStatus is one entry of the admin API's pool listing
+type PoolStatus struct {
+	Name    string   `json:"name"`
+	Health  Health   `json:"health"`
+	Metrics *Metrics `json:"metrics"`
+}
+
+// Admin serves a JSON API over registered pools for operators and the
+// workerpoolctl command:
+//
+//	GET  /pools                            list pools with health and metrics
+//	GET  /pools/{name}                     one pool's health and metrics
+//	GET  /pools/{name}/pending             jobs not started yet
+//	GET  /pools/{name}/failed              jobs whose last run failed
+//	POST /pools/{name}/failed/{id}/replay  requeue a failed job
+//	POST /pools/{name}/pause[?for=DUR]     stop starting jobs, for DUR or until resumed
+//	POST /pools/{name}/resume              end a pause; recurring blackouts stay
+//	POST /pools/{name}/resize?workers=N    set NumWorkers, from the next run
+//	GET  /pools/{name}/tail                live results as Server-Sent Events
+//
+// Mount it under a prefix with http.StripPrefix. Without WithAuth the API
+// is open to anyone who can reach it; only expose it on a trusted network.
+type Admin struct {
+	pools map[string]AdminPool
+	auth  []Authenticator
+	mu    sync.RWMutex
+}
+
+// NewAdmin creates an admin API with no pools
+func NewAdmin() *Admin {
+	return &Admin{pools: make(map[string]AdminPool)}
+}
+
+// Register adds a pool to the API under its Name, replacing any pool
+// registered under the same name
+func (a *Admin) Register(pool AdminPool) *Admin {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.pools[pool.Name()] = pool
+	return a
+}
+
+// Unregister removes the pool registered under name
+func (a *Admin) Unregister(name string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	delete(a.pools, name)
+}
+
+// ServeHTTP routes admin requests. Pool names and job IDs are path-escaped,
+// so IDs such as workflow "stage/id" ones can be addressed.
+func (a *Admin) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	// Reads need a read-only role and everything else an operator, checked
+	// before routing so unauthenticated callers learn nothing about pools
+	required := RoleOperator
+	if r.Method == http.MethodGet || r.Method == http.MethodHead {
+		required = RoleReadOnly
+	}
+	if !a.authorize(w, r, required) {
+		return
+	}
+
+	parts := strings.Split(strings.Trim(r.URL.EscapedPath(), "/"), "/")
+	for i, part := range parts {
+		unescaped, err := url.PathUnescape(part)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		parts[i] = unescaped
+	}
+	if parts[0] != "pools" {
+		http.NotFound(w, r)
+		return
+	}
+	if len(parts) == 1 {
+		if !allowMethod(w, r, http.MethodGet) {
+			return
+		}
+		writeJSON(w, http.StatusOK, a.statuses())
+		return
+	}
+
+	a.mu.RLock()
+	pool, ok := a.pools[parts[1]]
+	a.mu.RUnlock()
+	if !ok {
+		http.Error(w, "pool not found", http.StatusNotFound)
+		return
+	}
+
+	route := strings.Join(parts[2:], "/")
+	switch {
+	case len(parts) == 2:
+		if allowMethod(w, r, http.MethodGet) {
+			writeJSON(w, http.StatusOK, status(pool))
+		}
+	case route == "pending":
+		if allowMethod(w, r, http.MethodGet) {
+			writeJSON(w, http.StatusOK, pool.PendingJobs())
+		}
+	case route == "failed":
+		if allowMethod(w, r, http.MethodGet) {
+			writeJSON(w, http.StatusOK, pool.FailedJobs())
+		}
+	case route == "pause":
+		if !allowMethod(w, r, http.MethodPost) {
+			return
+		}
+		duration := pauseIndefinitely
+		if s := r.URL.Query().Get("for"); s != "" {
+			d, err := time.ParseDuration(s)
+			if err != nil || d <= 0 {
+				http.Error(w, "pause: for must be a positive duration", http.StatusBadRequest)
+				return
+			}
+			duration = d
+		}
+		now := time.Now()
+		pool.SetBlackout(now, now.Add(duration))
+		w.WriteHeader(http.StatusNoContent)
+	case route == "resume":
+		if allowMethod(w, r, http.MethodPost) {
+			pool.ClearBlackout()
+			w.WriteHeader(http.StatusNoContent)
+		}
+	case route == "resize":
+		if !allowMethod(w, r, http.MethodPost) {
+			return
+		}
+		workers, err := strconv.Atoi(r.URL.Query().Get("workers"))
+		if err != nil {
+			http.Error(w, "resize: workers must be a number", http.StatusBadRequest)
+			return
+		}
+		if err := pool.Reconfigure(ConfigDelta{NumWorkers: &workers}); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	case route == "tail":
+		if allowMethod(w, r, http.MethodGet) {
+			pool.ProgressHandler().ServeHTTP(w, r)
+		}
+	case len(parts) == 5 && parts[2] == "failed" && parts[4] == "replay":
+		if !allowMethod(w, r, http.MethodPost) {
+			return
+		}
+		if err := pool.Requeue(parts[3]); err != nil {
+			code := http.StatusConflict
+			if errors.Is(err, ErrJobNotFound) {
+				code = http.StatusNotFound
+			}
+			http.Error(w, err.Error(), code)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// statuses lists every registered pool, ordered by name
+func (a *Admin) statuses() []PoolStatus {
+	a.mu.RLock()
+	statuses := make([]PoolStatus, 0, len(a.pools))
+	for _, pool := range a.pools {
+		statuses = append(statuses, status(pool))
+	}
+	a.mu.RUnlock()
+
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].Name < statuses[j].Name })
+	return statuses
+}
+
+// status describes one pool
+func status(pool AdminPool) PoolStatus {
+	metrics := pool.GetMetrics()
+	return PoolStatus{Name: pool.Name(), Health: pool.Health(), Metrics: &metrics}
+}
+
+// allowMethod rejects requests with any other method
+func allowMethod(w http.ResponseWriter, r *http.Request, method string) bool {
+	if r.Method == method {
+		return true
+	}
+	w.Header().Set("Allow", method)
+	http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	return false
+}
+
+// writeJSON writes payload as the JSON response body. A payload that cannot
+// be encoded is answered with a 500; a failed write means the client is
+// gone, and is ignored.
+func writeJSON(w http.ResponseWriter, code int, payload any) {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	if _, err := w.Write(append(body, '\n')); err != nil {
+		return
+	}
+}
@@ -0,0 +1,135 @@
+package workerpool
+
+import (
+	"crypto/subtle"
+	"errors"
+	"net/http"
+	"strings"
+)
+
+// Role is what an authenticated caller of the admin API may do
+type Role int
+
+const (
+	RoleNone     Role = iota // Not authenticated
+	RoleReadOnly             // List and inspect pools and tail results
+	RoleOperator             // Also replay failed jobs
+)
+
+// String returns the role name
+func (r Role) String() string {
+	switch r {
+	case RoleReadOnly:
+		return "read-only"
+	case RoleOperator:
+		return "operator"
+	default:
+		return "none"
+	}
+}
+
+// Authenticator identifies the caller of an admin request. It returns
+// RoleNone when the request carries no credentials it recognizes, and an
+// error when it carries invalid ones.
+type Authenticator interface {
+	Authenticate(r *http.Request) (Role, error)
+}
+
+// AuthenticatorFunc adapts a function to Authenticator
+type AuthenticatorFunc func(r *http.Request) (Role, error)
+
+// Authenticate calls f
+func (f AuthenticatorFunc) Authenticate(r *http.Request) (Role, error) {
+	return f(r)
+}
+
+// errInvalidToken is reported for bearer tokens TokenAuth does not know
+var errInvalidToken = errors.New("invalid token")
+
+// TokenAuth authenticates "Authorization: Bearer" tokens, mapping each
+// token to its role. Tokens are compared in constant time.
+type TokenAuth map[string]Role
+
+// Authenticate returns the role of the request's bearer token
+func (a TokenAuth) Authenticate(r *http.Request) (Role, error) {
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok {
+		return RoleNone, nil
+	}
+	role := RoleNone
+	for known, granted := range a {
+		if subtle.ConstantTimeCompare([]byte(token), []byte(known)) == 1 {
+			role = granted
+		}
+	}
+	if role == RoleNone {
+		return RoleNone, errInvalidToken
+	}
+	return role, nil
+}
+
+// ClientCertAuth authenticates mTLS clients, mapping the common name or a
+// DNS name of the verified client certificate to a role. The server must
+// verify client certificates, e.g. with tls.RequireAndVerifyClientCert;
+// certificates that were not verified are ignored.
+type ClientCertAuth map[string]Role
+
+// Authenticate returns the role of the request's client certificate
+func (a ClientCertAuth) Authenticate(r *http.Request) (Role, error) {
+	if r.TLS == nil || len(r.TLS.VerifiedChains) == 0 || len(r.TLS.VerifiedChains[0]) == 0 {
+		return RoleNone, nil
+	}
+	cert := r.TLS.VerifiedChains[0][0]
+	role := a[cert.Subject.CommonName]
+	for _, name := range cert.DNSNames {
+		if a[name] > role {
+			role = a[name]
+		}
+	}
+	return role, nil
+}
+
+// WithAuth requires every admin request to be authenticated by one of auth,
+// tried in order until one recognizes the caller. GET requests require
+// RoleReadOnly and all others, such as replaying failed jobs, RoleOperator.
+// Unauthenticated requests get 401 and insufficient roles 403.
+func (a *Admin) WithAuth(auth ...Authenticator) *Admin {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.auth = auth
+	return a
+}
+
+// authorize checks the caller holds at least the required role, writing
+// the error response if not
+func (a *Admin) authorize(w http.ResponseWriter, r *http.Request, required Role) bool {
+	a.mu.RLock()
+	auth := a.auth
+	a.mu.RUnlock()
+	if len(auth) == 0 {
+		return true
+	}
+
+	role := RoleNone
+	for _, authenticator := range auth {
+		granted, err := authenticator.Authenticate(r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+			return false
+		}
+		if granted != RoleNone {
+			role = granted
+			break
+		}
+	}
+	switch {
+	case role == RoleNone:
+		w.Header().Set("WWW-Authenticate", `Bearer realm="workerpool"`)
+		http.Error(w, "authentication required", http.StatusUnauthorized)
+		return false
+	case role < required:
+		http.Error(w, "requires "+required.String()+" role", http.StatusForbidden)
+		return false
+	}
+	return true
+}
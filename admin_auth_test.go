@@ -0,0 +1,78 @@
+package workerpool
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"net/http"
+	"net/http/httptest"
+)
+
+func (ts *WorkerPoolTestSuite) TestAdminAuth() {
+	config := DefaultConfig()
+	config.Name = "ingest"
+	admin := NewAdmin().Register(NewWithConfig[int, int](config)).
+		WithAuth(TokenAuth{"viewer-token": RoleReadOnly, "ops-token": RoleOperator})
+
+	request := func(method, path, token string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, nil)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		rec := httptest.NewRecorder()
+		admin.ServeHTTP(rec, req)
+		return rec
+	}
+
+	rec := request(http.MethodGet, "/pools", "")
+	ts.Equal(http.StatusUnauthorized, rec.Code)
+	ts.NotEmpty(rec.Header().Get("WWW-Authenticate"))
+	ts.Equal(http.StatusUnauthorized, request(http.MethodGet, "/pools/missing", "").Code, "no pool enumeration before auth")
+	ts.Equal(http.StatusUnauthorized, request(http.MethodGet, "/pools", "guess").Code)
+
+	ts.Equal(http.StatusOK, request(http.MethodGet, "/pools", "viewer-token").Code)
+	ts.Equal(http.StatusOK, request(http.MethodGet, "/pools/ingest/failed", "viewer-token").Code)
+	rec = request(http.MethodPost, "/pools/ingest/failed/x/replay", "viewer-token")
+	ts.Equal(http.StatusForbidden, rec.Code)
+	ts.Contains(rec.Body.String(), "requires operator role")
+
+	ts.Equal(http.StatusNotFound, request(http.MethodPost, "/pools/ingest/failed/x/replay", "ops-token").Code)
+	ts.Equal(http.StatusOK, request(http.MethodGet, "/pools", "ops-token").Code)
+}
+
+func (ts *WorkerPoolTestSuite) TestClientCertAuth() {
+	auth := ClientCertAuth{"dashboard": RoleReadOnly, "oncall.example.com": RoleOperator}
+	withCert := func(cert *x509.Certificate, verified bool) *http.Request {
+		req := httptest.NewRequest(http.MethodGet, "/pools", nil)
+		req.TLS = &tls.ConnectionState{PeerCertificates: []*x509.Certificate{cert}}
+		if verified {
+			req.TLS.VerifiedChains = [][]*x509.Certificate{{cert}}
+		}
+		return req
+	}
+
+	role, err := auth.Authenticate(withCert(&x509.Certificate{Subject: pkix.Name{CommonName: "dashboard"}}, true))
+	ts.NoError(err)
+	ts.Equal(RoleReadOnly, role)
+
+	role, _ = auth.Authenticate(withCert(&x509.Certificate{
+		Subject:  pkix.Name{CommonName: "dashboard"},
+		DNSNames: []string{"oncall.example.com"},
+	}, true))
+	ts.Equal(RoleOperator, role)
+
+	role, _ = auth.Authenticate(withCert(&x509.Certificate{Subject: pkix.Name{CommonName: "dashboard"}}, false))
+	ts.Equal(RoleNone, role, "unverified certificates are ignored")
+	role, _ = auth.Authenticate(httptest.NewRequest(http.MethodGet, "/pools", nil))
+	ts.Equal(RoleNone, role)
+
+	// Certificates and tokens combine; the first authenticator that
+	// recognizes the caller decides
+	admin := NewAdmin().WithAuth(auth, TokenAuth{"t": RoleOperator})
+	rec := httptest.NewRecorder()
+	req := withCert(&x509.Certificate{Subject: pkix.Name{CommonName: "dashboard"}}, true)
+	req.Method = http.MethodPost
+	req.Header.Set("Authorization", "Bearer t")
+	admin.ServeHTTP(rec, req)
+	ts.Equal(http.StatusForbidden, rec.Code)
+}
@@ -0,0 +1,122 @@
+package workerpool
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"time"
+)
+
+func (ts *WorkerPoolTestSuite) TestAdminAPI() {
+	config := DefaultConfig()
+	config.Name = "ingest"
+	config.MaxRetries = 0
+	pool := NewWithConfig[int, int](config)
+	pool.WithProcessor(func(ctx context.Context, job Job[int]) (int, error) {
+		if job.Data < 0 {
+			return 0, errors.New("negative")
+		}
+		return job.Data, nil
+	})
+	pool.AddJobs([]Job[int]{{ID: "ok", Data: 1}, {ID: "load/7", Data: -1, Class: "load"}})
+	_, err := pool.Run()
+	ts.NoError(err)
+
+	server := httptest.NewServer(NewAdmin().Register(pool))
+	defer server.Close()
+
+	get := func(path string, v any) int {
+		resp, err := http.Get(server.URL + path)
+		ts.Require().NoError(err)
+		defer resp.Body.Close()
+		if v != nil && resp.StatusCode == http.StatusOK {
+			ts.NoError(json.NewDecoder(resp.Body).Decode(v))
+		}
+		return resp.StatusCode
+	}
+	post := func(path string) int {
+		resp, err := http.Post(server.URL+path, "", nil)
+		ts.Require().NoError(err)
+		resp.Body.Close()
+		return resp.StatusCode
+	}
+
+	var pools []PoolStatus
+	ts.Equal(http.StatusOK, get("/pools", &pools))
+	ts.Require().Len(pools, 1)
+	ts.Equal("ingest", pools[0].Name)
+	ts.Equal(4, pools[0].Health.Workers)
+	ts.Equal(1, pools[0].Metrics.ProcessedJobs)
+	ts.Equal(1, pools[0].Metrics.FailedJobs)
+
+	var failed []JobSummary
+	ts.Equal(http.StatusOK, get("/pools/ingest/failed", &failed))
+	ts.Require().Len(failed, 1)
+	ts.Equal("load/7", failed[0].ID)
+	ts.Equal("load", failed[0].Class)
+
+	// IDs containing slashes are addressed path-escaped
+	ts.Equal(http.StatusNoContent, post("/pools/ingest/failed/load%2F7/replay"))
+	ts.Equal(http.StatusNotFound, post("/pools/ingest/failed/load%2F7/replay"))
+	ts.Empty(pool.FailedJobs())
+
+	var pending []JobSummary
+	ts.Equal(http.StatusOK, get("/pools/ingest/pending", &pending))
+	ts.Len(pending, 2)
+
+	// Pausing blacks the pool out until it is resumed; a resume keeps the
+	// recurring maintenance windows
+	start := time.Duration((time.Now().UTC().Hour()+2)%22) * time.Hour
+	pool.AddRecurringBlackout(ExecutionWindow{Start: start, End: start + time.Hour})
+	ts.Equal(http.StatusNoContent, post("/pools/ingest/pause"))
+	var status PoolStatus
+	ts.Equal(http.StatusOK, get("/pools/ingest", &status))
+	ts.True(status.Health.Paused)
+	paused, _ := pool.InBlackout(time.Now().Add(24 * time.Hour))
+	ts.True(paused)
+	ts.Equal(http.StatusNoContent, post("/pools/ingest/resume"))
+	ts.False(pool.Health().Paused)
+	ts.Len(pool.blackouts.recurring, 1)
+
+	ts.Equal(http.StatusNoContent, post("/pools/ingest/pause?for=1h"))
+	paused, until := pool.InBlackout(time.Now())
+	ts.True(paused)
+	ts.WithinDuration(time.Now().Add(time.Hour), until, time.Minute)
+	ts.Equal(http.StatusBadRequest, post("/pools/ingest/pause?for=soon"))
+	ts.Equal(http.StatusMethodNotAllowed, get("/pools/ingest/resume", nil))
+	pool.ClearBlackouts()
+
+	ts.Equal(http.StatusNoContent, post("/pools/ingest/resize?workers=7"))
+	ts.Equal(7, pool.Health().Workers)
+	ts.Equal(http.StatusBadRequest, post("/pools/ingest/resize?workers=0"))
+	ts.Equal(http.StatusBadRequest, post("/pools/ingest/resize"))
+	ts.Equal(7, pool.Health().Workers)
+
+	ts.Equal(http.StatusNotFound, get("/pools/other", nil))
+	ts.Equal(http.StatusNotFound, get("/elsewhere", nil))
+	ts.Equal(http.StatusMethodNotAllowed, post("/pools/ingest"))
+	ts.Equal(http.StatusMethodNotAllowed, get("/pools/ingest/failed/ok/replay", nil))
+}
+
+func (ts *WorkerPoolTestSuite) TestMetricsJSONRoundTrip() {
+	pool := New[int, int]()
+	pool.WithProcessor(func(ctx context.Context, job Job[int]) (int, error) {
+		return 0, errors.New("boom")
+	})
+	pool.AddJob(Job[int]{ID: "a"})
+	_, err := pool.Run()
+	ts.NoError(err)
+
+	metrics := pool.GetMetrics()
+	data, err := json.Marshal(&metrics)
+	ts.NoError(err)
+
+	var decoded Metrics
+	ts.NoError(json.Unmarshal(data, &decoded))
+	ts.Equal(metrics.FailedJobs, decoded.FailedJobs)
+	ts.Equal(metrics.FailureCauses, decoded.FailureCauses)
+	ts.Equal(metrics.TotalDuration, decoded.TotalDuration)
+	ts.True(metrics.StartTime.Equal(decoded.StartTime))
+}
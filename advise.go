@@ -0,0 +1,133 @@
+package workerpool
+
+import (
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"sort"
+	"sync"
+	"time"
+)
+
+// durationHistorySize is how many recent job durations Advise draws on
+const durationHistorySize = 4096
+
+// Advice is a recommended configuration for finishing a batch in time
+type Advice struct {
+	NumWorkers int
+	BufferSize int
+	Strategy   DistributionStrategy
+	Predicted  time.Duration // Simulated completion time with the advice applied
+	Feasible   bool          // Whether Predicted meets the target
+	Reasons    []string      // How the advice was derived
+}
+
+// durationHistory keeps the durations of recently executed jobs across runs
+type durationHistory struct {
+	ring []time.Duration
+	next int
+	mu   sync.Mutex
+}
+
+// record adds the duration of a job that ran; skipped and expired jobs
+// say nothing about processing time and are ignored
+func (h *durationHistory) record(err error, d time.Duration) {
+	if isSkip(err) || errors.Is(err, ErrJobExpired) {
+		return
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if len(h.ring) < durationHistorySize {
+		h.ring = append(h.ring, d)
+		return
+	}
+	h.ring[h.next] = d
+	h.next = (h.next + 1) % durationHistorySize
+}
+
+// sorted returns the recorded durations in increasing order
+func (h *durationHistory) sorted() []time.Duration {
+	h.mu.Lock()
+	sorted := append([]time.Duration(nil), h.ring...)
+	h.mu.Unlock()
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	return sorted
+}
+
+// Advise recommends NumWorkers, BufferSize and Strategy for finishing the
+// queued jobs, or a batch the size of the last run when none are queued,
+// within target. Job durations are sampled from the recent runs of the pool
+// and the batch is played through Simulate, so the advice is only as good
+// as that history is representative. When target cannot be met with one
+// worker per job, the fastest configuration is returned with Feasible false.
+func (wp *WorkerPool[T, R]) Advise(target time.Duration) (Advice, error) {
+	history := wp.history.sorted()
+	if len(history) == 0 {
+		return Advice{}, fmt.Errorf("advise: no job durations observed yet")
+	}
+	if target <= 0 {
+		return Advice{}, fmt.Errorf("advise: target must be positive")
+	}
+
+	wp.mu.RLock()
+	jobs := append([]Job[T](nil), wp.jobs...)
+	base := wp.config
+	wp.mu.RUnlock()
+	if len(jobs) == 0 {
+		_, priorities := wp.lastRun.Load().summary()
+		for _, p := range priorities {
+			for i := 0; i < p.Jobs; i++ {
+				jobs = append(jobs, Job[T]{ID: fmt.Sprintf("%d/%d", p.Priority, i), Priority: p.Priority})
+			}
+		}
+	}
+	if len(jobs) == 0 {
+		return Advice{}, fmt.Errorf("advise: no jobs queued or run")
+	}
+
+	// Give every job an observed duration, picked by its ID so the same
+	// batch always gets the same sample
+	estimate := func(job Job[T]) time.Duration {
+		h := fnv.New64a()
+		h.Write([]byte(job.ID))
+		return history[h.Sum64()%uint64(len(history))]
+	}
+	fastest := func(workers int) (DistributionStrategy, time.Duration) {
+		config := base
+		config.NumWorkers = workers
+		best, makespan := RoundRobin, time.Duration(-1)
+		for i, sim := range Simulate(jobs, config, estimate) {
+			if s := simulatedStrategies[i]; s != Adaptive && (makespan < 0 || sim.Makespan < makespan) {
+				best, makespan = s, sim.Makespan
+			}
+		}
+		return best, makespan
+	}
+
+	// Find the fewest workers meeting the target; more workers than jobs never help
+	workers := sort.Search(len(jobs), func(i int) bool {
+		_, makespan := fastest(i + 1)
+		return makespan <= target
+	}) + 1
+	workers = min(workers, len(jobs))
+	strategy, predicted := fastest(workers)
+
+	advice := Advice{
+		NumWorkers: workers,
+		BufferSize: max(10, min(len(jobs), 4*workers)),
+		Strategy:   strategy,
+		Predicted:  predicted,
+		Feasible:   predicted <= target,
+		Reasons: []string{
+			fmt.Sprintf("%d jobs, durations sampled from %d observed (p50 %s, p99 %s)",
+				len(jobs), len(history), percentile(history, 0.5), percentile(history, 0.99)),
+			fmt.Sprintf("%s is the fastest strategy with %d workers: %s predicted against a %s target",
+				strategy, workers, predicted, target),
+			"buffer holds four results per worker so workers do not wait on the collector",
+		},
+	}
+	if !advice.Feasible {
+		advice.Reasons = append(advice.Reasons, "target unreachable even with one worker per job")
+	}
+	return advice, nil
+}
@@ -0,0 +1,52 @@
+package workerpool
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+func (ts *WorkerPoolTestSuite) TestAdviseSizesPoolForTarget() {
+	config := DefaultConfig()
+	config.NumWorkers = 1
+	pool := NewWithConfig[int, int](config)
+	pool.WithProcessor(func(ctx context.Context, job Job[int]) (int, error) {
+		time.Sleep(10 * time.Millisecond)
+		return job.Data, nil
+	})
+
+	_, err := pool.Advise(time.Second)
+	ts.Error(err, "no history yet")
+
+	for i := 0; i < 20; i++ {
+		pool.AddJob(Job[int]{ID: fmt.Sprintf("%d", i), Data: i})
+	}
+	_, err = pool.Run()
+	ts.NoError(err)
+
+	// About 200ms of work needs four or five workers to finish in 60ms
+	advice, err := pool.Advise(60 * time.Millisecond)
+	ts.NoError(err)
+	ts.True(advice.Feasible)
+	ts.GreaterOrEqual(advice.NumWorkers, 4)
+	ts.LessOrEqual(advice.NumWorkers, 6)
+	ts.LessOrEqual(advice.Predicted, 60*time.Millisecond)
+	ts.Equal(max(10, 4*advice.NumWorkers), advice.BufferSize)
+	ts.NotEmpty(advice.Reasons)
+
+	// No number of workers beats a single job's duration
+	advice, err = pool.Advise(time.Millisecond)
+	ts.NoError(err)
+	ts.False(advice.Feasible)
+	ts.Equal(20, advice.NumWorkers)
+	ts.Contains(advice.Reasons[len(advice.Reasons)-1], "unreachable")
+}
+
+func (ts *WorkerPoolTestSuite) TestDurationHistoryIgnoresSkips() {
+	var h durationHistory
+	h.record(nil, 3*time.Millisecond)
+	h.record(ErrDependencyFailed, 0)
+	h.record(ErrJobExpired, 0)
+	h.record(fmt.Errorf("boom"), time.Millisecond)
+	ts.Equal([]time.Duration{time.Millisecond, 3 * time.Millisecond}, h.sorted())
+}
@@ -0,0 +1,219 @@
+package workerpool
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Alert evaluation defaults
+const (
+	defaultAlertWindow   = 100
+	defaultAlertInterval = time.Second
+)
+
+// AlertRule is a threshold that fires an Alert when breached. Set one or
+// more limits; the rule is breached while any of them is exceeded. Error
+// rate and p99 latency are measured over the rule's last Window results.
+type AlertRule struct {
+	Name          string
+	MaxErrorRate  float64       // Failed fraction of recent results (0-1) above which the rule breaches
+	MaxQueueDepth int           // Pending jobs above which the rule breaches
+	MaxP99        time.Duration // p99 latency of recent results above which the rule breaches
+	For           time.Duration // How long the breach must last before the alert fires; zero fires at once
+	Window        int           // Results error rate and p99 are measured over; defaults to 100
+}
+
+// Alert reports a rule starting or ending to fire, with the measurements
+// that decided it
+type Alert struct {
+	Pool       string        `json:"pool,omitempty"`
+	Rule       string        `json:"rule"`
+	Firing     bool          `json:"firing"` // False when the alert resolves
+	Since      time.Time     `json:"since"`  // When the breach began, or ended for a resolved alert
+	Message    string        `json:"message"`
+	ErrorRate  float64       `json:"error_rate"`
+	QueueDepth int           `json:"queue_depth"`
+	P99        time.Duration `json:"p99_ns"`
+}
+
+// alertState tracks one rule between evaluations
+type alertState struct {
+	rule     AlertRule
+	breached time.Time // When the current breach began; zero when not breached
+	firing   bool
+}
+
+// alertMonitor evaluates alert rules against recent results and queue depth
+type alertMonitor struct {
+	pool     string
+	handler  func(Alert)
+	rules    []*alertState
+	failed   []bool // Ring of recent outcomes, as long as the largest window
+	latency  []time.Duration
+	next     int
+	filled   int
+	interval time.Duration
+	mu       sync.Mutex // Protects the ring
+	eval     sync.Mutex // Serializes evaluations so alerts reach the handler in order
+}
+
+// WithAlerts evaluates rules as results complete, and every second during
+// runs for queue depth and For durations, calling handler whenever an alert
+// fires or resolves. handler runs on the pool's result path, so it should
+// return quickly; AlertWebhook posts in the background.
+func (wp *WorkerPool[T, R]) WithAlerts(handler func(Alert), rules ...AlertRule) *WorkerPool[T, R] {
+	m := &alertMonitor{handler: handler, interval: defaultAlertInterval}
+	size := 0
+	for _, rule := range rules {
+		if rule.Window <= 0 {
+			rule.Window = defaultAlertWindow
+		}
+		size = max(size, rule.Window)
+		m.rules = append(m.rules, &alertState{rule: rule})
+	}
+	m.failed = make([]bool, size)
+	m.latency = make([]time.Duration, size)
+
+	wp.mu.Lock()
+	defer wp.mu.Unlock()
+	m.pool = wp.config.Name
+	wp.alerts = m
+	return wp
+}
+
+// AlertWebhook returns an alert handler that POSTs each alert as JSON to
+// url in the background. Delivery failures are dropped.
+func AlertWebhook(url string) func(Alert) {
+	client := &http.Client{Timeout: 10 * time.Second}
+	return func(alert Alert) {
+		body, err := json.Marshal(alert)
+		if err != nil {
+			return
+		}
+		go func() {
+			resp, err := client.Post(url, "application/json", bytes.NewReader(body))
+			if err == nil {
+				resp.Body.Close()
+			}
+		}()
+	}
+}
+
+// queueDepth returns how many jobs have not started
+func (wp *WorkerPool[T, R]) queueDepth() int {
+	wp.mu.RLock()
+	defer wp.mu.RUnlock()
+	if wp.pending != nil {
+		return wp.pending.count()
+	}
+	return len(wp.jobs)
+}
+
+// record adds the outcome of a job that ran and evaluates the rules;
+// skipped and expired jobs are ignored
+func (m *alertMonitor) record(err error, d time.Duration, depth func() int) {
+	if m == nil || isSkip(err) || errors.Is(err, ErrJobExpired) {
+		return
+	}
+	m.mu.Lock()
+	if len(m.failed) > 0 {
+		m.failed[m.next] = err != nil
+		m.latency[m.next] = d
+		m.next = (m.next + 1) % len(m.failed)
+		m.filled = min(m.filled+1, len(m.failed))
+	}
+	m.mu.Unlock()
+	m.evaluate(time.Now(), depth())
+}
+
+// watch evaluates the rules every interval until ctx is done, so queue
+// depth and For durations are checked while no results arrive
+func (m *alertMonitor) watch(ctx context.Context, depth func() int) {
+	ticker := time.NewTicker(m.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			m.evaluate(now, depth())
+		}
+	}
+}
+
+// evaluate checks every rule and calls the handler for alerts that fire or
+// resolve
+func (m *alertMonitor) evaluate(now time.Time, depth int) {
+	m.eval.Lock()
+	defer m.eval.Unlock()
+	m.mu.Lock()
+	var alerts []Alert
+	for _, state := range m.rules {
+		rule := state.rule
+		alert := Alert{Pool: m.pool, Rule: rule.Name, QueueDepth: depth}
+		alert.ErrorRate, alert.P99 = m.windowLocked(rule.Window)
+
+		var breaches []string
+		if rule.MaxErrorRate > 0 && alert.ErrorRate > rule.MaxErrorRate {
+			breaches = append(breaches, fmt.Sprintf("error rate %.1f%% above %.1f%%", 100*alert.ErrorRate, 100*rule.MaxErrorRate))
+		}
+		if rule.MaxQueueDepth > 0 && depth > rule.MaxQueueDepth {
+			breaches = append(breaches, fmt.Sprintf("queue depth %d above %d", depth, rule.MaxQueueDepth))
+		}
+		if rule.MaxP99 > 0 && alert.P99 > rule.MaxP99 {
+			breaches = append(breaches, fmt.Sprintf("p99 %s above %s", alert.P99, rule.MaxP99))
+		}
+
+		switch {
+		case len(breaches) > 0:
+			if state.breached.IsZero() {
+				state.breached = now
+			}
+			if !state.firing && now.Sub(state.breached) >= rule.For {
+				state.firing = true
+				alert.Firing, alert.Since = true, state.breached
+				alert.Message = strings.Join(breaches, "; ")
+				alerts = append(alerts, alert)
+			}
+		case state.firing:
+			state.firing, state.breached = false, time.Time{}
+			alert.Since, alert.Message = now, "resolved"
+			alerts = append(alerts, alert)
+		default:
+			state.breached = time.Time{}
+		}
+	}
+	m.mu.Unlock()
+
+	for _, alert := range alerts {
+		m.handler(alert)
+	}
+}
+
+// windowLocked returns the error rate and p99 latency of the last size
+// results
+func (m *alertMonitor) windowLocked(size int) (float64, time.Duration) {
+	n := min(size, m.filled)
+	if n == 0 {
+		return 0, 0
+	}
+	failed := 0
+	durations := make([]time.Duration, 0, n)
+	for i := 1; i <= n; i++ {
+		j := (m.next - i + len(m.failed)) % len(m.failed)
+		if m.failed[j] {
+			failed++
+		}
+		durations = append(durations, m.latency[j])
+	}
+	sort.Slice(durations, func(i, j int) bool { return durations[i] < durations[j] })
+	return float64(failed) / float64(n), percentile(durations, 0.99)
+}
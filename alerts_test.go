@@ -0,0 +1,122 @@
+package workerpool
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"time"
+)
+
+func (ts *WorkerPoolTestSuite) TestAlertErrorRateFiresAndResolves() {
+	var mu sync.Mutex
+	var alerts []Alert
+	m := NewWithConfig[int, int](DefaultConfig()).WithAlerts(func(a Alert) {
+		mu.Lock()
+		defer mu.Unlock()
+		alerts = append(alerts, a)
+	}, AlertRule{Name: "errors", MaxErrorRate: 0.5, Window: 4}).alerts
+	depth := func() int { return 0 }
+
+	fail := errors.New("boom")
+	m.record(nil, time.Millisecond, depth)
+	m.record(fail, time.Millisecond, depth)
+	ts.Empty(alerts)
+	m.record(fail, time.Millisecond, depth)
+	ts.Require().Len(alerts, 1)
+	ts.True(alerts[0].Firing)
+	ts.Equal("errors", alerts[0].Rule)
+	ts.InDelta(2.0/3, alerts[0].ErrorRate, 0.001)
+	ts.Contains(alerts[0].Message, "error rate")
+
+	// Still breached: no repeat
+	m.record(fail, time.Millisecond, depth)
+	ts.Len(alerts, 1)
+
+	// Skipped jobs say nothing about the error rate
+	m.record(ErrJobExpired, 0, depth)
+	m.record(nil, time.Millisecond, depth)
+	ts.Len(alerts, 1)
+	m.record(nil, time.Millisecond, depth)
+	ts.Require().Len(alerts, 2)
+	ts.False(alerts[1].Firing)
+	ts.Equal(0.5, alerts[1].ErrorRate)
+}
+
+func (ts *WorkerPoolTestSuite) TestAlertForDuration() {
+	var alerts []Alert
+	m := NewWithConfig[int, int](DefaultConfig()).WithAlerts(func(a Alert) {
+		alerts = append(alerts, a)
+	}, AlertRule{Name: "backlog", MaxQueueDepth: 10, For: time.Minute}, AlertRule{Name: "slow", MaxP99: 50 * time.Millisecond}).alerts
+
+	start := time.Now()
+	m.evaluate(start, 20)
+	m.evaluate(start.Add(30*time.Second), 20)
+	ts.Empty(alerts)
+	m.evaluate(start.Add(time.Minute), 20)
+	ts.Require().Len(alerts, 1)
+	ts.Equal("backlog", alerts[0].Rule)
+	ts.Equal(start, alerts[0].Since)
+	ts.Equal(20, alerts[0].QueueDepth)
+
+	// A dip below the limit restarts the clock
+	m.evaluate(start.Add(2*time.Minute), 5)
+	m.evaluate(start.Add(3*time.Minute), 20)
+	ts.Len(alerts, 2)
+	ts.False(alerts[1].Firing)
+
+	m.record(nil, 100*time.Millisecond, func() int { return 0 })
+	ts.Require().Len(alerts, 3)
+	ts.Equal("slow", alerts[2].Rule)
+	ts.Equal(100*time.Millisecond, alerts[2].P99)
+}
+
+func (ts *WorkerPoolTestSuite) TestAlertDuringRun() {
+	config := DefaultConfig()
+	config.NumWorkers = 1
+	pool := NewWithConfig[int, int](config)
+	fired := make(chan Alert, 10)
+	pool.WithAlerts(func(a Alert) { fired <- a }, AlertRule{Name: "backlog", MaxQueueDepth: 2})
+	pool.alerts.interval = time.Millisecond
+
+	release := make(chan struct{})
+	pool.WithProcessor(func(ctx context.Context, job Job[int]) (int, error) {
+		<-release
+		return job.Data, nil
+	})
+	for i := 0; i < 5; i++ {
+		pool.AddJob(Job[int]{ID: string(rune('a' + i)), Data: i})
+	}
+
+	done := make(chan error)
+	go func() {
+		_, err := pool.Run()
+		done <- err
+	}()
+	alert := <-fired
+	ts.True(alert.Firing)
+	ts.Greater(alert.QueueDepth, 2)
+	close(release)
+	ts.NoError(<-done)
+}
+
+func (ts *WorkerPoolTestSuite) TestAlertWebhook() {
+	received := make(chan Alert, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var alert Alert
+		ts.NoError(json.NewDecoder(r.Body).Decode(&alert))
+		received <- alert
+	}))
+	defer server.Close()
+
+	AlertWebhook(server.URL)(Alert{Pool: "p", Rule: "errors", Firing: true, ErrorRate: 0.75})
+	select {
+	case alert := <-received:
+		ts.Equal("errors", alert.Rule)
+		ts.Equal(0.75, alert.ErrorRate)
+	case <-time.After(5 * time.Second):
+		ts.Fail("webhook not called")
+	}
+}
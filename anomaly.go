@@ -0,0 +1,158 @@
+package workerpool
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+// LatencyAnomalyDetection flags jobs whose processing time deviates sharply
+// from the recent baseline of their class (Job.Class). Each class keeps an
+// exponentially weighted moving average and variance of the durations of
+// its successful jobs; a job more than Threshold standard deviations from
+// the average is reported. Baselines carry over between runs, and each class
+// is judged on its own, so a slow dependency of one class stands out long
+// before it moves the pool's p99. A zero Threshold disables detection.
+type LatencyAnomalyDetection struct {
+	Threshold  float64 // Z-score beyond which a duration is anomalous, e.g. 3
+	Alpha      float64 // Weight of each new duration in the baseline, between 0 and 1; defaults to 0.1
+	MinSamples int     // Jobs of a class needed before its baseline is trusted; defaults to 20
+}
+
+// LatencyAnomaly describes a job flagged by latency anomaly detection
+type LatencyAnomaly struct {
+	Job      JobSummary
+	Duration time.Duration // How long the job took
+	Baseline time.Duration // Average duration of the class before this job
+	StdDev   time.Duration // Standard deviation of the class before this job
+	ZScore   float64       // Deviations from the baseline; negative when the job was faster
+}
+
+// LatencyBaseline is what anomaly detection has learned about one class
+type LatencyBaseline struct {
+	Mean    time.Duration
+	StdDev  time.Duration
+	Samples int
+}
+
+const (
+	defaultAnomalyAlpha   = 0.1
+	defaultAnomalySamples = 20
+
+	// minAnomalyDeviation floors the standard deviation as a fraction of the
+	// mean, so classes with near-constant durations are not flagged for jitter
+	minAnomalyDeviation = 0.05
+)
+
+// classLatency is the moving baseline of one class
+type classLatency struct {
+	mean, variance float64 // In nanoseconds
+	samples        int
+}
+
+// anomalyDetector keeps latency baselines per class
+type anomalyDetector struct {
+	cfg     LatencyAnomalyDetection
+	classes map[string]*classLatency
+	mu      sync.Mutex
+}
+
+// newAnomalyDetector creates a detector for cfg, or nil when it is disabled
+func newAnomalyDetector(cfg LatencyAnomalyDetection) *anomalyDetector {
+	if cfg.Threshold <= 0 {
+		return nil
+	}
+	if cfg.Alpha <= 0 || cfg.Alpha > 1 {
+		cfg.Alpha = defaultAnomalyAlpha
+	}
+	if cfg.MinSamples <= 0 {
+		cfg.MinSamples = defaultAnomalySamples
+	}
+	return &anomalyDetector{cfg: cfg, classes: make(map[string]*classLatency)}
+}
+
+// WithLatencyAnomalyHandler sets a function called for every job flagged by
+// Config.LatencyAnomaly. It runs on the worker that ran the job and should
+// not block.
+func (wp *WorkerPool[T, R]) WithLatencyAnomalyHandler(handler func(LatencyAnomaly)) *WorkerPool[T, R] {
+	wp.mu.Lock()
+	defer wp.mu.Unlock()
+	wp.onAnomaly = handler
+	return wp
+}
+
+// LatencyBaselines returns the learned baseline of every class seen so far,
+// keyed by Job.Class
+func (wp *WorkerPool[T, R]) LatencyBaselines() map[string]LatencyBaseline {
+	d := wp.anomalies
+	if d == nil {
+		return nil
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	baselines := make(map[string]LatencyBaseline, len(d.classes))
+	for class, c := range d.classes {
+		baselines[class] = LatencyBaseline{
+			Mean:    time.Duration(c.mean),
+			StdDev:  time.Duration(math.Sqrt(c.variance)),
+			Samples: c.samples,
+		}
+	}
+	return baselines
+}
+
+// observe adds a duration to the baseline of class. It returns the anomaly
+// when the duration deviates from the baseline as it stood before.
+func (d *anomalyDetector) observe(class string, duration time.Duration) (LatencyAnomaly, bool) {
+	if d == nil {
+		return LatencyAnomaly{}, false
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	c := d.classes[class]
+	if c == nil {
+		c = &classLatency{}
+		d.classes[class] = c
+	}
+	x := float64(duration)
+	if c.samples == 0 {
+		c.mean, c.samples = x, 1
+		return LatencyAnomaly{}, false
+	}
+
+	stddev := math.Max(math.Sqrt(c.variance), minAnomalyDeviation*c.mean)
+	anomaly := LatencyAnomaly{
+		Duration: duration,
+		Baseline: time.Duration(c.mean),
+		StdDev:   time.Duration(stddev),
+	}
+	if stddev > 0 {
+		anomaly.ZScore = (x - c.mean) / stddev
+	}
+	flagged := c.samples >= d.cfg.MinSamples && math.Abs(anomaly.ZScore) > d.cfg.Threshold
+
+	// Exponentially weighted mean and variance
+	alpha := d.cfg.Alpha
+	diff := x - c.mean
+	c.mean += alpha * diff
+	c.variance = (1 - alpha) * (c.variance + alpha*diff*diff)
+	c.samples++
+	return anomaly, flagged
+}
+
+// checkLatency feeds a successful job's duration to anomaly detection and
+// reports it if it is anomalous
+func (wp *WorkerPool[T, R]) checkLatency(job Job[T], duration time.Duration) {
+	anomaly, flagged := wp.anomalies.observe(job.Class, duration)
+	if !flagged {
+		return
+	}
+	wp.mu.RLock()
+	handler := wp.onAnomaly
+	wp.mu.RUnlock()
+	if handler != nil {
+		anomaly.Job = summarize(job)
+		handler(anomaly)
+	}
+}
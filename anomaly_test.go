@@ -0,0 +1,60 @@
+package workerpool
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+func (ts *WorkerPoolTestSuite) TestLatencyAnomalyBaseline() {
+	ts.Nil(newAnomalyDetector(LatencyAnomalyDetection{}))
+
+	d := newAnomalyDetector(LatencyAnomalyDetection{Threshold: 3, MinSamples: 5})
+	for i := 0; i < 20; i++ {
+		_, flagged := d.observe("fast", time.Duration(10+i%3)*time.Millisecond)
+		ts.False(flagged)
+	}
+
+	anomaly, flagged := d.observe("fast", 100*time.Millisecond)
+	ts.True(flagged)
+	ts.Greater(anomaly.ZScore, 3.0)
+	ts.InDelta(float64(11*time.Millisecond), float64(anomaly.Baseline), float64(time.Millisecond))
+
+	// Other classes have their own baselines, and a new one is not judged
+	// before MinSamples
+	for i := 0; i < 4; i++ {
+		_, flagged := d.observe("slow", time.Duration(1+i*100)*time.Millisecond)
+		ts.False(flagged)
+	}
+}
+
+func (ts *WorkerPoolTestSuite) TestLatencyAnomalyDuringRun() {
+	config := DefaultConfig()
+	config.NumWorkers = 1
+	config.LatencyAnomaly = LatencyAnomalyDetection{Threshold: 100, MinSamples: 10}
+	pool := NewWithConfig[int, int](config)
+
+	var flagged []LatencyAnomaly
+	pool.WithLatencyAnomalyHandler(func(a LatencyAnomaly) { flagged = append(flagged, a) })
+	pool.WithProcessor(func(ctx context.Context, job Job[int]) (int, error) {
+		if job.Data == 15 {
+			time.Sleep(300 * time.Millisecond)
+		} else {
+			time.Sleep(5 * time.Millisecond)
+		}
+		return job.Data, nil
+	})
+	for i := 0; i < 20; i++ {
+		pool.AddJob(Job[int]{ID: fmt.Sprint(i), Data: i, Class: "io"})
+	}
+
+	_, err := pool.Run()
+	ts.NoError(err)
+	ts.Require().Len(flagged, 1)
+	ts.Equal("15", flagged[0].Job.ID)
+	ts.Equal("io", flagged[0].Job.Class)
+	ts.Greater(flagged[0].Duration, 300*time.Millisecond)
+
+	baselines := pool.LatencyBaselines()
+	ts.Equal(20, baselines["io"].Samples)
+}
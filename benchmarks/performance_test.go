@@ -187,6 +187,48 @@ func BenchmarkProcessingTimes(b *testing.B) {
 	}
 }
 
+// Benchmark the channel collector against the lock-free MPSC collector with
+// jobs small enough that handing over results dominates
+func BenchmarkCollectors(b *testing.B) {
+	const jobCount = 10000
+	jobs := make([]workerpool.Job[string], jobCount)
+	for i := range jobs {
+		jobs[i] = workerpool.Job[string]{ID: fmt.Sprintf("job_%d", i), Data: "x"}
+	}
+	collectors := []struct {
+		name      string
+		collector workerpool.ResultCollector
+	}{
+		{"Channel", workerpool.ChannelCollector},
+		{"MPSC", workerpool.MPSCCollector},
+	}
+
+	for _, numWorkers := range []int{1, 2, 4, 8, 16, 32, 64} {
+		for _, c := range collectors {
+			b.Run(fmt.Sprintf("Workers_%d/%s", numWorkers, c.name), func(b *testing.B) {
+				config := workerpool.Config{
+					NumWorkers: numWorkers,
+					Strategy:   workerpool.WorkStealing,
+					BufferSize: 100,
+					Timeout:    1 * time.Minute,
+					Collector:  c.collector,
+				}
+
+				b.ResetTimer()
+				for i := 0; i < b.N; i++ {
+					pool := workerpool.NewWithConfig[string, string](config).
+						WithProcessor(benchmarkProcessor)
+					pool.AddJobs(jobs)
+					if _, err := pool.Run(); err != nil {
+						b.Fatal(err)
+					}
+				}
+				b.ReportMetric(float64(jobCount*b.N)/b.Elapsed().Seconds(), "jobs/s")
+			})
+		}
+	}
+}
+
 // benchmarkProcessor is a simple processor for benchmarking
 func benchmarkProcessor(ctx context.Context, job workerpool.Job[string]) (string, error) {
 	// Simulate some minimal processing
@@ -0,0 +1,138 @@
+package workerpool
+
+import (
+	"context"
+	"time"
+)
+
+// blackouts holds the maintenance windows during which no job starts
+type blackouts struct {
+	start, end time.Time         // One-off blackout set by SetBlackout
+	recurring  []ExecutionWindow // Daily blackouts added by AddRecurringBlackout
+	changed    chan struct{}     // Closed and replaced whenever the schedule changes
+}
+
+// SetBlackout pauses dispatch between start and end. Jobs that have not
+// started wait, and retries of failed jobs are deferred, until the blackout
+// ends; jobs already executing are not interrupted. It replaces any earlier
+// one-off blackout, and takes effect immediately in a running pool.
+func (wp *WorkerPool[T, R]) SetBlackout(start, end time.Time) {
+	wp.mu.Lock()
+	defer wp.mu.Unlock()
+
+	wp.blackouts.start, wp.blackouts.end = start, end
+	wp.blackoutChangedLocked()
+}
+
+// AddRecurringBlackout pauses dispatch every day during the window, e.g. for
+// a dependency's nightly maintenance. A window with Start equal to End blacks
+// out the whole day.
+func (wp *WorkerPool[T, R]) AddRecurringBlackout(window ExecutionWindow) {
+	wp.mu.Lock()
+	defer wp.mu.Unlock()
+
+	wp.blackouts.recurring = append(wp.blackouts.recurring, window)
+	wp.blackoutChangedLocked()
+}
+
+// ClearBlackout removes the one-off blackout set by SetBlackout, keeping the
+// recurring ones
+func (wp *WorkerPool[T, R]) ClearBlackout() {
+	wp.mu.Lock()
+	defer wp.mu.Unlock()
+
+	wp.blackouts.start, wp.blackouts.end = time.Time{}, time.Time{}
+	wp.blackoutChangedLocked()
+}
+
+// ClearBlackouts removes the one-off and all recurring blackouts, resuming
+// dispatch immediately
+func (wp *WorkerPool[T, R]) ClearBlackouts() {
+	wp.mu.Lock()
+	defer wp.mu.Unlock()
+
+	wp.blackouts = blackouts{changed: wp.blackouts.changed}
+	wp.blackoutChangedLocked()
+}
+
+// InBlackout reports whether dispatch is paused at t and when the pause ends.
+// The end is the zero time if a recurring whole-day blackout never lifts.
+func (wp *WorkerPool[T, R]) InBlackout(t time.Time) (bool, time.Time) {
+	wp.mu.RLock()
+	defer wp.mu.RUnlock()
+	return wp.blackouts.until(t)
+}
+
+// blackoutChangedLocked wakes workers waiting out a blackout so they
+// re-evaluate the schedule. Callers must hold wp.mu.
+func (wp *WorkerPool[T, R]) blackoutChangedLocked() {
+	if wp.blackouts.changed != nil {
+		close(wp.blackouts.changed)
+	}
+	wp.blackouts.changed = make(chan struct{})
+}
+
+// until reports whether t is blacked out and when the blackout ends,
+// following back-to-back and overlapping blackouts. Chains of recurring
+// windows that cover the whole day are followed a bounded number of hops;
+// the caller re-evaluates when the returned end arrives.
+func (b *blackouts) until(t time.Time) (bool, time.Time) {
+	active := false
+	for hops := 0; hops <= len(b.recurring)+1; hops++ {
+		extended := false
+		if !t.Before(b.start) && t.Before(b.end) {
+			t, extended = b.end, true
+		}
+		for _, w := range b.recurring {
+			if !w.Open(t) {
+				continue
+			}
+			if w.Start == w.End {
+				return true, time.Time{}
+			}
+			t, extended = w.NextClose(t), true
+		}
+		if !extended {
+			return active, t
+		}
+		active = true
+	}
+	return active, t
+}
+
+// awaitBlackout blocks while dispatch is blacked out. It returns an error if
+// ctx ends first, and nil once the blackout is over or the pool is draining.
+func (wp *WorkerPool[T, R]) awaitBlackout(ctx context.Context) error {
+	for {
+		wp.mu.RLock()
+		active, end := wp.blackouts.until(time.Now())
+		changed, drained := wp.blackouts.changed, wp.drained
+		wp.mu.RUnlock()
+
+		if !active {
+			return nil
+		}
+
+		var timer *time.Timer
+		var wake <-chan time.Time
+		if !end.IsZero() {
+			timer = time.NewTimer(time.Until(end))
+			wake = timer.C
+		}
+		var err error
+		select {
+		case <-wake:
+		case <-changed:
+		case <-drained:
+			active = false
+		case <-ctx.Done():
+			err = ctx.Err()
+		}
+		if timer != nil {
+			timer.Stop()
+		}
+		if err != nil || !active {
+			return err
+		}
+	}
+}
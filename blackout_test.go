@@ -0,0 +1,89 @@
+package workerpool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+func (ts *WorkerPoolTestSuite) TestBlackoutDelaysDispatch() {
+	pool := New[string, string]()
+	pool.WithProcessor(func(ctx context.Context, job Job[string]) (string, error) {
+		return job.Data, nil
+	})
+	for i := 0; i < 3; i++ {
+		pool.AddJob(Job[string]{ID: fmt.Sprintf("%d", i), Data: "x"})
+	}
+
+	end := time.Now().Add(100 * time.Millisecond)
+	pool.SetBlackout(time.Now().Add(-time.Second), end)
+
+	results, err := pool.Run()
+	ts.NoError(err)
+	ts.Len(results, 3)
+	for _, r := range results {
+		ts.False(r.Started.Before(end), "job %s started during the blackout", r.JobID)
+	}
+}
+
+func (ts *WorkerPoolTestSuite) TestInBlackoutFollowsOverlaps() {
+	pool := New[string, string]()
+	day := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	active, _ := pool.InBlackout(day.Add(time.Hour))
+	ts.False(active)
+
+	// A one-off blackout running into a recurring one ends when the latter does
+	pool.SetBlackout(day.Add(time.Hour), day.Add(2*time.Hour))
+	pool.AddRecurringBlackout(ExecutionWindow{Start: 90 * time.Minute, End: 3 * time.Hour})
+
+	active, end := pool.InBlackout(day.Add(time.Hour))
+	ts.True(active)
+	ts.Equal(day.Add(3*time.Hour), end)
+
+	active, end = pool.InBlackout(day.AddDate(0, 0, 1).Add(2 * time.Hour))
+	ts.True(active)
+	ts.Equal(day.AddDate(0, 0, 1).Add(3*time.Hour), end)
+
+	pool.ClearBlackouts()
+	active, _ = pool.InBlackout(day.Add(time.Hour))
+	ts.False(active)
+}
+
+func (ts *WorkerPoolTestSuite) TestClearBlackoutsResumesRun() {
+	pool := New[string, string]()
+	pool.WithProcessor(func(ctx context.Context, job Job[string]) (string, error) {
+		return job.Data, nil
+	})
+	pool.AddJob(Job[string]{ID: "1", Data: "x"})
+	pool.SetBlackout(time.Now(), time.Now().Add(time.Hour))
+
+	time.AfterFunc(20*time.Millisecond, pool.ClearBlackouts)
+
+	start := time.Now()
+	results, err := pool.Run()
+	ts.NoError(err)
+	ts.Len(results, 1)
+	ts.Less(time.Since(start), time.Second)
+}
+
+func (ts *WorkerPoolTestSuite) TestBlackoutTimeoutLeavesJobsUnfinished() {
+	config := DefaultConfig()
+	config.Timeout = 50 * time.Millisecond
+	config.PartialResults = true
+	pool := NewWithConfig[string, string](config)
+	pool.WithProcessor(func(ctx context.Context, job Job[string]) (string, error) {
+		return job.Data, nil
+	})
+	pool.AddJobs([]Job[string]{{ID: "1", Data: "a"}, {ID: "2", Data: "b"}})
+	pool.AddRecurringBlackout(ExecutionWindow{})
+
+	results, err := pool.Run()
+	ts.True(errors.Is(err, ErrPoolTimeout))
+	ts.Empty(results)
+
+	var partial *PartialRunError[string]
+	ts.True(errors.As(err, &partial))
+	ts.Len(partial.Unfinished, 2)
+}
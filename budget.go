@@ -0,0 +1,125 @@
+package workerpool
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ErrBudgetExceeded is reported for jobs skipped because a run budget ran out
+var ErrBudgetExceeded = errors.New("run budget exceeded")
+
+// Budget caps what a run, or one job class within a run, may consume.
+// Once a limit is reached the remaining jobs it covers are skipped with
+// ErrBudgetExceeded. A zero value for any field means unlimited.
+type Budget struct {
+	MaxFailures int           // Failed jobs allowed
+	MaxDuration time.Duration // Cumulative processing time allowed
+	MaxCost     int           // Cost units allowed, charged with a job's estimated cost when it starts
+}
+
+// budgetUsage is what a run or class has consumed so far
+type budgetUsage struct {
+	failures int
+	duration time.Duration
+	cost     int
+}
+
+// exceeded describes the first limit of b that starting a job of the given
+// cost would break, or returns "" if the job may start
+func (u budgetUsage) exceeded(b Budget, cost int) string {
+	switch {
+	case b.MaxFailures > 0 && u.failures >= b.MaxFailures:
+		return fmt.Sprintf("%d failures", u.failures)
+	case b.MaxDuration > 0 && u.duration >= b.MaxDuration:
+		return fmt.Sprintf("%v processing time", u.duration)
+	case b.MaxCost > 0 && u.cost+cost > b.MaxCost:
+		return fmt.Sprintf("cost %d of %d", u.cost+cost, b.MaxCost)
+	}
+	return ""
+}
+
+// budgetTracker enforces the run and per-class budgets of a pool
+type budgetTracker struct {
+	run     Budget
+	classes map[string]Budget
+	total   budgetUsage
+	spent   map[string]*budgetUsage
+	mu      sync.Mutex
+}
+
+// newBudgetTracker creates a tracker for the budgets in config
+func newBudgetTracker(config Config) *budgetTracker {
+	classes := make(map[string]Budget, len(config.ClassBudgets))
+	for class, budget := range config.ClassBudgets {
+		classes[class] = budget
+	}
+	return &budgetTracker{
+		run:     config.RunBudget,
+		classes: classes,
+		spent:   make(map[string]*budgetUsage),
+	}
+}
+
+// reset forgets all usage at the start of a run
+func (b *budgetTracker) reset() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.total = budgetUsage{}
+	b.spent = make(map[string]*budgetUsage)
+}
+
+// class returns the mutable usage of a class. Callers must hold b.mu.
+func (b *budgetTracker) class(name string) *budgetUsage {
+	u, ok := b.spent[name]
+	if !ok {
+		u = &budgetUsage{}
+		b.spent[name] = u
+	}
+	return u
+}
+
+// reserve charges a job's cost before it starts, or returns an error
+// wrapping ErrBudgetExceeded if the run or its class is out of budget
+func (b *budgetTracker) reserve(class string, cost int) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if reason := b.total.exceeded(b.run, cost); reason != "" {
+		return fmt.Errorf("%w: run reached %s", ErrBudgetExceeded, reason)
+	}
+	u := b.class(class)
+	if budget, ok := b.classes[class]; ok {
+		if reason := u.exceeded(budget, cost); reason != "" {
+			return fmt.Errorf("%w: class %q reached %s", ErrBudgetExceeded, class, reason)
+		}
+	}
+	b.total.cost += cost
+	u.cost += cost
+	return nil
+}
+
+// refund returns the cost reserved for a job that did not start
+func (b *budgetTracker) refund(class string, cost int) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.total.cost -= cost
+	b.class(class).cost -= cost
+}
+
+// settle records the processing time and outcome of a finished job
+func (b *budgetTracker) settle(class string, duration time.Duration, failed bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	u := b.class(class)
+	b.total.duration += duration
+	u.duration += duration
+	if failed {
+		b.total.failures++
+		u.failures++
+	}
+}
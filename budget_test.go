@@ -0,0 +1,140 @@
+package workerpool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+func (ts *WorkerPoolTestSuite) TestRunBudgetMaxFailures() {
+	config := DefaultConfig()
+	config.NumWorkers = 1
+	config.MaxRetries = 0
+	config.RunBudget = Budget{MaxFailures: 2}
+	pool := NewWithConfig[string, string](config)
+
+	pool.WithProcessor(func(ctx context.Context, job Job[string]) (string, error) {
+		return "", errors.New("boom")
+	})
+
+	var jobs []Job[string]
+	for i := 0; i < 5; i++ {
+		jobs = append(jobs, Job[string]{ID: fmt.Sprintf("%d", i), Data: "x"})
+	}
+	pool.AddJobs(jobs)
+
+	results, err := pool.Run()
+	ts.NoError(err)
+	ts.Len(results, 5)
+
+	skipped := 0
+	for _, r := range results {
+		if errors.Is(r.Error, ErrBudgetExceeded) {
+			skipped++
+			ts.Zero(r.Attempts)
+		}
+	}
+	ts.Equal(3, skipped)
+
+	metrics := pool.GetMetrics()
+	ts.Equal(2, metrics.FailedJobs)
+	ts.Equal(3, metrics.SkippedJobs)
+
+	// Budgets start over with every run
+	pool.WithProcessor(func(ctx context.Context, job Job[string]) (string, error) {
+		return job.Data, nil
+	})
+	pool.AddJobs(jobs)
+	results, err = pool.Run()
+	ts.NoError(err)
+	for _, r := range results {
+		ts.NoError(r.Error)
+	}
+}
+
+func (ts *WorkerPoolTestSuite) TestClassBudgetMaxCost() {
+	config := DefaultConfig()
+	config.NumWorkers = 2
+	config.ClassBudgets = map[string]Budget{"export": {MaxCost: 5}}
+	pool := NewWithConfig[string, string](config)
+
+	pool.WithProcessor(func(ctx context.Context, job Job[string]) (string, error) {
+		return job.Data, nil
+	})
+
+	var jobs []Job[string]
+	for i := 0; i < 4; i++ {
+		jobs = append(jobs,
+			Job[string]{ID: fmt.Sprintf("export-%d", i), Data: "x", Class: "export", Cost: 2},
+			Job[string]{ID: fmt.Sprintf("report-%d", i), Data: "x", Class: "report", Cost: 2},
+		)
+	}
+	pool.AddJobs(jobs)
+
+	results, err := pool.Run()
+	ts.NoError(err)
+	ts.Len(results, 8)
+
+	skipped := make(map[string]int)
+	for _, r := range results {
+		if errors.Is(r.Error, ErrBudgetExceeded) {
+			skipped[r.JobID[:6]]++
+			ts.Contains(r.Error.Error(), `class "export"`)
+		}
+	}
+	ts.Equal(map[string]int{"export": 2}, skipped)
+}
+
+func (ts *WorkerPoolTestSuite) TestClassBudgetMaxDuration() {
+	config := DefaultConfig()
+	config.NumWorkers = 1
+	config.ClassBudgets = map[string]Budget{"slow": {MaxDuration: 15 * time.Millisecond}}
+	pool := NewWithConfig[string, string](config)
+
+	pool.WithProcessor(func(ctx context.Context, job Job[string]) (string, error) {
+		time.Sleep(10 * time.Millisecond)
+		return job.Data, nil
+	})
+
+	var jobs []Job[string]
+	for i := 0; i < 4; i++ {
+		jobs = append(jobs, Job[string]{ID: fmt.Sprintf("%d", i), Data: "x", Class: "slow"})
+	}
+	pool.AddJobs(jobs)
+
+	results, err := pool.Run()
+	ts.NoError(err)
+	ts.Len(results, 4)
+	ts.Equal(2, pool.GetMetrics().SkippedJobs)
+}
+
+func (ts *WorkerPoolTestSuite) TestBudgetRefundsJobsThatNeverStart() {
+	config := DefaultConfig()
+	config.NumWorkers = 2
+	config.ClassConcurrency = map[string]int{"export": 1}
+	config.RunBudget = Budget{MaxCost: 10}
+	pool := NewWithConfig[string, string](config)
+
+	started := make(chan struct{})
+	pool.WithProcessor(func(ctx context.Context, job Job[string]) (string, error) {
+		close(started)
+		<-ctx.Done()
+		return "", ctx.Err()
+	})
+	pool.AddJobs([]Job[string]{
+		{ID: "a", Data: "x", Class: "export", Cost: 3},
+		{ID: "b", Data: "x", Class: "export", Cost: 3},
+	})
+
+	// One job holds the class slot while the other waits for it, charged,
+	// until the run is stopped
+	go func() {
+		<-started
+		time.Sleep(10 * time.Millisecond)
+		pool.Stop()
+	}()
+	_, err := pool.Run()
+	ts.ErrorIs(err, ErrPoolStopped)
+	ts.Equal(3, pool.budgets.total.cost, "only the job that started is charged")
+}
@@ -0,0 +1,36 @@
+package workerpool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrPoolStopped is the cancellation cause recorded by Stop
+	ErrPoolStopped = errors.New("worker pool stopped")
+
+	// ErrPoolTimeout is the cancellation cause recorded when Config.Timeout elapses
+	ErrPoolTimeout = errors.New("worker pool timeout exceeded")
+
+	// ErrPoolRunning is returned by Run while another run of the pool is in
+	// progress and has not been stopped
+	ErrPoolRunning = errors.New("worker pool already running")
+
+	// ErrEnoughSuccesses is the cancellation cause recorded when a run has
+	// the successes Config.StopAfterSuccesses asks for
+	ErrEnoughSuccesses = errors.New("run stopped after enough successes")
+)
+
+// cancellationError describes why ctx was cancelled. The result matches both
+// ctx.Err() and the recorded cause with errors.Is.
+func cancellationError(ctx context.Context) error {
+	err := ctx.Err()
+	if err == nil {
+		return nil
+	}
+	if cause := context.Cause(ctx); cause != nil && cause != err {
+		return fmt.Errorf("%w: %w", err, cause)
+	}
+	return err
+}
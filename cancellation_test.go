@@ -0,0 +1,317 @@
+package workerpool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+func (ts *WorkerPoolTestSuite) TestStopCause() {
+	pool := New[string, string]()
+	started := make(chan struct{}, 4)
+	pool.WithProcessor(func(ctx context.Context, job Job[string]) (string, error) {
+		started <- struct{}{}
+		<-ctx.Done()
+		return "", ctx.Err()
+	})
+	pool.AddJob(Job[string]{ID: "1", Data: "a"})
+
+	maintenance := errors.New("maintenance")
+	go func() {
+		<-started
+		pool.StopWithCause(maintenance)
+	}()
+
+	_, err := pool.Run()
+	ts.True(errors.Is(err, context.Canceled))
+	ts.True(errors.Is(err, maintenance))
+	ts.False(errors.Is(err, ErrPoolTimeout))
+}
+
+func (ts *WorkerPoolTestSuite) TestTimeoutCause() {
+	config := DefaultConfig()
+	config.Timeout = 50 * time.Millisecond
+	pool := NewWithConfig[string, string](config)
+	pool.WithProcessor(func(ctx context.Context, job Job[string]) (string, error) {
+		<-ctx.Done()
+		return "", ctx.Err()
+	})
+	pool.AddJob(Job[string]{ID: "1", Data: "a"})
+
+	_, err := pool.Run()
+	ts.True(errors.Is(err, context.DeadlineExceeded))
+	ts.True(errors.Is(err, ErrPoolTimeout))
+}
+
+func (ts *WorkerPoolTestSuite) TestInterruptedJobResultCarriesCause() {
+	config := DefaultConfig()
+	config.MaxRetries = 3
+	pool := NewWithConfig[string, string](config)
+	pool.results = make(chan Result[string], 1)
+
+	calls := 0
+	pool.WithProcessor(func(ctx context.Context, job Job[string]) (string, error) {
+		calls++
+		return "", ctx.Err()
+	})
+
+	ctx, cancel := context.WithCancelCause(context.Background())
+	cancel(ErrPoolStopped)
+	pool.processJob(0, Job[string]{ID: "1"}, ctx)
+
+	result := <-pool.results
+	ts.True(errors.Is(result.Error, ErrPoolStopped))
+	ts.Equal(1, calls)
+}
+
+func (ts *WorkerPoolTestSuite) TestRunContextCancel() {
+	pool := New[string, string]()
+	started := make(chan struct{}, 4)
+	pool.WithProcessor(func(ctx context.Context, job Job[string]) (string, error) {
+		started <- struct{}{}
+		<-ctx.Done()
+		return "", ctx.Err()
+	})
+	pool.AddJob(Job[string]{ID: "1", Data: "a"})
+
+	shutdown := errors.New("shutdown")
+	ctx, cancel := context.WithCancelCause(context.Background())
+	go func() {
+		<-started
+		cancel(shutdown)
+	}()
+
+	_, err := pool.RunContext(ctx)
+	ts.True(errors.Is(err, context.Canceled))
+	ts.True(errors.Is(err, shutdown))
+
+	// The pool runs again once the parent is done with
+	pool = New[string, string]().WithProcessor(func(ctx context.Context, job Job[string]) (string, error) {
+		return job.Data, nil
+	})
+	pool.AddJob(Job[string]{ID: "1", Data: "a"})
+	_, err = pool.RunContext(context.Background())
+	ts.NoError(err)
+}
+
+func (ts *WorkerPoolTestSuite) TestRunContextDeadline() {
+	pool := New[string, string]() // Config.Timeout is minutes
+	pool.WithProcessor(func(ctx context.Context, job Job[string]) (string, error) {
+		<-ctx.Done()
+		return "", ctx.Err()
+	})
+	pool.AddJob(Job[string]{ID: "1", Data: "a"})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	_, err := pool.RunContext(ctx)
+	ts.True(errors.Is(err, context.DeadlineExceeded))
+	ts.False(errors.Is(err, ErrPoolTimeout), "the parent's deadline, not the pool timeout")
+	ts.Less(time.Since(start), 5*time.Second)
+}
+
+func (ts *WorkerPoolTestSuite) TestRunAfterStop() {
+	config := DefaultConfig()
+	config.NumWorkers = 2
+	config.MaxRetries = 0
+	pool := NewWithConfig[int, int](config)
+
+	var stopped atomic.Bool
+	started := make(chan struct{}, 10)
+	pool.WithProcessor(func(ctx context.Context, job Job[int]) (int, error) {
+		if !stopped.Load() {
+			started <- struct{}{}
+			<-ctx.Done()
+			return 0, ctx.Err()
+		}
+		return job.Data, nil
+	})
+	var jobs []Job[int]
+	for i := 0; i < 6; i++ {
+		jobs = append(jobs, Job[int]{ID: fmt.Sprint(i), Data: i})
+	}
+	pool.AddJobs(jobs)
+
+	go func() {
+		<-started
+		pool.Stop()
+	}()
+	_, err := pool.Run()
+	ts.ErrorIs(err, ErrPoolStopped)
+	ts.False(pool.Health().Running)
+
+	// The stopped pool runs its jobs again from a clean state
+	stopped.Store(true)
+	results, err := pool.Run()
+	ts.NoError(err)
+	ts.Len(results, 6)
+	for _, r := range results {
+		ts.NoError(r.Error)
+	}
+	ts.Zero(pool.InFlightCost())
+	for _, w := range pool.WorkerStats() {
+		ts.Zero(w.InFlight)
+	}
+}
+
+func (ts *WorkerPoolTestSuite) TestRunDuringRun() {
+	pool := New[int, int]()
+	release := make(chan struct{})
+	started := make(chan struct{}, 1)
+	pool.WithProcessor(func(ctx context.Context, job Job[int]) (int, error) {
+		started <- struct{}{}
+		<-release
+		return job.Data, nil
+	})
+	pool.AddJob(Job[int]{ID: "a", Data: 1})
+
+	done := make(chan error)
+	go func() {
+		_, err := pool.Run()
+		done <- err
+	}()
+	<-started
+
+	// A run in progress that was not stopped is not interrupted
+	_, err := pool.Run()
+	ts.ErrorIs(err, ErrPoolRunning)
+
+	close(release)
+	ts.NoError(<-done)
+}
+
+func (ts *WorkerPoolTestSuite) TestRunWaitsForStoppedRun() {
+	pool := New[int, int]()
+	var runs atomic.Int32
+	started := make(chan struct{}, 1)
+	pool.WithProcessor(func(ctx context.Context, job Job[int]) (int, error) {
+		if runs.Add(1) == 1 {
+			started <- struct{}{}
+			<-ctx.Done()
+			// Wind down slowly, ignoring the cancellation for a while
+			time.Sleep(50 * time.Millisecond)
+			return 0, ctx.Err()
+		}
+		return job.Data, nil
+	})
+	pool.AddJob(Job[int]{ID: "a", Data: 1})
+
+	first := make(chan error)
+	go func() {
+		_, err := pool.Run()
+		first <- err
+	}()
+	<-started
+	pool.Stop()
+
+	results, err := pool.Run()
+	ts.NoError(err)
+	ts.Require().Len(results, 1)
+	ts.Equal(1, results[0].Data)
+	ts.ErrorIs(<-first, ErrPoolStopped)
+}
+
+func (ts *WorkerPoolTestSuite) TestConcurrentStopAndRun() {
+	config := DefaultConfig()
+	config.NumWorkers = 3
+	config.MaxRetries = 0
+	pool := NewWithConfig[int, int](config)
+	pool.WithProcessor(func(ctx context.Context, job Job[int]) (int, error) {
+		return job.Data, sleepOrDone(ctx, time.Millisecond)
+	})
+	var jobs []Job[int]
+	for i := 0; i < 20; i++ {
+		jobs = append(jobs, Job[int]{ID: fmt.Sprint(i), Data: i})
+	}
+	pool.AddJobs(jobs)
+
+	for i := 0; i < 20; i++ {
+		var wg sync.WaitGroup
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			_, err := pool.Run()
+			if err != nil {
+				ts.ErrorIs(err, ErrPoolStopped)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			time.Sleep(time.Duration(i%4) * time.Millisecond)
+			pool.Stop()
+		}()
+		wg.Wait()
+	}
+
+	// Whatever the interleaving, the pool is left ready for a clean run
+	results, err := pool.Run()
+	ts.NoError(err)
+	ts.Len(results, 20)
+	ts.False(pool.Health().Running)
+}
+
+func (ts *WorkerPoolTestSuite) TestFirstSuccessWins() {
+	config := DefaultConfig()
+	config.NumWorkers = 2
+	config.Strategy = RoundRobin
+	config.MaxRetries = 0
+	config.StopAfterSuccesses = FirstSuccessWins
+	pool := NewWithConfig[int, string](config)
+
+	var cancelled atomic.Int32
+	pool.WithProcessor(func(ctx context.Context, job Job[int]) (string, error) {
+		switch {
+		case job.Data < 2:
+			return "", fmt.Errorf("mirror %d down", job.Data)
+		case job.Data == 2:
+			return "mirror 2", nil
+		}
+		// Slow mirrors only finish once the run is stopped
+		<-ctx.Done()
+		if errors.Is(context.Cause(ctx), ErrEnoughSuccesses) {
+			cancelled.Add(1)
+		}
+		return "", ctx.Err()
+	})
+	for i := 0; i < 6; i++ {
+		pool.AddJob(Job[int]{ID: fmt.Sprint(i), Data: i})
+	}
+
+	results, err := pool.Run()
+	ts.NoError(err)
+	var winners []string
+	for _, r := range results {
+		if r.Error == nil {
+			winners = append(winners, r.Data)
+		}
+	}
+	ts.Equal([]string{"mirror 2"}, winners)
+	ts.LessOrEqual(len(results), 3, "failures before the winner, and the winner")
+	ts.Positive(cancelled.Load())
+}
+
+func (ts *WorkerPoolTestSuite) TestStopAfterSuccesses() {
+	config := DefaultConfig()
+	config.NumWorkers = 1
+	config.StopAfterSuccesses = 3
+	pool := NewWithConfig[int, int](config)
+	pool.WithProcessor(func(ctx context.Context, job Job[int]) (int, error) {
+		return job.Data, nil
+	})
+	for i := 0; i < 20; i++ {
+		pool.AddJob(Job[int]{ID: fmt.Sprint(i), Data: i})
+	}
+
+	results, err := pool.Run()
+	ts.NoError(err)
+	ts.Len(results, 3)
+
+	// The pool stays usable and stops early again
+	results, err = pool.Run()
+	ts.NoError(err)
+	ts.Len(results, 3)
+}
@@ -0,0 +1,161 @@
+package workerpool
+
+import "context"
+
+// ClassifiedJob is implemented by payloads that know their own job class,
+// typically through a small enum type, so submitters need not set Job.Class
+// by hand. A job submitted with an empty Class takes its payload's class.
+//
+//	type Traffic int
+//
+//	const (
+//		Interactive Traffic = iota
+//		Batch
+//	)
+//
+//	func (t Traffic) JobClass() string { return [...]string{"interactive", "batch"}[t] }
+type ClassifiedJob interface {
+	JobClass() string
+}
+
+// JobClass declares how jobs of one class are routed, in Config.JobClasses
+type JobClass struct {
+	Priority    int              // Priority of jobs of the class submitted with none
+	Cost        int              // Cost of jobs of the class submitted with none
+	Window      *ExecutionWindow // Daily window outside which the class is held back; nil means always open
+	Budget      *Budget          // Per-run limits for the class; nil means none
+	AllocHeavy  bool             // Held back under GC pressure, as if listed in GCPressure.Classes
+	Concurrency int              // Cap on executing jobs of the class, as in ClassConcurrency; zero means unlimited
+}
+
+// ClassTable converts a routing table keyed by a class enum into the
+// string-keyed form Config.JobClasses takes, so the compiler checks that
+// every key is a declared class
+func ClassTable[C interface {
+	comparable
+	ClassifiedJob
+}](routes map[C]JobClass) map[string]JobClass {
+	table := make(map[string]JobClass, len(routes))
+	for class, route := range routes {
+		table[class.JobClass()] = route
+	}
+	return table
+}
+
+// withJobClasses returns the config with its JobClasses table expanded into
+// ClassWindows, ClassBudgets, ClassConcurrency and GCPressure.Classes.
+// Entries already set in those take precedence over the table.
+func (c Config) withJobClasses() Config {
+	if len(c.JobClasses) == 0 {
+		return c
+	}
+	c = c.clone()
+	heavy := make(map[string]bool, len(c.GCPressure.Classes))
+	for _, class := range c.GCPressure.Classes {
+		heavy[class] = true
+	}
+	c.GCPressure.Classes = append([]string(nil), c.GCPressure.Classes...)
+
+	for class, route := range c.JobClasses {
+		if route.Window != nil {
+			if c.ClassWindows == nil {
+				c.ClassWindows = make(map[string]ExecutionWindow)
+			}
+			if _, ok := c.ClassWindows[class]; !ok {
+				c.ClassWindows[class] = *route.Window
+			}
+		}
+		if route.Budget != nil {
+			if c.ClassBudgets == nil {
+				c.ClassBudgets = make(map[string]Budget)
+			}
+			if _, ok := c.ClassBudgets[class]; !ok {
+				c.ClassBudgets[class] = *route.Budget
+			}
+		}
+		if route.Concurrency > 0 {
+			if c.ClassConcurrency == nil {
+				c.ClassConcurrency = make(map[string]int)
+			}
+			if _, ok := c.ClassConcurrency[class]; !ok {
+				c.ClassConcurrency[class] = route.Concurrency
+			}
+		}
+		if route.AllocHeavy && !heavy[class] {
+			c.GCPressure.Classes = append(c.GCPressure.Classes, class)
+		}
+	}
+	return c
+}
+
+// classify fills in the class of a submitted job from its payload, and its
+// priority and cost from the class's entry in Config.JobClasses
+func (wp *WorkerPool[T, R]) classify(job Job[T]) Job[T] {
+	if job.Class == "" {
+		if classified, ok := any(job.Data).(ClassifiedJob); ok {
+			job.Class = classified.JobClass()
+		}
+	}
+	route, ok := wp.config.JobClasses[job.Class]
+	if !ok {
+		return job
+	}
+	if job.Priority == 0 {
+		job.Priority = route.Priority
+	}
+	if job.Cost == 0 {
+		job.Cost = route.Cost
+	}
+	return job
+}
+
+// ClassInFlight returns how many jobs of each class capped by
+// Config.ClassConcurrency are executing right now
+func (wp *WorkerPool[T, R]) ClassInFlight() map[string]int {
+	return wp.classSlots.inFlight()
+}
+
+// classLimits caps concurrent executions per job class, with one weighted
+// semaphore per capped class. Workers stay shared: a worker holding a job of
+// a class at its cap waits for a slot. A nil map caps nothing.
+type classLimits map[string]*costLimiter
+
+// newClassLimits creates the semaphores of the positive limits
+func newClassLimits(limits map[string]int) classLimits {
+	var l classLimits
+	for class, limit := range limits {
+		if limit <= 0 {
+			continue
+		}
+		if l == nil {
+			l = make(classLimits)
+		}
+		l[class] = newCostLimiter(limit)
+	}
+	return l
+}
+
+// acquire blocks until a job of class may execute
+func (l classLimits) acquire(ctx context.Context, class string) error {
+	if limiter := l[class]; limiter != nil {
+		_, err := limiter.acquire(ctx, 1)
+		return err
+	}
+	return nil
+}
+
+// release frees the slot taken by acquire
+func (l classLimits) release(class string) {
+	if limiter := l[class]; limiter != nil {
+		limiter.release(1)
+	}
+}
+
+// inFlight returns the executing jobs of every capped class
+func (l classLimits) inFlight() map[string]int {
+	counts := make(map[string]int, len(l))
+	for class, limiter := range l {
+		counts[class] = limiter.inFlight()
+	}
+	return counts
+}
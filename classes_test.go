@@ -0,0 +1,134 @@
+package workerpool
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// testTraffic is a job class enum for the classification tests
+type testTraffic int
+
+const (
+	interactiveTraffic testTraffic = iota
+	batchTraffic
+)
+
+func (t testTraffic) JobClass() string {
+	return [...]string{"interactive", "batch"}[t]
+}
+
+// trafficJob is a payload carrying its own class
+type trafficJob struct {
+	Traffic testTraffic
+	N       int
+}
+
+func (j trafficJob) JobClass() string { return j.Traffic.JobClass() }
+
+func (ts *WorkerPoolTestSuite) TestJobClassesRouteClassifiedJobs() {
+	config := DefaultConfig()
+	config.NumWorkers = 1
+	config.Strategy = PriorityBased
+	config.ClassBudgets = map[string]Budget{"batch": {MaxFailures: 5}}
+	config.JobClasses = ClassTable(map[testTraffic]JobClass{
+		interactiveTraffic: {Priority: 10, Budget: &Budget{MaxFailures: 1}},
+		batchTraffic: {
+			Priority:   1,
+			Cost:       3,
+			Window:     &ExecutionWindow{},
+			Budget:     &Budget{MaxFailures: 2},
+			AllocHeavy: true,
+		},
+	})
+	pool := NewWithConfig[trafficJob, int](config)
+
+	// The table fills in what the config leaves unset
+	ts.Equal(Budget{MaxFailures: 1}, pool.config.ClassBudgets["interactive"])
+	ts.Equal(Budget{MaxFailures: 5}, pool.config.ClassBudgets["batch"])
+	ts.Contains(pool.config.ClassWindows, "batch")
+	ts.Equal([]string{"batch"}, pool.config.GCPressure.Classes)
+	ts.Len(config.ClassBudgets, 1, "the caller's config is not modified")
+
+	var mu sync.Mutex
+	var order []string
+	pool.WithProcessor(func(ctx context.Context, job Job[trafficJob]) (int, error) {
+		mu.Lock()
+		order = append(order, job.ID)
+		mu.Unlock()
+		return job.Data.N, nil
+	})
+	pool.AddJobs([]Job[trafficJob]{
+		{ID: "b1", Data: trafficJob{Traffic: batchTraffic}},
+		{ID: "b2", Data: trafficJob{Traffic: batchTraffic}, Priority: 20},
+		{ID: "i1", Data: trafficJob{Traffic: interactiveTraffic}},
+		{ID: "x1", Data: trafficJob{Traffic: batchTraffic}, Class: "other", Cost: 1},
+	})
+
+	classes := make(map[string]JobSummary)
+	for _, job := range pool.PendingJobs() {
+		classes[job.ID] = job
+	}
+	ts.Equal("batch", classes["b1"].Class)
+	ts.Equal(1, classes["b1"].Priority)
+	ts.Equal(20, classes["b2"].Priority, "an explicit priority wins")
+	ts.Equal("interactive", classes["i1"].Class)
+	ts.Equal(10, classes["i1"].Priority)
+	ts.Equal("other", classes["x1"].Class, "an explicit class wins")
+	ts.Zero(classes["x1"].Priority)
+
+	_, err := pool.Run()
+	ts.NoError(err)
+	ts.Equal([]string{"b2", "i1", "b1", "x1"}, order)
+}
+
+func (ts *WorkerPoolTestSuite) TestClassifyDefaultsCost() {
+	config := DefaultConfig()
+	config.JobClasses = map[string]JobClass{"batch": {Cost: 3}}
+	pool := NewWithConfig[trafficJob, int](config)
+
+	ts.Equal(3, pool.classify(Job[trafficJob]{Data: trafficJob{Traffic: batchTraffic}}).Cost)
+	ts.Equal(1, pool.classify(Job[trafficJob]{Class: "batch", Cost: 1}).Cost)
+	ts.Zero(pool.classify(Job[trafficJob]{Data: trafficJob{Traffic: interactiveTraffic}}).Cost)
+}
+
+func (ts *WorkerPoolTestSuite) TestClassConcurrency() {
+	config := DefaultConfig()
+	config.NumWorkers = 4
+	config.Strategy = PriorityBased
+	config.ClassConcurrency = map[string]int{"export": 1, "unused": 0}
+	config.JobClasses = map[string]JobClass{"render": {Concurrency: 2}}
+	pool := NewWithConfig[int, int](config)
+	ts.Equal(map[string]int{"export": 0, "render": 0}, pool.ClassInFlight())
+
+	var mu sync.Mutex
+	running := make(map[string]int)
+	peak := make(map[string]int)
+	pool.WithProcessor(func(ctx context.Context, job Job[int]) (int, error) {
+		mu.Lock()
+		running[job.Class]++
+		peak[job.Class] = max(peak[job.Class], running[job.Class])
+		mu.Unlock()
+		time.Sleep(10 * time.Millisecond)
+		mu.Lock()
+		running[job.Class]--
+		mu.Unlock()
+		return job.Data, nil
+	})
+
+	var jobs []Job[int]
+	for i := 0; i < 4; i++ {
+		for _, class := range []string{"export", "render", "other"} {
+			jobs = append(jobs, Job[int]{ID: fmt.Sprint(class, i), Class: class, Data: i})
+		}
+	}
+	pool.AddJobs(jobs)
+	results, err := pool.Run()
+	ts.NoError(err)
+	ts.Len(results, 12)
+
+	ts.Equal(1, peak["export"])
+	ts.LessOrEqual(peak["render"], 2)
+	ts.Equal(map[string]int{"export": 0, "render": 0}, pool.ClassInFlight())
+}
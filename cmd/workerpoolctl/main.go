@@ -0,0 +1,325 @@
+// Command workerpoolctl operates worker pools through the admin API served
+// by workerpool.Admin.
+//
+// Usage:
+//
+//	workerpoolctl [-addr URL] pools
+//	workerpoolctl [-addr URL] show POOL
+//	workerpoolctl [-addr URL] pending POOL
+//	workerpoolctl [-addr URL] failed POOL
+//	workerpoolctl [-addr URL] replay POOL JOB_ID...
+//	workerpoolctl [-addr URL] pause POOL [DURATION]
+//	workerpoolctl [-addr URL] resume POOL
+//	workerpoolctl [-addr URL] resize POOL WORKERS
+//	workerpoolctl [-addr URL] tail POOL
+//
+// The address defaults to $WORKERPOOLCTL_ADDR, or http://localhost:8080.
+// When the API requires authentication, pass a bearer token with -token or
+// $WORKERPOOLCTL_TOKEN, or a client certificate with -cert and -key.
+package main
+
+import (
+	"bufio"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/go-foundations/workerpool"
+)
+
+// client sends admin API requests, with token as the bearer token if set
+var (
+	client = http.DefaultClient
+	token  string
+)
+
+func main() {
+	addr := os.Getenv("WORKERPOOLCTL_ADDR")
+	if addr == "" {
+		addr = "http://localhost:8080"
+	}
+	flag.StringVar(&addr, "addr", addr, "base URL of the admin API")
+	flag.StringVar(&token, "token", os.Getenv("WORKERPOOLCTL_TOKEN"), "bearer token for the admin API")
+	certFile := flag.String("cert", "", "client certificate for mutual TLS")
+	keyFile := flag.String("key", "", "client certificate key for mutual TLS")
+	caFile := flag.String("cacert", "", "CA certificate to verify the server with")
+	flag.Usage = usage
+	flag.Parse()
+
+	if *certFile != "" || *caFile != "" {
+		config, err := tlsConfig(*certFile, *keyFile, *caFile)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "workerpoolctl:", err)
+			os.Exit(1)
+		}
+		client = &http.Client{Transport: &http.Transport{TLSClientConfig: config}}
+	}
+
+	if err := run(strings.TrimSuffix(addr, "/"), flag.Args(), os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, "workerpoolctl:", err)
+		os.Exit(1)
+	}
+}
+
+func usage() {
+	fmt.Fprint(os.Stderr, `usage: workerpoolctl [-addr URL] COMMAND [ARGS]
+
+commands:
+  pools                   list pools with their state and counters
+  show POOL               print a pool's health and metrics as JSON
+  pending POOL            list jobs not started yet
+  failed POOL             list jobs whose last run failed
+  replay POOL JOB_ID...   requeue failed jobs
+  pause POOL [DURATION]   stop starting jobs, e.g. for 30m, or until resumed
+  resume POOL             end a pause; recurring blackouts stay
+  resize POOL WORKERS     set the number of workers, from the next run
+  tail POOL               stream results as they complete
+`)
+	flag.PrintDefaults()
+}
+
+// run executes one command against the admin API at addr
+func run(addr string, args []string, out io.Writer) error {
+	if len(args) == 0 {
+		usage()
+		return errors.New("no command")
+	}
+	command, args := args[0], args[1:]
+	if command != "pools" && len(args) == 0 {
+		return fmt.Errorf("%s: pool name required", command)
+	}
+
+	switch command {
+	case "pools":
+		var pools []workerpool.PoolStatus
+		if err := getJSON(addr+"/pools", &pools); err != nil {
+			return err
+		}
+		tw := tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(tw, "NAME\tSTATE\tWORKERS\tTOTAL\tPROCESSED\tFAILED")
+		for _, p := range pools {
+			fmt.Fprintf(tw, "%s\t%s\t%d\t%d\t%d\t%d\n", p.Name, state(p.Health),
+				p.Health.Workers, p.Metrics.TotalJobs, p.Metrics.ProcessedJobs, p.Metrics.FailedJobs)
+		}
+		return tw.Flush()
+
+	case "show":
+		var raw json.RawMessage
+		if err := getJSON(poolURL(addr, args[0]), &raw); err != nil {
+			return err
+		}
+		var pretty strings.Builder
+		enc := json.NewEncoder(&pretty)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(raw); err != nil {
+			return err
+		}
+		_, err := io.WriteString(out, pretty.String())
+		return err
+
+	case "pending", "failed":
+		var jobs []workerpool.JobSummary
+		if err := getJSON(poolURL(addr, args[0])+"/"+command, &jobs); err != nil {
+			return err
+		}
+		tw := tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(tw, "ID\tPRIORITY\tATTEMPTS\tCLASS\tTENANT\tCREATED")
+		for _, j := range jobs {
+			fmt.Fprintf(tw, "%s\t%d\t%d\t%s\t%s\t%s\n", j.ID, j.Priority, j.Attempts,
+				j.Class, j.TenantID, j.Created.Format(time.RFC3339))
+		}
+		return tw.Flush()
+
+	case "replay":
+		if len(args) < 2 {
+			return errors.New("replay: job ID required")
+		}
+		var errs []error
+		for _, id := range args[1:] {
+			target := poolURL(addr, args[0]) + "/failed/" + url.PathEscape(id) + "/replay"
+			if err := post(target); err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", id, err))
+				continue
+			}
+			fmt.Fprintf(out, "requeued %s\n", id)
+		}
+		return errors.Join(errs...)
+
+	case "pause":
+		target := poolURL(addr, args[0]) + "/pause"
+		until := "until resumed"
+		if len(args) > 1 {
+			d, err := time.ParseDuration(args[1])
+			if err != nil {
+				return fmt.Errorf("pause: %w", err)
+			}
+			target += "?for=" + url.QueryEscape(d.String())
+			until = "for " + d.String()
+		}
+		if err := post(target); err != nil {
+			return err
+		}
+		fmt.Fprintf(out, "paused %s %s\n", args[0], until)
+		return nil
+
+	case "resume":
+		if err := post(poolURL(addr, args[0]) + "/resume"); err != nil {
+			return err
+		}
+		fmt.Fprintf(out, "resumed %s\n", args[0])
+		return nil
+
+	case "resize":
+		if len(args) < 2 {
+			return errors.New("resize: number of workers required")
+		}
+		workers, err := strconv.Atoi(args[1])
+		if err != nil {
+			return fmt.Errorf("resize: %w", err)
+		}
+		if err := post(poolURL(addr, args[0]) + "/resize?workers=" + strconv.Itoa(workers)); err != nil {
+			return err
+		}
+		fmt.Fprintf(out, "resized %s to %d workers\n", args[0], workers)
+		return nil
+
+	case "tail":
+		return tail(poolURL(addr, args[0])+"/tail", out)
+
+	default:
+		return fmt.Errorf("unknown command %q", command)
+	}
+}
+
+// state describes a pool's lifecycle state in one word
+func state(h workerpool.Health) string {
+	switch {
+	case h.Draining:
+		return "draining"
+	case h.Paused:
+		return "paused"
+	case h.Running:
+		return "running"
+	case !h.Warm:
+		return "warming"
+	default:
+		return "idle"
+	}
+}
+
+// poolURL returns the admin URL of a pool
+func poolURL(addr, pool string) string {
+	return addr + "/pools/" + url.PathEscape(pool)
+}
+
+// tlsConfig loads the client certificate and CA for mutual TLS
+func tlsConfig(certFile, keyFile, caFile string) (*tls.Config, error) {
+	config := &tls.Config{MinVersion: tls.VersionTLS12}
+	if certFile != "" {
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return nil, err
+		}
+		config.Certificates = []tls.Certificate{cert}
+	}
+	if caFile != "" {
+		pem, err := os.ReadFile(caFile)
+		if err != nil {
+			return nil, err
+		}
+		config.RootCAs = x509.NewCertPool()
+		if !config.RootCAs.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("%s: no certificates found", caFile)
+		}
+	}
+	return config, nil
+}
+
+// send makes a request with the configured credentials
+func send(method, target string) (*http.Response, error) {
+	req, err := http.NewRequest(method, target, nil)
+	if err != nil {
+		return nil, err
+	}
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	return client.Do(req)
+}
+
+// getJSON decodes the JSON response of a GET request into v
+func getJSON(target string, v any) error {
+	resp, err := send(http.MethodGet, target)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if err := checkStatus(resp); err != nil {
+		return err
+	}
+	return json.NewDecoder(resp.Body).Decode(v)
+}
+
+// post sends an empty POST request
+func post(target string) error {
+	resp, err := send(http.MethodPost, target)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	return checkStatus(resp)
+}
+
+// checkStatus turns an error response into an error carrying its message
+func checkStatus(resp *http.Response) error {
+	if resp.StatusCode < 300 {
+		return nil
+	}
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	return fmt.Errorf("%s: %s", resp.Status, strings.TrimSpace(string(body)))
+}
+
+// tail prints result events from a pool's feed until the server closes it
+func tail(target string, out io.Writer) error {
+	resp, err := send(http.MethodGet, target)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if err := checkStatus(resp); err != nil {
+		return err
+	}
+
+	var event string
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case strings.HasPrefix(line, "event: "):
+			event = strings.TrimPrefix(line, "event: ")
+		case strings.HasPrefix(line, "data: ") && event == "result":
+			var result workerpool.ResultEvent
+			if err := json.Unmarshal([]byte(strings.TrimPrefix(line, "data: ")), &result); err != nil {
+				return err
+			}
+			status := "ok"
+			if result.Error != "" {
+				status = "error: " + result.Error
+			}
+			fmt.Fprintf(out, "%s worker=%d attempts=%d duration=%s %s\n",
+				result.JobID, result.Worker, result.Attempts, result.Duration, status)
+		}
+	}
+	return scanner.Err()
+}
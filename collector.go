@@ -0,0 +1,207 @@
+package workerpool
+
+import (
+	"sync/atomic"
+)
+
+// ResultCollector selects how workers hand results to the collector
+type ResultCollector int
+
+const (
+	// ChannelCollector passes results through a channel of ResultBuffer
+	// results, handled as ResultOverflow says once it is full
+	ChannelCollector ResultCollector = iota
+
+	// MPSCCollector passes results through a lock-free multi-producer,
+	// single-consumer queue. Workers never wait on the collector and no
+	// lock is taken per result, which pays off for very small jobs. The
+	// queue is unbounded, so a slow sink or stream consumer lets results
+	// pile up in memory rather than slowing workers down.
+	MPSCCollector
+)
+
+// ResultOverflow says what a worker does with a result when the
+// ChannelCollector's buffer is full. The collector empties the buffer as
+// fast as sinks, WatchResults subscribers and the All or stream consumer
+// accept results, so the buffer only fills when one of them falls behind.
+type ResultOverflow int
+
+const (
+	// BlockOnFull makes the worker wait until the collector takes the
+	// result, so a slow consumer slows the pool down. No result is lost.
+	BlockOnFull ResultOverflow = iota
+
+	// DropOnFull hands the result to the WithDroppedResults handler instead
+	// and moves on to the next job. A dropped result skips the collector
+	// entirely: it is not returned by Run, fed to sinks or counted as
+	// processed or failed, and jobs depending on it are skipped as part of
+	// a dependency cycle. Metrics.DroppedResults counts them.
+	DropOnFull
+)
+
+// WithDroppedResults sets a function receiving every result dropped under
+// DropOnFull, e.g. to write it to a dead-letter store. It runs on the
+// worker that produced the result and should not block.
+func (wp *WorkerPool[T, R]) WithDroppedResults(handler func(Result[R])) *WorkerPool[T, R] {
+	wp.mu.Lock()
+	defer wp.mu.Unlock()
+	wp.onDropped = handler
+	return wp
+}
+
+// resultBuffer returns the capacity of a wave's results channel
+func (wp *WorkerPool[T, R]) resultBuffer() int {
+	if wp.config.ResultBuffer > 0 {
+		return wp.config.ResultBuffer
+	}
+	return wp.config.BufferSize
+}
+
+// dropResult counts a result that did not fit the buffer and passes it on
+func (wp *WorkerPool[T, R]) dropResult(result Result[R]) {
+	wp.metrics.mu.Lock()
+	wp.metrics.DroppedResults++
+	wp.metrics.mu.Unlock()
+
+	wp.mu.RLock()
+	handler := wp.onDropped
+	wp.mu.RUnlock()
+	if handler != nil {
+		handler(result)
+	}
+}
+
+// resultWave feeds one dispatch wave's results to emit, returning once the
+// wave is complete
+type resultWave[R any] func(emit func(Result[R]))
+
+// channelWave collects a wave from a results channel closed by its strategy
+func channelWave[R any](results <-chan Result[R]) resultWave[R] {
+	return func(emit func(Result[R])) {
+		for result := range results {
+			emit(result)
+		}
+	}
+}
+
+// sendResult hands a result to the collector of the current wave
+func (wp *WorkerPool[T, R]) sendResult(result Result[R]) {
+	if q := wp.resultQueue; q != nil {
+		q.push(result)
+		return
+	}
+	if wp.config.ResultOverflow == DropOnFull {
+		select {
+		case wp.results <- result:
+		default:
+			wp.dropResult(result)
+		}
+		return
+	}
+	wp.results <- result
+}
+
+// closeResults tells the collector the current wave has no more results.
+// Strategies call it once every worker has exited.
+func (wp *WorkerPool[T, R]) closeResults() {
+	if q := wp.resultQueue; q != nil {
+		q.close()
+		return
+	}
+	close(wp.results)
+}
+
+// mpscNode is one queued result
+type mpscNode[R any] struct {
+	next   atomic.Pointer[mpscNode[R]]
+	result Result[R]
+}
+
+// mpscQueue is an unbounded, lock-free multi-producer single-consumer
+// queue after Vyukov. Producers swap themselves in as the head and then
+// link the previous head to their node; the consumer follows links from
+// the tail, which always points at an already consumed node.
+type mpscQueue[R any] struct {
+	head    atomic.Pointer[mpscNode[R]] // Most recently pushed node
+	tail    *mpscNode[R]                // Last consumed node; owned by the consumer
+	closed  atomic.Bool
+	waiting atomic.Bool   // The consumer found the queue empty and is parked
+	wake    chan struct{} // Unparks the consumer
+}
+
+// newMPSCQueue creates an empty queue
+func newMPSCQueue[R any]() *mpscQueue[R] {
+	q := &mpscQueue[R]{wake: make(chan struct{}, 1)}
+	stub := &mpscNode[R]{}
+	q.head.Store(stub)
+	q.tail = stub
+	return q
+}
+
+// push adds a result; it is safe for any number of concurrent producers
+func (q *mpscQueue[R]) push(result Result[R]) {
+	n := &mpscNode[R]{result: result}
+	prev := q.head.Swap(n)
+	prev.next.Store(n)
+	if q.waiting.Load() {
+		q.signal()
+	}
+}
+
+// close marks the end of the results once every producer has returned
+func (q *mpscQueue[R]) close() {
+	q.closed.Store(true)
+	q.signal()
+}
+
+// signal unparks the consumer if it is parked or about to park
+func (q *mpscQueue[R]) signal() {
+	select {
+	case q.wake <- struct{}{}:
+	default:
+	}
+}
+
+// pop removes the oldest result. It reports false when no linked result
+// is available, which includes a push that has swapped the head but not
+// linked it yet.
+func (q *mpscQueue[R]) pop() (Result[R], bool) {
+	next := q.tail.next.Load()
+	if next == nil {
+		return Result[R]{}, false
+	}
+	result := next.result
+	next.result = Result[R]{} // Let the result be collected once emitted
+	q.tail = next
+	return result, true
+}
+
+// drain feeds every result to emit until the queue is closed and empty.
+// Only one goroutine may drain a queue.
+func (q *mpscQueue[R]) drain(emit func(Result[R])) {
+	for {
+		if result, ok := q.pop(); ok {
+			emit(result)
+			continue
+		}
+		// Announce the park before looking again, so a producer linking a
+		// node after our last look sees waiting and signals
+		q.waiting.Store(true)
+		if result, ok := q.pop(); ok {
+			q.waiting.Store(false)
+			emit(result)
+			continue
+		}
+		if q.closed.Load() {
+			// close happens after every push has linked its node
+			if result, ok := q.pop(); ok {
+				q.waiting.Store(false)
+				emit(result)
+				continue
+			}
+			return
+		}
+		<-q.wake
+		q.waiting.Store(false)
+	}
+}
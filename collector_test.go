@@ -0,0 +1,129 @@
+package workerpool
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+func (ts *WorkerPoolTestSuite) TestMPSCQueue() {
+	const producers, perProducer = 8, 1000
+	q := newMPSCQueue[int]()
+
+	var wg sync.WaitGroup
+	for p := 0; p < producers; p++ {
+		wg.Add(1)
+		go func(p int) {
+			defer wg.Done()
+			for i := 0; i < perProducer; i++ {
+				q.push(Result[int]{Worker: p, Data: i})
+			}
+		}(p)
+	}
+	go func() {
+		wg.Wait()
+		q.close()
+	}()
+
+	// Every result arrives once, in order per producer
+	next := make([]int, producers)
+	count := 0
+	q.drain(func(r Result[int]) {
+		ts.Equal(next[r.Worker], r.Data)
+		next[r.Worker]++
+		count++
+	})
+	ts.Equal(producers*perProducer, count)
+
+	// An empty closed queue drains immediately
+	q = newMPSCQueue[int]()
+	q.close()
+	q.drain(func(Result[int]) { ts.Fail("unexpected result") })
+}
+
+func (ts *WorkerPoolTestSuite) TestMPSCCollector() {
+	for _, strategy := range []DistributionStrategy{RoundRobin, Chunked, WorkStealing, PriorityBased, Adaptive, FairShare} {
+		config := DefaultConfig()
+		config.Strategy = strategy
+		config.Collector = MPSCCollector
+		config.BufferSize = 1
+		pool := NewWithConfig[int, int](config).WithProcessor(func(ctx context.Context, job Job[int]) (int, error) {
+			return job.Data * 2, nil
+		})
+		for i := 0; i < 500; i++ {
+			pool.AddJob(Job[int]{ID: fmt.Sprint(i), Data: i})
+		}
+		// A dependent job runs in a second wave
+		pool.AddJob(Job[int]{ID: "after", Data: 1000, Dependencies: []string{"0"}})
+
+		results, err := pool.Run()
+		ts.NoError(err, strategy.String())
+		ts.Len(results, 501, strategy.String())
+		sum := 0
+		for _, r := range results {
+			sum += r.Data
+		}
+		ts.Equal(2*(499*500/2+1000), sum, strategy.String())
+	}
+}
+
+func (ts *WorkerPoolTestSuite) TestResultOverflow() {
+	for _, overflow := range []ResultOverflow{BlockOnFull, DropOnFull} {
+		config := DefaultConfig()
+		config.NumWorkers = 4
+		config.ResultBuffer = 1
+		config.ResultOverflow = overflow
+		pool := NewWithConfig[int, int](config).WithProcessor(func(ctx context.Context, job Job[int]) (int, error) {
+			return job.Data, nil
+		})
+		var mu sync.Mutex
+		var dropped []Result[int]
+		pool.WithDroppedResults(func(r Result[int]) {
+			mu.Lock()
+			defer mu.Unlock()
+			dropped = append(dropped, r)
+		})
+		for i := 0; i < 100; i++ {
+			pool.AddJob(Job[int]{ID: fmt.Sprint(i), Data: i})
+		}
+
+		// The consumer holds up the collector on the first result until
+		// every job has finished or been dropped
+		release := make(chan struct{})
+		go func() {
+			for {
+				stats := pool.WorkerStats()
+				jobs := 0
+				for _, w := range stats {
+					jobs += w.Jobs
+				}
+				if jobs == 100 || overflow == BlockOnFull {
+					close(release)
+					return
+				}
+				time.Sleep(time.Millisecond)
+			}
+		}()
+		received := 0
+		_, err := pool.run(context.Background(), func(Result[int]) {
+			if received == 0 {
+				<-release
+			}
+			received++
+		})
+		ts.NoError(err)
+
+		mu.Lock()
+		if overflow == BlockOnFull {
+			ts.Equal(100, received)
+			ts.Empty(dropped)
+		} else {
+			ts.NotEmpty(dropped)
+			ts.Equal(100, received+len(dropped))
+		}
+		ts.Equal(len(dropped), pool.GetMetrics().DroppedResults)
+		ts.Equal(received, pool.GetMetrics().ProcessedJobs)
+		mu.Unlock()
+	}
+}
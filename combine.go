@@ -0,0 +1,175 @@
+package workerpool
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// ErrPartNotRun is recorded in a SplitError for parts of a split job that
+// produced no result, e.g. because the run was stopped before they started
+var ErrPartNotRun = errors.New("split part did not run")
+
+// Combiner merges the results of the parts a Splitter split a job into
+// into the result of the original job
+type Combiner[R any] func(parentID string, parts []Result[R]) (R, error)
+
+// SplitFailurePolicy says how a combined result treats failed parts
+type SplitFailurePolicy int
+
+const (
+	// FailParent fails the combined result with a *SplitError if any part
+	// failed, without calling the combiner
+	FailParent SplitFailurePolicy = iota
+
+	// PartialSuccess combines the parts that succeeded. If any failed, the
+	// combined result carries both the combined data and a *SplitError
+	// listing the failures.
+	PartialSuccess
+
+	// combineEveryPart passes every part that completed, failed or not, to
+	// the combiner, whose error alone decides the combined result
+	combineEveryPart SplitFailurePolicy = -1
+)
+
+// SplitError reports the failed parts of a split job
+type SplitError struct {
+	ParentID string
+	Parts    int              // Parts the job was split into
+	Failed   map[string]error // Error of every failed part by job ID
+}
+
+// Error summarizes the failures
+func (e *SplitError) Error() string {
+	return fmt.Sprintf("job %s: %d of %d parts failed", e.ParentID, len(e.Failed), e.Parts)
+}
+
+// Unwrap returns the errors of the failed parts, so errors.Is matches any
+func (e *SplitError) Unwrap() []error {
+	errs := make([]error, 0, len(e.Failed))
+	for _, err := range e.Failed {
+		errs = append(errs, err)
+	}
+	return errs
+}
+
+// WithCombiner merges the results of split jobs: instead of one result per
+// part, Run, streams, sinks and WatchResults get one result per original
+// job once all of its parts have completed, with the parts in
+// Result.Parts. Metrics, dependencies and Watch still see every part.
+func (wp *WorkerPool[T, R]) WithCombiner(combine Combiner[R], policy SplitFailurePolicy) *WorkerPool[T, R] {
+	wp.mu.Lock()
+	defer wp.mu.Unlock()
+	wp.combiner = combine
+	wp.splitPolicy = policy
+	return wp
+}
+
+// splitResults holds the results of split jobs' parts until every part of
+// a job has completed
+type splitResults[R any] struct {
+	combine Combiner[R]
+	policy  SplitFailurePolicy
+	parts   map[string][]string // IDs of the parts of every split job in the run
+	held    map[string][]Result[R]
+}
+
+// newSplitResults collects the parts listed in parents, the parent ID of
+// every part by job ID. It returns nil when there is no combiner.
+func newSplitResults[R any](combine Combiner[R], policy SplitFailurePolicy, parents map[string]string) *splitResults[R] {
+	if combine == nil {
+		return nil
+	}
+	s := &splitResults[R]{combine: combine, policy: policy, parts: make(map[string][]string), held: make(map[string][]Result[R])}
+	for part, parent := range parents {
+		s.parts[parent] = append(s.parts[parent], part)
+	}
+	return s
+}
+
+// add holds a part's result and returns the combined result once it was the
+// last part of its job
+func (s *splitResults[R]) add(result Result[R]) (Result[R], bool) {
+	parts := append(s.held[result.ParentID], result)
+	if len(parts) < len(s.parts[result.ParentID]) {
+		s.held[result.ParentID] = parts
+		return Result[R]{}, false
+	}
+	delete(s.held, result.ParentID)
+	return s.combined(result.ParentID, parts), true
+}
+
+// flush returns the combined results of the jobs some of whose parts never
+// completed, ordered by job ID
+func (s *splitResults[R]) flush() []Result[R] {
+	ids := make([]string, 0, len(s.held))
+	for id := range s.held {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	combined := make([]Result[R], len(ids))
+	for i, id := range ids {
+		combined[i] = s.combined(id, s.held[id])
+		delete(s.held, id)
+	}
+	return combined
+}
+
+// combined builds the result of a split job from the results of its parts
+func (s *splitResults[R]) combined(parentID string, parts []Result[R]) Result[R] {
+	result := Result[R]{JobID: parentID, Parts: parts}
+	var succeeded []Result[R]
+	failed := make(map[string]error)
+	for i, part := range parts {
+		if i == 0 || part.Started.Before(result.Started) {
+			result.Started = part.Started
+		}
+		if part.Completed.After(result.Completed) {
+			result.Completed = part.Completed
+			result.Worker = part.Worker
+		}
+		result.Attempts += part.Attempts
+		result.ExecutionIDs = append(result.ExecutionIDs, part.ExecutionIDs...)
+		result.CPUTime += part.CPUTime
+		result.Late = result.Late || part.Late
+		if part.Error != nil {
+			failed[part.JobID] = part.Error
+		} else {
+			succeeded = append(succeeded, part)
+		}
+	}
+	result.Duration = result.Completed.Sub(result.Started)
+	if len(parts) > 0 {
+		result.Strategy = parts[0].Strategy
+	}
+
+	if s.policy == combineEveryPart {
+		result.Data, result.Error = s.combine(parentID, parts)
+		return result
+	}
+
+	reported := make(map[string]bool, len(parts))
+	for _, part := range parts {
+		reported[part.JobID] = true
+	}
+	for _, id := range s.parts[parentID] {
+		if !reported[id] {
+			failed[id] = ErrPartNotRun
+		}
+	}
+	var splitErr error
+	if len(failed) > 0 {
+		splitErr = &SplitError{ParentID: parentID, Parts: len(s.parts[parentID]), Failed: failed}
+	}
+
+	if splitErr != nil && (s.policy == FailParent || len(succeeded) == 0) {
+		result.Error = splitErr
+		return result
+	}
+	result.Data, result.Error = s.combine(parentID, succeeded)
+	if result.Error == nil {
+		result.Error = splitErr
+	}
+	return result
+}
@@ -0,0 +1,98 @@
+package workerpool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// newSplittingPool returns a pool splitting every job into one part per
+// element of its data, failing the parts whose element is negative
+func newSplittingPool() *WorkerPool[[]int, int] {
+	config := DefaultConfig()
+	config.MaxRetries = 0
+	pool := NewWithConfig[[]int, int](config)
+	pool.WithSplitter(func(job Job[[]int]) []Job[[]int] {
+		parts := make([]Job[[]int], len(job.Data))
+		for i, v := range job.Data {
+			parts[i].Data = []int{v}
+		}
+		return parts
+	})
+	pool.WithProcessor(func(ctx context.Context, job Job[[]int]) (int, error) {
+		if job.Data[0] < 0 {
+			return 0, fmt.Errorf("negative %d", job.Data[0])
+		}
+		return job.Data[0], nil
+	})
+	return pool
+}
+
+// sumParts combines parts by adding their data
+func sumParts(parentID string, parts []Result[int]) (int, error) {
+	sum := 0
+	for _, part := range parts {
+		sum += part.Data
+	}
+	return sum, nil
+}
+
+func (ts *WorkerPoolTestSuite) TestCombinerMergesParts() {
+	pool := newSplittingPool()
+	pool.WithCombiner(sumParts, FailParent)
+	pool.AddJobs([]Job[[]int]{
+		{ID: "ok", Data: []int{1, 2, 3}},
+		{ID: "bad", Data: []int{4, -5, 6}},
+		{ID: "single", Data: []int{9}},
+	})
+
+	results, err := pool.Run()
+	ts.NoError(err)
+	byID := make(map[string]Result[int])
+	for _, result := range results {
+		byID[result.JobID] = result
+	}
+	ts.Len(byID, 3)
+
+	ts.NoError(byID["ok"].Error)
+	ts.Equal(6, byID["ok"].Data)
+	ts.Len(byID["ok"].Parts, 3)
+	ts.Equal(3, byID["ok"].Attempts)
+
+	var splitErr *SplitError
+	ts.Require().True(errors.As(byID["bad"].Error, &splitErr))
+	ts.Equal(3, splitErr.Parts)
+	ts.Len(splitErr.Failed, 1)
+	ts.EqualError(splitErr.Failed["bad/1"], "negative -5")
+	ts.Zero(byID["bad"].Data)
+
+	ts.Equal(9, byID["single"].Data, "jobs that are not split pass through")
+	ts.Equal(6, pool.GetMetrics().ProcessedJobs, "metrics count every part")
+	ts.Equal(1, pool.GetMetrics().FailedJobs)
+}
+
+func (ts *WorkerPoolTestSuite) TestCombinerPartialSuccess() {
+	pool := newSplittingPool()
+	pool.WithCombiner(sumParts, PartialSuccess)
+	pool.AddJob(Job[[]int]{ID: "bad", Data: []int{4, -5, 6}})
+
+	results, err := pool.Run()
+	ts.NoError(err)
+	ts.Require().Len(results, 1)
+	ts.Equal(10, results[0].Data)
+	var splitErr *SplitError
+	ts.True(errors.As(results[0].Error, &splitErr))
+	ts.EqualError(results[0].Error, "job bad: 1 of 3 parts failed")
+}
+
+func (ts *WorkerPoolTestSuite) TestSplitResultsFlushMissingParts() {
+	s := newSplitResults(sumParts, PartialSuccess, map[string]string{"p/0": "p", "p/1": "p"})
+	_, done := s.add(Result[int]{JobID: "p/0", ParentID: "p", Data: 2})
+	ts.False(done)
+
+	flushed := s.flush()
+	ts.Require().Len(flushed, 1)
+	ts.Equal(2, flushed[0].Data)
+	ts.ErrorIs(flushed[0].Error, ErrPartNotRun)
+	ts.Empty(s.flush())
+}
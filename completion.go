@@ -0,0 +1,240 @@
+package workerpool
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// ErrAlreadyCompleted is reported for jobs the completion store has already
+// seen succeed; the processor is not called again
+var ErrAlreadyCompleted = errors.New("job already completed")
+
+// CompletionStore records which jobs have completed successfully, so jobs
+// delivered more than once by an at-least-once source are processed
+// effectively once. Implementations backed by a shared database let several
+// pools or processes deduplicate against each other.
+//
+// A job is marked complete only after its processor succeeds. If the process
+// dies in between, the job runs again, so processors should still be
+// idempotent.
+type CompletionStore interface {
+	// IsComplete reports whether the job with the given key has completed
+	IsComplete(ctx context.Context, key string) (bool, error)
+	// MarkComplete records that the job with the given key has completed
+	MarkComplete(ctx context.Context, key string) error
+}
+
+// MemoryCompletionStore is a CompletionStore that lives in process memory
+type MemoryCompletionStore struct {
+	keys map[string]struct{}
+	mu   sync.RWMutex
+}
+
+// NewMemoryCompletionStore creates an empty in-memory completion store
+func NewMemoryCompletionStore() *MemoryCompletionStore {
+	return &MemoryCompletionStore{keys: make(map[string]struct{})}
+}
+
+// IsComplete reports whether key has been marked complete
+func (s *MemoryCompletionStore) IsComplete(_ context.Context, key string) (bool, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	_, ok := s.keys[key]
+	return ok, nil
+}
+
+// MarkComplete marks key as complete
+func (s *MemoryCompletionStore) MarkComplete(_ context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.keys[key] = struct{}{}
+	return nil
+}
+
+// SQLCompletionStore is a CompletionStore backed by a database/sql table, so
+// pools in several processes can deduplicate against each other. The table
+// needs a unique key column, e.g.
+//
+//	CREATE TABLE job_completions (idempotency_key VARCHAR(255) PRIMARY KEY)
+//
+// Queries use ? placeholders; call WithNumberedPlaceholders for drivers that
+// expect $1, such as PostgreSQL's.
+type SQLCompletionStore struct {
+	db       *sql.DB
+	table    string
+	numbered bool
+}
+
+// NewSQLCompletionStore creates a completion store over table in db. The
+// table name is written into queries as is and must come from trusted code.
+func NewSQLCompletionStore(db *sql.DB, table string) *SQLCompletionStore {
+	return &SQLCompletionStore{db: db, table: table}
+}
+
+// WithNumberedPlaceholders makes queries use $1 instead of ?
+func (s *SQLCompletionStore) WithNumberedPlaceholders() *SQLCompletionStore {
+	s.numbered = true
+	return s
+}
+
+// placeholder returns the parameter marker for the store's driver
+func (s *SQLCompletionStore) placeholder() string {
+	if s.numbered {
+		return "$1"
+	}
+	return "?"
+}
+
+// IsComplete reports whether key has a row in the table
+func (s *SQLCompletionStore) IsComplete(ctx context.Context, key string) (bool, error) {
+	query := fmt.Sprintf("SELECT COUNT(*) FROM %s WHERE idempotency_key = %s", s.table, s.placeholder())
+	var n int
+	if err := s.db.QueryRowContext(ctx, query, key).Scan(&n); err != nil {
+		return false, err
+	}
+	return n > 0, nil
+}
+
+// MarkComplete inserts a row for key. A key another process recorded first
+// is already complete, so the failed insert is not an error.
+func (s *SQLCompletionStore) MarkComplete(ctx context.Context, key string) error {
+	query := fmt.Sprintf("INSERT INTO %s (idempotency_key) VALUES (%s)", s.table, s.placeholder())
+	_, err := s.db.ExecContext(ctx, query, key)
+	if err == nil {
+		return nil
+	}
+	if complete, checkErr := s.IsComplete(ctx, key); checkErr == nil && complete {
+		return nil
+	}
+	return err
+}
+
+// WithCompletionStore deduplicates jobs by Job.IdempotencyKey (or ID) against
+// store. Jobs already complete are reported with ErrAlreadyCompleted, and
+// duplicates delivered concurrently are processed one at a time.
+func (wp *WorkerPool[T, R]) WithCompletionStore(store CompletionStore) *WorkerPool[T, R] {
+	wp.mu.Lock()
+	defer wp.mu.Unlock()
+	wp.completions = store
+	return wp
+}
+
+// IdempotencyKeyOrID returns the key the job is deduplicated by
+func (j Job[T]) IdempotencyKeyOrID() string {
+	if j.IdempotencyKey != "" {
+		return j.IdempotencyKey
+	}
+	return j.ID
+}
+
+// completionStore returns the configured store, or nil
+func (wp *WorkerPool[T, R]) completionStore() CompletionStore {
+	wp.mu.RLock()
+	defer wp.mu.RUnlock()
+	return wp.completions
+}
+
+// onceClaim holds a job's idempotency key for one delivery
+type onceClaim struct {
+	ctx    context.Context
+	store  CompletionStore // nil when deduplication is off
+	key    string
+	unlock func()
+}
+
+// finish records the job's outcome, marking a success complete, releases
+// the key and returns the outcome
+func (c onceClaim) finish(err error) error {
+	defer c.release()
+	if err != nil || c.store == nil {
+		return err
+	}
+	if err := c.store.MarkComplete(c.ctx, c.key); err != nil {
+		return fmt.Errorf("completion store: job succeeded but was not recorded: %w", err)
+	}
+	return nil
+}
+
+// release frees the key of a delivery that ends without an outcome, such as
+// one that yielded or was lost
+func (c onceClaim) release() {
+	if c.unlock != nil {
+		c.unlock()
+	}
+}
+
+// beginOnce serializes jobs sharing an idempotency key and checks the store.
+// The returned claim must be finished with the job's outcome or released.
+// done reports that the job already completed.
+func (wp *WorkerPool[T, R]) beginOnce(ctx context.Context, job Job[T]) (claim onceClaim, done bool, err error) {
+	store := wp.completionStore()
+	if store == nil {
+		return onceClaim{}, false, nil
+	}
+
+	key := job.IdempotencyKeyOrID()
+	unlock, err := wp.keyLocks.lock(ctx, key)
+	if err != nil {
+		return onceClaim{}, false, err
+	}
+	complete, err := store.IsComplete(ctx, key)
+	if err != nil || complete {
+		unlock()
+		if err != nil {
+			return onceClaim{}, false, fmt.Errorf("completion store: %w", err)
+		}
+		return onceClaim{}, true, nil
+	}
+	return onceClaim{ctx: ctx, store: store, key: key, unlock: unlock}, false, nil
+}
+
+// keyMutex hands out one lock per key, freeing it once nobody holds it
+type keyMutex struct {
+	locks map[string]*keyLock
+	mu    sync.Mutex
+}
+
+// keyLock is a lock for one key and the number of goroutines using it
+type keyLock struct {
+	held chan struct{} // Holds a token while the key is locked
+	refs int
+}
+
+// lock locks key, waiting until it is free or ctx ends, and returns the
+// function that unlocks it
+func (m *keyMutex) lock(ctx context.Context, key string) (func(), error) {
+	m.mu.Lock()
+	if m.locks == nil {
+		m.locks = make(map[string]*keyLock)
+	}
+	l, ok := m.locks[key]
+	if !ok {
+		l = &keyLock{held: make(chan struct{}, 1)}
+		m.locks[key] = l
+	}
+	l.refs++
+	m.mu.Unlock()
+
+	select {
+	case l.held <- struct{}{}:
+	case <-ctx.Done():
+		m.unref(key, l)
+		return nil, ctx.Err()
+	}
+	return func() {
+		<-l.held
+		m.unref(key, l)
+	}, nil
+}
+
+// unref drops one use of key's lock, freeing it once nobody uses it
+func (m *keyMutex) unref(key string, l *keyLock) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if l.refs--; l.refs == 0 {
+		delete(m.locks, key)
+	}
+}
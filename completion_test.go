@@ -0,0 +1,218 @@
+package workerpool
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// failingCompletionStore is a CompletionStore whose backend is unreachable
+type failingCompletionStore struct{}
+
+func (failingCompletionStore) IsComplete(context.Context, string) (bool, error) {
+	return false, errors.New("connection refused")
+}
+
+func (failingCompletionStore) MarkComplete(context.Context, string) error {
+	return errors.New("connection refused")
+}
+
+// fakeCompletionDB is a database/sql driver keeping a completion table's keys
+// in memory
+type fakeCompletionDB struct {
+	mu      sync.Mutex
+	keys    map[string]bool
+	queries []string
+}
+
+func (d *fakeCompletionDB) Open(string) (driver.Conn, error)             { return d, nil }
+func (d *fakeCompletionDB) Connect(context.Context) (driver.Conn, error) { return d, nil }
+func (d *fakeCompletionDB) Driver() driver.Driver                        { return d }
+func (d *fakeCompletionDB) Close() error                                 { return nil }
+func (d *fakeCompletionDB) Begin() (driver.Tx, error)                    { return nil, errors.New("not supported") }
+
+func (d *fakeCompletionDB) Prepare(query string) (driver.Stmt, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.queries = append(d.queries, query)
+	return fakeCompletionStmt{d}, nil
+}
+
+// fakeCompletionStmt inserts keys on Exec and counts them on Query
+type fakeCompletionStmt struct{ db *fakeCompletionDB }
+
+func (s fakeCompletionStmt) Close() error  { return nil }
+func (s fakeCompletionStmt) NumInput() int { return 1 }
+
+func (s fakeCompletionStmt) Exec(args []driver.Value) (driver.Result, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+	key := args[0].(string)
+	if s.db.keys[key] {
+		return nil, errors.New("duplicate key")
+	}
+	s.db.keys[key] = true
+	return driver.RowsAffected(1), nil
+}
+
+func (s fakeCompletionStmt) Query(args []driver.Value) (driver.Rows, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+	n := int64(0)
+	if s.db.keys[args[0].(string)] {
+		n = 1
+	}
+	return &fakeCountRows{n: n}, nil
+}
+
+// fakeCountRows is the single-row result of a COUNT(*) query
+type fakeCountRows struct {
+	n    int64
+	done bool
+}
+
+func (r *fakeCountRows) Columns() []string { return []string{"count"} }
+func (r *fakeCountRows) Close() error      { return nil }
+
+func (r *fakeCountRows) Next(dest []driver.Value) error {
+	if r.done {
+		return io.EOF
+	}
+	dest[0], r.done = r.n, true
+	return nil
+}
+
+func (ts *WorkerPoolTestSuite) TestCompletionStoreSkipsCompletedJobs() {
+	store := NewMemoryCompletionStore()
+	ts.NoError(store.MarkComplete(context.Background(), "a"))
+
+	pool := New[string, string]()
+	var calls int32
+	pool.WithCompletionStore(store).WithProcessor(func(ctx context.Context, job Job[string]) (string, error) {
+		atomic.AddInt32(&calls, 1)
+		if job.Data == "fail" {
+			return "", errors.New("boom")
+		}
+		return job.Data, nil
+	})
+
+	jobs := []Job[string]{{ID: "a", Data: "x"}, {ID: "b", Data: "y"}, {ID: "c", Data: "fail"}}
+	pool.AddJobs(jobs)
+	results, err := pool.Run()
+	ts.NoError(err)
+	ts.Len(results, 3)
+
+	for _, r := range results {
+		switch r.JobID {
+		case "a":
+			ts.ErrorIs(r.Error, ErrAlreadyCompleted)
+		case "b":
+			ts.NoError(r.Error)
+		case "c":
+			ts.Error(r.Error)
+			ts.NotErrorIs(r.Error, ErrAlreadyCompleted)
+		}
+	}
+	ts.Equal(1, pool.GetMetrics().SkippedJobs)
+
+	// Failed jobs are not recorded, so only c runs again
+	done, _ := store.IsComplete(context.Background(), "c")
+	ts.False(done)
+	callsBefore := atomic.LoadInt32(&calls)
+	pool.SetJobs(jobs)
+	_, err = pool.Run()
+	ts.NoError(err)
+	ts.Equal(int32(1+pool.config.MaxRetries), atomic.LoadInt32(&calls)-callsBefore)
+}
+
+func (ts *WorkerPoolTestSuite) TestCompletionStoreConcurrentDuplicates() {
+	config := DefaultConfig()
+	config.NumWorkers = 4
+	pool := NewWithConfig[string, string](config)
+
+	var calls int32
+	pool.WithCompletionStore(NewMemoryCompletionStore()).WithProcessor(func(ctx context.Context, job Job[string]) (string, error) {
+		atomic.AddInt32(&calls, 1)
+		time.Sleep(5 * time.Millisecond)
+		return job.Data, nil
+	})
+
+	var jobs []Job[string]
+	for i := 0; i < 4; i++ {
+		jobs = append(jobs, Job[string]{ID: fmt.Sprintf("delivery-%d", i), Data: "x", IdempotencyKey: "order-42"})
+	}
+	pool.AddJobs(jobs)
+
+	results, err := pool.Run()
+	ts.NoError(err)
+	ts.Len(results, 4)
+	ts.Equal(int32(1), atomic.LoadInt32(&calls))
+	ts.Equal(3, pool.GetMetrics().SkippedJobs)
+}
+
+func (ts *WorkerPoolTestSuite) TestCompletionStoreErrors() {
+	pool := New[string, string]()
+	pool.WithCompletionStore(failingCompletionStore{}).WithProcessor(func(ctx context.Context, job Job[string]) (string, error) {
+		return job.Data, nil
+	})
+
+	pool.AddJob(Job[string]{ID: "a", Data: "x"})
+	results, err := pool.Run()
+	ts.NoError(err)
+	ts.Len(results, 1)
+	ts.ErrorContains(results[0].Error, "completion store: connection refused")
+}
+
+func (ts *WorkerPoolTestSuite) TestSQLCompletionStore() {
+	fake := &fakeCompletionDB{keys: map[string]bool{"order-7": true}}
+	db := sql.OpenDB(fake)
+	defer db.Close()
+	store := NewSQLCompletionStore(db, "job_completions")
+
+	var calls int32
+	pool := New[string, string]()
+	pool.WithCompletionStore(store).WithProcessor(func(ctx context.Context, job Job[string]) (string, error) {
+		atomic.AddInt32(&calls, 1)
+		return job.Data, nil
+	})
+	pool.AddJobs([]Job[string]{
+		{ID: "a", Data: "x", IdempotencyKey: "order-1"},
+		{ID: "b", Data: "x", IdempotencyKey: "order-1"},
+		{ID: "c", Data: "x", IdempotencyKey: "order-7"},
+	})
+
+	_, err := pool.Run()
+	ts.NoError(err)
+	ts.Equal(int32(1), atomic.LoadInt32(&calls))
+	ts.Equal(2, pool.GetMetrics().SkippedJobs)
+	ts.True(fake.keys["order-1"])
+
+	// Another process recording the key first is not an error
+	ts.NoError(store.MarkComplete(context.Background(), "order-7"))
+	ts.Contains(fake.queries, "INSERT INTO job_completions (idempotency_key) VALUES (?)")
+
+	complete, err := store.WithNumberedPlaceholders().IsComplete(context.Background(), "order-7")
+	ts.NoError(err)
+	ts.True(complete)
+	ts.Contains(fake.queries, "SELECT COUNT(*) FROM job_completions WHERE idempotency_key = $1")
+}
+
+func (ts *WorkerPoolTestSuite) TestCompletionKeyWaitEndsWithContext() {
+	var locks keyMutex
+	unlock, err := locks.lock(context.Background(), "order-1")
+	ts.Require().NoError(err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = locks.lock(ctx, "order-1")
+	ts.ErrorIs(err, context.DeadlineExceeded)
+
+	unlock()
+	ts.Empty(locks.locks)
+}
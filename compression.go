@@ -0,0 +1,104 @@
+package workerpool
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"sync"
+)
+
+// Codec compresses payloads. Gzip is built in; register others, such as a
+// zstd codec wrapping github.com/klauspost/compress/zstd, with RegisterCodec
+// so that ReadJSONL can decompress what they wrote.
+type Codec interface {
+	Name() string                                             // Name recorded next to compressed payloads, e.g. "zstd"
+	NewWriter(w io.Writer, level int) (io.WriteCloser, error) // Level zero means the codec's default
+	NewReader(r io.Reader) (io.ReadCloser, error)
+}
+
+// Compression configures compression of large payloads
+type Compression struct {
+	Codec   Codec // Nil means gzip
+	Level   int   // Codec-specific level; zero means the codec's default
+	MinSize int   // Payloads smaller than this many bytes are stored as is
+}
+
+// Gzip compresses with compress/gzip
+var Gzip Codec = gzipCodec{}
+
+var (
+	codecs   = map[string]Codec{"gzip": Gzip}
+	codecsMu sync.RWMutex
+)
+
+// RegisterCodec makes a codec available to Decompress under its name,
+// replacing any codec registered under the same name
+func RegisterCodec(codec Codec) {
+	codecsMu.Lock()
+	defer codecsMu.Unlock()
+	codecs[codec.Name()] = codec
+}
+
+// Decompress decompresses a payload compressed with the named codec
+func Decompress(codecName string, compressed []byte) ([]byte, error) {
+	codecsMu.RLock()
+	codec, ok := codecs[codecName]
+	codecsMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("unknown compression codec %q", codecName)
+	}
+
+	r, err := codec.NewReader(bytes.NewReader(compressed))
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	return io.ReadAll(r)
+}
+
+// codec returns the configured codec or gzip
+func (c Compression) codec() Codec {
+	if c.Codec == nil {
+		return Gzip
+	}
+	return c.Codec
+}
+
+// compress compresses data with codec at level
+func compress(codec Codec, level int, data []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	w, err := codec.NewWriter(&buf, level)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := w.Write(data); err != nil {
+		w.Close()
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// gzipCodec is the built-in gzip Codec
+type gzipCodec struct{}
+
+// Name returns "gzip"
+func (gzipCodec) Name() string {
+	return "gzip"
+}
+
+// NewWriter returns a gzip writer; level takes the compress/gzip constants
+func (gzipCodec) NewWriter(w io.Writer, level int) (io.WriteCloser, error) {
+	if level == 0 {
+		level = gzip.DefaultCompression
+	}
+	return gzip.NewWriterLevel(w, level)
+}
+
+// NewReader returns a gzip reader
+func (gzipCodec) NewReader(r io.Reader) (io.ReadCloser, error) {
+	return gzip.NewReader(r)
+}
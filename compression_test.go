@@ -0,0 +1,75 @@
+package workerpool
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// plainCodec stores payloads uncompressed, standing in for a third-party codec
+type plainCodec struct{ name string }
+
+type nopWriteCloser struct{ io.Writer }
+
+func (nopWriteCloser) Close() error { return nil }
+
+func (c plainCodec) Name() string { return c.name }
+
+func (plainCodec) NewWriter(w io.Writer, level int) (io.WriteCloser, error) {
+	return nopWriteCloser{w}, nil
+}
+
+func (plainCodec) NewReader(r io.Reader) (io.ReadCloser, error) {
+	return io.NopCloser(r), nil
+}
+
+func (ts *WorkerPoolTestSuite) TestJSONLSinkCompression() {
+	var buf bytes.Buffer
+	sink := NewJSONLSink[string](&buf).WithCompression(Compression{Level: gzip.BestSpeed, MinSize: 100})
+
+	large := strings.Repeat("payload ", 100)
+	now := time.Now().UTC().Truncate(time.Millisecond)
+	ts.NoError(sink.Write(Result[string]{JobID: "small", Data: "x", Completed: now, Attempts: 1}))
+	ts.NoError(sink.Write(Result[string]{JobID: "large", Data: large, Error: errors.New("partial"), Duration: time.Second}))
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	ts.Require().Len(lines, 2)
+	var small, compressed map[string]any
+	ts.NoError(json.Unmarshal([]byte(lines[0]), &small))
+	ts.NoError(json.Unmarshal([]byte(lines[1]), &compressed))
+	ts.Equal("x", small["data"])
+	ts.NotContains(compressed, "data")
+	ts.Equal("gzip", compressed["data_encoding"])
+	ts.Less(len(lines[1]), len(large))
+
+	results, err := ReadJSONL[string](&buf)
+	ts.NoError(err)
+	ts.Require().Len(results, 2)
+	ts.Equal("x", results[0].Data)
+	ts.True(now.Equal(results[0].Completed))
+	ts.Equal(1, results[0].Attempts)
+	ts.Equal(large, results[1].Data)
+	ts.EqualError(results[1].Error, "partial")
+	ts.Equal(time.Second, results[1].Duration)
+}
+
+func (ts *WorkerPoolTestSuite) TestRegisterCodec() {
+	// The registry is process-wide, so every run registers a new name
+	codec := plainCodec{name: fmt.Sprintf("plain-%d", time.Now().UnixNano())}
+	var buf bytes.Buffer
+	sink := NewJSONLSink[[]int](&buf).WithCompression(Compression{Codec: codec})
+	ts.NoError(sink.Write(Result[[]int]{JobID: "a", Data: []int{1, 2}}))
+
+	_, err := ReadJSONL[[]int](bytes.NewReader(buf.Bytes()))
+	ts.ErrorContains(err, "unknown compression codec")
+
+	RegisterCodec(codec)
+	results, err := ReadJSONL[[]int](&buf)
+	ts.NoError(err)
+	ts.Equal([]int{1, 2}, results[0].Data)
+}
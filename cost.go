@@ -0,0 +1,158 @@
+package workerpool
+
+import (
+	"context"
+	"sync"
+)
+
+// WithCostEstimator sets the function that prices a job in cost units. The
+// estimate is charged against Config.MaxConcurrentCost while the job executes
+// and against run budgets. Without an estimator Job.Cost is used.
+func (wp *WorkerPool[T, R]) WithCostEstimator(estimate func(Job[T]) int) *WorkerPool[T, R] {
+	wp.mu.Lock()
+	defer wp.mu.Unlock()
+	wp.estimator = estimate
+	return wp
+}
+
+// InFlightCost returns the summed cost of the jobs executing right now
+func (wp *WorkerPool[T, R]) InFlightCost() int {
+	return wp.costs.inFlight()
+}
+
+// jobCost returns the estimated cost of a job
+func (wp *WorkerPool[T, R]) jobCost(job Job[T]) int {
+	wp.mu.RLock()
+	estimate := wp.estimator
+	wp.mu.RUnlock()
+
+	if estimate != nil {
+		return estimate(job)
+	}
+	return job.Cost
+}
+
+// costLimiter is a weighted semaphore bounding the cost of executing jobs.
+// Waiters are served in arrival order so expensive jobs are not starved by
+// a stream of cheap ones.
+type costLimiter struct {
+	capacity int
+	used     int
+	waiters  []*costWaiter
+	mu       sync.Mutex
+}
+
+// costWaiter is a job waiting for cost capacity
+type costWaiter struct {
+	cost  int
+	ready chan struct{}
+}
+
+// newCostLimiter creates a limiter; a capacity of zero or less is unlimited
+func newCostLimiter(capacity int) *costLimiter {
+	return &costLimiter{capacity: capacity}
+}
+
+// clamp bounds a cost so a single job never needs more than the capacity.
+// Callers must hold l.mu.
+func (l *costLimiter) clamp(cost int) int {
+	if cost < 0 {
+		return 0
+	}
+	if l.capacity > 0 && cost > l.capacity {
+		return l.capacity
+	}
+	return cost
+}
+
+// acquire blocks until cost fits under the capacity and returns the cost
+// held, which is what must be released
+func (l *costLimiter) acquire(ctx context.Context, cost int) (int, error) {
+	l.mu.Lock()
+	cost = l.clamp(cost)
+	if l.capacity <= 0 || (len(l.waiters) == 0 && l.used+cost <= l.capacity) {
+		l.used += cost
+		l.mu.Unlock()
+		return cost, nil
+	}
+	w := &costWaiter{cost: cost, ready: make(chan struct{})}
+	l.waiters = append(l.waiters, w)
+	l.mu.Unlock()
+
+	select {
+	case <-w.ready:
+		return w.cost, nil
+	case <-ctx.Done():
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	select {
+	case <-w.ready:
+		// Granted while giving up; hand the capacity back
+		l.used -= w.cost
+	default:
+		for i, waiter := range l.waiters {
+			if waiter == w {
+				l.waiters = append(l.waiters[:i], l.waiters[i+1:]...)
+				break
+			}
+		}
+	}
+	l.notifyLocked()
+	return 0, ctx.Err()
+}
+
+// tryAcquire takes cost if it fits under the capacity without waiting, and
+// returns the cost held
+func (l *costLimiter) tryAcquire(cost int) (int, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	cost = l.clamp(cost)
+	if l.capacity <= 0 || (len(l.waiters) == 0 && l.used+cost <= l.capacity) {
+		l.used += cost
+		return cost, true
+	}
+	return 0, false
+}
+
+// release returns cost held since acquire to the limiter
+func (l *costLimiter) release(cost int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.used -= cost
+	l.notifyLocked()
+}
+
+// resize changes the capacity, admitting waiters that now fit. Jobs already
+// executing keep what they hold, so usage may exceed a lowered capacity
+// until they finish.
+func (l *costLimiter) resize(capacity int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.capacity = capacity
+	for _, w := range l.waiters {
+		w.cost = l.clamp(w.cost)
+	}
+	l.notifyLocked()
+}
+
+// inFlight returns the cost currently held
+func (l *costLimiter) inFlight() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.used
+}
+
+// notifyLocked grants capacity to waiters in order. Callers must hold l.mu.
+func (l *costLimiter) notifyLocked() {
+	for len(l.waiters) > 0 {
+		w := l.waiters[0]
+		if l.capacity > 0 && l.used+w.cost > l.capacity {
+			return
+		}
+		l.used += w.cost
+		close(w.ready)
+		l.waiters = l.waiters[1:]
+	}
+}
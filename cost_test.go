@@ -0,0 +1,82 @@
+package workerpool
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+func (ts *WorkerPoolTestSuite) TestMaxConcurrentCost() {
+	config := DefaultConfig()
+	config.NumWorkers = 8
+	config.MaxConcurrentCost = 10
+	pool := NewWithConfig[int, int](config)
+
+	var mu sync.Mutex
+	peak := 0
+	pool.WithCostEstimator(func(job Job[int]) int {
+		return job.Data
+	}).WithProcessor(func(ctx context.Context, job Job[int]) (int, error) {
+		mu.Lock()
+		peak = max(peak, pool.InFlightCost())
+		mu.Unlock()
+		time.Sleep(5 * time.Millisecond)
+		return job.Data, nil
+	})
+
+	var jobs []Job[int]
+	for i := 0; i < 12; i++ {
+		weight := 1
+		if i%4 == 0 {
+			weight = 8
+		}
+		jobs = append(jobs, Job[int]{ID: fmt.Sprintf("%d", i), Data: weight})
+	}
+	// A job costing more than the cap still runs, on its own
+	jobs = append(jobs, Job[int]{ID: "huge", Data: 50})
+	pool.AddJobs(jobs)
+
+	results, err := pool.Run()
+	ts.NoError(err)
+	ts.Len(results, 13)
+	for _, r := range results {
+		ts.NoError(r.Error)
+	}
+	ts.LessOrEqual(peak, 10)
+	ts.Greater(peak, 1)
+	ts.Zero(pool.InFlightCost())
+}
+
+func (ts *WorkerPoolTestSuite) TestCostLimiterCancelledWaiter() {
+	l := newCostLimiter(4)
+	held, err := l.acquire(context.Background(), 3)
+	ts.NoError(err)
+	ts.Equal(3, held)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = l.acquire(ctx, 2)
+	ts.ErrorIs(err, context.Canceled)
+	ts.Equal(3, l.inFlight())
+
+	// Waiters are served in order once capacity frees up
+	granted := make(chan int, 2)
+	go func() {
+		_, _ = l.acquire(context.Background(), 4)
+		granted <- 4
+	}()
+	time.Sleep(5 * time.Millisecond)
+	go func() {
+		_, _ = l.acquire(context.Background(), 1)
+		granted <- 1
+	}()
+	time.Sleep(5 * time.Millisecond)
+
+	l.release(3)
+	ts.Equal(4, <-granted)
+	l.release(4)
+	ts.Equal(1, <-granted)
+	l.release(1)
+	ts.Zero(l.inFlight())
+}
@@ -0,0 +1,42 @@
+package workerpool
+
+import (
+	"runtime"
+	"time"
+)
+
+// process calls the processor for one attempt. With Config.CPUAccounting
+// the goroutine stays on its OS thread for the call, so the thread's CPU
+// clock measures the processor alone, and the CPU time used is added to the
+// attempt's context. Goroutines the processor starts are not counted.
+func (wp *WorkerPool[T, R]) process(ctx *jobContext, job Job[T]) (R, error) {
+	processor := wp.processorFunc()
+	if !wp.config.CPUAccounting {
+		return processor(ctx, job)
+	}
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	start, ok := threadCPUTime()
+	result, err := processor(ctx, job)
+	if end, measured := threadCPUTime(); ok && measured {
+		ctx.cpu.Add(int64(end - start))
+	}
+	return result, err
+}
+
+// recordCPU charges a job's CPU time to the pool, its class and its tenant
+func (wp *WorkerPool[T, R]) recordCPU(job Job[T], cpu time.Duration) {
+	if cpu <= 0 {
+		return
+	}
+	wp.tenants.chargeCPU(job.TenantID, cpu)
+
+	wp.metrics.mu.Lock()
+	defer wp.metrics.mu.Unlock()
+	wp.metrics.CPUTime += cpu
+	if wp.metrics.ClassCPUTime == nil {
+		wp.metrics.ClassCPUTime = make(map[string]time.Duration)
+	}
+	wp.metrics.ClassCPUTime[job.Class] += cpu
+}
@@ -0,0 +1,11 @@
+//go:build !linux
+
+package workerpool
+
+import "time"
+
+// threadCPUTime reports that per-thread CPU time is not available on this
+// platform, so CPU accounting records nothing
+func threadCPUTime() (time.Duration, bool) {
+	return 0, false
+}
@@ -0,0 +1,62 @@
+package workerpool
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"time"
+)
+
+func (ts *WorkerPoolTestSuite) TestCPUAccounting() {
+	if _, ok := threadCPUTime(); !ok {
+		ts.T().Skip("per-thread CPU time not available on " + runtime.GOOS)
+	}
+
+	config := DefaultConfig()
+	config.NumWorkers = 2
+	config.CPUAccounting = true
+	pool := NewWithConfig[int, int](config)
+	pool.WithProcessor(func(ctx context.Context, job Job[int]) (int, error) {
+		if job.Class == "idle" {
+			time.Sleep(20 * time.Millisecond)
+			return 0, nil
+		}
+		// Spin for the job's data in milliseconds of CPU time; the goroutine
+		// is pinned to its thread while accounting is on
+		start, _ := threadCPUTime()
+		n := 0
+		for used, _ := threadCPUTime(); used-start < time.Duration(job.Data)*time.Millisecond; used, _ = threadCPUTime() {
+			n++
+		}
+		return n, nil
+	})
+	for i := 0; i < 4; i++ {
+		pool.AddJob(Job[int]{ID: fmt.Sprint("busy", i), Data: 20, Class: "busy", TenantID: "acme"})
+	}
+	pool.AddJob(Job[int]{ID: "idle", Class: "idle", TenantID: "acme"})
+
+	results, err := pool.Run()
+	ts.NoError(err)
+	for _, r := range results {
+		if r.JobID == "idle" {
+			ts.Less(r.CPUTime, 10*time.Millisecond, "sleeping uses no CPU")
+		} else {
+			ts.GreaterOrEqual(r.CPUTime, 20*time.Millisecond, r.JobID)
+		}
+	}
+
+	metrics := pool.GetMetrics()
+	ts.GreaterOrEqual(metrics.ClassCPUTime["busy"], 80*time.Millisecond)
+	ts.Equal(metrics.CPUTime, metrics.ClassCPUTime["busy"]+metrics.ClassCPUTime["idle"])
+	ts.Equal(metrics.CPUTime, metrics.Tenants["acme"].CPUTime)
+
+	// Without the option nothing is measured
+	pool = NewWithConfig[int, int](DefaultConfig()).WithProcessor(func(ctx context.Context, job Job[int]) (int, error) {
+		return 0, nil
+	})
+	pool.AddJob(Job[int]{ID: "a"})
+	results, err = pool.Run()
+	ts.NoError(err)
+	ts.Zero(results[0].CPUTime)
+	ts.Zero(pool.GetMetrics().CPUTime)
+}
@@ -0,0 +1,158 @@
+package workerpool
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+)
+
+// ErrDependencyFailed is reported for a job that was skipped because one of
+// its dependencies did not succeed, is not part of the run, or is part of a
+// dependency cycle
+var ErrDependencyFailed = errors.New("dependency did not succeed")
+
+// DependsOn returns a copy of the job that only becomes eligible once the
+// jobs with the given IDs, in the same run, have succeeded. If any of them
+// fails or is skipped, the job is skipped too with ErrDependencyFailed.
+// For the run, dependencies inherit the job's priority when it is higher
+// than their own.
+func (j Job[T]) DependsOn(ids ...string) Job[T] {
+	j.Dependencies = append(slices.Clone(j.Dependencies), ids...)
+	return j
+}
+
+// inheritPriorities raises every job's priority to the highest priority of
+// the jobs that transitively depend on it, so a critical job is not held up
+// by its own low-priority prerequisites
+func inheritPriorities[T any](jobs []Job[T]) {
+	byID := make(map[string][]int, len(jobs))
+	hasDeps := false
+	for i, job := range jobs {
+		byID[job.ID] = append(byID[job.ID], i)
+		hasDeps = hasDeps || len(job.Dependencies) > 0
+	}
+	if !hasDeps {
+		return
+	}
+
+	var raise func(deps []string, priority int)
+	raise = func(deps []string, priority int) {
+		for _, id := range deps {
+			for _, i := range byID[id] {
+				// Only descend while raising, which also stops at cycles
+				if jobs[i].Priority < priority {
+					jobs[i].Priority = priority
+					raise(jobs[i].Dependencies, priority)
+				}
+			}
+		}
+	}
+	for i := range jobs {
+		raise(jobs[i].Dependencies, jobs[i].Priority)
+	}
+}
+
+// dependencyTracker records job outcomes during a run so that jobs with
+// dependencies can be released in dependency order
+type dependencyTracker struct {
+	inRun     map[string]bool // IDs of the jobs in the run
+	succeeded map[string]bool // Outcome of every job that has finished
+	mu        sync.Mutex
+}
+
+// newDependencyTracker creates a tracker for the jobs of a run
+func newDependencyTracker[T any](jobs []Job[T]) *dependencyTracker {
+	d := &dependencyTracker{
+		inRun:     make(map[string]bool, len(jobs)),
+		succeeded: make(map[string]bool, len(jobs)),
+	}
+	for _, job := range jobs {
+		d.inRun[job.ID] = true
+	}
+	return d
+}
+
+// record stores the outcome of a finished job
+func (d *dependencyTracker) record(id string, ok bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.inRun[id] = true
+	d.succeeded[id] = ok
+}
+
+// check reports whether every dependency of job succeeded, or with
+// anyOutcome merely finished. When the job can never become eligible it
+// returns the reason.
+func (d *dependencyTracker) check(deps []string, anyOutcome bool) (eligible bool, err error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	eligible = true
+	for _, id := range deps {
+		ok, finished := d.succeeded[id]
+		switch {
+		case !d.inRun[id]:
+			return false, fmt.Errorf("%w: %s is not in the run", ErrDependencyFailed, id)
+		case finished && !ok && !anyOutcome:
+			return false, fmt.Errorf("%w: %s failed", ErrDependencyFailed, id)
+		case !finished:
+			eligible = false
+		}
+	}
+	return eligible, nil
+}
+
+// resolveDependencies splits jobs into those whose dependencies have all
+// succeeded, those still waiting on unfinished dependencies, and skipped
+// results for those whose dependencies can no longer succeed
+func (wp *WorkerPool[T, R]) resolveDependencies(deps *dependencyTracker, jobs []Job[T]) (ready, waiting []Job[T], skipped []Result[R]) {
+	for _, job := range jobs {
+		anyOutcome := wp.gateAll != nil && wp.gateAll(job)
+		eligible, err := deps.check(job.Dependencies, anyOutcome)
+		if eligible && wp.gate != nil {
+			if err = wp.gate(job); err != nil {
+				eligible = false
+			}
+		}
+		switch {
+		case err != nil:
+			skipped = append(skipped, wp.skipJob(job, err))
+		case eligible:
+			ready = append(ready, job)
+		default:
+			waiting = append(waiting, job)
+		}
+	}
+	return ready, waiting, skipped
+}
+
+// skipJob takes a job out of the run without executing it and returns its result
+func (wp *WorkerPool[T, R]) skipJob(job Job[T], err error) Result[R] {
+	wp.mu.RLock()
+	pending := wp.pending
+	wp.mu.RUnlock()
+	if pending != nil {
+		pending.claim(job.ID)
+	}
+	wp.tenants.dequeue(job.TenantID)
+	wp.recordFailure(job)
+
+	now := time.Now()
+	return Result[R]{
+		JobID:     job.ID,
+		Error:     err,
+		Started:   now,
+		Completed: now,
+	}
+}
+
+// sendWave hands already-known results to the collector as a wave of their own
+func sendWave[R any](waves chan<- resultWave[R], results []Result[R]) {
+	waves <- func(emit func(Result[R])) {
+		for _, result := range results {
+			emit(result)
+		}
+	}
+}
@@ -0,0 +1,155 @@
+package workerpool
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+func (ts *WorkerPoolTestSuite) TestDependenciesRunInOrder() {
+	config := DefaultConfig()
+	config.NumWorkers = 4
+	pool := NewWithConfig[string, string](config)
+
+	var mu sync.Mutex
+	var order []string
+	pool.WithProcessor(func(ctx context.Context, job Job[string]) (string, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		order = append(order, job.ID)
+		return job.ID, nil
+	})
+
+	pool.AddJobs([]Job[string]{
+		Job[string]{ID: "report"}.DependsOn("merge"),
+		Job[string]{ID: "merge"}.DependsOn("fetch-a", "fetch-b"),
+		{ID: "fetch-a"},
+		{ID: "fetch-b"},
+	})
+	results, err := pool.Run()
+	ts.NoError(err)
+	ts.Len(results, 4)
+
+	index := make(map[string]int)
+	for i, id := range order {
+		index[id] = i
+	}
+	ts.Less(index["fetch-a"], index["merge"])
+	ts.Less(index["fetch-b"], index["merge"])
+	ts.Less(index["merge"], index["report"])
+}
+
+func (ts *WorkerPoolTestSuite) TestFailedDependencyCascades() {
+	config := DefaultConfig()
+	config.MaxRetries = 0
+	pool := NewWithConfig[string, string](config)
+
+	var mu sync.Mutex
+	ran := make(map[string]bool)
+	pool.WithProcessor(func(ctx context.Context, job Job[string]) (string, error) {
+		mu.Lock()
+		ran[job.ID] = true
+		mu.Unlock()
+		if job.ID == "extract" {
+			return "", errors.New("source unavailable")
+		}
+		return job.ID, nil
+	})
+
+	pool.AddJobs([]Job[string]{
+		{ID: "extract"},
+		Job[string]{ID: "transform"}.DependsOn("extract"),
+		Job[string]{ID: "load"}.DependsOn("transform"),
+		{ID: "independent"},
+	})
+	results, err := pool.Run()
+	ts.NoError(err)
+	ts.Len(results, 4)
+
+	byID := make(map[string]Result[string])
+	for _, r := range results {
+		byID[r.JobID] = r
+	}
+	ts.NoError(byID["independent"].Error)
+	ts.ErrorIs(byID["transform"].Error, ErrDependencyFailed)
+	ts.ErrorIs(byID["load"].Error, ErrDependencyFailed)
+	ts.False(ran["transform"])
+	ts.False(ran["load"])
+
+	m := pool.GetMetrics()
+	ts.Equal(1, m.FailedJobs)
+	ts.Equal(2, m.SkippedJobs)
+}
+
+func (ts *WorkerPoolTestSuite) TestDependencyCycleAndUnknownDependency() {
+	pool := New[string, string]()
+	pool.WithProcessor(func(ctx context.Context, job Job[string]) (string, error) {
+		return job.ID, nil
+	})
+
+	pool.AddJobs([]Job[string]{
+		Job[string]{ID: "a"}.DependsOn("b"),
+		Job[string]{ID: "b"}.DependsOn("a"),
+		Job[string]{ID: "c"}.DependsOn("missing"),
+		{ID: "d"},
+	})
+	results, err := pool.Run()
+	ts.NoError(err)
+	ts.Len(results, 4)
+
+	for _, r := range results {
+		if r.JobID == "d" {
+			ts.NoError(r.Error)
+			continue
+		}
+		ts.ErrorIs(r.Error, ErrDependencyFailed, r.JobID)
+	}
+}
+
+func (ts *WorkerPoolTestSuite) TestDependsOnDoesNotAlias() {
+	base := Job[int]{ID: "x"}.DependsOn("a")
+	one := base.DependsOn("b")
+	two := base.DependsOn("c")
+	ts.Equal([]string{"a"}, base.Dependencies)
+	ts.Equal([]string{"a", "b"}, one.Dependencies)
+	ts.Equal([]string{"a", "c"}, two.Dependencies)
+}
+
+func (ts *WorkerPoolTestSuite) TestDependenciesInheritPriority() {
+	config := DefaultConfig()
+	config.NumWorkers = 1
+	config.Strategy = PriorityBased
+	pool := NewWithConfig[string, string](config)
+
+	var order []string
+	pool.WithProcessor(func(ctx context.Context, job Job[string]) (string, error) {
+		order = append(order, job.ID)
+		return job.ID, nil
+	})
+
+	jobs := []Job[string]{
+		Job[string]{ID: "critical", Priority: 10}.DependsOn("schema"),
+		{ID: "schema", Priority: 0},
+	}
+	for _, id := range []string{"noise-1", "noise-2", "noise-3"} {
+		jobs = append(jobs, Job[string]{ID: id, Priority: 5})
+	}
+	pool.AddJobs(jobs)
+	_, err := pool.Run()
+	ts.NoError(err)
+
+	// The prerequisite runs at the critical job's priority, ahead of the noise
+	ts.Equal("schema", order[0])
+}
+
+func (ts *WorkerPoolTestSuite) TestInheritPrioritiesTransitiveAndCyclic() {
+	jobs := []Job[int]{
+		Job[int]{ID: "top", Priority: 9}.DependsOn("mid"),
+		Job[int]{ID: "mid", Priority: 1}.DependsOn("leaf"),
+		{ID: "leaf", Priority: 3},
+		Job[int]{ID: "x", Priority: 2}.DependsOn("y"),
+		Job[int]{ID: "y", Priority: 4}.DependsOn("x"),
+	}
+	inheritPriorities(jobs)
+	ts.Equal([]int{9, 9, 9, 4, 4}, []int{jobs[0].Priority, jobs[1].Priority, jobs[2].Priority, jobs[3].Priority, jobs[4].Priority})
+}
@@ -0,0 +1,156 @@
+package workerpool
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// RetryDamping eases retry pressure on a struggling dependency. When the
+// failure rate of the last Window attempts across the pool reaches
+// Threshold, retry backoff is multiplied by BackoffFactor and at most
+// MaxConcurrentRetries retries run at once. Damping stays on until the rate
+// drops to Release, so it does not flap around the threshold. A zero
+// Threshold disables damping.
+type RetryDamping struct {
+	Threshold            float64 // Failure rate (0-1) that turns damping on
+	Release              float64 // Failure rate that turns it off again; defaults to Threshold/2
+	Window               int     // Attempts the rate is measured over; defaults to 20
+	BackoffFactor        float64 // Backoff multiplier while damped; defaults to 4
+	MaxConcurrentRetries int     // Retries allowed in flight while damped; zero means no limit
+}
+
+// defaultDampingWindow is the rate window when RetryDamping.Window is unset
+const defaultDampingWindow = 20
+
+// retryDamper tracks the pool-wide failure rate and applies RetryDamping
+type retryDamper struct {
+	cfg      RetryDamping
+	outcomes []bool // Ring of recent attempt outcomes; true means failed
+	next     int
+	filled   int
+	failures int
+	damped   bool
+	retries  int           // Retries in flight while damped
+	changed  chan struct{} // Closed when a retry slot frees or damping ends
+	mu       sync.Mutex
+}
+
+// newRetryDamper creates a damper for cfg, or nil when damping is disabled
+func newRetryDamper(cfg RetryDamping) *retryDamper {
+	if cfg.Threshold <= 0 {
+		return nil
+	}
+	if cfg.Release <= 0 || cfg.Release > cfg.Threshold {
+		cfg.Release = cfg.Threshold / 2
+	}
+	if cfg.Window <= 0 {
+		cfg.Window = defaultDampingWindow
+	}
+	if cfg.BackoffFactor <= 0 {
+		cfg.BackoffFactor = 4
+	}
+	return &retryDamper{
+		cfg:      cfg,
+		outcomes: make([]bool, cfg.Window),
+		changed:  make(chan struct{}),
+	}
+}
+
+// RetryDamped reports whether retry damping is currently engaged
+func (wp *WorkerPool[T, R]) RetryDamped() bool {
+	d := wp.damper
+	if d == nil {
+		return false
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.damped
+}
+
+// observe records the outcome of an attempt and updates the damping state
+func (d *retryDamper) observe(failed bool) {
+	if d == nil {
+		return
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.filled == len(d.outcomes) && d.outcomes[d.next] {
+		d.failures--
+	}
+	d.outcomes[d.next] = failed
+	if failed {
+		d.failures++
+	}
+	d.next = (d.next + 1) % len(d.outcomes)
+	d.filled = min(d.filled+1, len(d.outcomes))
+
+	// Only judge the rate once the window is full
+	if d.filled < len(d.outcomes) {
+		return
+	}
+	rate := float64(d.failures) / float64(d.filled)
+	switch {
+	case !d.damped && rate >= d.cfg.Threshold:
+		d.damped = true
+	case d.damped && rate <= d.cfg.Release:
+		d.damped = false
+		d.notifyLocked()
+	}
+}
+
+// backoff stretches a retry delay while damped
+func (d *retryDamper) backoff(delay time.Duration) time.Duration {
+	if d == nil {
+		return delay
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if !d.damped {
+		return delay
+	}
+	return time.Duration(float64(delay) * d.cfg.BackoffFactor)
+}
+
+// acquire waits for a retry slot while damped and returns the function that
+// frees it once the retry attempt is over
+func (d *retryDamper) acquire(ctx context.Context) (func(), error) {
+	if d == nil || d.cfg.MaxConcurrentRetries <= 0 {
+		return func() {}, nil
+	}
+	for {
+		d.mu.Lock()
+		if !d.damped {
+			d.mu.Unlock()
+			return func() {}, nil
+		}
+		if d.retries < d.cfg.MaxConcurrentRetries {
+			d.retries++
+			d.mu.Unlock()
+			return d.release, nil
+		}
+		changed := d.changed
+		d.mu.Unlock()
+
+		select {
+		case <-changed:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// release frees a retry slot
+func (d *retryDamper) release() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.retries--
+	d.notifyLocked()
+}
+
+// notifyLocked wakes retries waiting for a slot. Callers must hold d.mu.
+func (d *retryDamper) notifyLocked() {
+	close(d.changed)
+	d.changed = make(chan struct{})
+}
@@ -0,0 +1,92 @@
+package workerpool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+func (ts *WorkerPoolTestSuite) TestRetryDamperHysteresis() {
+	d := newRetryDamper(RetryDamping{Threshold: 0.5, Release: 0.25, Window: 4, BackoffFactor: 3})
+
+	// Not judged until the window fills
+	d.observe(true)
+	d.observe(true)
+	d.observe(true)
+	ts.False(d.damped)
+	ts.Equal(time.Second, d.backoff(time.Second))
+
+	d.observe(false) // 3 of 4 failed
+	ts.True(d.damped)
+	ts.Equal(3*time.Second, d.backoff(time.Second))
+
+	// Between Release and Threshold damping stays on
+	d.observe(false) // 2 of 4
+	ts.True(d.damped)
+	d.observe(false) // 1 of 4: down to Release
+	ts.False(d.damped)
+
+	d.observe(false) // 0 of 4
+	d.observe(true)  // 1 of 4: below Threshold
+	ts.False(d.damped)
+	d.observe(true) // 2 of 4: at Threshold again
+	ts.True(d.damped)
+}
+
+func (ts *WorkerPoolTestSuite) TestRetryDamperLimitsConcurrentRetries() {
+	d := newRetryDamper(RetryDamping{Threshold: 0.5, Window: 2, MaxConcurrentRetries: 1})
+	d.observe(true)
+	d.observe(true)
+	ts.True(d.damped)
+
+	release, err := d.acquire(context.Background())
+	ts.NoError(err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err = d.acquire(ctx)
+	ts.ErrorIs(err, context.DeadlineExceeded)
+
+	acquired := make(chan struct{})
+	go func() {
+		next, err := d.acquire(context.Background())
+		ts.NoError(err)
+		next()
+		close(acquired)
+	}()
+	release()
+	<-acquired
+
+	// Once damping ends retries are no longer limited
+	d.observe(false)
+	d.observe(false)
+	ts.False(d.damped)
+	r1, _ := d.acquire(context.Background())
+	r2, _ := d.acquire(context.Background())
+	r1()
+	r2()
+
+	ts.Nil(newRetryDamper(RetryDamping{}))
+}
+
+func (ts *WorkerPoolTestSuite) TestRetryDampingEngagesDuringRun() {
+	config := DefaultConfig()
+	config.NumWorkers = 2
+	config.MaxRetries = 1
+	config.RetryDamping = RetryDamping{Threshold: 0.8, Window: 4, BackoffFactor: 1}
+	pool := NewWithConfig[int, int](config)
+	pool.WithProcessor(func(ctx context.Context, job Job[int]) (int, error) {
+		return 0, errors.New("dependency down")
+	})
+
+	var jobs []Job[int]
+	for i := 0; i < 4; i++ {
+		jobs = append(jobs, Job[int]{ID: fmt.Sprintf("%d", i)})
+	}
+	pool.AddJobs(jobs)
+	ts.False(pool.RetryDamped())
+	_, err := pool.Run()
+	ts.NoError(err)
+	ts.True(pool.RetryDamped())
+}
@@ -0,0 +1,151 @@
+package workerpool
+
+import (
+	"sync"
+	"time"
+)
+
+// DeadlineMissBounds are the upper bounds of the miss magnitude histogram
+// in DeadlineStats; misses beyond the last bound are counted in a final
+// bucket
+var DeadlineMissBounds = []time.Duration{
+	10 * time.Millisecond, 100 * time.Millisecond, time.Second,
+	10 * time.Second, time.Minute, 10 * time.Minute, time.Hour,
+}
+
+// deadlineSlots is how many slots the rolling SLO window is divided into
+const deadlineSlots = 60
+
+// DeadlineSLO is the objective for jobs carrying a Job.Deadline: at least
+// Target of them should complete by their deadline. It is applied to every
+// class on its own. A job completes when its result is produced, whatever
+// its outcome; jobs skipped without running are not counted.
+type DeadlineSLO struct {
+	Target float64       // Fraction of jobs that must meet their deadline, e.g. 0.99; zero tracks hits and misses without a burn rate
+	Window time.Duration // Rolling window the burn rate is computed over; defaults to one hour
+}
+
+// DeadlineStats are the deadline outcomes of one class
+type DeadlineStats struct {
+	Hits          int     `json:"hits"`
+	Misses        int     `json:"misses"`
+	HitRatio      float64 `json:"hit_ratio"`
+	MissHistogram []int   `json:"miss_histogram"` // Misses by how late they were, bucketed by DeadlineMissBounds
+
+	WindowHits   int `json:"window_hits"`   // Hits within the rolling window
+	WindowMisses int `json:"window_misses"` // Misses within the rolling window
+
+	// BurnRate is the miss ratio within the window divided by the error
+	// budget 1-Target: at 1 the budget is spent exactly as fast as the SLO
+	// allows, above 1 it runs out early. Zero without a Target.
+	BurnRate float64 `json:"burn_rate"`
+}
+
+// deadlineSlot counts the outcomes of one slice of the rolling window
+type deadlineSlot struct {
+	start        time.Time
+	hits, misses int
+}
+
+// classDeadlines tracks one class
+type classDeadlines struct {
+	hits, misses int
+	histogram    []int
+	slots        [deadlineSlots]deadlineSlot
+}
+
+// deadlineTracker keeps deadline outcomes per class across runs
+type deadlineTracker struct {
+	slo     DeadlineSLO
+	classes map[string]*classDeadlines
+	mu      sync.Mutex
+}
+
+// newDeadlineTracker creates a tracker for slo
+func newDeadlineTracker(slo DeadlineSLO) *deadlineTracker {
+	if slo.Window <= 0 {
+		slo.Window = time.Hour
+	}
+	return &deadlineTracker{slo: slo, classes: make(map[string]*classDeadlines)}
+}
+
+// record counts a job with a deadline that completed at completed
+func (t *deadlineTracker) record(class string, deadline, completed time.Time) {
+	if deadline.IsZero() {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	c := t.classes[class]
+	if c == nil {
+		c = &classDeadlines{histogram: make([]int, len(DeadlineMissBounds)+1)}
+		t.classes[class] = c
+	}
+	slot := t.slotLocked(c, completed)
+	late := completed.Sub(deadline)
+	if late <= 0 {
+		c.hits++
+		slot.hits++
+		return
+	}
+	c.misses++
+	slot.misses++
+	bucket := len(DeadlineMissBounds)
+	for i, bound := range DeadlineMissBounds {
+		if late <= bound {
+			bucket = i
+			break
+		}
+	}
+	c.histogram[bucket]++
+}
+
+// slotLocked returns the window slot covering at, clearing it if it last
+// covered an earlier slice. Outcomes older than the slot's current slice
+// are outside the window and get a throwaway slot. Callers must hold t.mu.
+func (t *deadlineTracker) slotLocked(c *classDeadlines, at time.Time) *deadlineSlot {
+	width := t.slo.Window / deadlineSlots
+	start := at.Truncate(width)
+	slot := &c.slots[int(start.UnixNano()/int64(width))%deadlineSlots]
+	switch {
+	case slot.start.After(start):
+		return &deadlineSlot{}
+	case slot.start.Before(start):
+		*slot = deadlineSlot{start: start}
+	}
+	return slot
+}
+
+// stats returns the outcomes of every class as of now
+func (t *deadlineTracker) stats(now time.Time) map[string]DeadlineStats {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	stats := make(map[string]DeadlineStats, len(t.classes))
+	for class, c := range t.classes {
+		s := DeadlineStats{
+			Hits:          c.hits,
+			Misses:        c.misses,
+			HitRatio:      float64(c.hits) / float64(c.hits+c.misses),
+			MissHistogram: append([]int(nil), c.histogram...),
+		}
+		for _, slot := range c.slots {
+			if now.Sub(slot.start) < t.slo.Window {
+				s.WindowHits += slot.hits
+				s.WindowMisses += slot.misses
+			}
+		}
+		if total := s.WindowHits + s.WindowMisses; total > 0 && t.slo.Target > 0 && t.slo.Target < 1 {
+			s.BurnRate = float64(s.WindowMisses) / float64(total) / (1 - t.slo.Target)
+		}
+		stats[class] = s
+	}
+	return stats
+}
+
+// DeadlineStats returns the deadline outcomes of jobs carrying a
+// Job.Deadline, keyed by Job.Class, over every run of the pool
+func (wp *WorkerPool[T, R]) DeadlineStats() map[string]DeadlineStats {
+	return wp.deadlines.stats(time.Now())
+}
@@ -0,0 +1,63 @@
+package workerpool
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+func (ts *WorkerPoolTestSuite) TestDeadlineTracker() {
+	t := newDeadlineTracker(DeadlineSLO{Target: 0.9, Window: time.Minute})
+	now := time.Now()
+
+	t.record("report", time.Time{}, now) // No deadline: not tracked
+	for i := 0; i < 8; i++ {
+		t.record("report", now, now.Add(-time.Second))
+	}
+	t.record("report", now.Add(-50*time.Millisecond), now)
+	t.record("report", now.Add(-2*time.Hour), now)
+	// Outcomes older than the window count in totals only
+	t.record("report", now.Add(-5*time.Minute), now.Add(-4*time.Minute))
+
+	stats := t.stats(now)["report"]
+	ts.Equal(8, stats.Hits)
+	ts.Equal(3, stats.Misses)
+	ts.InDelta(8.0/11, stats.HitRatio, 0.0001)
+	ts.Equal([]int{0, 1, 0, 0, 1, 0, 0, 1}, stats.MissHistogram)
+	ts.Equal(8, stats.WindowHits)
+	ts.Equal(2, stats.WindowMisses)
+	ts.InDelta(2.0, stats.BurnRate, 0.0001, "20% misses against a 10% budget")
+
+	// The window rolls on
+	stats = t.stats(now.Add(2 * time.Minute))["report"]
+	ts.Zero(stats.WindowHits + stats.WindowMisses)
+	ts.Zero(stats.BurnRate)
+	ts.Equal(11, stats.Hits+stats.Misses)
+}
+
+func (ts *WorkerPoolTestSuite) TestDeadlineStatsDuringRun() {
+	config := DefaultConfig()
+	config.NumWorkers = 1
+	config.DeadlineSLO = DeadlineSLO{Target: 0.5}
+	pool := NewWithConfig[int, int](config).WithProcessor(func(ctx context.Context, job Job[int]) (int, error) {
+		time.Sleep(time.Duration(job.Data) * time.Millisecond)
+		return job.Data, nil
+	})
+	now := time.Now()
+	for i := 0; i < 3; i++ {
+		pool.AddJob(Job[int]{ID: fmt.Sprint("on-time", i), Class: "batch", Deadline: now.Add(time.Hour)})
+	}
+	pool.AddJob(Job[int]{ID: "late", Data: 5, Class: "batch", Deadline: now})
+	pool.AddJob(Job[int]{ID: "untracked", Class: "batch"})
+	pool.AddJob(Job[int]{ID: "expired", Class: "other", ExpiresAt: now, Deadline: now.Add(-time.Second)})
+
+	_, err := pool.Run()
+	ts.NoError(err)
+
+	stats := pool.DeadlineStats()
+	ts.Equal(3, stats["batch"].Hits)
+	ts.Equal(1, stats["batch"].Misses)
+	ts.InDelta(0.5, stats["batch"].BurnRate, 0.0001)
+	ts.Equal(1, stats["other"].Misses)
+	ts.Equal(1, stats["other"].MissHistogram[len(DeadlineMissBounds)-4])
+}
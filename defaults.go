@@ -0,0 +1,75 @@
+package workerpool
+
+import "sync"
+
+var (
+	defaults   *Config
+	defaultsMu sync.RWMutex
+)
+
+// SetDefaults overrides the configuration returned by DefaultConfig and used
+// by New, so libraries embedding pools inherit an application's tuning.
+// Pools that already exist are not affected.
+func SetDefaults(config Config) {
+	c := config.clone()
+
+	defaultsMu.Lock()
+	defer defaultsMu.Unlock()
+	defaults = &c
+}
+
+// ResetDefaults restores the built-in default configuration
+func ResetDefaults() {
+	defaultsMu.Lock()
+	defer defaultsMu.Unlock()
+	defaults = nil
+}
+
+// clone returns a copy of the config that shares no maps or slices
+func (c Config) clone() Config {
+	if c.TenantQuotas != nil {
+		quotas := make(map[string]TenantQuota, len(c.TenantQuotas))
+		for tenant, quota := range c.TenantQuotas {
+			quotas[tenant] = quota
+		}
+		c.TenantQuotas = quotas
+	}
+	if c.ClassWindows != nil {
+		windows := make(map[string]ExecutionWindow, len(c.ClassWindows))
+		for class, window := range c.ClassWindows {
+			windows[class] = window
+		}
+		c.ClassWindows = windows
+	}
+	if c.JobClasses != nil {
+		classes := make(map[string]JobClass, len(c.JobClasses))
+		for class, route := range c.JobClasses {
+			classes[class] = route
+		}
+		c.JobClasses = classes
+	}
+	if c.ClassConcurrency != nil {
+		limits := make(map[string]int, len(c.ClassConcurrency))
+		for class, limit := range c.ClassConcurrency {
+			limits[class] = limit
+		}
+		c.ClassConcurrency = limits
+	}
+	if c.ClassBudgets != nil {
+		budgets := make(map[string]Budget, len(c.ClassBudgets))
+		for class, budget := range c.ClassBudgets {
+			budgets[class] = budget
+		}
+		c.ClassBudgets = budgets
+	}
+	if c.RetryBands != nil {
+		c.RetryBands = append([]RetryBand(nil), c.RetryBands...)
+	}
+	if c.PriorityLanes != nil {
+		c.PriorityLanes = append([]PriorityLane(nil), c.PriorityLanes...)
+	}
+	if c.GCPressure.Classes != nil {
+		c.GCPressure.Classes = append([]string(nil), c.GCPressure.Classes...)
+	}
+	return c
+}
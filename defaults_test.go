@@ -0,0 +1,27 @@
+package workerpool
+
+import "time"
+
+func (ts *WorkerPoolTestSuite) TestSetDefaults() {
+	defer ResetDefaults()
+
+	tuned := DefaultConfig()
+	tuned.NumWorkers = 16
+	tuned.Timeout = time.Minute
+	tuned.TenantQuotas = map[string]TenantQuota{"acme": {MaxInFlight: 2}}
+	tuned.GCPressure.Classes = []string{"import"}
+	SetDefaults(tuned)
+
+	// Later changes to the caller's config do not leak into the defaults
+	tuned.TenantQuotas["acme"] = TenantQuota{MaxInFlight: 99}
+	tuned.GCPressure.Classes[0] = "report"
+
+	pool := New[string, string]()
+	ts.Equal(16, pool.GetNumWorkers())
+	ts.Equal(time.Minute, pool.config.Timeout)
+	ts.Equal(2, DefaultConfig().TenantQuotas["acme"].MaxInFlight)
+	ts.Equal([]string{"import"}, DefaultConfig().GCPressure.Classes)
+
+	ResetDefaults()
+	ts.Equal(4, New[string, string]().GetNumWorkers())
+}
@@ -0,0 +1,82 @@
+package workerpool
+
+import "sync"
+
+// WithDuplicateResults sets a function receiving every result a job
+// produced after its first, e.g. when a redelivered job and its abandoned
+// delivery both complete. Only the first result of a job is kept: later
+// ones are not returned by Run, fed to sinks or counted as processed or
+// failed, and Metrics.DuplicateResults counts them. Jobs sharing an ID are
+// allowed one result each. The handler runs on the result collector and
+// should not block.
+func (wp *WorkerPool[T, R]) WithDuplicateResults(handler func(first, duplicate Result[R])) *WorkerPool[T, R] {
+	wp.mu.Lock()
+	defer wp.mu.Unlock()
+	wp.onDuplicate = handler
+	return wp
+}
+
+// resultGuard keeps the first result of every job of a run. All methods
+// are safe to call on a nil *resultGuard.
+type resultGuard[R any] struct {
+	expected map[string]int       // Jobs in the run by ID, each entitled to one result
+	seen     map[string]int       // Results admitted by job ID
+	first    map[string]Result[R] // First result by job ID, kept only for a handler
+	keep     bool
+	mu       sync.Mutex
+}
+
+// newResultGuard expects one result per job, keeping first results when
+// keep is set so they can be handed to the duplicate handler
+func newResultGuard[T, R any](jobs []Job[T], keep bool) *resultGuard[R] {
+	g := &resultGuard[R]{expected: make(map[string]int), seen: make(map[string]int), keep: keep}
+	for _, job := range jobs {
+		g.expected[job.ID]++
+	}
+	if keep {
+		g.first = make(map[string]Result[R])
+	}
+	return g
+}
+
+// expect entitles a job that joined the run late, e.g. a requeued one, to
+// a result of its own
+func (g *resultGuard[R]) expect(id string) {
+	if g == nil {
+		return
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.expected[id]++
+}
+
+// admit reports whether result is one its job is entitled to, or returns
+// the job's first result if it is a duplicate. Results of jobs the guard
+// never heard of are admitted once.
+func (g *resultGuard[R]) admit(result Result[R]) (Result[R], bool) {
+	if g == nil {
+		return Result[R]{}, true
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	id := result.JobID
+	if g.seen[id] >= max(g.expected[id], 1) {
+		return g.first[id], false
+	}
+	if g.seen[id] == 0 && g.keep {
+		g.first[id] = result
+	}
+	g.seen[id]++
+	return Result[R]{}, true
+}
+
+// duplicateResult counts a suppressed result and passes it on
+func (wp *WorkerPool[T, R]) duplicateResult(handler func(first, duplicate Result[R]), first, duplicate Result[R]) {
+	wp.metrics.mu.Lock()
+	wp.metrics.DuplicateResults++
+	wp.metrics.mu.Unlock()
+
+	if handler != nil {
+		handler(first, duplicate)
+	}
+}
@@ -0,0 +1,28 @@
+package workerpool
+
+import "fmt"
+
+func (ts *WorkerPoolTestSuite) TestResultGuardKeepsFirstResult() {
+	// Jobs sharing an ID each keep their own result
+	jobs := []Job[int]{{ID: "a"}, {ID: "b"}, {ID: "b"}}
+	guard := newResultGuard[int, int](jobs, true)
+
+	pool := New[int, int]()
+	var conflicts []string
+	pool.WithDuplicateResults(func(first, duplicate Result[int]) {
+		conflicts = append(conflicts, fmt.Sprintf("%s:%d/%d", first.JobID, first.Data, duplicate.Data))
+	})
+
+	kept := 0
+	for i, id := range []string{"a", "b", "a", "b", "b", "late", "late"} {
+		result := Result[int]{JobID: id, Data: i}
+		if first, ok := guard.admit(result); ok {
+			kept++
+		} else {
+			pool.duplicateResult(pool.onDuplicate, first, result)
+		}
+	}
+	ts.Equal(4, kept, "a once, b twice and the job submitted mid-run once")
+	ts.Equal([]string{"a:0/2", "b:1/4", "late:5/6"}, conflicts)
+	ts.Equal(3, pool.GetMetrics().DuplicateResults)
+}
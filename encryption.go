@@ -0,0 +1,123 @@
+package workerpool
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// sealedVersion is the format version of payloads sealed by an Encryptor
+const sealedVersion = 1
+
+// ErrDecrypt is returned when a sealed payload cannot be opened: it is
+// corrupt, was tampered with, or its key is unavailable
+var ErrDecrypt = errors.New("cannot decrypt payload")
+
+// KeyProvider supplies AES keys to an Encryptor. Implement it over a KMS to
+// fetch or unwrap data keys; key IDs are stored with every sealed payload,
+// so old keys must stay available for decryption after a rotation.
+type KeyProvider interface {
+	EncryptionKey() (id string, key []byte, err error) // Key to seal new payloads with
+	DecryptionKey(id string) ([]byte, error)           // Key a payload was sealed with
+}
+
+// StaticKeys is a KeyProvider over keys held in memory, e.g. loaded from a
+// secret store at startup. Keys must be 16, 24 or 32 bytes long.
+type StaticKeys struct {
+	Current string            // ID of the key new payloads are sealed with
+	Keys    map[string][]byte // Every key that may still be needed, by ID
+}
+
+// EncryptionKey returns the current key
+func (k StaticKeys) EncryptionKey() (string, []byte, error) {
+	key, err := k.DecryptionKey(k.Current)
+	return k.Current, key, err
+}
+
+// DecryptionKey returns the key with the given ID
+func (k StaticKeys) DecryptionKey(id string) ([]byte, error) {
+	key, ok := k.Keys[id]
+	if !ok {
+		return nil, fmt.Errorf("no key %q", id)
+	}
+	return key, nil
+}
+
+// Encryptor seals payloads with AES-GCM before they are written to disk, so
+// PII in jobs and results is never stored in plaintext. Attach it with
+// JSONLSink.WithEncryption or EnvelopeRegistry.WithEncryption, or journal
+// queued jobs with NewEncryptedFileQueue.
+type Encryptor struct {
+	keys KeyProvider
+}
+
+// NewEncryptor creates an encryptor using keys from keys
+func NewEncryptor(keys KeyProvider) *Encryptor {
+	return &Encryptor{keys: keys}
+}
+
+// Seal encrypts plaintext, binding it to aad so it cannot be moved to
+// another record unnoticed. The result holds the format version, the key ID
+// and the nonce, followed by the ciphertext.
+func (e *Encryptor) Seal(plaintext, aad []byte) ([]byte, error) {
+	id, key, err := e.keys.EncryptionKey()
+	if err != nil {
+		return nil, fmt.Errorf("encryption key: %w", err)
+	}
+	if len(id) > 255 {
+		return nil, fmt.Errorf("key ID %q longer than 255 bytes", id)
+	}
+	aead, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+
+	sealed := make([]byte, 0, 2+len(id)+aead.NonceSize()+len(plaintext)+aead.Overhead())
+	sealed = append(sealed, sealedVersion, byte(len(id)))
+	sealed = append(sealed, id...)
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+	sealed = append(sealed, nonce...)
+	return aead.Seal(sealed, nonce, plaintext, aad), nil
+}
+
+// Open decrypts a payload sealed by Seal with the same aad
+func (e *Encryptor) Open(sealed, aad []byte) ([]byte, error) {
+	if len(sealed) < 2 || sealed[0] != sealedVersion || len(sealed) < 2+int(sealed[1]) {
+		return nil, fmt.Errorf("%w: malformed payload", ErrDecrypt)
+	}
+	idLen := int(sealed[1])
+	id := string(sealed[2 : 2+idLen])
+	key, err := e.keys.DecryptionKey(id)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrDecrypt, err)
+	}
+	aead, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+
+	rest := sealed[2+idLen:]
+	if len(rest) < aead.NonceSize() {
+		return nil, fmt.Errorf("%w: malformed payload", ErrDecrypt)
+	}
+	plaintext, err := aead.Open(nil, rest[:aead.NonceSize()], rest[aead.NonceSize():], aad)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrDecrypt, err)
+	}
+	return plaintext, nil
+}
+
+// newGCM creates an AES-GCM AEAD for key
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,100 @@
+package workerpool
+
+import (
+	"bytes"
+	"strings"
+)
+
+// testKeys returns keys with a current key "k2" and a retired key "k1"
+func testKeys() StaticKeys {
+	return StaticKeys{Current: "k2", Keys: map[string][]byte{
+		"k1": bytes.Repeat([]byte{1}, 32),
+		"k2": bytes.Repeat([]byte{2}, 16),
+	}}
+}
+
+func (ts *WorkerPoolTestSuite) TestEncryptorSealOpen() {
+	keys := testKeys()
+	e := NewEncryptor(keys)
+	sealed, err := e.Seal([]byte("ssn=123"), []byte("job-1"))
+	ts.NoError(err)
+	ts.NotContains(string(sealed), "ssn")
+
+	plaintext, err := e.Open(sealed, []byte("job-1"))
+	ts.NoError(err)
+	ts.Equal("ssn=123", string(plaintext))
+
+	// Bound to its record
+	_, err = e.Open(sealed, []byte("job-2"))
+	ts.ErrorIs(err, ErrDecrypt)
+	sealed[len(sealed)-1] ^= 1
+	_, err = e.Open(sealed, []byte("job-1"))
+	ts.ErrorIs(err, ErrDecrypt)
+	_, err = e.Open([]byte{9}, nil)
+	ts.ErrorIs(err, ErrDecrypt)
+
+	// Payloads sealed before a rotation still open
+	keys.Current = "k1"
+	old, err := NewEncryptor(keys).Seal([]byte("x"), nil)
+	ts.NoError(err)
+	plaintext, err = e.Open(old, nil)
+	ts.NoError(err)
+	ts.Equal("x", string(plaintext))
+
+	delete(keys.Keys, "k1")
+	_, err = e.Open(old, nil)
+	ts.ErrorIs(err, ErrDecrypt)
+}
+
+func (ts *WorkerPoolTestSuite) TestJSONLSinkEncryption() {
+	e := NewEncryptor(testKeys())
+	var buf bytes.Buffer
+	sink := NewJSONLSink[string](&buf).
+		WithCompression(Compression{MinSize: 100}).
+		WithEncryption(e)
+
+	large := strings.Repeat("alice@example.com ", 20)
+	ts.NoError(sink.Write(Result[string]{JobID: "small", Data: "alice@example.com"}))
+	ts.NoError(sink.Write(Result[string]{JobID: "large", Data: large}))
+	ts.NotContains(buf.String(), "alice")
+	ts.Contains(buf.String(), `"job_id":"small"`)
+
+	_, err := ReadJSONL[string](bytes.NewReader(buf.Bytes()))
+	ts.ErrorContains(err, "payload is encrypted")
+
+	results, err := ReadEncryptedJSONL[string](&buf, e)
+	ts.NoError(err)
+	ts.Require().Len(results, 2)
+	ts.Equal("alice@example.com", results[0].Data)
+	ts.Equal(large, results[1].Data)
+}
+
+func (ts *WorkerPoolTestSuite) TestEnvelopeEncryption() {
+	r := NewEnvelopeRegistry()
+	ts.NoError(RegisterPayload[string](r, "text"))
+	plain, err := r.MarshalJob(Job[any]{ID: "a", Data: "card 4111"})
+	ts.NoError(err)
+
+	r.WithEncryption(NewEncryptor(testKeys()))
+	sealed, err := r.MarshalJob(Job[any]{ID: "a", Data: "card 4111"})
+	ts.NoError(err)
+	ts.NotContains(string(sealed), "4111")
+
+	for _, b := range [][]byte{plain, sealed} {
+		job, err := r.UnmarshalJob(b)
+		ts.NoError(err)
+		ts.Equal("card 4111", job.Data)
+	}
+
+	result, err := r.MarshalResult(Result[any]{JobID: "a", Data: "token xyz"})
+	ts.NoError(err)
+	ts.NotContains(string(result), "xyz")
+	decoded, err := r.UnmarshalResult(result)
+	ts.NoError(err)
+	ts.Equal("token xyz", decoded.Data)
+
+	other := NewEnvelopeRegistry()
+	ts.NoError(RegisterPayload[string](other, "text"))
+	_, err = other.UnmarshalJob(sealed)
+	ts.ErrorIs(err, ErrDecrypt)
+}
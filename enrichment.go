@@ -0,0 +1,139 @@
+package workerpool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// ErrEnrichmentFailed wraps errors returned by an Enricher
+var ErrEnrichmentFailed = errors.New("job enrichment failed")
+
+// Enricher hydrates a job before it is processed, e.g. loading a record from a cache or database
+type Enricher[T any] func(ctx context.Context, job Job[T]) (Job[T], error)
+
+// EnrichmentOptions controls the enrichment stage
+type EnrichmentOptions struct {
+	Concurrency     int  // Dedicated enrichment workers; defaults to NumWorkers
+	ContinueOnError bool // Process the original job when enrichment fails instead of failing it
+}
+
+// WithEnricher sets a stage that runs on dedicated workers before the processor.
+// Jobs are enriched while the run dispatches them, so one slow lookup only
+// holds up its own job. Scheduling fields such as Priority, Class and
+// DependsOn are read from the job as submitted.
+func (wp *WorkerPool[T, R]) WithEnricher(e Enricher[T], opts EnrichmentOptions) *WorkerPool[T, R] {
+	wp.mu.Lock()
+	defer wp.mu.Unlock()
+	wp.enricher = e
+	wp.enrichOpt = opts
+	return wp
+}
+
+// enrichment is a job's hydration, started when its run begins and shared by
+// every copy of the job
+type enrichment[T any] struct {
+	done chan struct{}
+	job  Job[T]
+	err  error
+}
+
+// enrich starts hydrating jobs on the enrichment workers, highest priority
+// first, while the run dispatches them. Each job waits for its own enrichment
+// only, when a worker picks it up. The returned function stops feeding the
+// enrichment workers and waits for them to exit.
+func (wp *WorkerPool[T, R]) enrich(ctx context.Context, jobs []Job[T]) func() {
+	wp.mu.RLock()
+	enricher, workers := wp.enricher, wp.config.NumWorkers
+	concurrency := wp.enrichOpt.Concurrency
+	wp.mu.RUnlock()
+
+	// Jobs handed back by an earlier run may still carry its enrichment
+	for i := range jobs {
+		jobs[i].enriching = nil
+	}
+	if enricher == nil {
+		return func() {}
+	}
+	if concurrency <= 0 {
+		concurrency = workers
+	}
+
+	// The enricher sees the jobs as submitted; the run may reprioritize its own copies
+	submitted := make([]Job[T], len(jobs))
+	for i := range jobs {
+		jobs[i].enriching = &enrichment[T]{done: make(chan struct{})}
+		submitted[i] = jobs[i]
+	}
+	order := make([]int, len(jobs))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return submitted[order[a]].Priority > submitted[order[b]].Priority
+	})
+
+	ctx, cancel := context.WithCancel(ctx)
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				if ctx.Err() != nil {
+					return // Unenriched jobs stay pending
+				}
+				e := submitted[i].enriching
+				e.job, e.err = enricher(ctx, submitted[i])
+				close(e.done)
+			}
+		}()
+	}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		defer close(indexes)
+		for _, i := range order {
+			select {
+			case indexes <- i:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return func() {
+		cancel()
+		wg.Wait()
+	}
+}
+
+// awaitEnrichment waits for a job's enrichment and returns the hydrated job.
+// A failed enrichment returns an error wrapping ErrEnrichmentFailed, unless
+// ContinueOnError keeps the original job; otherwise a non-nil error is ctx's.
+func (wp *WorkerPool[T, R]) awaitEnrichment(ctx context.Context, job Job[T]) (Job[T], error) {
+	e := job.enriching
+	if e == nil {
+		return job, nil
+	}
+	select {
+	case <-e.done:
+	case <-ctx.Done():
+		return job, ctx.Err()
+	}
+
+	wp.mu.RLock()
+	continueOnError := wp.enrichOpt.ContinueOnError
+	wp.mu.RUnlock()
+	switch {
+	case e.err == nil:
+		job = e.job
+	case !continueOnError:
+		job.enriching = nil
+		return job, fmt.Errorf("%w: %w", ErrEnrichmentFailed, e.err)
+	}
+	job.enriching = nil
+	return job, nil
+}
@@ -0,0 +1,130 @@
+package workerpool
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+func (ts *WorkerPoolTestSuite) TestEnricherHydratesJobs() {
+	pool := New[string, string]()
+
+	var active, peak atomic.Int32
+	pool.WithEnricher(func(ctx context.Context, job Job[string]) (Job[string], error) {
+		n := active.Add(1)
+		defer active.Add(-1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+		if job.Data == "missing" {
+			return job, errors.New("record not found")
+		}
+		job.Data = "record:" + job.Data
+		return job, nil
+	}, EnrichmentOptions{Concurrency: 2})
+
+	pool.WithProcessor(func(ctx context.Context, job Job[string]) (string, error) {
+		return strings.ToUpper(job.Data), nil
+	})
+	pool.AddJobs([]Job[string]{
+		{ID: "1", Data: "a"},
+		{ID: "2", Data: "b"},
+		{ID: "3", Data: "missing"},
+		{ID: "4", Data: "c"},
+	})
+
+	results, err := pool.Run()
+	ts.NoError(err)
+	ts.Len(results, 4)
+	ts.LessOrEqual(peak.Load(), int32(2))
+
+	for _, result := range results {
+		if result.JobID == "3" {
+			ts.ErrorIs(result.Error, ErrEnrichmentFailed)
+			continue
+		}
+		ts.NoError(result.Error)
+		ts.True(strings.HasPrefix(result.Data, "RECORD:"))
+	}
+	ts.Equal(1, pool.GetMetrics().FailedJobs)
+}
+
+func (ts *WorkerPoolTestSuite) TestEnricherContinueOnError() {
+	pool := New[string, string]()
+	pool.WithEnricher(func(ctx context.Context, job Job[string]) (Job[string], error) {
+		return job, errors.New("cache unavailable")
+	}, EnrichmentOptions{ContinueOnError: true})
+	pool.WithProcessor(func(ctx context.Context, job Job[string]) (string, error) {
+		return job.Data, nil
+	})
+	pool.AddJob(Job[string]{ID: "1", Data: "raw"})
+
+	results, err := pool.Run()
+	ts.NoError(err)
+	ts.Len(results, 1)
+	ts.NoError(results[0].Error)
+	ts.Equal("raw", results[0].Data)
+}
+
+func (ts *WorkerPoolTestSuite) TestSlowEnrichmentHoldsUpOnlyItsJob() {
+	config := DefaultConfig()
+	config.NumWorkers = 2
+	pool := NewWithConfig[string, string](config)
+
+	fastDone := make(chan struct{})
+	pool.WithEnricher(func(ctx context.Context, job Job[string]) (Job[string], error) {
+		if job.ID == "slow" {
+			select {
+			case <-fastDone:
+			case <-time.After(2 * time.Second):
+				return job, errors.New("fast job waited for the batch")
+			}
+		}
+		return job, nil
+	}, EnrichmentOptions{Concurrency: 2})
+	pool.WithProcessor(func(ctx context.Context, job Job[string]) (string, error) {
+		if job.ID == "fast" {
+			close(fastDone)
+		}
+		return job.Data, nil
+	})
+	pool.AddJobs([]Job[string]{
+		{ID: "slow", Data: "a", Priority: 1},
+		{ID: "fast", Data: "b"},
+	})
+
+	results, err := pool.Run()
+	ts.NoError(err)
+	ts.Len(results, 2)
+	for _, result := range results {
+		ts.NoError(result.Error, result.JobID)
+	}
+}
+
+func (ts *WorkerPoolTestSuite) TestStoppedRunStopsEnriching() {
+	pool := New[int, int]()
+
+	var calls atomic.Int32
+	pool.WithEnricher(func(ctx context.Context, job Job[int]) (Job[int], error) {
+		calls.Add(1)
+		pool.Stop()
+		return job, nil
+	}, EnrichmentOptions{Concurrency: 1})
+	pool.WithProcessor(func(ctx context.Context, job Job[int]) (int, error) {
+		return job.Data, nil
+	})
+	for i := 0; i < 20; i++ {
+		pool.AddJob(Job[int]{ID: strconv.Itoa(i), Data: i})
+	}
+
+	_, err := pool.Run()
+	ts.Error(err)
+	ts.Equal(int32(1), calls.Load())
+}
@@ -0,0 +1,482 @@
+package workerpool
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"sync"
+	"time"
+)
+
+// ErrUnknownPayloadType is returned for payloads whose type is not registered
+var ErrUnknownPayloadType = errors.New("unknown payload type")
+
+// EnvelopeRegistry converts jobs and results to and from the JobEnvelope and
+// ResultEnvelope protobuf messages defined in
+// proto/workerpool/v1/envelope.proto, so services built from different
+// binaries or languages can submit to and consume from one pool. Every
+// payload type is registered under a stable name; a WorkerPool[any, any]
+// then carries heterogeneous jobs and its processor switches on the
+// decoded payload's type.
+type EnvelopeRegistry struct {
+	byName    map[string]*payloadType
+	byType    map[reflect.Type]*payloadType
+	encryptor *Encryptor // Seals encoded payloads; nil leaves them in plaintext
+	mu        sync.RWMutex
+}
+
+// payloadType is one registered payload type
+type payloadType struct {
+	name       string
+	marshal    func(any) ([]byte, error)
+	unmarshal  func([]byte) (any, error)
+	migrations *PayloadMigrations[[]byte] // Upgrades payloads of older versions before unmarshal
+}
+
+// NewEnvelopeRegistry creates a registry with no types
+func NewEnvelopeRegistry() *EnvelopeRegistry {
+	return &EnvelopeRegistry{
+		byName: make(map[string]*payloadType),
+		byType: make(map[reflect.Type]*payloadType),
+	}
+}
+
+// RegisterPayload registers the concrete type T under name with JSON as its
+// codec. Names are part of the wire contract; version them, e.g.
+// "thumbnail.v1".
+func RegisterPayload[T any](r *EnvelopeRegistry, name string) error {
+	return RegisterPayloadCodec(r, name,
+		func(v T) ([]byte, error) { return json.Marshal(v) },
+		func(data []byte) (T, error) {
+			var v T
+			err := json.Unmarshal(data, &v)
+			return v, err
+		})
+}
+
+// RegisterPayloadCodec registers T under name with its own codec, e.g.
+// proto.Marshal and proto.Unmarshal for generated protobuf messages
+func RegisterPayloadCodec[T any](r *EnvelopeRegistry, name string, marshal func(T) ([]byte, error), unmarshal func([]byte) (T, error)) error {
+	if name == "" {
+		return errors.New("register payload: empty type name")
+	}
+	goType := reflect.TypeOf((*T)(nil)).Elem()
+	pt := &payloadType{
+		name:      name,
+		marshal:   func(v any) ([]byte, error) { return marshal(v.(T)) },
+		unmarshal: func(data []byte) (any, error) { return unmarshal(data) },
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if existing, ok := r.byName[name]; ok {
+		return fmt.Errorf("register payload: %q already registered for another type", existing.name)
+	}
+	if existing, ok := r.byType[goType]; ok {
+		return fmt.Errorf("register payload: %s already registered as %q", goType, existing.name)
+	}
+	r.byName[name] = pt
+	r.byType[goType] = pt
+	return nil
+}
+
+// WithEncryption seals the encoded payload of every job and result
+// envelope with e, bound to the job ID, so envelopes can be persisted
+// without exposing payloads. Envelopes record whether their payload is
+// sealed, so plaintext ones written earlier can still be read.
+func (r *EnvelopeRegistry) WithEncryption(e *Encryptor) *EnvelopeRegistry {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.encryptor = e
+	return r
+}
+
+// encodePayload encodes v with the codec of its registered type, sealing it
+// when encryption is on. A nil payload encodes as no type and no bytes.
+func (r *EnvelopeRegistry) encodePayload(jobID string, v any) (name string, data []byte, sealed bool, err error) {
+	if v == nil {
+		return "", nil, false, nil
+	}
+	r.mu.RLock()
+	pt, ok := r.byType[reflect.TypeOf(v)]
+	encryptor := r.encryptor
+	r.mu.RUnlock()
+	if !ok {
+		return "", nil, false, fmt.Errorf("%w: %T", ErrUnknownPayloadType, v)
+	}
+	if data, err = pt.marshal(v); err != nil || encryptor == nil {
+		return pt.name, data, false, err
+	}
+	data, err = encryptor.Seal(data, []byte(jobID))
+	return pt.name, data, true, err
+}
+
+// openPayload decrypts a sealed payload
+func (r *EnvelopeRegistry) openPayload(jobID string, data []byte) ([]byte, error) {
+	r.mu.RLock()
+	encryptor := r.encryptor
+	r.mu.RUnlock()
+	if encryptor == nil {
+		return nil, fmt.Errorf("%w: no encryptor configured", ErrDecrypt)
+	}
+	return encryptor.Open(data, []byte(jobID))
+}
+
+// decodePayload decodes data with the codec registered under name. Job
+// payloads, written at version, are migrated first when the type has
+// migrations; the version after migration is returned.
+func (r *EnvelopeRegistry) decodePayload(name string, version int, data []byte, migrate bool) (any, int, error) {
+	if name == "" {
+		return nil, version, nil
+	}
+	r.mu.RLock()
+	pt, ok := r.byName[name]
+	r.mu.RUnlock()
+	if !ok {
+		return nil, version, fmt.Errorf("%w: %q", ErrUnknownPayloadType, name)
+	}
+	if migrate && pt.migrations != nil {
+		var err error
+		if data, err = pt.migrations.Migrate(version, data); err != nil {
+			return nil, version, err
+		}
+		version = pt.migrations.Current()
+	}
+	v, err := pt.unmarshal(data)
+	return v, version, err
+}
+
+// MarshalJob encodes a job as a JobEnvelope
+func (r *EnvelopeRegistry) MarshalJob(job Job[any]) ([]byte, error) {
+	name, payload, sealed, err := r.encodePayload(job.ID, job.Data)
+	if err != nil {
+		return nil, fmt.Errorf("job %s: %w", job.ID, err)
+	}
+	var b []byte
+	b = appendString(b, 1, job.ID)
+	b = appendString(b, 2, name)
+	b = appendBytes(b, 3, payload)
+	b = appendInt(b, 4, int64(job.Priority))
+	b = appendInt(b, 5, unixNano(job.Created))
+	b = appendString(b, 6, job.TenantID)
+	b = appendString(b, 7, job.Owner)
+	b = appendInt(b, 8, int64(job.Attempts))
+	b = appendString(b, 9, job.Class)
+	b = appendInt(b, 10, int64(job.Cost))
+	b = appendInt(b, 11, int64(job.TTL))
+	b = appendInt(b, 12, unixNano(job.ExpiresAt))
+	b = appendInt(b, 13, int64(job.Redeliveries))
+	b = appendString(b, 14, job.IdempotencyKey)
+	for _, dep := range job.Dependencies {
+		b = appendField(b, 15, []byte(dep))
+	}
+	b = appendInt(b, 16, int64(job.Version))
+	b = appendBool(b, 17, sealed)
+	b = appendInt(b, 18, unixNano(job.Deadline))
+	b = appendString(b, 19, job.Continuation)
+	b = appendString(b, 20, job.ParentID)
+	return b, nil
+}
+
+// UnmarshalJob decodes a JobEnvelope, decoding the payload into its
+// registered type. Unknown fields are skipped.
+func (r *EnvelopeRegistry) UnmarshalJob(data []byte) (Job[any], error) {
+	var job Job[any]
+	var name string
+	var payload []byte
+	var sealed bool
+	err := readMessage(data, func(field int, v uint64, raw []byte) {
+		switch field {
+		case 1:
+			job.ID = string(raw)
+		case 2:
+			name = string(raw)
+		case 3:
+			payload = raw
+		case 4:
+			job.Priority = int(int64(v))
+		case 5:
+			job.Created = fromUnixNano(int64(v))
+		case 6:
+			job.TenantID = string(raw)
+		case 7:
+			job.Owner = string(raw)
+		case 8:
+			job.Attempts = int(int64(v))
+		case 9:
+			job.Class = string(raw)
+		case 10:
+			job.Cost = int(int64(v))
+		case 11:
+			job.TTL = time.Duration(int64(v))
+		case 12:
+			job.ExpiresAt = fromUnixNano(int64(v))
+		case 13:
+			job.Redeliveries = int(int64(v))
+		case 14:
+			job.IdempotencyKey = string(raw)
+		case 15:
+			job.Dependencies = append(job.Dependencies, string(raw))
+		case 16:
+			job.Version = int(int64(v))
+		case 17:
+			sealed = v != 0
+		case 18:
+			job.Deadline = fromUnixNano(int64(v))
+		case 19:
+			job.Continuation = string(raw)
+		case 20:
+			job.ParentID = string(raw)
+		}
+	})
+	if err != nil {
+		return job, fmt.Errorf("job envelope: %w", err)
+	}
+	if sealed {
+		if payload, err = r.openPayload(job.ID, payload); err != nil {
+			return job, fmt.Errorf("job %s: %w", job.ID, err)
+		}
+	}
+	if job.Data, job.Version, err = r.decodePayload(name, job.Version, payload, true); err != nil {
+		return job, fmt.Errorf("job %s: %w", job.ID, err)
+	}
+	return job, nil
+}
+
+// MarshalResult encodes a result as a ResultEnvelope. Of the attempt and
+// dispatch details, only the attempt count and labels are carried.
+func (r *EnvelopeRegistry) MarshalResult(result Result[any]) ([]byte, error) {
+	name, data, sealed, err := r.encodePayload(result.JobID, result.Data)
+	if err != nil {
+		return nil, fmt.Errorf("result %s: %w", result.JobID, err)
+	}
+	var b []byte
+	b = appendString(b, 1, result.JobID)
+	b = appendString(b, 2, name)
+	b = appendBytes(b, 3, data)
+	if result.Error != nil {
+		b = appendString(b, 4, result.Error.Error())
+	}
+	b = appendInt(b, 5, int64(result.Worker))
+	b = appendInt(b, 6, unixNano(result.Started))
+	b = appendInt(b, 7, unixNano(result.Completed))
+	b = appendInt(b, 8, int64(result.Duration))
+	b = appendInt(b, 9, int64(result.Attempts))
+
+	// Map entries are sorted so equal results encode identically
+	keys := make([]string, 0, len(result.Labels))
+	for k := range result.Labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		var entry []byte
+		entry = appendField(entry, 1, []byte(k))
+		entry = appendField(entry, 2, []byte(result.Labels[k]))
+		b = appendField(b, 10, entry)
+	}
+	b = appendBool(b, 11, sealed)
+	return b, nil
+}
+
+// UnmarshalResult decodes a ResultEnvelope. The error, if any, is restored
+// as a plain error carrying the original message.
+func (r *EnvelopeRegistry) UnmarshalResult(data []byte) (Result[any], error) {
+	var result Result[any]
+	var name string
+	var payload []byte
+	var sealed bool
+	var entryErr error
+	err := readMessage(data, func(field int, v uint64, raw []byte) {
+		switch field {
+		case 1:
+			result.JobID = string(raw)
+		case 2:
+			name = string(raw)
+		case 3:
+			payload = raw
+		case 4:
+			result.Error = errors.New(string(raw))
+		case 5:
+			result.Worker = int(int64(v))
+		case 6:
+			result.Started = fromUnixNano(int64(v))
+		case 7:
+			result.Completed = fromUnixNano(int64(v))
+		case 8:
+			result.Duration = time.Duration(int64(v))
+		case 9:
+			result.Attempts = int(int64(v))
+		case 10:
+			var key, value string
+			if err := readMessage(raw, func(field int, _ uint64, raw []byte) {
+				switch field {
+				case 1:
+					key = string(raw)
+				case 2:
+					value = string(raw)
+				}
+			}); err != nil {
+				entryErr = err
+				return
+			}
+			if result.Labels == nil {
+				result.Labels = make(map[string]string)
+			}
+			result.Labels[key] = value
+		case 11:
+			sealed = v != 0
+		}
+	})
+	if err == nil {
+		err = entryErr
+	}
+	if err != nil {
+		return result, fmt.Errorf("result envelope: %w", err)
+	}
+	if sealed {
+		if payload, err = r.openPayload(result.JobID, payload); err != nil {
+			return result, fmt.Errorf("result %s: %w", result.JobID, err)
+		}
+	}
+	if result.Data, _, err = r.decodePayload(name, 0, payload, false); err != nil {
+		return result, fmt.Errorf("result %s: %w", result.JobID, err)
+	}
+	return result, nil
+}
+
+// unixNano returns t in Unix nanoseconds, or zero for the zero time
+func unixNano(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.UnixNano()
+}
+
+// fromUnixNano is the inverse of unixNano
+func fromUnixNano(n int64) time.Time {
+	if n == 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, n)
+}
+
+// Protobuf wire types used by the envelopes
+const (
+	wireVarint = 0
+	wire64     = 1
+	wireBytes  = 2
+	wire32     = 5
+)
+
+// appendVarint appends v in base-128 varint encoding
+func appendVarint(b []byte, v uint64) []byte {
+	for v >= 0x80 {
+		b = append(b, byte(v)|0x80)
+		v >>= 7
+	}
+	return append(b, byte(v))
+}
+
+// appendInt appends a varint field, omitting zero as proto3 does
+func appendInt(b []byte, field int, v int64) []byte {
+	if v == 0 {
+		return b
+	}
+	b = appendVarint(b, uint64(field)<<3|wireVarint)
+	return appendVarint(b, uint64(v))
+}
+
+// appendField appends a length-delimited field, even when empty
+func appendField(b []byte, field int, v []byte) []byte {
+	b = appendVarint(b, uint64(field)<<3|wireBytes)
+	b = appendVarint(b, uint64(len(v)))
+	return append(b, v...)
+}
+
+// appendBool appends a bool field, omitting false as proto3 does
+func appendBool(b []byte, field int, v bool) []byte {
+	if !v {
+		return b
+	}
+	return appendInt(b, field, 1)
+}
+
+// appendBytes appends a length-delimited field, omitting it when empty
+func appendBytes(b []byte, field int, v []byte) []byte {
+	if len(v) == 0 {
+		return b
+	}
+	return appendField(b, field, v)
+}
+
+// appendString appends a string field, omitting it when empty
+func appendString(b []byte, field int, v string) []byte {
+	return appendBytes(b, field, []byte(v))
+}
+
+// readVarint decodes a varint, returning it and the bytes it used
+func readVarint(b []byte) (uint64, int, error) {
+	var v uint64
+	for i := 0; i < len(b) && i < 10; i++ {
+		v |= uint64(b[i]&0x7f) << (7 * i)
+		if b[i] < 0x80 {
+			return v, i + 1, nil
+		}
+	}
+	return 0, 0, errors.New("malformed varint")
+}
+
+// readMessage calls field for every field of a protobuf message, with the
+// value of varint fields and the contents of length-delimited ones. Fixed
+// width fields, which the envelopes do not use, are skipped.
+func readMessage(b []byte, field func(num int, v uint64, raw []byte)) error {
+	for len(b) > 0 {
+		tag, n, err := readVarint(b)
+		if err != nil {
+			return err
+		}
+		b = b[n:]
+		num := tag >> 3
+		if num == 0 || num > math.MaxInt32 {
+			return fmt.Errorf("invalid field number %d", num)
+		}
+
+		switch tag & 7 {
+		case wireVarint:
+			v, n, err := readVarint(b)
+			if err != nil {
+				return err
+			}
+			b = b[n:]
+			field(int(num), v, nil)
+		case wireBytes:
+			size, n, err := readVarint(b)
+			if err != nil {
+				return err
+			}
+			b = b[n:]
+			if size > uint64(len(b)) {
+				return errors.New("truncated field")
+			}
+			field(int(num), 0, b[:size])
+			b = b[size:]
+		case wire64:
+			if len(b) < 8 {
+				return errors.New("truncated field")
+			}
+			b = b[8:]
+		case wire32:
+			if len(b) < 4 {
+				return errors.New("truncated field")
+			}
+			b = b[4:]
+		default:
+			return fmt.Errorf("unsupported wire type %d", tag&7)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,115 @@
+package workerpool
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+)
+
+type thumbnailJob struct {
+	URL   string `json:"url"`
+	Width int    `json:"width"`
+}
+
+type emailJob struct {
+	To string `json:"to"`
+}
+
+func (ts *WorkerPoolTestSuite) TestEnvelopeWireFormat() {
+	r := NewEnvelopeRegistry()
+	ts.NoError(RegisterPayloadCodec(r, "raw", func(s string) ([]byte, error) { return []byte(s), nil },
+		func(b []byte) (string, error) { return string(b), nil }))
+
+	// Matches what protoc-generated code emits for the same JobEnvelope
+	b, err := r.MarshalJob(Job[any]{ID: "a", Data: "hi", Priority: -1, Dependencies: []string{""}})
+	ts.NoError(err)
+	ts.Equal([]byte{
+		0x0a, 1, 'a',
+		0x12, 3, 'r', 'a', 'w',
+		0x1a, 2, 'h', 'i',
+		0x20, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x01,
+		0x7a, 0,
+	}, b)
+
+	job, err := r.UnmarshalJob(append(b, 0xa8, 0x06, 0x01)) // Unknown field 101 is skipped
+	ts.NoError(err)
+	ts.Equal(Job[any]{ID: "a", Data: "hi", Priority: -1, Dependencies: []string{""}}, job)
+
+	_, err = r.UnmarshalJob([]byte{0x0a, 5, 'a'})
+	ts.ErrorContains(err, "truncated field")
+}
+
+func (ts *WorkerPoolTestSuite) TestEnvelopeHeterogeneousPool() {
+	r := NewEnvelopeRegistry()
+	ts.NoError(RegisterPayload[thumbnailJob](r, "thumbnail.v1"))
+	ts.NoError(RegisterPayload[emailJob](r, "email.v1"))
+	ts.NoError(RegisterPayload[string](r, "text.v1"))
+	ts.Error(RegisterPayload[emailJob](r, "email.v2"))
+	ts.Error(RegisterPayload[int](r, "email.v1"))
+
+	created := time.Unix(0, time.Now().UnixNano())
+	var wire [][]byte
+	for _, job := range []Job[any]{
+		{ID: "t", Data: thumbnailJob{URL: "a.png", Width: 64}, Created: created, TenantID: "acme", Cost: 3, TTL: time.Minute, Deadline: created.Add(time.Hour), Continuation: "row=40", ParentID: "batch"},
+		{ID: "e", Data: emailJob{To: "ops@example.com"}, Created: created, Dependencies: []string{"t"}},
+	} {
+		b, err := r.MarshalJob(job)
+		ts.NoError(err)
+		wire = append(wire, b)
+	}
+	_, err := r.MarshalJob(Job[any]{ID: "x", Data: 1.5})
+	ts.ErrorIs(err, ErrUnknownPayloadType)
+
+	config := DefaultConfig()
+	config.MaxRetries = 0
+	pool := NewWithConfig[any, any](config)
+	pool.WithProcessor(func(ctx context.Context, job Job[any]) (any, error) {
+		switch data := job.Data.(type) {
+		case thumbnailJob:
+			return "resized " + data.URL + " to " + strconv.Itoa(data.Width), nil
+		case emailJob:
+			return nil, errors.New("smtp down: " + data.To)
+		}
+		return nil, errors.New("unexpected payload")
+	})
+	for _, b := range wire {
+		job, err := r.UnmarshalJob(b)
+		ts.Require().NoError(err)
+		ts.True(created.Equal(job.Created))
+		if job.ID == "t" {
+			ts.Equal(3, job.Cost)
+			ts.Equal(time.Minute, job.TTL)
+			ts.True(created.Add(time.Hour).Equal(job.Deadline))
+			ts.Equal("row=40", job.Continuation)
+			ts.Equal("batch", job.ParentID)
+		}
+		pool.AddJob(job)
+	}
+
+	results, err := pool.Run()
+	ts.NoError(err)
+	ts.Len(results, 2)
+	for _, result := range results {
+		result.Labels = map[string]string{"region": "eu", "tier": "gold"}
+		b, err := r.MarshalResult(result)
+		ts.NoError(err)
+		decoded, err := r.UnmarshalResult(b)
+		ts.NoError(err)
+
+		ts.Equal(result.JobID, decoded.JobID)
+		ts.Equal(result.Data, decoded.Data)
+		ts.Equal(result.Attempts, decoded.Attempts)
+		ts.Equal(result.Duration, decoded.Duration)
+		ts.Equal(result.Labels, decoded.Labels)
+		if result.Error != nil {
+			ts.EqualError(decoded.Error, result.Error.Error())
+		} else {
+			ts.NoError(decoded.Error)
+		}
+	}
+
+	other := NewEnvelopeRegistry()
+	_, err = other.UnmarshalJob(wire[0])
+	ts.ErrorIs(err, ErrUnknownPayloadType)
+}
@@ -0,0 +1,111 @@
+package workerpool
+
+import (
+	"sync"
+	"time"
+)
+
+// ErrorSampling throttles how often failures reach the error reporter, so a
+// dead downstream does not flood logs with thousands of identical errors.
+// Failures are grouped by fingerprint. Zero values disable each limit.
+type ErrorSampling struct {
+	First          int           // Failures of each kind always reported before sampling starts
+	Every          int           // After First, report one in Every failures of a kind; zero reports none
+	MaxPerInterval int           // Cap on reports across all kinds within Interval (burst suppression)
+	Interval       time.Duration // Window for MaxPerInterval
+}
+
+// ErrorReport accompanies a failure handed to the error reporter
+type ErrorReport struct {
+	Key         string // Fingerprint of the failure
+	Occurrences int    // Failures with this key so far, including this one
+	Suppressed  int    // Failures with this key dropped since the last report
+}
+
+// errorSampler decides which failures are reported
+type errorSampler struct {
+	sampling    ErrorSampling
+	counts      map[string]int
+	suppressed  map[string]int
+	windowStart time.Time
+	inWindow    int
+	mu          sync.Mutex
+}
+
+// WithErrorReporter calls report for failed results, throttled by sampling.
+// Every failure still counts in Metrics; ErrorsReported and ErrorsSuppressed
+// show how many reached the reporter.
+func (wp *WorkerPool[T, R]) WithErrorReporter(report func(Result[R], ErrorReport), sampling ErrorSampling) *WorkerPool[T, R] {
+	wp.mu.Lock()
+	defer wp.mu.Unlock()
+	wp.reporter = report
+	wp.sampler = &errorSampler{
+		sampling:   sampling,
+		counts:     make(map[string]int),
+		suppressed: make(map[string]int),
+	}
+	return wp
+}
+
+// reportError passes a failed result to the error reporter unless sampled out
+func (wp *WorkerPool[T, R]) reportError(result Result[R]) {
+	if result.Error == nil {
+		return
+	}
+	wp.mu.RLock()
+	report, sampler := wp.reporter, wp.sampler
+	wp.mu.RUnlock()
+	if report == nil {
+		return
+	}
+
+	r, ok := sampler.sample(wp.fingerprint(result.Error), time.Now())
+
+	wp.metrics.mu.Lock()
+	if ok {
+		wp.metrics.ErrorsReported++
+	} else {
+		wp.metrics.ErrorsSuppressed++
+	}
+	wp.metrics.mu.Unlock()
+
+	if ok {
+		report(result, r)
+	}
+}
+
+// sample counts a failure under key and reports whether it should be reported
+func (s *errorSampler) sample(key string, now time.Time) (ErrorReport, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.counts[key]++
+	n := s.counts[key]
+	report := ErrorReport{Key: key, Occurrences: n}
+
+	sampled := s.sampling.First <= 0 && s.sampling.Every <= 0
+	if n <= s.sampling.First {
+		sampled = true
+	} else if s.sampling.Every > 0 && (n-s.sampling.First)%s.sampling.Every == 0 {
+		sampled = true
+	}
+
+	if sampled && s.sampling.MaxPerInterval > 0 {
+		if now.Sub(s.windowStart) >= s.sampling.Interval {
+			s.windowStart, s.inWindow = now, 0
+		}
+		if s.inWindow >= s.sampling.MaxPerInterval {
+			sampled = false
+		} else {
+			s.inWindow++
+		}
+	}
+
+	if !sampled {
+		s.suppressed[key]++
+		return report, false
+	}
+	report.Suppressed = s.suppressed[key]
+	s.suppressed[key] = 0
+	return report, true
+}
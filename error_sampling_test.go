@@ -0,0 +1,76 @@
+package workerpool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+func (ts *WorkerPoolTestSuite) TestErrorReporterSamplesRepeatedFailures() {
+	config := DefaultConfig()
+	config.NumWorkers = 1
+	config.MaxRetries = 0
+	pool := NewWithConfig[int, int](config)
+	pool.WithProcessor(func(ctx context.Context, job Job[int]) (int, error) {
+		if job.Data%2 == 0 {
+			return 0, errors.New("downstream unavailable")
+		}
+		return 0, errors.New("bad input")
+	})
+
+	var reports []ErrorReport
+	pool.WithErrorReporter(func(r Result[int], report ErrorReport) {
+		reports = append(reports, report)
+	}, ErrorSampling{First: 2, Every: 5})
+
+	// 12 failures of each kind
+	var jobs []Job[int]
+	for i := 0; i < 24; i++ {
+		jobs = append(jobs, Job[int]{ID: fmt.Sprintf("%d", i), Data: i})
+	}
+	pool.AddJobs(jobs)
+	_, err := pool.Run()
+	ts.NoError(err)
+
+	// Per kind: occurrences 1, 2, 7 and 12 are reported
+	ts.Len(reports, 8)
+	byKey := make(map[string][]ErrorReport)
+	for _, r := range reports {
+		byKey[r.Key] = append(byKey[r.Key], r)
+	}
+	for _, rs := range byKey {
+		ts.Require().Len(rs, 4)
+		ts.Equal([]int{1, 2, 7, 12}, []int{rs[0].Occurrences, rs[1].Occurrences, rs[2].Occurrences, rs[3].Occurrences})
+		ts.Equal(4, rs[2].Suppressed)
+		ts.Equal(4, rs[3].Suppressed)
+	}
+
+	m := pool.GetMetrics()
+	ts.Equal(24, m.FailedJobs)
+	ts.Equal(8, m.ErrorsReported)
+	ts.Equal(16, m.ErrorsSuppressed)
+}
+
+func (ts *WorkerPoolTestSuite) TestErrorSamplingSuppressesBursts() {
+	s := &errorSampler{
+		sampling:   ErrorSampling{MaxPerInterval: 3, Interval: time.Minute},
+		counts:     make(map[string]int),
+		suppressed: make(map[string]int),
+	}
+	now := time.Now()
+
+	reported := 0
+	for i := 0; i < 10; i++ {
+		if _, ok := s.sample(fmt.Sprintf("err %d", i), now); ok {
+			reported++
+		}
+	}
+	ts.Equal(3, reported)
+
+	// A new interval admits reports again, carrying the suppressed count
+	report, ok := s.sample("err 9", now.Add(time.Minute))
+	ts.True(ok)
+	ts.Equal(2, report.Occurrences)
+	ts.Equal(1, report.Suppressed)
+}
@@ -0,0 +1,229 @@
+package workerpool
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"math"
+	"sort"
+	"time"
+)
+
+// experimentMargin is the relative throughput difference below which an
+// experiment declares no winner
+const experimentMargin = 0.05
+
+// Variant is one arm of an experiment: a named pool configuration
+type Variant struct {
+	Name   string
+	Config Config
+}
+
+// ExperimentOptions configures RunExperiment
+type ExperimentOptions struct {
+	// SampleRate is the fraction of jobs run, between 0 and 1; zero runs them
+	// all. Sampling is by job ID, so both variants see the same jobs.
+	SampleRate float64
+
+	// Repetitions is how many times each variant runs the workload; defaults
+	// to 1. The order alternates so drift affects both variants alike.
+	Repetitions int
+}
+
+// VariantReport summarizes how one variant performed over all its runs
+type VariantReport struct {
+	Name       string        `json:"name"`
+	Strategy   string        `json:"strategy"`
+	Runs       int           `json:"runs"`
+	Jobs       int           `json:"jobs"`
+	Failed     int           `json:"failed"`
+	Elapsed    time.Duration `json:"elapsed_ns"`
+	Throughput float64       `json:"throughput"` // Jobs finished per second, failures included
+	P50        time.Duration `json:"p50_ns"`     // Median job duration
+	P99        time.Duration `json:"p99_ns"`     // 99th percentile job duration
+
+	// Fairness is Jain's index of busy time across workers: 1 when every
+	// worker did the same amount of work, 1/NumWorkers when one did it all
+	Fairness float64 `json:"fairness"`
+}
+
+// ExperimentReport compares two variants run over the same workload
+type ExperimentReport struct {
+	A               VariantReport `json:"a"`
+	B               VariantReport `json:"b"`
+	ThroughputDelta float64       `json:"throughput_delta"` // B's throughput relative to A's, e.g. 0.1 is 10% higher
+	P99Delta        float64       `json:"p99_delta"`        // B's p99 relative to A's; negative is better
+	Winner          string        `json:"winner"`           // Variant with clearly higher throughput; empty when within 5%
+}
+
+// RunExperiment runs the same jobs through pools configured by variants a
+// and b and compares throughput, latency and fairness. Every run uses a
+// fresh pool, so state such as Adaptive learning does not carry over.
+// Cancelling ctx stops the experiment and returns the error.
+func RunExperiment[T any, R any](ctx context.Context, jobs []Job[T], processor Processor[T, R], a, b Variant, opts ExperimentOptions) (ExperimentReport, error) {
+	if a.Name == b.Name {
+		return ExperimentReport{}, fmt.Errorf("experiment: variants must have distinct names, got %q twice", a.Name)
+	}
+	sample := sampleJobs(jobs, opts.SampleRate)
+	if len(sample) == 0 {
+		return ExperimentReport{}, fmt.Errorf("experiment: no jobs to run")
+	}
+	repetitions := max(1, opts.Repetitions)
+
+	arms := []*variantRun{{variant: a}, {variant: b}}
+	for rep := 0; rep < repetitions; rep++ {
+		order := arms
+		if rep%2 == 1 {
+			order = []*variantRun{arms[1], arms[0]}
+		}
+		for _, arm := range order {
+			if err := runVariant(ctx, arm, sample, processor); err != nil {
+				return ExperimentReport{}, fmt.Errorf("experiment: variant %s: %w", arm.variant.Name, err)
+			}
+		}
+	}
+
+	report := ExperimentReport{A: arms[0].report(), B: arms[1].report()}
+	report.ThroughputDelta = relativeDelta(report.A.Throughput, report.B.Throughput)
+	report.P99Delta = relativeDelta(float64(report.A.P99), float64(report.B.P99))
+	switch {
+	case report.ThroughputDelta > experimentMargin:
+		report.Winner = b.Name
+	case report.ThroughputDelta < -experimentMargin:
+		report.Winner = a.Name
+	}
+	return report, nil
+}
+
+// String formats the report as a short comparison table
+func (r ExperimentReport) String() string {
+	winner := r.Winner
+	if winner == "" {
+		winner = "none (within 5%)"
+	}
+	return fmt.Sprintf("%-12s %10s %10s %10s %8s\n", "variant", "jobs/s", "p50", "p99", "fairness") +
+		r.A.row() + r.B.row() +
+		fmt.Sprintf("throughput %+.1f%%, p99 %+.1f%%, winner: %s", 100*r.ThroughputDelta, 100*r.P99Delta, winner)
+}
+
+// row formats one line of the comparison table
+func (v VariantReport) row() string {
+	return fmt.Sprintf("%-12s %10.1f %10s %10s %8.3f\n", v.Name, v.Throughput, v.P50, v.P99, v.Fairness)
+}
+
+// variantRun accumulates the results of one variant across repetitions
+type variantRun struct {
+	variant   Variant
+	runs      int
+	failed    int
+	elapsed   time.Duration
+	durations []time.Duration
+	busy      map[int]time.Duration // Processing time by worker ID
+}
+
+// runVariant processes jobs once with a fresh pool configured by v
+func runVariant[T any, R any](ctx context.Context, v *variantRun, jobs []Job[T], processor Processor[T, R]) error {
+	pool := NewWithConfig[T, R](v.variant.Config).WithProcessor(processor)
+	pool.AddJobs(jobs)
+	defer context.AfterFunc(ctx, pool.Stop)()
+
+	start := time.Now()
+	results, err := pool.Run()
+	elapsed := time.Since(start)
+	if ctx.Err() != nil {
+		return context.Cause(ctx)
+	}
+	if err != nil {
+		return err
+	}
+
+	if v.busy == nil {
+		v.busy = make(map[int]time.Duration)
+	}
+	v.runs++
+	v.elapsed += elapsed
+	for _, result := range results {
+		if result.Error != nil {
+			v.failed++
+		}
+		v.durations = append(v.durations, result.Duration)
+		v.busy[result.Worker] += result.Duration
+	}
+	return nil
+}
+
+// report summarizes the accumulated runs
+func (v *variantRun) report() VariantReport {
+	r := VariantReport{
+		Name:     v.variant.Name,
+		Strategy: v.variant.Config.Strategy.String(),
+		Runs:     v.runs,
+		Jobs:     len(v.durations),
+		Failed:   v.failed,
+		Elapsed:  v.elapsed,
+	}
+	if v.elapsed > 0 {
+		r.Throughput = float64(r.Jobs) / v.elapsed.Seconds()
+	}
+
+	sorted := append([]time.Duration(nil), v.durations...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	r.P50 = percentile(sorted, 0.50)
+	r.P99 = percentile(sorted, 0.99)
+
+	// Workers that never ran a job count as idle
+	busy := make([]float64, max(1, v.variant.Config.NumWorkers))
+	for worker, d := range v.busy {
+		if worker < len(busy) {
+			busy[worker] = d.Seconds()
+		}
+	}
+	r.Fairness = jainIndex(busy)
+	return r
+}
+
+// sampleJobs keeps about rate of the jobs, chosen by hashing their IDs
+func sampleJobs[T any](jobs []Job[T], rate float64) []Job[T] {
+	if rate <= 0 || rate >= 1 {
+		return jobs
+	}
+	var sample []Job[T]
+	for _, job := range jobs {
+		h := fnv.New64a()
+		h.Write([]byte(job.ID))
+		if float64(h.Sum64()%10000) < rate*10000 {
+			sample = append(sample, job)
+		}
+	}
+	return sample
+}
+
+// percentile returns the q-th quantile of sorted durations by nearest rank
+func percentile(sorted []time.Duration, q float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := int(math.Ceil(q*float64(len(sorted)))) - 1
+	return sorted[min(max(rank, 0), len(sorted)-1)]
+}
+
+// jainIndex returns Jain's fairness index of xs: (Σx)² / (n·Σx²)
+func jainIndex(xs []float64) float64 {
+	var sum, squares float64
+	for _, x := range xs {
+		sum += x
+		squares += x * x
+	}
+	if squares == 0 {
+		return 1
+	}
+	return sum * sum / (float64(len(xs)) * squares)
+}
+
+// relativeDelta returns how much b differs from a, relative to a
+func relativeDelta(a, b float64) float64 {
+	if a == 0 {
+		return 0
+	}
+	return (b - a) / a
+}
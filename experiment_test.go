@@ -0,0 +1,75 @@
+package workerpool
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+func (ts *WorkerPoolTestSuite) TestRunExperimentComparesVariants() {
+	var jobs []Job[int]
+	for i := 0; i < 16; i++ {
+		jobs = append(jobs, Job[int]{ID: fmt.Sprintf("job-%d", i), Data: i})
+	}
+	processor := func(ctx context.Context, job Job[int]) (int, error) {
+		time.Sleep(2 * time.Millisecond)
+		return job.Data, nil
+	}
+
+	serial := DefaultConfig()
+	serial.NumWorkers = 1
+	parallel := DefaultConfig()
+	parallel.NumWorkers = 8
+	parallel.Strategy = WorkStealing
+
+	report, err := RunExperiment(context.Background(), jobs, processor,
+		Variant{Name: "serial", Config: serial},
+		Variant{Name: "parallel", Config: parallel},
+		ExperimentOptions{Repetitions: 2})
+	ts.NoError(err)
+
+	ts.Equal(2, report.A.Runs)
+	ts.Equal(32, report.A.Jobs)
+	ts.Equal(32, report.B.Jobs)
+	ts.Equal("work_stealing", report.B.Strategy)
+	ts.Equal("parallel", report.Winner)
+	ts.Greater(report.ThroughputDelta, 1.0)
+	ts.GreaterOrEqual(report.A.P99, 2*time.Millisecond)
+	ts.InDelta(1.0, report.A.Fairness, 0.001)
+	ts.Contains(report.String(), "winner: parallel")
+
+	data, err := json.Marshal(report)
+	ts.NoError(err)
+	ts.Contains(string(data), `"winner":"parallel"`)
+}
+
+func (ts *WorkerPoolTestSuite) TestRunExperimentSamplesSameJobs() {
+	var jobs []Job[int]
+	for i := 0; i < 200; i++ {
+		jobs = append(jobs, Job[int]{ID: fmt.Sprintf("job-%d", i), Data: i})
+	}
+	sample := sampleJobs(jobs, 0.25)
+	ts.InDelta(50, len(sample), 20)
+	ts.Equal(sample, sampleJobs(jobs, 0.25))
+
+	report, err := RunExperiment(context.Background(), jobs,
+		func(ctx context.Context, job Job[int]) (int, error) { return job.Data, nil },
+		Variant{Name: "a", Config: DefaultConfig()},
+		Variant{Name: "b", Config: DefaultConfig()},
+		ExperimentOptions{SampleRate: 0.25})
+	ts.NoError(err)
+	ts.Equal(len(sample), report.A.Jobs)
+	ts.Equal(len(sample), report.B.Jobs)
+
+	_, err = RunExperiment(context.Background(), jobs,
+		func(ctx context.Context, job Job[int]) (int, error) { return job.Data, nil },
+		Variant{Name: "a"}, Variant{Name: "a"}, ExperimentOptions{})
+	ts.Error(err)
+}
+
+func (ts *WorkerPoolTestSuite) TestJainIndex() {
+	ts.Equal(1.0, jainIndex([]float64{3, 3, 3}))
+	ts.InDelta(0.25, jainIndex([]float64{5, 0, 0, 0}), 1e-9)
+	ts.Equal(1.0, jainIndex([]float64{0, 0}))
+}
@@ -0,0 +1,209 @@
+package workerpool
+
+import (
+	"sync"
+	"time"
+)
+
+// OwnerKey returns the key used for fair-share accounting
+func (j Job[T]) OwnerKey() string {
+	if j.Owner != "" {
+		return j.Owner
+	}
+	return j.TenantID
+}
+
+// usageSample is processing time consumed by an owner at a point in time
+type usageSample struct {
+	at       time.Time
+	duration time.Duration
+}
+
+// ownerUsage tracks processing time consumed per owner over a sliding window
+type ownerUsage struct {
+	window  time.Duration
+	samples map[string][]usageSample
+	totals  map[string]time.Duration
+	mu      sync.Mutex
+}
+
+// newOwnerUsage creates a usage tracker; a zero window keeps all history
+func newOwnerUsage(window time.Duration) *ownerUsage {
+	return &ownerUsage{
+		window:  window,
+		samples: make(map[string][]usageSample),
+		totals:  make(map[string]time.Duration),
+	}
+}
+
+// record adds processing time consumed by an owner
+func (u *ownerUsage) record(owner string, at time.Time, d time.Duration) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	u.totals[owner] += d
+	if u.window > 0 {
+		u.samples[owner] = append(u.samples[owner], usageSample{at: at, duration: d})
+		u.prune(owner, at)
+	}
+}
+
+// consumed returns the processing time an owner used within the window
+func (u *ownerUsage) consumed(owner string, now time.Time) time.Duration {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	if u.window > 0 {
+		u.prune(owner, now)
+	}
+	return u.totals[owner]
+}
+
+// prune drops samples that fell out of the window. Callers must hold u.mu.
+func (u *ownerUsage) prune(owner string, now time.Time) {
+	samples := u.samples[owner]
+	cutoff := now.Add(-u.window)
+	i := 0
+	for i < len(samples) && samples[i].at.Before(cutoff) {
+		u.totals[owner] -= samples[i].duration
+		i++
+	}
+	u.samples[owner] = samples[i:]
+}
+
+// FairShareQueue holds one priority queue per owner and always pops from the
+// owner that has consumed the least processing time. Ties go to the owner
+// served least recently, so owners rotate before any usage is recorded.
+type FairShareQueue[T any] struct {
+	usage      *ownerUsage
+	owners     map[string]*PriorityQueue[T]
+	lastServed map[string]uint64
+	served     uint64
+	mu         sync.Mutex
+}
+
+// NewFairShareQueue creates a fair-share queue that accounts usage over the
+// given sliding window; a zero window keeps all history
+func NewFairShareQueue[T any](window time.Duration) *FairShareQueue[T] {
+	return newFairShareQueue[T](newOwnerUsage(window))
+}
+
+// newFairShareQueue creates a fair-share queue sharing an existing usage tracker
+func newFairShareQueue[T any](usage *ownerUsage) *FairShareQueue[T] {
+	return &FairShareQueue[T]{
+		usage:      usage,
+		owners:     make(map[string]*PriorityQueue[T]),
+		lastServed: make(map[string]uint64),
+	}
+}
+
+// Push adds a job to its owner's queue
+func (fq *FairShareQueue[T]) Push(job Job[T]) {
+	fq.mu.Lock()
+	defer fq.mu.Unlock()
+
+	owner := job.OwnerKey()
+	q, ok := fq.owners[owner]
+	if !ok {
+		q = NewPriorityQueue[T]()
+		fq.owners[owner] = q
+	}
+	q.Push(job)
+}
+
+// Pop removes the next job of the owner with the least consumed time
+func (fq *FairShareQueue[T]) Pop() (Job[T], bool) {
+	fq.mu.Lock()
+	defer fq.mu.Unlock()
+
+	best, found := fq.nextLocked()
+	if !found {
+		return Job[T]{}, false
+	}
+
+	fq.served++
+	fq.lastServed[best] = fq.served
+	return fq.owners[best].Pop()
+}
+
+// Peek returns the job Pop would return without removing it
+func (fq *FairShareQueue[T]) Peek() (Job[T], bool) {
+	fq.mu.Lock()
+	defer fq.mu.Unlock()
+
+	if best, found := fq.nextLocked(); found {
+		return fq.owners[best].Peek()
+	}
+	return Job[T]{}, false
+}
+
+// nextLocked returns the owner the next job comes from, if any. Callers must
+// hold fq.mu.
+func (fq *FairShareQueue[T]) nextLocked() (string, bool) {
+	now := time.Now()
+	best := ""
+	var bestUsage time.Duration
+	found := false
+	for owner, q := range fq.owners {
+		if q.IsEmpty() {
+			continue
+		}
+		used := fq.usage.consumed(owner, now)
+		// Break remaining ties by name so Peek and Pop agree
+		served, bestServed := fq.lastServed[owner], fq.lastServed[best]
+		if !found || used < bestUsage ||
+			(used == bestUsage && (served < bestServed || served == bestServed && owner < best)) {
+			best, bestUsage, found = owner, used, true
+		}
+	}
+	return best, found
+}
+
+// Record adds processing time consumed by an owner
+func (fq *FairShareQueue[T]) Record(owner string, d time.Duration) {
+	fq.usage.record(owner, time.Now(), d)
+}
+
+// Size returns the number of jobs across all owners
+func (fq *FairShareQueue[T]) Size() int {
+	fq.mu.Lock()
+	defer fq.mu.Unlock()
+
+	size := 0
+	for _, q := range fq.owners {
+		size += q.Size()
+	}
+	return size
+}
+
+// Len returns the number of jobs across all owners, as Size does
+func (fq *FairShareQueue[T]) Len() int {
+	return fq.Size()
+}
+
+// IsEmpty checks if no owner has queued jobs
+func (fq *FairShareQueue[T]) IsEmpty() bool {
+	return fq.Size() == 0
+}
+
+// Update applies fn to matching jobs and returns the number of jobs updated
+func (fq *FairShareQueue[T]) Update(match func(Job[T]) bool, fn func(*Job[T])) int {
+	moved := fq.Remove(match)
+	for i := range moved {
+		fn(&moved[i])
+		fq.Push(moved[i])
+	}
+	return len(moved)
+}
+
+// Remove deletes every queued job matching the predicate and returns them
+func (fq *FairShareQueue[T]) Remove(match func(Job[T]) bool) []Job[T] {
+	fq.mu.Lock()
+	defer fq.mu.Unlock()
+
+	var removed []Job[T]
+	for _, q := range fq.owners {
+		removed = append(removed, q.Remove(match)...)
+	}
+	return removed
+}
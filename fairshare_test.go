@@ -0,0 +1,67 @@
+package workerpool
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+func (ts *WorkerPoolTestSuite) TestFairShareQueuePrefersLightOwners() {
+	fq := NewFairShareQueue[string](0)
+	fq.Record("heavy", time.Second)
+	fq.Record("light", 10*time.Millisecond)
+
+	fq.Push(Job[string]{ID: "h1", Owner: "heavy", Priority: 10})
+	fq.Push(Job[string]{ID: "l1", Owner: "light"})
+	fq.Push(Job[string]{ID: "n1", TenantID: "new"})
+
+	job, _ := fq.Pop()
+	ts.Equal("n1", job.ID)
+	job, _ = fq.Pop()
+	ts.Equal("l1", job.ID)
+	job, _ = fq.Pop()
+	ts.Equal("h1", job.ID)
+	ts.True(fq.IsEmpty())
+}
+
+func (ts *WorkerPoolTestSuite) TestFairShareWindowForgetsOldUsage() {
+	usage := newOwnerUsage(time.Minute)
+	now := time.Now()
+	usage.record("a", now.Add(-2*time.Minute), time.Hour)
+	usage.record("a", now, time.Second)
+	ts.Equal(time.Second, usage.consumed("a", now))
+}
+
+func (ts *WorkerPoolTestSuite) TestFairShareStrategy() {
+	config := DefaultConfig()
+	config.NumWorkers = 1
+	config.Strategy = FairShare
+	pool := NewWithConfig[string, string](config)
+
+	// heavy already consumed a lot of processing time in an earlier run
+	pool.usage.record("heavy", time.Now(), time.Hour)
+
+	var mu sync.Mutex
+	var order []string
+	pool.WithProcessor(func(ctx context.Context, job Job[string]) (string, error) {
+		mu.Lock()
+		order = append(order, job.Owner)
+		mu.Unlock()
+		return job.Data, nil
+	})
+
+	var jobs []Job[string]
+	for i := 0; i < 4; i++ {
+		jobs = append(jobs, Job[string]{ID: fmt.Sprintf("h%d", i), Owner: "heavy"})
+		jobs = append(jobs, Job[string]{ID: fmt.Sprintf("l%d", i), Owner: "light"})
+	}
+	pool.AddJobs(jobs)
+
+	results, err := pool.Run()
+	ts.NoError(err)
+	ts.Len(results, 8)
+
+	ts.Equal([]string{"light", "light", "light", "light"}, order[:4])
+	ts.Greater(pool.usage.consumed("light", time.Now()), time.Duration(0))
+}
@@ -0,0 +1,98 @@
+package workerpool
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Fingerprinter maps an error to the failure cause it belongs to. Errors
+// with the same fingerprint are counted together in Metrics.FailureCauses
+// and sampled together by the error reporter.
+type Fingerprinter func(error) string
+
+var (
+	quotedPattern = regexp.MustCompile(`"[^"]*"|'[^']*'`)
+	uuidPattern   = regexp.MustCompile(`(?i)\b[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}\b`)
+	hexPattern    = regexp.MustCompile(`(?i)\b0x[0-9a-f]+\b`)
+	numberPattern = regexp.MustCompile(`\d+(\.\d+)?`)
+	spacePattern  = regexp.MustCompile(`\s+`)
+)
+
+// DefaultFingerprint normalizes the error string so that failures differing
+// only in identifiers or quantities group together: quoted strings become
+// "*", UUIDs <uuid>, hex literals <hex> and numbers <n>. For example
+// `fetch "a.json": status 404 after 1.2s` becomes
+// `fetch "*": status <n> after <n>s`.
+func DefaultFingerprint(err error) string {
+	if err == nil {
+		return ""
+	}
+	s := err.Error()
+	s = quotedPattern.ReplaceAllString(s, `"*"`)
+	s = uuidPattern.ReplaceAllString(s, "<uuid>")
+	s = hexPattern.ReplaceAllString(s, "<hex>")
+	s = numberPattern.ReplaceAllString(s, "<n>")
+	return strings.TrimSpace(spacePattern.ReplaceAllString(s, " "))
+}
+
+// WithFingerprinter sets how failures are grouped into causes; nil restores
+// DefaultFingerprint
+func (wp *WorkerPool[T, R]) WithFingerprinter(f Fingerprinter) *WorkerPool[T, R] {
+	wp.mu.Lock()
+	defer wp.mu.Unlock()
+	wp.fingerprinter = f
+	return wp
+}
+
+// fingerprint returns the failure cause of err
+func (wp *WorkerPool[T, R]) fingerprint(err error) string {
+	wp.mu.RLock()
+	f := wp.fingerprinter
+	wp.mu.RUnlock()
+	if f == nil {
+		f = DefaultFingerprint
+	}
+	return f(err)
+}
+
+// FailureCause is one group of failures in a run summary
+type FailureCause struct {
+	Fingerprint string `json:"fingerprint"`
+	Count       int    `json:"count"`
+}
+
+// TopFailureCauses returns the failure causes ordered by count, most
+// frequent first
+func (m *Metrics) TopFailureCauses() []FailureCause {
+	causes := make([]FailureCause, 0, len(m.FailureCauses))
+	for fp, count := range m.FailureCauses {
+		causes = append(causes, FailureCause{Fingerprint: fp, Count: count})
+	}
+	sort.Slice(causes, func(i, j int) bool {
+		if causes[i].Count != causes[j].Count {
+			return causes[i].Count > causes[j].Count
+		}
+		return causes[i].Fingerprint < causes[j].Fingerprint
+	})
+	return causes
+}
+
+// FailureSummary describes the failures by cause, e.g.
+// `2 distinct failure causes (timeout 812, status <n> 35)`
+func (m *Metrics) FailureSummary() string {
+	causes := m.TopFailureCauses()
+	if len(causes) == 0 {
+		return "no failures"
+	}
+	parts := make([]string, len(causes))
+	for i, c := range causes {
+		parts[i] = fmt.Sprintf("%s %d", c.Fingerprint, c.Count)
+	}
+	noun := "causes"
+	if len(causes) == 1 {
+		noun = "cause"
+	}
+	return fmt.Sprintf("%d distinct failure %s (%s)", len(causes), noun, strings.Join(parts, ", "))
+}
@@ -0,0 +1,68 @@
+package workerpool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+func (ts *WorkerPoolTestSuite) TestDefaultFingerprintNormalizes() {
+	ts.Equal(
+		DefaultFingerprint(errors.New(`fetch "a.json": status 404 after 1.2s`)),
+		DefaultFingerprint(errors.New(`fetch "b.json": status 500 after 30s`)),
+	)
+	ts.Equal(`fetch "*": status <n> after <n>s`, DefaultFingerprint(errors.New(`fetch "a.json": status 404 after 1.2s`)))
+	ts.Equal("row <uuid> at <hex>", DefaultFingerprint(errors.New("row 3f2b8c1e-0d4a-4b6e-9c1f-2a7d5e8b9f01 at 0xc000123")))
+	ts.Empty(DefaultFingerprint(nil))
+}
+
+func (ts *WorkerPoolTestSuite) TestFailureCausesGroupByFingerprint() {
+	config := DefaultConfig()
+	config.MaxRetries = 0
+	pool := NewWithConfig[int, int](config)
+	pool.WithProcessor(func(ctx context.Context, job Job[int]) (int, error) {
+		switch {
+		case job.Data%10 == 0:
+			return 0, fmt.Errorf("parse record %d", job.Data)
+		case job.Data%2 == 0:
+			return 0, fmt.Errorf("timeout after %dms", job.Data)
+		}
+		return job.Data, nil
+	})
+
+	var jobs []Job[int]
+	for i := 1; i <= 30; i++ {
+		jobs = append(jobs, Job[int]{ID: fmt.Sprintf("%d", i), Data: i})
+	}
+	pool.AddJobs(jobs)
+	_, err := pool.Run()
+	ts.NoError(err)
+
+	m := pool.GetMetrics()
+	ts.Equal(15, m.FailedJobs)
+	ts.Equal(map[string]int{"timeout after <n>ms": 12, "parse record <n>": 3}, m.FailureCauses)
+	ts.Equal("2 distinct failure causes (timeout after <n>ms 12, parse record <n> 3)", m.FailureSummary())
+}
+
+func (ts *WorkerPoolTestSuite) TestWithFingerprinter() {
+	config := DefaultConfig()
+	config.MaxRetries = 0
+	pool := NewWithConfig[int, int](config)
+	pool.WithProcessor(func(ctx context.Context, job Job[int]) (int, error) {
+		return 0, fmt.Errorf("HTTP %d", job.Data)
+	})
+	pool.WithFingerprinter(func(err error) string {
+		if strings.Contains(err.Error(), "404") {
+			return "not found"
+		}
+		return "other"
+	})
+
+	pool.AddJobs([]Job[int]{{ID: "a", Data: 404}, {ID: "b", Data: 404}, {ID: "c", Data: 503}})
+	_, err := pool.Run()
+	ts.NoError(err)
+
+	m := pool.GetMetrics()
+	ts.Equal([]FailureCause{{"not found", 2}, {"other", 1}}, m.TopFailureCauses())
+}
@@ -0,0 +1,225 @@
+package workerpool
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"runtime/metrics"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// gcPauseMetrics name the GC pause histogram in newer and older runtimes
+var gcPauseMetrics = []string{"/sched/pauses/total/gc:seconds", "/gc/pauses:seconds"}
+
+// heapObjectsMetric is the live and not yet swept heap size
+const heapObjectsMetric = "/memory/classes/heap/objects:bytes"
+
+// GCPressure holds back jobs of allocation-heavy classes while the garbage
+// collector is under pressure, so they do not push it into a pause spiral.
+// Every Interval during a run, the longest GC pause and the heap growth since
+// the last sample are read from runtime/metrics; pressure starts when either
+// reaches its limit and ends after an interval within both. Jobs of Classes
+// wait before starting while there is pressure; other jobs are unaffected.
+// Each change is recorded as a "GCPressure" ConfigEvent. GCPressure is
+// disabled without Classes or without either limit.
+type GCPressure struct {
+	Classes       []string      // Job classes (Job.Class) that allocate heavily
+	MaxPause      time.Duration // A GC pause at least this long signals pressure; zero ignores pauses
+	MaxHeapGrowth float64       // Heap growth within one interval, as a fraction, that signals pressure, e.g. 0.5; zero ignores growth
+	Interval      time.Duration // How often runtime/metrics is sampled; defaults to 500ms
+	MaxDelay      time.Duration // Longest a job is held back; zero holds it until pressure recedes
+}
+
+// gcSample is what one runtime/metrics sample says about GC pressure
+type gcSample struct {
+	pause time.Duration // Longest pause since the previous sample
+	heap  uint64        // Heap objects in bytes
+}
+
+// gcMonitor tracks GC pressure and holds back heavy jobs while it lasts
+type gcMonitor struct {
+	cfg      GCPressure
+	classes  map[string]bool
+	sample   func() gcSample // Reads runtime/metrics; replaced in tests
+	pressure bool
+	changed  chan struct{} // Closed and replaced whenever pressure ends
+	mu       sync.Mutex
+}
+
+// newGCMonitor creates a monitor for cfg, or nil when it is disabled
+func newGCMonitor(cfg GCPressure) *gcMonitor {
+	if len(cfg.Classes) == 0 || (cfg.MaxPause <= 0 && cfg.MaxHeapGrowth <= 0) {
+		return nil
+	}
+	if cfg.Interval <= 0 {
+		cfg.Interval = 500 * time.Millisecond
+	}
+	classes := make(map[string]bool, len(cfg.Classes))
+	for _, class := range cfg.Classes {
+		classes[class] = true
+	}
+	return &gcMonitor{cfg: cfg, classes: classes, sample: gcSampler(), changed: make(chan struct{})}
+}
+
+// GCPressured reports whether jobs of GCPressure.Classes are being held back
+func (wp *WorkerPool[T, R]) GCPressured() bool {
+	m := wp.gc
+	if m == nil {
+		return false
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.pressure
+}
+
+// start samples GC metrics in the background until the returned function is
+// called, passing every change of pressure to report. The returned function
+// ends any pressure once sampling has stopped.
+func (m *gcMonitor) start(report func(ConfigEvent)) (stop func()) {
+	if m == nil {
+		return func() {}
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		m.watch(ctx, report)
+	}()
+	return func() {
+		cancel()
+		<-done
+		m.set(false, "run ended", report)
+	}
+}
+
+// watch samples GC metrics until ctx is done
+func (m *gcMonitor) watch(ctx context.Context, report func(ConfigEvent)) {
+	last := m.sample()
+	ticker := time.NewTicker(m.cfg.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		s := m.sample()
+		var growth float64
+		if last.heap > 0 {
+			growth = (float64(s.heap) - float64(last.heap)) / float64(last.heap)
+		}
+		last = s
+
+		switch {
+		case m.cfg.MaxPause > 0 && s.pause >= m.cfg.MaxPause:
+			m.set(true, fmt.Sprintf("GC pause of %s", s.pause), report)
+		case m.cfg.MaxHeapGrowth > 0 && growth >= m.cfg.MaxHeapGrowth:
+			m.set(true, fmt.Sprintf("heap grew %.0f%% in %s", growth*100, m.cfg.Interval), report)
+		default:
+			m.set(false, "GC pauses and heap growth within limits", report)
+		}
+	}
+}
+
+// set changes the pressure state, reporting actual changes
+func (m *gcMonitor) set(pressure bool, reason string, report func(ConfigEvent)) {
+	m.mu.Lock()
+	if m.pressure == pressure {
+		m.mu.Unlock()
+		return
+	}
+	m.pressure = pressure
+	if !pressure {
+		close(m.changed)
+		m.changed = make(chan struct{})
+	}
+	m.mu.Unlock()
+
+	report(ConfigEvent{
+		Time:    time.Now(),
+		Setting: "GCPressure",
+		Old:     strconv.FormatBool(!pressure),
+		New:     strconv.FormatBool(pressure),
+		Reason:  reason,
+	})
+}
+
+// awaitGCPressure holds back a job of a heavy class while there is GC
+// pressure. It returns an error if ctx ends first, and nil once pressure
+// recedes, MaxDelay passes or the pool starts draining.
+func (wp *WorkerPool[T, R]) awaitGCPressure(ctx context.Context, job Job[T]) error {
+	m := wp.gc
+	if m == nil || !m.classes[job.Class] {
+		return nil
+	}
+	wp.mu.RLock()
+	drained := wp.drained
+	wp.mu.RUnlock()
+
+	var deadline <-chan time.Time
+	if m.cfg.MaxDelay > 0 {
+		timer := time.NewTimer(m.cfg.MaxDelay)
+		defer timer.Stop()
+		deadline = timer.C
+	}
+	for {
+		m.mu.Lock()
+		pressure, changed := m.pressure, m.changed
+		m.mu.Unlock()
+		if !pressure {
+			return nil
+		}
+
+		select {
+		case <-changed:
+		case <-deadline:
+			return nil
+		case <-drained:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// gcSampler returns a function reading GC pressure from runtime/metrics.
+// Each call reports the longest pause since the previous call.
+func gcSampler() func() gcSample {
+	samples := []metrics.Sample{{Name: heapObjectsMetric}}
+	supported := make(map[string]bool)
+	for _, d := range metrics.All() {
+		supported[d.Name] = true
+	}
+	for _, name := range gcPauseMetrics {
+		if supported[name] {
+			samples = append(samples, metrics.Sample{Name: name})
+			break
+		}
+	}
+
+	var counts []uint64
+	return func() gcSample {
+		metrics.Read(samples)
+		var s gcSample
+		if samples[0].Value.Kind() == metrics.KindUint64 {
+			s.heap = samples[0].Value.Uint64()
+		}
+		if len(samples) < 2 || samples[1].Value.Kind() != metrics.KindFloat64Histogram {
+			return s
+		}
+
+		// The longest pause is the lowest bound of the highest bucket
+		// that gained a count
+		h := samples[1].Value.Float64Histogram()
+		for i := len(h.Counts) - 1; i >= 0; i-- {
+			if i < len(counts) && h.Counts[i] > counts[i] && !math.IsInf(h.Buckets[i], -1) {
+				s.pause = time.Duration(h.Buckets[i] * float64(time.Second))
+				break
+			}
+		}
+		counts = append(counts[:0], h.Counts...)
+		return s
+	}
+}
@@ -0,0 +1,91 @@
+package workerpool
+
+import (
+	"context"
+	"runtime"
+	"sync/atomic"
+	"time"
+)
+
+func (ts *WorkerPoolTestSuite) TestGCPressureHoldsHeavyClasses() {
+	ts.Nil(newGCMonitor(GCPressure{Classes: []string{"heavy"}}))
+
+	config := DefaultConfig()
+	config.GCPressure = GCPressure{Classes: []string{"heavy"}, MaxPause: time.Millisecond}
+	pool := NewWithConfig[int, int](config)
+	pool.gc.set(true, "test", pool.recordEvent)
+	ts.True(pool.GCPressured())
+
+	ts.NoError(pool.awaitGCPressure(context.Background(), Job[int]{Class: "light"}))
+
+	released := make(chan error)
+	go func() { released <- pool.awaitGCPressure(context.Background(), Job[int]{Class: "heavy"}) }()
+	select {
+	case <-released:
+		ts.Fail("heavy job started under pressure")
+	case <-time.After(5 * time.Millisecond):
+	}
+	pool.gc.set(false, "test", pool.recordEvent)
+	ts.NoError(<-released)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	pool.gc.set(true, "test", pool.recordEvent)
+	ts.ErrorIs(pool.awaitGCPressure(ctx, Job[int]{Class: "heavy"}), context.Canceled)
+
+	// MaxDelay bounds the hold
+	pool.gc.cfg.MaxDelay = time.Millisecond
+	ts.NoError(pool.awaitGCPressure(context.Background(), Job[int]{Class: "heavy"}))
+	ts.Len(pool.ConfigEvents(), 3)
+}
+
+func (ts *WorkerPoolTestSuite) TestGCPressureDuringRun() {
+	config := DefaultConfig()
+	config.NumWorkers = 1
+	config.GCPressure = GCPressure{Classes: []string{"heavy"}, MaxHeapGrowth: 0.5, Interval: time.Millisecond}
+	pool := NewWithConfig[int, int](config)
+
+	// The heap doubles every sample until the light job has seen the pressure
+	var heap, calm atomic.Uint64
+	heap.Store(1 << 20)
+	pool.gc.sample = func() gcSample {
+		if calm.Load() == 0 {
+			return gcSample{heap: heap.Add(heap.Load())}
+		}
+		return gcSample{heap: heap.Load()}
+	}
+
+	var order []string
+	pool.WithProcessor(func(ctx context.Context, job Job[int]) (int, error) {
+		if job.Class == "light" {
+			for !pool.GCPressured() {
+				time.Sleep(time.Millisecond)
+			}
+			calm.Store(1)
+		}
+		order = append(order, job.ID)
+		return job.Data, nil
+	})
+	pool.AddJobs([]Job[int]{{ID: "light", Class: "light"}, {ID: "heavy", Class: "heavy"}})
+
+	results, err := pool.Run()
+	ts.NoError(err)
+	ts.Len(results, 2)
+	ts.Equal([]string{"light", "heavy"}, order)
+	ts.False(pool.GCPressured())
+
+	events := pool.ConfigEvents()
+	ts.Require().Len(events, 2)
+	ts.Equal("GCPressure", events[0].Setting)
+	ts.Equal("true", events[0].New)
+	ts.Contains(events[0].Reason, "heap grew 100%")
+	ts.Equal("false", events[1].New)
+}
+
+func (ts *WorkerPoolTestSuite) TestGCSampler() {
+	sample := gcSampler()
+	ts.Greater(sample().heap, uint64(0))
+
+	runtime.GC()
+	ts.Greater(sample().pause, time.Duration(0))
+}
@@ -0,0 +1,46 @@
+//go:build go1.23
+
+package workerpool
+
+import (
+	"context"
+	"iter"
+)
+
+// All runs the pool and yields each result as it completes:
+//
+//	for res, err := range pool.All() {
+//		if err != nil {
+//			// the run failed or ended early
+//		}
+//	}
+//
+// A run-level error is yielded last with a zero Result. Breaking out of the
+// loop stops the run with ErrPoolStopped as the cause.
+func (wp *WorkerPool[T, R]) All() iter.Seq2[Result[R], error] {
+	return func(yield func(Result[R], error) bool) {
+		results := make(chan Result[R])
+		errc := make(chan error, 1)
+		go func() {
+			_, err := wp.run(context.Background(), func(result Result[R]) {
+				results <- result
+			})
+			close(results)
+			errc <- err
+		}()
+
+		for result := range results {
+			if !yield(result, nil) {
+				wp.Stop()
+				for range results {
+					// Drain so the run can finish
+				}
+				<-errc
+				return
+			}
+		}
+		if err := <-errc; err != nil {
+			yield(Result[R]{}, err)
+		}
+	}
+}
@@ -0,0 +1,64 @@
+//go:build go1.23
+
+package workerpool
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+func (ts *WorkerPoolTestSuite) TestAllYieldsResults() {
+	pool := New[string, string]()
+	pool.WithProcessor(func(ctx context.Context, job Job[string]) (string, error) {
+		return strings.ToUpper(job.Data), nil
+	})
+	pool.AddJobs([]Job[string]{{ID: "1", Data: "a"}, {ID: "2", Data: "b"}, {ID: "3", Data: "c"}})
+
+	seen := map[string]bool{}
+	for res, err := range pool.All() {
+		ts.NoError(err)
+		seen[res.Data] = true
+	}
+	ts.Equal(map[string]bool{"A": true, "B": true, "C": true}, seen)
+	ts.Equal(3, pool.GetMetrics().ProcessedJobs)
+}
+
+func (ts *WorkerPoolTestSuite) TestAllBreakStopsRun() {
+	config := DefaultConfig()
+	config.NumWorkers = 1
+	pool := NewWithConfig[string, string](config)
+
+	var processed atomic.Int32
+	pool.WithProcessor(func(ctx context.Context, job Job[string]) (string, error) {
+		processed.Add(1)
+		time.Sleep(time.Millisecond)
+		return job.Data, nil
+	})
+	var jobs []Job[string]
+	for i := 0; i < 100; i++ {
+		jobs = append(jobs, Job[string]{ID: fmt.Sprintf("%d", i), Data: "x"})
+	}
+	pool.AddJobs(jobs)
+
+	count := 0
+	for range pool.All() {
+		count++
+		if count == 2 {
+			break
+		}
+	}
+	ts.Equal(2, count)
+	ts.Less(processed.Load(), int32(100))
+}
+
+func (ts *WorkerPoolTestSuite) TestAllYieldsRunError() {
+	pool := New[string, string]()
+	var lastErr error
+	for _, err := range pool.All() {
+		lastErr = err
+	}
+	ts.ErrorContains(lastErr, "no processor configured")
+}
@@ -0,0 +1,189 @@
+package workerpool
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// ErrJobStuck is the cancellation cause of an attempt that stopped heartbeating
+var ErrJobStuck = errors.New("job stopped heartbeating")
+
+// jobControlKey is the context key under which the JobControl is stored
+type jobControlKey struct{}
+
+// JobControl lets a processor keep a long-running attempt alive. Every
+// attempt gets its own control, available through JobControlFromContext.
+// All methods are safe to call on a nil *JobControl.
+type JobControl struct {
+	lastBeat  time.Time
+	deadline  time.Time     // Zero when the attempt has no WorkerTimeout
+	heartbeat time.Duration // Config.HeartbeatTimeout
+	visible   time.Duration // Config.VisibilityTimeout
+	lost      chan struct{} // Closed when the visibility timeout lapses
+	timer     *time.Timer
+	cancel    context.CancelCauseFunc
+	mu        sync.Mutex
+}
+
+// JobControlFromContext returns the control of the attempt ctx belongs to,
+// or nil if ctx was not created by a pool
+func JobControlFromContext(ctx context.Context) *JobControl {
+	c, _ := ctx.Value(jobControlKey{}).(*JobControl)
+	return c
+}
+
+// Heartbeat reports that the attempt is making progress, resetting the
+// Config.HeartbeatTimeout and Config.VisibilityTimeout watchdogs
+func (c *JobControl) Heartbeat() {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.lastBeat = time.Now()
+}
+
+// ExtendDeadline pushes the attempt's WorkerTimeout deadline back by d and
+// counts as a heartbeat. It has no effect on the run's own timeout, or when
+// the pool has no WorkerTimeout.
+func (c *JobControl) ExtendDeadline(d time.Duration) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.lastBeat = time.Now()
+	if !c.deadline.IsZero() {
+		c.deadline = c.deadline.Add(d)
+	}
+}
+
+// Deadline returns the attempt's current deadline, or the zero time if it has none
+func (c *JobControl) Deadline() time.Time {
+	if c == nil {
+		return time.Time{}
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.deadline
+}
+
+// LastHeartbeat returns when the attempt last heartbeated, or when it started
+func (c *JobControl) LastHeartbeat() time.Time {
+	if c == nil {
+		return time.Time{}
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.lastBeat
+}
+
+// watch is the watchdog: it cancels the attempt once its deadline passes or
+// its heartbeat goes stale, and otherwise re-arms itself for the next check
+func (c *JobControl) watch() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	if !c.deadline.IsZero() && !now.Before(c.deadline) {
+		c.cancel(context.DeadlineExceeded)
+		return
+	}
+	if c.visible > 0 && now.Sub(c.lastBeat) >= c.visible {
+		close(c.lost)
+		c.cancel(ErrVisibilityTimeout)
+		return
+	}
+	if c.heartbeat > 0 && now.Sub(c.lastBeat) >= c.heartbeat {
+		c.cancel(ErrJobStuck)
+		return
+	}
+	c.timer.Reset(c.nextCheckLocked().Sub(now))
+}
+
+// nextCheckLocked returns when the watchdog must look again. Callers must hold c.mu.
+func (c *JobControl) nextCheckLocked() time.Time {
+	next := c.deadline
+	for _, limit := range []time.Duration{c.heartbeat, c.visible} {
+		if limit <= 0 {
+			continue
+		}
+		if stale := c.lastBeat.Add(limit); next.IsZero() || stale.Before(next) {
+			next = stale
+		}
+	}
+	return next
+}
+
+// jobContext is the context of one attempt. Its deadline follows the
+// JobControl, and it reports DeadlineExceeded when that deadline passes.
+type jobContext struct {
+	context.Context
+	ctl *JobControl
+	cpu atomic.Int64 // Nanoseconds of CPU time used by the processor, with Config.CPUAccounting
+}
+
+// newJobContext starts an attempt under parent with the given WorkerTimeout,
+// HeartbeatTimeout and VisibilityTimeout (zero disables each). The returned
+// function stops the watchdog and releases the context.
+func newJobContext(parent context.Context, timeout, heartbeat, visibility time.Duration) (*jobContext, func()) {
+	base, cancel := context.WithCancelCause(parent)
+	now := time.Now()
+	ctl := &JobControl{
+		lastBeat:  now,
+		heartbeat: heartbeat,
+		visible:   visibility,
+		lost:      make(chan struct{}),
+		cancel:    cancel,
+	}
+	if timeout > 0 {
+		ctl.deadline = now.Add(timeout)
+	}
+
+	ctl.mu.Lock()
+	if next := ctl.nextCheckLocked(); !next.IsZero() {
+		ctl.timer = time.AfterFunc(next.Sub(now), ctl.watch)
+	}
+	ctl.mu.Unlock()
+
+	stop := func() {
+		ctl.mu.Lock()
+		if ctl.timer != nil {
+			ctl.timer.Stop()
+		}
+		ctl.mu.Unlock()
+		cancel(nil)
+	}
+	return &jobContext{Context: base, ctl: ctl}, stop
+}
+
+// Deadline returns the earlier of the attempt's and the parent's deadline
+func (c *jobContext) Deadline() (time.Time, bool) {
+	parent, ok := c.Context.Deadline()
+	own := c.ctl.Deadline()
+	if own.IsZero() || (ok && parent.Before(own)) {
+		return parent, ok
+	}
+	return own, true
+}
+
+// Err reports DeadlineExceeded when the watchdog ended the attempt for
+// running past its deadline
+func (c *jobContext) Err() error {
+	err := c.Context.Err()
+	if errors.Is(err, context.Canceled) && errors.Is(context.Cause(c.Context), context.DeadlineExceeded) {
+		return context.DeadlineExceeded
+	}
+	return err
+}
+
+// Value exposes the attempt's JobControl
+func (c *jobContext) Value(key any) any {
+	if key == (jobControlKey{}) {
+		return c.ctl
+	}
+	return c.Context.Value(key)
+}
@@ -0,0 +1,99 @@
+package workerpool
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// sleepOrDone waits for d, stopping early with ctx's error
+func sleepOrDone(ctx context.Context, d time.Duration) error {
+	select {
+	case <-time.After(d):
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (ts *WorkerPoolTestSuite) TestExtendDeadline() {
+	config := DefaultConfig()
+	config.WorkerTimeout = 30 * time.Millisecond
+	config.MaxRetries = 0
+	pool := NewWithConfig[bool, string](config)
+
+	pool.WithProcessor(func(ctx context.Context, job Job[bool]) (string, error) {
+		ctl := JobControlFromContext(ctx)
+		initial := ctl.Deadline()
+		for i := 0; i < 6; i++ {
+			if job.Data {
+				ctl.ExtendDeadline(15 * time.Millisecond)
+			}
+			if err := sleepOrDone(ctx, 10*time.Millisecond); err != nil {
+				return "", err
+			}
+		}
+		if deadline, _ := ctx.Deadline(); !deadline.After(initial) {
+			return "", errors.New("deadline not extended")
+		}
+		return "done", nil
+	})
+
+	pool.AddJobs([]Job[bool]{{ID: "extends", Data: true}, {ID: "stalls", Data: false}})
+	results, err := pool.Run()
+	ts.NoError(err)
+	ts.Len(results, 2)
+
+	for _, r := range results {
+		if r.JobID == "extends" {
+			ts.NoError(r.Error)
+		} else {
+			ts.ErrorIs(r.Error, context.DeadlineExceeded)
+		}
+	}
+}
+
+func (ts *WorkerPoolTestSuite) TestHeartbeatWatchdog() {
+	config := DefaultConfig()
+	config.HeartbeatTimeout = 20 * time.Millisecond
+	config.MaxRetries = 0
+	pool := NewWithConfig[bool, string](config)
+
+	pool.WithProcessor(func(ctx context.Context, job Job[bool]) (string, error) {
+		ctl := JobControlFromContext(ctx)
+		for i := 0; i < 12; i++ {
+			if job.Data {
+				ctl.Heartbeat()
+			}
+			if err := sleepOrDone(ctx, 5*time.Millisecond); err != nil {
+				return "", err
+			}
+		}
+		return "done", nil
+	})
+
+	pool.AddJobs([]Job[bool]{{ID: "alive", Data: true}, {ID: "stuck", Data: false}})
+	results, err := pool.Run()
+	ts.NoError(err)
+	ts.Len(results, 2)
+
+	for _, r := range results {
+		if r.JobID == "alive" {
+			ts.NoError(r.Error)
+		} else {
+			ts.ErrorIs(r.Error, ErrJobStuck)
+			ts.ErrorIs(r.Error, context.Canceled)
+		}
+	}
+}
+
+func (ts *WorkerPoolTestSuite) TestJobControlOutsidePool() {
+	ctl := JobControlFromContext(context.Background())
+	ts.Nil(ctl)
+
+	// Methods are no-ops on a nil control
+	ctl.Heartbeat()
+	ctl.ExtendDeadline(time.Second)
+	ts.True(ctl.Deadline().IsZero())
+	ts.True(ctl.LastHeartbeat().IsZero())
+}
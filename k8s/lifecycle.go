@@ -0,0 +1,162 @@
+// Package k8s ties a worker pool to the Kubernetes pod lifecycle: readiness
+// and liveness probes that reflect the pool's health, and a preStop hook that
+// drains the pool within the pod's termination grace period.
+//
+// Serve the handler and point the pod at it:
+//
+//	lifecycle := k8s.Lifecycle(pool, k8s.Options{TerminationGracePeriod: 60 * time.Second})
+//	go http.ListenAndServe(":8081", lifecycle)
+//
+//	readinessProbe:
+//	  httpGet: {path: /readyz, port: 8081}
+//	lifecycle:
+//	  preStop:
+//	    httpGet: {path: /prestop, port: 8081}
+package k8s
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+	"os/signal"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"github.com/go-foundations/workerpool"
+)
+
+// Kubernetes defaults used when Options leave them unset
+const (
+	defaultGracePeriod = 30 * time.Second
+	defaultMargin      = 2 * time.Second
+)
+
+// Options configures Lifecycle
+type Options struct {
+	TerminationGracePeriod time.Duration // The pod's terminationGracePeriodSeconds; zero means the Kubernetes default of 30s
+	Margin                 time.Duration // Part of the grace period kept back for persisting unstarted jobs; zero means 2s
+	RequireWarm            bool          // Report not ready until every worker's OnWorkerStart initializer has completed
+	HandleSIGTERM          bool          // Drain on SIGTERM too, for pods without the preStop hook
+}
+
+// Handler serves the probe and preStop endpoints of one pool:
+//
+//	/livez    200 while the process can serve requests
+//	/readyz   200 when the pool can take work, 503 once draining starts
+//	/prestop  drains the pool, responding when it is done
+type Handler[T, R any] struct {
+	pool     *workerpool.WorkerPool[T, R]
+	opts     Options
+	draining atomic.Bool
+	once     sync.Once
+	done     chan struct{}
+	result   workerpool.ShutdownResult[T]
+	err      error
+	stop     func()
+}
+
+// drainResponse is the body of a /prestop response
+type drainResponse struct {
+	Remaining int    `json:"remaining"`
+	Error     string `json:"error,omitempty"`
+}
+
+// Lifecycle creates the lifecycle handler of pool. With HandleSIGTERM it
+// starts listening for SIGTERM right away; call Stop to release it.
+func Lifecycle[T, R any](pool *workerpool.WorkerPool[T, R], opts Options) *Handler[T, R] {
+	if opts.TerminationGracePeriod <= 0 {
+		opts.TerminationGracePeriod = defaultGracePeriod
+	}
+	if opts.Margin <= 0 {
+		opts.Margin = defaultMargin
+	}
+	h := &Handler[T, R]{pool: pool, opts: opts, done: make(chan struct{}), stop: func() {}}
+
+	if opts.HandleSIGTERM {
+		sigs := make(chan os.Signal, 1)
+		signal.Notify(sigs, syscall.SIGTERM)
+		quit := make(chan struct{})
+		var once sync.Once
+		h.stop = func() {
+			once.Do(func() {
+				signal.Stop(sigs)
+				close(quit)
+			})
+		}
+		go func() {
+			select {
+			case <-sigs:
+				h.Drain()
+			case <-quit:
+			}
+		}()
+	}
+	return h
+}
+
+// Drain shuts the pool down, giving in-flight jobs the grace period less the
+// margin to finish, and returns the jobs that never started. The grace period
+// is counted from the first call; later calls wait for the same drain.
+func (h *Handler[T, R]) Drain() (workerpool.ShutdownResult[T], error) {
+	h.once.Do(func() {
+		h.draining.Store(true)
+		ctx, cancel := context.WithTimeout(context.Background(), h.opts.TerminationGracePeriod-h.opts.Margin)
+		defer cancel()
+		h.result, h.err = h.pool.Shutdown(ctx)
+		close(h.done)
+	})
+	<-h.done
+	return h.result, h.err
+}
+
+// Done is closed once a drain has completed
+func (h *Handler[T, R]) Done() <-chan struct{} {
+	return h.done
+}
+
+// Ready reports whether the pool should receive traffic
+func (h *Handler[T, R]) Ready() bool {
+	health := h.pool.Health()
+	if h.draining.Load() || health.Draining {
+		return false
+	}
+	return health.Warm || !h.opts.RequireWarm
+}
+
+// Stop stops listening for SIGTERM
+func (h *Handler[T, R]) Stop() {
+	h.stop()
+}
+
+// ServeHTTP routes the lifecycle endpoints
+func (h *Handler[T, R]) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.URL.Path {
+	case "/livez":
+		w.WriteHeader(http.StatusOK)
+	case "/readyz":
+		code := http.StatusOK
+		if !h.Ready() {
+			code = http.StatusServiceUnavailable
+		}
+		writeJSON(w, code, h.pool.Health())
+	case "/prestop":
+		res, err := h.Drain()
+		body := drainResponse{Remaining: len(res.Remaining)}
+		if err != nil {
+			body.Error = err.Error()
+		}
+		writeJSON(w, http.StatusOK, body)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// writeJSON writes payload as the JSON response body
+func writeJSON(w http.ResponseWriter, code int, payload any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(payload)
+}
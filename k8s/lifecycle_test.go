@@ -0,0 +1,109 @@
+package k8s
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-foundations/workerpool"
+	"github.com/stretchr/testify/suite"
+)
+
+// LifecycleTestSuite holds the Kubernetes lifecycle tests
+type LifecycleTestSuite struct {
+	suite.Suite
+}
+
+// TestLifecycleTestSuite runs all tests in the suite
+func TestLifecycleTestSuite(t *testing.T) {
+	suite.Run(t, new(LifecycleTestSuite))
+}
+
+// blockingPool returns a one-worker pool with jobs queued whose processor
+// signals started and waits for release or cancellation
+func blockingPool(jobs int) (*workerpool.WorkerPool[int, int], chan struct{}, chan struct{}) {
+	config := workerpool.DefaultConfig()
+	config.NumWorkers = 1
+	pool := workerpool.NewWithConfig[int, int](config)
+	started, release := make(chan struct{}, jobs), make(chan struct{})
+	pool.WithProcessor(func(ctx context.Context, job workerpool.Job[int]) (int, error) {
+		started <- struct{}{}
+		select {
+		case <-release:
+			return job.Data, nil
+		case <-ctx.Done():
+			return 0, context.Cause(ctx)
+		}
+	})
+	for i := 0; i < jobs; i++ {
+		pool.AddJob(workerpool.Job[int]{ID: fmt.Sprint(i), Data: i})
+	}
+	return pool, started, release
+}
+
+func (ts *LifecycleTestSuite) get(h http.Handler, path string) *httptest.ResponseRecorder {
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+	return rec
+}
+
+func (ts *LifecycleTestSuite) TestPreStopDrainsAndFailsReadiness() {
+	pool, started, release := blockingPool(3)
+	lifecycle := Lifecycle(pool, Options{})
+	ts.Equal(http.StatusOK, ts.get(lifecycle, "/readyz").Code)
+	ts.Equal(http.StatusOK, ts.get(lifecycle, "/livez").Code)
+
+	go pool.Run()
+	<-started
+
+	prestop := make(chan *httptest.ResponseRecorder)
+	go func() { prestop <- ts.get(lifecycle, "/prestop") }()
+	ts.Eventually(func() bool { return !lifecycle.Ready() }, time.Second, time.Millisecond)
+	ts.Equal(http.StatusServiceUnavailable, ts.get(lifecycle, "/readyz").Code)
+	ts.Equal(http.StatusOK, ts.get(lifecycle, "/livez").Code)
+	close(release)
+
+	rec := <-prestop
+	ts.Equal(http.StatusOK, rec.Code)
+	var body drainResponse
+	ts.NoError(json.NewDecoder(rec.Body).Decode(&body))
+	ts.Equal(drainResponse{Remaining: 2}, body)
+	<-lifecycle.Done()
+
+	res, err := lifecycle.Drain()
+	ts.NoError(err)
+	ts.Len(res.Remaining, 2)
+}
+
+func (ts *LifecycleTestSuite) TestDrainHonorsGracePeriod() {
+	pool, started, _ := blockingPool(2)
+	lifecycle := Lifecycle(pool, Options{TerminationGracePeriod: 30 * time.Millisecond, Margin: 20 * time.Millisecond})
+
+	go pool.Run()
+	<-started
+	begin := time.Now()
+	res, err := lifecycle.Drain()
+	ts.ErrorIs(err, context.DeadlineExceeded)
+	ts.Less(time.Since(begin), time.Second)
+	ts.Len(res.Remaining, 1)
+}
+
+func (ts *LifecycleTestSuite) TestRequireWarm() {
+	pool := workerpool.New[int, int]()
+	init := make(chan struct{})
+	pool.OnWorkerStart(func(ctx context.Context, workerID int) (any, error) {
+		<-init
+		return nil, nil
+	})
+	lifecycle := Lifecycle(pool, Options{RequireWarm: true})
+	go pool.Warm(context.Background())
+	ts.False(lifecycle.Ready())
+
+	close(init)
+	ts.Eventually(lifecycle.Ready, time.Second, time.Millisecond)
+	ts.Equal(http.StatusNotFound, ts.get(lifecycle, "/other").Code)
+}
@@ -0,0 +1,116 @@
+package workerpool
+
+import "sync"
+
+// KeyedResults splits the result stream of a pool into one channel per key,
+// e.g. per customer, created by ResultsByKey. Every key's channel buffers
+// independently, so a slow consumer of one key never holds back another.
+type KeyedResults[K comparable, R any] struct {
+	key         func(Result[R]) K
+	buffer      int
+	unsubscribe func()
+	chans       map[K]chan Result[R]
+	dropped     map[K]int64
+	closed      bool
+	mu          sync.Mutex
+}
+
+// ResultsByKey streams every result of the pool, across runs, to the
+// channel of the key it maps to until Stop is called. buffer bounds each
+// key's channel (zero means 256); results for a key whose channel is full
+// are dropped and counted rather than stalling the pool.
+func ResultsByKey[T, R any, K comparable](wp *WorkerPool[T, R], key func(Result[R]) K, buffer int) *KeyedResults[K, R] {
+	if buffer <= 0 {
+		buffer = defaultWatchBuffer
+	}
+	kr := &KeyedResults[K, R]{
+		key:     key,
+		buffer:  buffer,
+		chans:   make(map[K]chan Result[R]),
+		dropped: make(map[K]int64),
+	}
+	// Results are routed as the pool publishes them, so no shared buffer
+	// sits in front of the per-key ones
+	kr.unsubscribe = wp.watchers.subscribe(kr.route)
+	return kr
+}
+
+// route hands a published result to its key's channel
+func (kr *KeyedResults[K, R]) route(result Result[R]) {
+	k := kr.key(result)
+	kr.mu.Lock()
+	defer kr.mu.Unlock()
+	select {
+	case kr.channelLocked(k) <- result:
+	default:
+		kr.dropped[k]++
+	}
+}
+
+// channelLocked returns the channel of key, creating it on first use.
+// Callers must hold kr.mu.
+func (kr *KeyedResults[K, R]) channelLocked(key K) chan Result[R] {
+	ch, ok := kr.chans[key]
+	if !ok {
+		ch = make(chan Result[R], kr.buffer)
+		if kr.closed {
+			close(ch)
+		}
+		kr.chans[key] = ch
+	}
+	return ch
+}
+
+// C returns the channel of results for key. It may be called before any
+// result for the key arrives; all channels are closed by Stop.
+func (kr *KeyedResults[K, R]) C(key K) <-chan Result[R] {
+	kr.mu.Lock()
+	defer kr.mu.Unlock()
+	return kr.channelLocked(key)
+}
+
+// Keys returns every key seen so far or asked for with C
+func (kr *KeyedResults[K, R]) Keys() []K {
+	kr.mu.Lock()
+	defer kr.mu.Unlock()
+	keys := make([]K, 0, len(kr.chans))
+	for k := range kr.chans {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+// Dropped returns how many results for key were discarded because its
+// channel was full
+func (kr *KeyedResults[K, R]) Dropped(key K) int64 {
+	kr.mu.Lock()
+	defer kr.mu.Unlock()
+	return kr.dropped[key]
+}
+
+// Stop ends the partitioning and closes every key's channel; results
+// already buffered can still be received. It is safe to call more than once.
+func (kr *KeyedResults[K, R]) Stop() {
+	kr.unsubscribe()
+
+	kr.mu.Lock()
+	defer kr.mu.Unlock()
+	if kr.closed {
+		return
+	}
+	kr.closed = true
+	for _, ch := range kr.chans {
+		close(ch)
+	}
+}
+
+// GroupResults partitions collected results, such as those returned by
+// Run, into a map by key, keeping their order within each key
+func GroupResults[K comparable, R any](results []Result[R], key func(Result[R]) K) map[K][]Result[R] {
+	groups := make(map[K][]Result[R])
+	for _, result := range results {
+		k := key(result)
+		groups[k] = append(groups[k], result)
+	}
+	return groups
+}
@@ -0,0 +1,46 @@
+package workerpool
+
+import (
+	"context"
+	"fmt"
+)
+
+func (ts *WorkerPoolTestSuite) TestResultsByKey() {
+	pool := New[int, int]()
+	pool.WithProcessor(func(ctx context.Context, job Job[int]) (int, error) {
+		return job.Data, nil
+	})
+	customer := func(r Result[int]) string { return fmt.Sprint("customer-", r.Data%3) }
+	byCustomer := ResultsByKey(pool, customer, 2)
+	early := byCustomer.C("customer-0")
+	ts.Empty(pool.watchers.watches) // No result watch sits behind the per-key channels
+
+	var jobs []Job[int]
+	for i := 0; i < 9; i++ {
+		jobs = append(jobs, Job[int]{ID: fmt.Sprint(i), Data: i})
+	}
+	pool.AddJobs(jobs)
+	results, err := pool.Run()
+	ts.NoError(err)
+	byCustomer.Stop()
+	byCustomer.Stop() // Stopping twice is harmless
+	ts.Empty(pool.watchers.hooks)
+
+	ts.ElementsMatch([]string{"customer-0", "customer-1", "customer-2"}, byCustomer.Keys())
+	for _, key := range byCustomer.Keys() {
+		got := 0
+		for r := range byCustomer.C(key) {
+			ts.Equal(key, customer(r))
+			got++
+		}
+		// Each key buffers two results and drops the third
+		ts.Equal(2, got, key)
+		ts.Equal(int64(1), byCustomer.Dropped(key), key)
+	}
+	_, open := <-early
+	ts.False(open)
+
+	groups := GroupResults(results, customer)
+	ts.Len(groups, 3)
+	ts.Len(groups["customer-1"], 3)
+}
@@ -0,0 +1,21 @@
+package workerpool
+
+import (
+	"context"
+	"fmt"
+	"runtime/pprof"
+)
+
+// labelGoroutine tags the calling goroutine with pprof labels naming the
+// pool and the goroutine's role in the run, e.g. "worker-3" or
+// "collector", on top of any labels ctx carries. Goroutine profiles of a
+// wedged process, such as /debug/pprof/goroutine?debug=1, then show which
+// pool each goroutine belongs to.
+func (wp *WorkerPool[T, R]) labelGoroutine(ctx context.Context, role string) {
+	pprof.SetGoroutineLabels(pprof.WithLabels(ctx, pprof.Labels("workerpool", wp.config.Name, "goroutine", role)))
+}
+
+// labelWorker tags a worker goroutine with labelGoroutine
+func (wp *WorkerPool[T, R]) labelWorker(ctx context.Context, id int) {
+	wp.labelGoroutine(ctx, fmt.Sprintf("worker-%d", id))
+}
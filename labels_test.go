@@ -0,0 +1,41 @@
+package workerpool
+
+import (
+	"bytes"
+	"context"
+	"runtime/pprof"
+)
+
+func (ts *WorkerPoolTestSuite) TestGoroutineLabels() {
+	config := DefaultConfig()
+	config.Name = "billing"
+	config.NumWorkers = 2
+	pool := NewWithConfig[int, int](config)
+
+	started := make(chan struct{}, 2)
+	release := make(chan struct{})
+	pool.WithProcessor(func(ctx context.Context, job Job[int]) (int, error) {
+		started <- struct{}{}
+		<-release
+		return job.Data, nil
+	})
+	pool.AddJobs([]Job[int]{{ID: "1"}, {ID: "2"}})
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_, err := pool.Run()
+		ts.NoError(err)
+	}()
+	<-started
+	<-started
+
+	var dump bytes.Buffer
+	ts.Require().NoError(pprof.Lookup("goroutine").WriteTo(&dump, 1))
+	close(release)
+	<-done
+
+	ts.Contains(dump.String(), `"goroutine":"worker-0", "workerpool":"billing"`)
+	ts.Contains(dump.String(), `"goroutine":"worker-1", "workerpool":"billing"`)
+	ts.Contains(dump.String(), `"goroutine":"collector", "workerpool":"billing"`)
+}
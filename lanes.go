@@ -0,0 +1,176 @@
+package workerpool
+
+import (
+	"sort"
+	"sync"
+)
+
+// PriorityLane is a class of priorities served with a share of dispatch slots
+type PriorityLane struct {
+	MinPriority int // Jobs with Priority >= MinPriority belong to this lane
+	Weight      int // Relative share of dispatches, e.g. 70/20/10
+}
+
+// LaneQueue keeps a separate priority queue per lane and drains them by weight
+// using smooth weighted round-robin, so every lane gets a predictable share of
+// dispatches instead of lower lanes starving behind higher ones.
+type LaneQueue[T any] struct {
+	lanes   []PriorityLane
+	queues  []*PriorityQueue[T]
+	current []int // Smooth weighted round-robin state per lane
+	mu      sync.Mutex
+}
+
+// NewLaneQueue creates a lane queue. Lanes are ordered by MinPriority, highest
+// first; jobs below every MinPriority fall into the lowest lane.
+func NewLaneQueue[T any](lanes []PriorityLane) *LaneQueue[T] {
+	sorted := make([]PriorityLane, len(lanes))
+	copy(sorted, lanes)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].MinPriority > sorted[j].MinPriority
+	})
+	for i := range sorted {
+		if sorted[i].Weight <= 0 {
+			sorted[i].Weight = 1
+		}
+	}
+	if len(sorted) == 0 {
+		sorted = []PriorityLane{{Weight: 1}}
+	}
+
+	queues := make([]*PriorityQueue[T], len(sorted))
+	for i := range queues {
+		queues[i] = NewPriorityQueue[T]()
+	}
+	return &LaneQueue[T]{
+		lanes:   sorted,
+		queues:  queues,
+		current: make([]int, len(sorted)),
+	}
+}
+
+// laneFor returns the index of the lane a priority belongs to
+func (lq *LaneQueue[T]) laneFor(priority int) int {
+	for i, lane := range lq.lanes {
+		if priority >= lane.MinPriority {
+			return i
+		}
+	}
+	return len(lq.lanes) - 1
+}
+
+// Push adds a job to its lane
+func (lq *LaneQueue[T]) Push(job Job[T]) {
+	lq.mu.Lock()
+	defer lq.mu.Unlock()
+	lq.queues[lq.laneFor(job.Priority)].Push(job)
+}
+
+// Pop removes the next job, choosing the lane by weight among non-empty lanes
+func (lq *LaneQueue[T]) Pop() (Job[T], bool) {
+	lq.mu.Lock()
+	defer lq.mu.Unlock()
+
+	best, total := lq.nextLocked()
+	if best < 0 {
+		return Job[T]{}, false
+	}
+	for i, q := range lq.queues {
+		if !q.IsEmpty() {
+			lq.current[i] += lq.lanes[i].Weight
+		}
+	}
+	lq.current[best] -= total
+	return lq.queues[best].Pop()
+}
+
+// Peek returns the job Pop would return without removing it
+func (lq *LaneQueue[T]) Peek() (Job[T], bool) {
+	lq.mu.Lock()
+	defer lq.mu.Unlock()
+
+	if best, _ := lq.nextLocked(); best >= 0 {
+		return lq.queues[best].Peek()
+	}
+	return Job[T]{}, false
+}
+
+// nextLocked returns the lane the next job comes from, or -1 when every lane
+// is empty, and the summed weight of the non-empty lanes. Callers must hold
+// lq.mu.
+func (lq *LaneQueue[T]) nextLocked() (best, total int) {
+	best = -1
+	for i, q := range lq.queues {
+		if q.IsEmpty() {
+			continue
+		}
+		total += lq.lanes[i].Weight
+		if best < 0 || lq.current[i]+lq.lanes[i].Weight > lq.current[best]+lq.lanes[best].Weight {
+			best = i
+		}
+	}
+	return best, total
+}
+
+// Size returns the number of jobs across all lanes
+func (lq *LaneQueue[T]) Size() int {
+	lq.mu.Lock()
+	defer lq.mu.Unlock()
+
+	size := 0
+	for _, q := range lq.queues {
+		size += q.Size()
+	}
+	return size
+}
+
+// Len returns the number of jobs across all lanes, as Size does
+func (lq *LaneQueue[T]) Len() int {
+	return lq.Size()
+}
+
+// IsEmpty checks if every lane is empty
+func (lq *LaneQueue[T]) IsEmpty() bool {
+	return lq.Size() == 0
+}
+
+// LaneSizes returns the number of queued jobs per lane, highest lane first
+func (lq *LaneQueue[T]) LaneSizes() []int {
+	lq.mu.Lock()
+	defer lq.mu.Unlock()
+
+	sizes := make([]int, len(lq.queues))
+	for i, q := range lq.queues {
+		sizes[i] = q.Size()
+	}
+	return sizes
+}
+
+// Update applies fn to matching jobs, moving them to a new lane if their
+// priority changed lanes. It returns the number of jobs updated.
+func (lq *LaneQueue[T]) Update(match func(Job[T]) bool, fn func(*Job[T])) int {
+	lq.mu.Lock()
+	defer lq.mu.Unlock()
+
+	var moved []Job[T]
+	for _, q := range lq.queues {
+		moved = append(moved, q.Remove(match)...)
+	}
+	for i := range moved {
+		fn(&moved[i])
+		lq.queues[lq.laneFor(moved[i].Priority)].Push(moved[i])
+	}
+	return len(moved)
+}
+
+// Remove deletes every queued job matching the predicate and returns them
+func (lq *LaneQueue[T]) Remove(match func(Job[T]) bool) []Job[T] {
+	lq.mu.Lock()
+	defer lq.mu.Unlock()
+
+	var removed []Job[T]
+	for _, q := range lq.queues {
+		removed = append(removed, q.Remove(match)...)
+	}
+	return removed
+}
@@ -0,0 +1,85 @@
+package workerpool
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+func (ts *WorkerPoolTestSuite) TestLaneQueueWeightedShares() {
+	lq := NewLaneQueue[string]([]PriorityLane{
+		{MinPriority: 0, Weight: 10},
+		{MinPriority: 10, Weight: 70},
+		{MinPriority: 5, Weight: 20},
+	})
+	for i := 0; i < 100; i++ {
+		lq.Push(Job[string]{ID: fmt.Sprintf("high-%d", i), Priority: 10})
+		lq.Push(Job[string]{ID: fmt.Sprintf("mid-%d", i), Priority: 5})
+		lq.Push(Job[string]{ID: fmt.Sprintf("low-%d", i), Priority: 1})
+	}
+	ts.Equal([]int{100, 100, 100}, lq.LaneSizes())
+
+	counts := map[int]int{}
+	for i := 0; i < 100; i++ {
+		job, ok := lq.Pop()
+		ts.True(ok)
+		counts[job.Priority]++
+	}
+	ts.Equal(70, counts[10])
+	ts.Equal(20, counts[5])
+	ts.Equal(10, counts[1])
+}
+
+func (ts *WorkerPoolTestSuite) TestLaneQueueUpdateMovesLanes() {
+	lq := NewLaneQueue[string]([]PriorityLane{
+		{MinPriority: 10, Weight: 1},
+		{MinPriority: 0, Weight: 1},
+	})
+	lq.Push(Job[string]{ID: "a", Priority: 1})
+
+	ts.Equal(1, lq.Update(func(job Job[string]) bool { return job.ID == "a" },
+		func(job *Job[string]) { job.Priority = 50 }))
+	ts.Equal([]int{1, 0}, lq.LaneSizes())
+	ts.Len(lq.Remove(func(job Job[string]) bool { return true }), 1)
+	ts.True(lq.IsEmpty())
+}
+
+func (ts *WorkerPoolTestSuite) TestPriorityBasedWithLanes() {
+	config := DefaultConfig()
+	config.NumWorkers = 1
+	config.Strategy = PriorityBased
+	config.PriorityLanes = []PriorityLane{
+		{MinPriority: 5, Weight: 3},
+		{MinPriority: 0, Weight: 1},
+	}
+	pool := NewWithConfig[string, string](config)
+
+	var mu sync.Mutex
+	var order []int
+	pool.WithProcessor(func(ctx context.Context, job Job[string]) (string, error) {
+		mu.Lock()
+		order = append(order, job.Priority)
+		mu.Unlock()
+		return job.Data, nil
+	})
+
+	var jobs []Job[string]
+	for i := 0; i < 8; i++ {
+		jobs = append(jobs, Job[string]{ID: fmt.Sprintf("h%d", i), Priority: 9})
+		jobs = append(jobs, Job[string]{ID: fmt.Sprintf("l%d", i), Priority: 1})
+	}
+	pool.AddJobs(jobs)
+
+	results, err := pool.Run()
+	ts.NoError(err)
+	ts.Len(results, 16)
+
+	// Strict priority would run all high jobs first; lanes interleave 3:1
+	low := 0
+	for _, p := range order[:8] {
+		if p == 1 {
+			low++
+		}
+	}
+	ts.Equal(2, low)
+}
@@ -0,0 +1,260 @@
+package workerpool
+
+import (
+	"context"
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// KeyValue is an intermediate record emitted by a MapReduce map function
+type KeyValue[K comparable, V any] struct {
+	Key   K
+	Value V
+}
+
+// MapReduceOptions configures MapReduce. Each phase runs on its own pool;
+// a phase config with NumWorkers zero uses DefaultConfig.
+type MapReduceOptions struct {
+	MapConfig    Config
+	ReduceConfig Config
+
+	// Partitions is how many key partitions intermediate records are split
+	// into (default 16). Partitions are reduced one at a time, so with
+	// spilling only one partition's values are held in memory while reducing.
+	Partitions int
+
+	// SpillThreshold is how many intermediate values may be held in memory
+	// before they are written to disk; zero never spills. Keys and values
+	// must then be encodable with encoding/gob.
+	SpillThreshold int
+
+	// SpillDir is where spill files are created; empty uses os.TempDir
+	SpillDir string
+}
+
+// defaultPartitions is the number of key partitions when Partitions is unset
+const defaultPartitions = 16
+
+// MapReduce runs mapFn over items on one pool, groups the emitted values by
+// key, and runs reduceFn for every key concurrently on a second pool. It
+// returns the reduced value of every key whose reduce succeeded; failed map
+// and reduce jobs are joined into the error.
+func MapReduce[In any, K comparable, V any, Out any](
+	items []In,
+	mapFn func(ctx context.Context, item In) ([]KeyValue[K, V], error),
+	reduceFn func(ctx context.Context, key K, values []V) (Out, error),
+	opts MapReduceOptions,
+) (map[K]Out, error) {
+	if len(items) == 0 {
+		return map[K]Out{}, nil
+	}
+
+	shuffle, err := newShuffle[K, V](opts)
+	if err != nil {
+		return nil, err
+	}
+	defer shuffle.close()
+
+	// Map phase: partition intermediate records as map jobs complete
+	mapPool := NewWithConfig[In, []KeyValue[K, V]](phaseConfig(opts.MapConfig))
+	mapPool.WithProcessor(func(ctx context.Context, job Job[In]) ([]KeyValue[K, V], error) {
+		return mapFn(ctx, job.Data)
+	})
+	jobs := make([]Job[In], len(items))
+	for i, item := range items {
+		jobs[i] = Job[In]{ID: fmt.Sprintf("map-%d", i), Data: item}
+	}
+	mapPool.AddJobs(jobs)
+
+	var errs []error
+	_, runErr := mapPool.run(context.Background(), func(result Result[[]KeyValue[K, V]]) {
+		if result.Error != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", result.JobID, result.Error))
+			return
+		}
+		if err := shuffle.add(result.Data); err != nil {
+			errs = append(errs, err)
+		}
+	})
+	if runErr != nil {
+		return nil, errors.Join(append(errs, runErr)...)
+	}
+
+	// Reduce phase: one partition at a time, every key of it concurrently
+	out := make(map[K]Out)
+	for p := 0; p < shuffle.partitions(); p++ {
+		groups, err := shuffle.load(p)
+		if err != nil {
+			return nil, errors.Join(append(errs, err)...)
+		}
+		if len(groups) == 0 {
+			continue
+		}
+
+		keyOf := make(map[string]K, len(groups))
+		reducePool := NewWithConfig[KeyValue[K, []V], Out](phaseConfig(opts.ReduceConfig))
+		reducePool.WithProcessor(func(ctx context.Context, job Job[KeyValue[K, []V]]) (Out, error) {
+			return reduceFn(ctx, job.Data.Key, job.Data.Value)
+		})
+		for key, values := range groups {
+			id := fmt.Sprintf("reduce-%d-%d", p, len(keyOf))
+			keyOf[id] = key
+			reducePool.AddJob(Job[KeyValue[K, []V]]{ID: id, Data: KeyValue[K, []V]{Key: key, Value: values}})
+		}
+
+		_, runErr := reducePool.run(context.Background(), func(result Result[Out]) {
+			key := keyOf[result.JobID]
+			if result.Error != nil {
+				errs = append(errs, fmt.Errorf("reduce %v: %w", key, result.Error))
+				return
+			}
+			out[key] = result.Data
+		})
+		if runErr != nil {
+			return out, errors.Join(append(errs, runErr)...)
+		}
+	}
+	return out, errors.Join(errs...)
+}
+
+// phaseConfig returns the config for a MapReduce phase pool
+func phaseConfig(config Config) Config {
+	if config.NumWorkers == 0 {
+		return DefaultConfig()
+	}
+	return config
+}
+
+// shuffle partitions intermediate records by key, spilling them to disk
+// when too many are held in memory
+type shuffle[K comparable, V any] struct {
+	memory    []map[K][]V
+	buffered  int
+	threshold int
+	dir       string
+	files     []*os.File
+	encoders  []*gob.Encoder
+}
+
+// newShuffle creates a shuffle for the given options
+func newShuffle[K comparable, V any](opts MapReduceOptions) (*shuffle[K, V], error) {
+	n := opts.Partitions
+	if n <= 0 {
+		n = defaultPartitions
+	}
+	s := &shuffle[K, V]{
+		memory:    make([]map[K][]V, n),
+		threshold: opts.SpillThreshold,
+		files:     make([]*os.File, n),
+		encoders:  make([]*gob.Encoder, n),
+	}
+	for i := range s.memory {
+		s.memory[i] = make(map[K][]V)
+	}
+	if s.threshold > 0 {
+		dir, err := os.MkdirTemp(opts.SpillDir, "workerpool-mapreduce-")
+		if err != nil {
+			return nil, fmt.Errorf("mapreduce spill dir: %w", err)
+		}
+		s.dir = dir
+	}
+	return s, nil
+}
+
+// partitions returns the number of partitions
+func (s *shuffle[K, V]) partitions() int {
+	return len(s.memory)
+}
+
+// partition returns the partition of key
+func (s *shuffle[K, V]) partition(key K) int {
+	h := fnv.New32a()
+	fmt.Fprint(h, key)
+	return int(h.Sum32() % uint32(len(s.memory)))
+}
+
+// add buffers records, spilling every partition once over the threshold
+func (s *shuffle[K, V]) add(records []KeyValue[K, V]) error {
+	for _, kv := range records {
+		p := s.partition(kv.Key)
+		s.memory[p][kv.Key] = append(s.memory[p][kv.Key], kv.Value)
+		s.buffered++
+	}
+	if s.threshold > 0 && s.buffered > s.threshold {
+		return s.spill()
+	}
+	return nil
+}
+
+// spill appends the in-memory records to each partition's spill file
+func (s *shuffle[K, V]) spill() error {
+	for p, groups := range s.memory {
+		if len(groups) == 0 {
+			continue
+		}
+		if s.files[p] == nil {
+			f, err := os.Create(filepath.Join(s.dir, fmt.Sprintf("partition-%d", p)))
+			if err != nil {
+				return fmt.Errorf("mapreduce spill: %w", err)
+			}
+			s.files[p] = f
+			s.encoders[p] = gob.NewEncoder(f)
+		}
+		for key, values := range groups {
+			for _, v := range values {
+				if err := s.encoders[p].Encode(KeyValue[K, V]{Key: key, Value: v}); err != nil {
+					return fmt.Errorf("mapreduce spill: %w", err)
+				}
+			}
+		}
+		s.memory[p] = make(map[K][]V)
+	}
+	s.buffered = 0
+	return nil
+}
+
+// load returns the groups of partition p, merging spilled and in-memory records
+func (s *shuffle[K, V]) load(p int) (map[K][]V, error) {
+	groups := s.memory[p]
+	s.memory[p] = nil
+	f := s.files[p]
+	if f == nil {
+		return groups, nil
+	}
+
+	merged := make(map[K][]V)
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return nil, fmt.Errorf("mapreduce spill: %w", err)
+	}
+	dec := gob.NewDecoder(f)
+	for {
+		var kv KeyValue[K, V]
+		if err := dec.Decode(&kv); err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, fmt.Errorf("mapreduce spill: %w", err)
+		}
+		merged[kv.Key] = append(merged[kv.Key], kv.Value)
+	}
+	for key, values := range groups {
+		merged[key] = append(merged[key], values...)
+	}
+	return merged, nil
+}
+
+// close removes the spill files
+func (s *shuffle[K, V]) close() {
+	for _, f := range s.files {
+		if f != nil {
+			f.Close()
+		}
+	}
+	if s.dir != "" {
+		os.RemoveAll(s.dir)
+	}
+}
@@ -0,0 +1,63 @@
+package workerpool
+
+import (
+	"context"
+	"errors"
+	"os"
+	"strings"
+)
+
+func wordCount(opts MapReduceOptions, lines []string) (map[string]int, error) {
+	return MapReduce(lines,
+		func(ctx context.Context, line string) ([]KeyValue[string, int], error) {
+			if line == "" {
+				return nil, errors.New("empty line")
+			}
+			var kvs []KeyValue[string, int]
+			for _, word := range strings.Fields(line) {
+				kvs = append(kvs, KeyValue[string, int]{Key: word, Value: 1})
+			}
+			return kvs, nil
+		},
+		func(ctx context.Context, word string, counts []int) (int, error) {
+			total := 0
+			for _, c := range counts {
+				total += c
+			}
+			return total, nil
+		},
+		opts,
+	)
+}
+
+func (ts *WorkerPoolTestSuite) TestMapReduceWordCount() {
+	lines := []string{"the quick brown fox", "the lazy dog", "the fox"}
+	counts, err := wordCount(MapReduceOptions{}, lines)
+	ts.NoError(err)
+	ts.Equal(map[string]int{"the": 3, "quick": 1, "brown": 1, "fox": 2, "lazy": 1, "dog": 1}, counts)
+}
+
+func (ts *WorkerPoolTestSuite) TestMapReduceSpillsToDisk() {
+	var lines []string
+	for i := 0; i < 200; i++ {
+		lines = append(lines, "alpha beta gamma alpha")
+	}
+	dir := ts.T().TempDir()
+	counts, err := wordCount(MapReduceOptions{Partitions: 4, SpillThreshold: 50, SpillDir: dir}, lines)
+	ts.NoError(err)
+	ts.Equal(map[string]int{"alpha": 400, "beta": 200, "gamma": 200}, counts)
+
+	// Spill files are removed once the reduce phase finishes
+	entries, err := os.ReadDir(dir)
+	ts.NoError(err)
+	ts.Empty(entries)
+}
+
+func (ts *WorkerPoolTestSuite) TestMapReduceReportsFailedJobs() {
+	config := DefaultConfig()
+	config.MaxRetries = 0
+	counts, err := wordCount(MapReduceOptions{MapConfig: config}, []string{"a b", "", "b"})
+	ts.Error(err)
+	ts.Contains(err.Error(), "empty line")
+	ts.Equal(map[string]int{"a": 1, "b": 2}, counts)
+}
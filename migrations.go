@@ -0,0 +1,80 @@
+package workerpool
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrPayloadVersion is returned for payloads that cannot be brought to the
+// current version: newer than this binary knows, or missing a migration step
+var ErrPayloadVersion = errors.New("unsupported payload version")
+
+// PayloadMigrations upgrades payloads written by older binaries, one version
+// at a time, so jobs persisted or queued before a rolling upgrade can still
+// be processed after it. Version 0 is the version of unversioned payloads.
+type PayloadMigrations[T any] struct {
+	current int
+	steps   map[int]func(T) (T, error)
+}
+
+// NewPayloadMigrations creates a registry for payloads whose current version
+// is current. Register a step for every older version that may still be in
+// flight.
+func NewPayloadMigrations[T any](current int) *PayloadMigrations[T] {
+	return &PayloadMigrations[T]{current: current, steps: make(map[int]func(T) (T, error))}
+}
+
+// Register sets the step upgrading a payload from version from to from+1.
+// It is meant to be called during initialization.
+func (m *PayloadMigrations[T]) Register(from int, step func(T) (T, error)) *PayloadMigrations[T] {
+	m.steps[from] = step
+	return m
+}
+
+// Current returns the version payloads are migrated to
+func (m *PayloadMigrations[T]) Current() int {
+	return m.current
+}
+
+// Migrate upgrades a payload written at version to the current version
+func (m *PayloadMigrations[T]) Migrate(version int, payload T) (T, error) {
+	if version > m.current {
+		return payload, fmt.Errorf("%w: version %d is newer than %d", ErrPayloadVersion, version, m.current)
+	}
+	for v := version; v < m.current; v++ {
+		step, ok := m.steps[v]
+		if !ok {
+			return payload, fmt.Errorf("%w: no migration from version %d", ErrPayloadVersion, v)
+		}
+		var err error
+		if payload, err = step(payload); err != nil {
+			return payload, fmt.Errorf("migrate payload from version %d: %w", v, err)
+		}
+	}
+	return payload, nil
+}
+
+// WithMigrations upgrades the payload of every job added to the pool from
+// its Job.Version to the current version, before the validator runs. Jobs
+// that cannot be migrated are refused: Submit returns the error and AddJobs
+// drops them.
+func (wp *WorkerPool[T, R]) WithMigrations(m *PayloadMigrations[T]) *WorkerPool[T, R] {
+	wp.mu.Lock()
+	defer wp.mu.Unlock()
+	wp.migrations = m
+	return wp
+}
+
+// RegisterPayloadMigrations sets the migrations of a registered payload
+// type. UnmarshalJob applies them to the encoded payload before decoding it,
+// according to the envelope's payload_version.
+func (r *EnvelopeRegistry) RegisterPayloadMigrations(name string, m *PayloadMigrations[[]byte]) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	pt, ok := r.byName[name]
+	if !ok {
+		return fmt.Errorf("%w: %q", ErrUnknownPayloadType, name)
+	}
+	pt.migrations = m
+	return nil
+}
@@ -0,0 +1,88 @@
+package workerpool
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+)
+
+func (ts *WorkerPoolTestSuite) TestPayloadMigrations() {
+	// v0 was a bare name, v1 an object, v2 renamed the field
+	m := NewPayloadMigrations[json.RawMessage](2).
+		Register(0, func(p json.RawMessage) (json.RawMessage, error) {
+			var name string
+			if err := json.Unmarshal(p, &name); err != nil {
+				return nil, err
+			}
+			return json.Marshal(map[string]string{"name": name})
+		}).
+		Register(1, func(p json.RawMessage) (json.RawMessage, error) {
+			return json.RawMessage(strings.Replace(string(p), `"name"`, `"user"`, 1)), nil
+		})
+
+	pool := New[json.RawMessage, string]()
+	pool.WithMigrations(m)
+	pool.WithValidator(func(p json.RawMessage) error {
+		var v map[string]string
+		return json.Unmarshal(p, &v)
+	})
+	ts.NoError(pool.Submit(Job[json.RawMessage]{ID: "old", Data: json.RawMessage(`"ada"`)}))
+	ts.NoError(pool.Submit(Job[json.RawMessage]{ID: "v1", Version: 1, Data: json.RawMessage(`{"name":"bob"}`)}))
+	ts.NoError(pool.Submit(Job[json.RawMessage]{ID: "new", Version: 2, Data: json.RawMessage(`{"user":"cy"}`)}))
+	ts.ErrorIs(pool.Submit(Job[json.RawMessage]{ID: "future", Version: 3}), ErrPayloadVersion)
+	ts.ErrorContains(pool.Submit(Job[json.RawMessage]{ID: "bad", Data: json.RawMessage(`{}`)}), "migrate payload from version 0")
+
+	pool.WithProcessor(func(ctx context.Context, job Job[json.RawMessage]) (string, error) {
+		ts.Equal(2, job.Version)
+		var v struct{ User string }
+		err := json.Unmarshal(job.Data, &v)
+		return v.User, err
+	})
+	results, err := pool.Run()
+	ts.NoError(err)
+	users := map[string]string{}
+	for _, r := range results {
+		ts.NoError(r.Error)
+		users[r.JobID] = r.Data
+	}
+	ts.Equal(map[string]string{"old": "ada", "v1": "bob", "new": "cy"}, users)
+
+	gap := NewPayloadMigrations[int](2).Register(1, func(v int) (int, error) { return v + 1, nil })
+	_, err = gap.Migrate(0, 0)
+	ts.ErrorIs(err, ErrPayloadVersion)
+}
+
+func (ts *WorkerPoolTestSuite) TestEnvelopePayloadMigrations() {
+	type order struct {
+		SKU string `json:"sku"`
+		Qty int    `json:"qty"`
+	}
+	r := NewEnvelopeRegistry()
+	ts.NoError(RegisterPayload[order](r, "order"))
+	ts.ErrorIs(r.RegisterPayloadMigrations("missing", NewPayloadMigrations[[]byte](1)), ErrUnknownPayloadType)
+
+	// An older binary wrote orders without a quantity, at version 0
+	old, err := r.MarshalJob(Job[any]{ID: "o", Data: order{SKU: "x"}})
+	ts.NoError(err)
+
+	ts.NoError(r.RegisterPayloadMigrations("order", NewPayloadMigrations[[]byte](1).
+		Register(0, func(p []byte) ([]byte, error) {
+			var o order
+			if err := json.Unmarshal(p, &o); err != nil {
+				return nil, err
+			}
+			o.Qty = 1
+			return json.Marshal(o)
+		})))
+	job, err := r.UnmarshalJob(old)
+	ts.NoError(err)
+	ts.Equal(order{SKU: "x", Qty: 1}, job.Data)
+	ts.Equal(1, job.Version)
+
+	// Current jobs round-trip without migrating
+	current, err := r.MarshalJob(job)
+	ts.NoError(err)
+	job, err = r.UnmarshalJob(current)
+	ts.NoError(err)
+	ts.Equal(order{SKU: "x", Qty: 1}, job.Data)
+}
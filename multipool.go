@@ -0,0 +1,247 @@
+package workerpool
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ErrNoRoute is returned when a MultiPool's selector names no member pool
+var ErrNoRoute = errors.New("no pool for job")
+
+// MultiPool fronts several pools, e.g. one per datacenter or resource class,
+// behind a single Submit/Run API. Jobs are routed by a selector function;
+// results and metrics of the member pools are merged.
+type MultiPool[T any, R any] struct {
+	selector func(Job[T]) string
+	pools    map[string]*WorkerPool[T, R]
+	names    []string // Member names in the order they were added
+	mu       sync.RWMutex
+}
+
+// NewMultiPool creates an empty MultiPool that routes each job to the member
+// named by selector
+func NewMultiPool[T any, R any](selector func(Job[T]) string) *MultiPool[T, R] {
+	return &MultiPool[T, R]{
+		selector: selector,
+		pools:    make(map[string]*WorkerPool[T, R]),
+	}
+}
+
+// Add registers pool under name, replacing any member with the same name
+func (m *MultiPool[T, R]) Add(name string, pool *WorkerPool[T, R]) *MultiPool[T, R] {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.pools[name]; !ok {
+		m.names = append(m.names, name)
+	}
+	m.pools[name] = pool
+	return m
+}
+
+// Pool returns the member registered under name, or nil
+func (m *MultiPool[T, R]) Pool(name string) *WorkerPool[T, R] {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.pools[name]
+}
+
+// Submit routes a job to the member chosen by the selector
+func (m *MultiPool[T, R]) Submit(job Job[T]) error {
+	name := m.selector(job)
+	pool := m.Pool(name)
+	if pool == nil {
+		return fmt.Errorf("job %s: %w %q", job.ID, ErrNoRoute, name)
+	}
+	return pool.Submit(job)
+}
+
+// AddJobs submits every job, returning the errors of the jobs that were not
+// accepted joined together
+func (m *MultiPool[T, R]) AddJobs(jobs []Job[T]) error {
+	var errs []error
+	for _, job := range jobs {
+		if err := m.Submit(job); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// members returns the member names and pools in the order they were added
+func (m *MultiPool[T, R]) members() ([]string, []*WorkerPool[T, R]) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	pools := make([]*WorkerPool[T, R], len(m.names))
+	for i, name := range m.names {
+		pools[i] = m.pools[name]
+	}
+	return append([]string(nil), m.names...), pools
+}
+
+// Run runs every member that has jobs concurrently and returns their merged
+// results, in member order. Errors of failed members are joined, each
+// prefixed with the member's name.
+func (m *MultiPool[T, R]) Run() ([]Result[R], error) {
+	names, pools := m.members()
+
+	type outcome struct {
+		results []Result[R]
+		err     error
+	}
+	outcomes := make([]outcome, len(pools))
+
+	var wg sync.WaitGroup
+	for i, pool := range pools {
+		pool.mu.RLock()
+		idle := len(pool.jobs) == 0
+		pool.mu.RUnlock()
+		if idle {
+			continue
+		}
+
+		wg.Add(1)
+		go func(i int, pool *WorkerPool[T, R]) {
+			defer wg.Done()
+			results, err := pool.Run()
+			outcomes[i] = outcome{results, err}
+		}(i, pool)
+	}
+	wg.Wait()
+
+	var results []Result[R]
+	var errs []error
+	ran := false
+	for i, o := range outcomes {
+		results = append(results, o.results...)
+		if o.err != nil {
+			errs = append(errs, fmt.Errorf("pool %s: %w", names[i], o.err))
+		}
+		ran = ran || o.results != nil || o.err != nil
+	}
+	if !ran {
+		return nil, fmt.Errorf("no jobs to process")
+	}
+	return results, errors.Join(errs...)
+}
+
+// Stop stops every member pool
+func (m *MultiPool[T, R]) Stop() {
+	_, pools := m.members()
+	for _, pool := range pools {
+		pool.Stop()
+	}
+}
+
+// GetMetrics returns the members' metrics merged: counters and tenant
+// metrics are summed and the time span covers every member's run
+func (m *MultiPool[T, R]) GetMetrics() Metrics {
+	_, pools := m.members()
+	metrics := make([]Metrics, len(pools))
+	for i, pool := range pools {
+		metrics[i] = pool.GetMetrics()
+	}
+	return mergeMetrics(metrics)
+}
+
+// mergeMetrics sums the metrics of several pools, with the time span
+// covering every pool's run
+func mergeMetrics(metrics []Metrics) Metrics {
+	var total, processed, failed, expired, late, skipped, reported, suppressed, starved int
+	var start, end time.Time
+	tenants := make(map[string]TenantMetrics)
+	var causes map[string]int
+	var counters map[string]float64
+	var cpu time.Duration
+	var dropped, duplicates int
+	var classCPU map[string]time.Duration
+
+	for i := range metrics {
+		pm := &metrics[i]
+		total += pm.TotalJobs
+		processed += pm.ProcessedJobs
+		failed += pm.FailedJobs
+		expired += pm.ExpiredJobs
+		late += pm.LateCompletions
+		skipped += pm.SkippedJobs
+		reported += pm.ErrorsReported
+		suppressed += pm.ErrorsSuppressed
+		starved += pm.StarvedJobs
+		for cause, n := range pm.FailureCauses {
+			if causes == nil {
+				causes = make(map[string]int)
+			}
+			causes[cause] += n
+		}
+		for key, v := range pm.Counters {
+			if counters == nil {
+				counters = make(map[string]float64)
+			}
+			counters[key] += v
+		}
+		cpu += pm.CPUTime
+		dropped += pm.DroppedResults
+		duplicates += pm.DuplicateResults
+		for class, d := range pm.ClassCPUTime {
+			if classCPU == nil {
+				classCPU = make(map[string]time.Duration)
+			}
+			classCPU[class] += d
+		}
+
+		if !pm.StartTime.IsZero() && (start.IsZero() || pm.StartTime.Before(start)) {
+			start = pm.StartTime
+		}
+		if pm.EndTime.After(end) {
+			end = pm.EndTime
+		}
+
+		for tenant, tm := range pm.Tenants {
+			sum := tenants[tenant]
+			sum.Queued += tm.Queued
+			sum.InFlight += tm.InFlight
+			sum.Processed += tm.Processed
+			sum.Failed += tm.Failed
+			sum.Rejected += tm.Rejected
+			sum.CPUTime += tm.CPUTime
+			tenants[tenant] = sum
+		}
+	}
+
+	var duration, average time.Duration
+	if !start.IsZero() && end.After(start) {
+		duration = end.Sub(start)
+		if processed > 0 {
+			average = duration / time.Duration(processed)
+		}
+	}
+
+	return Metrics{
+		TotalJobs:       total,
+		ProcessedJobs:   processed,
+		FailedJobs:      failed,
+		ExpiredJobs:     expired,
+		LateCompletions: late,
+		SkippedJobs:     skipped,
+		TotalDuration:   duration,
+		AverageDuration: average,
+		StartTime:       start,
+		EndTime:         end,
+		Tenants:         tenants,
+
+		ErrorsReported:   reported,
+		ErrorsSuppressed: suppressed,
+		FailureCauses:    causes,
+		Counters:         counters,
+		StarvedJobs:      starved,
+
+		CPUTime:      cpu,
+		ClassCPUTime: classCPU,
+
+		DroppedResults:   dropped,
+		DuplicateResults: duplicates,
+	}
+}
@@ -0,0 +1,71 @@
+package workerpool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+func (ts *WorkerPoolTestSuite) TestMultiPoolRoutesAndMerges() {
+	processor := func(region string) Processor[string, string] {
+		return func(ctx context.Context, job Job[string]) (string, error) {
+			return region + ":" + job.Data, nil
+		}
+	}
+
+	east := New[string, string]().WithProcessor(processor("east"))
+	west := New[string, string]().WithProcessor(processor("west"))
+	multi := NewMultiPool[string, string](func(job Job[string]) string {
+		return strings.SplitN(job.ID, "-", 2)[0]
+	}).Add("east", east).Add("west", west)
+
+	var jobs []Job[string]
+	for i := 0; i < 3; i++ {
+		jobs = append(jobs,
+			Job[string]{ID: fmt.Sprintf("east-%d", i), Data: "x", TenantID: "acme"},
+			Job[string]{ID: fmt.Sprintf("west-%d", i), Data: "x", TenantID: "acme"},
+		)
+	}
+	ts.NoError(multi.AddJobs(jobs))
+
+	err := multi.Submit(Job[string]{ID: "north-1", Data: "x"})
+	ts.ErrorIs(err, ErrNoRoute)
+
+	results, err := multi.Run()
+	ts.NoError(err)
+	ts.Len(results, 6)
+	for _, r := range results {
+		ts.True(strings.HasPrefix(r.Data, strings.SplitN(r.JobID, "-", 2)[0]+":"))
+	}
+
+	metrics := multi.GetMetrics()
+	ts.Equal(6, metrics.TotalJobs)
+	ts.Equal(6, metrics.ProcessedJobs)
+	ts.Equal(6, metrics.Tenants["acme"].Processed)
+	ts.False(metrics.StartTime.After(metrics.EndTime))
+	ts.Equal(3, east.GetMetrics().ProcessedJobs)
+}
+
+func (ts *WorkerPoolTestSuite) TestMultiPoolJoinsMemberErrors() {
+	ok := New[string, string]().WithProcessor(func(ctx context.Context, job Job[string]) (string, error) {
+		return job.Data, nil
+	})
+	broken := New[string, string]() // No processor configured
+	idle := New[string, string]().WithProcessor(func(ctx context.Context, job Job[string]) (string, error) {
+		return job.Data, nil
+	})
+
+	multi := NewMultiPool[string, string](func(job Job[string]) string {
+		return job.Data
+	}).Add("ok", ok).Add("broken", broken).Add("idle", idle)
+	ts.NoError(multi.AddJobs([]Job[string]{{ID: "1", Data: "ok"}, {ID: "2", Data: "broken"}}))
+
+	results, err := multi.Run()
+	ts.Len(results, 1)
+	ts.ErrorContains(err, "pool broken: no processor configured")
+
+	_, err = NewMultiPool[string, string](func(Job[string]) string { return "" }).Run()
+	ts.Error(err)
+	ts.False(errors.Is(err, ErrNoRoute))
+}
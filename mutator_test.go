@@ -0,0 +1,64 @@
+package workerpool
+
+import (
+	"fmt"
+	"strings"
+)
+
+func (ts *WorkerPoolTestSuite) TestJobMutator() {
+	config := DefaultConfig()
+	config.TenantQuotas = map[string]TenantQuota{"acme": {MaxQueued: 1}}
+	pool := NewWithConfig[string, string](config)
+
+	next := 0
+	pool.WithJobMutator(func(job Job[string]) Job[string] {
+		if job.ID == "" {
+			next++
+			job.ID = fmt.Sprintf("auto-%d", next)
+		}
+		if strings.HasPrefix(job.Data, "urgent") {
+			job.Priority = 10
+		}
+		job.TenantID = "acme"
+		return job
+	})
+
+	pool.AddJobs([]Job[string]{{Data: "urgent: restart"}})
+	ts.Len(pool.jobs, 1)
+	ts.Equal("auto-1", pool.jobs[0].ID)
+	ts.Equal(10, pool.jobs[0].Priority)
+	ts.False(pool.jobs[0].Created.IsZero())
+
+	// The mutator runs before tenant admission, so the stamped tenant is enforced
+	err := pool.Submit(Job[string]{Data: "routine"})
+	ts.ErrorIs(err, ErrTenantQueueFull)
+	ts.Contains(err.Error(), "auto-2")
+}
+
+func (ts *WorkerPoolTestSuite) TestPriorityFunc() {
+	type order struct {
+		Value int
+		Tier  string
+	}
+	config := DefaultConfig()
+	config.JobClasses = map[string]JobClass{"bulk": {Priority: 1}}
+	pool := NewWithConfig[order, int](config)
+	pool.WithPriorityFunc(func(o order) int {
+		if o.Tier == "gold" {
+			return 100
+		}
+		return o.Value / 1000
+	})
+
+	pool.AddJobs([]Job[order]{
+		{ID: "gold", Data: order{Value: 10, Tier: "gold"}},
+		{ID: "large", Data: order{Value: 5000}},
+		{ID: "set", Data: order{Value: 5000}, Priority: 7},
+		{ID: "small", Data: order{Value: 10}, Class: "bulk"},
+	})
+	priorities := make(map[string]int)
+	for _, job := range pool.jobs {
+		priorities[job.ID] = job.Priority
+	}
+	ts.Equal(map[string]int{"gold": 100, "large": 5, "set": 7, "small": 1}, priorities)
+}
@@ -0,0 +1,72 @@
+package workerpool
+
+import "fmt"
+
+// PartialRunError is returned by Run when Config.PartialResults is set and the
+// run ended early. The results completed so far are returned alongside it.
+type PartialRunError[T any] struct {
+	Err        error    // Why the run ended, e.g. a timeout or Stop
+	Unfinished []Job[T] // Jobs that never started processing, by priority then creation
+}
+
+// Error implements the error interface
+func (e *PartialRunError[T]) Error() string {
+	return fmt.Sprintf("run ended early with %d unfinished jobs: %v", len(e.Unfinished), e.Err)
+}
+
+// Unwrap returns the underlying cause
+func (e *PartialRunError[T]) Unwrap() error {
+	return e.Err
+}
+
+// UndeliveredError is returned by Run when the run ended early while jobs
+// were still queued, e.g. in the PriorityBased dispatcher, and
+// Config.PartialResults is not set. WithUndeliveredHandler receives the jobs.
+type UndeliveredError struct {
+	Err         error // Why the run ended, e.g. a timeout or Stop
+	Undelivered int   // Jobs that never started processing
+}
+
+// Error implements the error interface
+func (e *UndeliveredError) Error() string {
+	return fmt.Sprintf("%v (%d jobs undelivered)", e.Err, e.Undelivered)
+}
+
+// Unwrap returns the underlying cause
+func (e *UndeliveredError) Unwrap() error {
+	return e.Err
+}
+
+// WithUndeliveredHandler sets a function receiving the jobs a run left
+// undelivered when it ended early, together with the cause, so they can be
+// persisted and submitted again later. It is called before Run returns.
+func (wp *WorkerPool[T, R]) WithUndeliveredHandler(handler func(jobs []Job[T], cause error)) *WorkerPool[T, R] {
+	wp.mu.Lock()
+	defer wp.mu.Unlock()
+	wp.onUndelivered = handler
+	return wp
+}
+
+// earlyEndError builds the error of a run that ended early because of err,
+// handing the jobs still pending to the undelivered handler
+func (wp *WorkerPool[T, R]) earlyEndError(err error) error {
+	wp.mu.RLock()
+	var unfinished []Job[T]
+	if wp.pending != nil {
+		unfinished = wp.pending.list()
+	}
+	handler := wp.onUndelivered
+	wp.mu.RUnlock()
+
+	if handler != nil && len(unfinished) > 0 {
+		handler(unfinished, err)
+	}
+	switch {
+	case wp.config.PartialResults:
+		return &PartialRunError[T]{Err: err, Unfinished: unfinished}
+	case len(unfinished) > 0:
+		return &UndeliveredError{Err: err, Undelivered: len(unfinished)}
+	default:
+		return err
+	}
+}
@@ -0,0 +1,128 @@
+package workerpool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+func (ts *WorkerPoolTestSuite) TestPartialResultsOnTimeout() {
+	config := DefaultConfig()
+	config.NumWorkers = 1
+	config.Timeout = 100 * time.Millisecond
+	config.PartialResults = true
+	pool := NewWithConfig[string, string](config)
+
+	pool.WithProcessor(func(ctx context.Context, job Job[string]) (string, error) {
+		if job.ID == "slow" {
+			<-ctx.Done()
+			return "", ctx.Err()
+		}
+		return job.Data, nil
+	})
+	pool.AddJobs([]Job[string]{
+		{ID: "fast-1", Data: "a"},
+		{ID: "fast-2", Data: "b"},
+		{ID: "slow", Data: "c"},
+		{ID: "never-1", Data: "d"},
+		{ID: "never-2", Data: "e"},
+	})
+
+	results, err := pool.Run()
+	ts.ErrorIs(err, ErrPoolTimeout)
+
+	var partial *PartialRunError[string]
+	ts.True(errors.As(err, &partial))
+	ts.Len(partial.Unfinished, 2)
+
+	ts.Len(results, 3)
+	completed := 0
+	for _, result := range results {
+		if result.Error == nil {
+			completed++
+		}
+	}
+	ts.Equal(2, completed)
+}
+
+func (ts *WorkerPoolTestSuite) TestTimeoutWithoutPartialResults() {
+	config := DefaultConfig()
+	config.Timeout = 20 * time.Millisecond
+	pool := NewWithConfig[string, string](config)
+	pool.WithProcessor(func(ctx context.Context, job Job[string]) (string, error) {
+		<-ctx.Done()
+		return "", ctx.Err()
+	})
+	pool.AddJob(Job[string]{ID: "1"})
+
+	results, err := pool.Run()
+	ts.ErrorIs(err, ErrPoolTimeout)
+	ts.Nil(results)
+}
+
+func (ts *WorkerPoolTestSuite) TestStopReportsUndeliveredJobs() {
+	config := DefaultConfig()
+	config.NumWorkers = 1
+	config.Strategy = PriorityBased
+	pool := NewWithConfig[int, int](config)
+
+	started := make(chan struct{})
+	pool.WithProcessor(func(ctx context.Context, job Job[int]) (int, error) {
+		close(started)
+		<-ctx.Done()
+		return 0, ctx.Err()
+	})
+	var undelivered []Job[int]
+	var cause error
+	pool.WithUndeliveredHandler(func(jobs []Job[int], err error) {
+		undelivered, cause = jobs, err
+	})
+
+	var jobs []Job[int]
+	for i := 0; i < 10; i++ {
+		jobs = append(jobs, Job[int]{ID: fmt.Sprint(i), Priority: i, Data: i})
+	}
+	pool.AddJobs(jobs)
+	go func() {
+		<-started
+		pool.Stop()
+	}()
+
+	results, err := pool.Run()
+	ts.Nil(results)
+	ts.ErrorIs(err, ErrPoolStopped)
+	var undeliveredErr *UndeliveredError
+	ts.Require().ErrorAs(err, &undeliveredErr)
+	ts.Equal(9, undeliveredErr.Undelivered)
+	ts.Contains(err.Error(), "9 jobs undelivered")
+
+	// The highest priority job was running; the rest reach the handler
+	ts.ErrorIs(cause, ErrPoolStopped)
+	ts.Len(undelivered, 9)
+	for _, job := range undelivered {
+		ts.NotEqual("9", job.ID)
+	}
+
+	// A run that delivered every job keeps its plain error
+	ts.Equal(ErrPoolStopped, pool.earlyEndError(ErrPoolStopped))
+}
+
+func (ts *WorkerPoolTestSuite) TestMoreJobsThanBuffer() {
+	config := DefaultConfig()
+	config.BufferSize = 10
+	pool := NewWithConfig[string, string](config)
+	pool.WithProcessor(func(ctx context.Context, job Job[string]) (string, error) {
+		return job.Data, nil
+	})
+
+	var jobs []Job[string]
+	for i := 0; i < 500; i++ {
+		jobs = append(jobs, Job[string]{ID: fmt.Sprintf("%d", i), Data: "x"})
+	}
+	pool.AddJobs(jobs)
+
+	results, err := pool.Run()
+	ts.NoError(err)
+	ts.Len(results, 500)
+}
@@ -0,0 +1,203 @@
+package workerpool
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// JobSummary describes a pending job without exposing its payload
+type JobSummary struct {
+	ID       string    `json:"id"`
+	Priority int       `json:"priority"`
+	TenantID string    `json:"tenant_id,omitempty"`
+	Owner    string    `json:"owner,omitempty"`
+	Created  time.Time `json:"created"`
+	Attempts int       `json:"attempts"`
+	Class    string    `json:"class,omitempty"`
+}
+
+// summarize builds a JobSummary for a job
+func summarize[T any](job Job[T]) JobSummary {
+	return JobSummary{
+		ID:       job.ID,
+		Priority: job.Priority,
+		TenantID: job.TenantID,
+		Owner:    job.Owner,
+		Created:  job.Created,
+		Attempts: job.Attempts,
+		Class:    job.Class,
+	}
+}
+
+// pendingSet tracks the jobs of a run that no worker has started yet.
+// Jobs are keyed by ID; duplicates of an ID are counted individually.
+type pendingSet[T any] struct {
+	jobs map[string][]Job[T]
+	n    int // Total jobs held
+	mu   sync.Mutex
+}
+
+// newPendingSet creates a pending set holding the given jobs
+func newPendingSet[T any](jobs []Job[T]) *pendingSet[T] {
+	ps := &pendingSet[T]{jobs: make(map[string][]Job[T], len(jobs)), n: len(jobs)}
+	for _, job := range jobs {
+		ps.jobs[job.ID] = append(ps.jobs[job.ID], job)
+	}
+	return ps
+}
+
+// add marks a job as pending again, e.g. after a requeue
+func (ps *pendingSet[T]) add(job Job[T]) {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+	ps.jobs[job.ID] = append(ps.jobs[job.ID], job)
+	ps.n++
+}
+
+// claim removes a job when a worker starts it. It reports false if the job
+// was removed from the backlog and must not run.
+func (ps *pendingSet[T]) claim(id string) bool {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+
+	jobs := ps.jobs[id]
+	if len(jobs) == 0 {
+		return false
+	}
+	ps.n--
+	if len(jobs) == 1 {
+		delete(ps.jobs, id)
+	} else {
+		ps.jobs[id] = jobs[1:]
+	}
+	return true
+}
+
+// count returns how many jobs are pending
+func (ps *pendingSet[T]) count() int {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+	return ps.n
+}
+
+// list returns the pending jobs in dispatch order, see sortPending
+func (ps *pendingSet[T]) list() []Job[T] {
+	ps.mu.Lock()
+	var jobs []Job[T]
+	for _, same := range ps.jobs {
+		jobs = append(jobs, same...)
+	}
+	ps.mu.Unlock()
+
+	sortPending(jobs)
+	return jobs
+}
+
+// sortPending orders jobs by descending priority, then by creation time,
+// then by ID, so listings are stable across calls
+func sortPending[T any](jobs []Job[T]) {
+	sort.SliceStable(jobs, func(i, j int) bool {
+		if jobs[i].Priority != jobs[j].Priority {
+			return jobs[i].Priority > jobs[j].Priority
+		}
+		if !jobs[i].Created.Equal(jobs[j].Created) {
+			return jobs[i].Created.Before(jobs[j].Created)
+		}
+		return jobs[i].ID < jobs[j].ID
+	})
+}
+
+// update applies fn to pending jobs matching the predicate
+func (ps *pendingSet[T]) update(match func(Job[T]) bool, fn func(*Job[T])) {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+
+	for _, same := range ps.jobs {
+		for i := range same {
+			if match(same[i]) {
+				fn(&same[i])
+			}
+		}
+	}
+}
+
+// remove deletes pending jobs matching the predicate and returns them
+func (ps *pendingSet[T]) remove(match func(Job[T]) bool) []Job[T] {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+
+	var removed []Job[T]
+	for id, same := range ps.jobs {
+		var kept []Job[T]
+		for _, job := range same {
+			if match(job) {
+				removed = append(removed, job)
+			} else {
+				kept = append(kept, job)
+			}
+		}
+		if len(kept) == 0 {
+			delete(ps.jobs, id)
+		} else {
+			ps.jobs[id] = kept
+		}
+	}
+	ps.n -= len(removed)
+	return removed
+}
+
+// PendingJobs lists jobs that have not started processing, highest priority
+// first. Before Run these are the queued jobs; during Run they are the jobs
+// no worker has picked up yet.
+func (wp *WorkerPool[T, R]) PendingJobs() []JobSummary {
+	wp.mu.RLock()
+	var jobs []Job[T]
+	if wp.pending != nil {
+		jobs = wp.pending.list()
+	} else {
+		jobs = make([]Job[T], len(wp.jobs))
+		copy(jobs, wp.jobs)
+	}
+	wp.mu.RUnlock()
+
+	sortPending(jobs)
+
+	summaries := make([]JobSummary, len(jobs))
+	for i, job := range jobs {
+		summaries[i] = summarize(job)
+	}
+	return summaries
+}
+
+// RemovePending drops pending jobs matching the predicate so they are never
+// processed, and returns how many were removed. Removed jobs produce no result.
+func (wp *WorkerPool[T, R]) RemovePending(predicate func(Job[T]) bool) int {
+	wp.mu.Lock()
+	defer wp.mu.Unlock()
+
+	var removed []Job[T]
+	if wp.pending != nil {
+		removed = wp.pending.remove(predicate)
+		if wp.queue != nil {
+			wp.queue.Remove(predicate)
+		}
+	} else {
+		kept := wp.jobs[:0]
+		for _, job := range wp.jobs {
+			if predicate(job) {
+				removed = append(removed, job)
+				continue
+			}
+			kept = append(kept, job)
+		}
+		wp.jobs = kept
+		wp.metrics.TotalJobs = len(wp.jobs)
+	}
+
+	for _, job := range removed {
+		wp.tenants.dequeue(job.TenantID)
+	}
+	wp.publishRemoved(removed, nil)
+	return len(removed)
+}
@@ -0,0 +1,99 @@
+package workerpool
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+func (ts *WorkerPoolTestSuite) TestPendingJobsBeforeRun() {
+	pool := New[string, string]()
+	pool.AddJobs([]Job[string]{
+		{ID: "1", Data: "a", Priority: 1, TenantID: "deleted"},
+		{ID: "2", Data: "b", Priority: 5},
+		{ID: "3", Data: "c", Priority: 3, TenantID: "deleted"},
+	})
+
+	pending := pool.PendingJobs()
+	ts.Len(pending, 3)
+	ts.Equal("2", pending[0].ID)
+	ts.Equal("3", pending[1].ID)
+
+	removed := pool.RemovePending(func(job Job[string]) bool {
+		return job.TenantID == "deleted"
+	})
+	ts.Equal(2, removed)
+	ts.Len(pool.PendingJobs(), 1)
+	ts.Equal(1, pool.GetMetrics().TotalJobs)
+}
+
+func (ts *WorkerPoolTestSuite) TestRemovePendingWhileRunning() {
+	config := DefaultConfig()
+	config.NumWorkers = 1
+	pool := NewWithConfig[string, string](config)
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	var once sync.Once
+	pool.WithProcessor(func(ctx context.Context, job Job[string]) (string, error) {
+		once.Do(func() { close(started) })
+		<-release
+		return job.Data, nil
+	})
+	pool.AddJobs([]Job[string]{
+		{ID: "1", Data: "a"},
+		{ID: "2", Data: "b", TenantID: "deleted"},
+		{ID: "3", Data: "c"},
+		{ID: "4", Data: "d", TenantID: "deleted"},
+	})
+
+	done := make(chan []Result[string])
+	go func() {
+		results, err := pool.Run()
+		ts.NoError(err)
+		done <- results
+	}()
+
+	<-started
+	ts.Len(pool.PendingJobs(), 3)
+	ts.Equal(2, pool.RemovePending(func(job Job[string]) bool {
+		return job.TenantID == "deleted"
+	}))
+	close(release)
+
+	results := <-done
+	ts.Len(results, 2)
+	for _, result := range results {
+		ts.Contains([]string{"1", "3"}, result.JobID)
+	}
+}
+
+func (ts *WorkerPoolTestSuite) TestDuplicateJobIDsAllRun() {
+	pool := New[string, string]()
+	pool.WithProcessor(func(ctx context.Context, job Job[string]) (string, error) {
+		return job.Data, nil
+	})
+	pool.AddJobs([]Job[string]{{Data: "a"}, {Data: "b"}, {Data: "c"}})
+
+	results, err := pool.Run()
+	ts.NoError(err)
+	ts.Len(results, 3)
+}
+
+func (ts *WorkerPoolTestSuite) TestPendingListIsStable() {
+	now := time.Now()
+	ps := newPendingSet([]Job[int]{
+		{ID: "late", Created: now.Add(time.Second)},
+		{ID: "b", Created: now},
+		{ID: "urgent", Priority: 5, Created: now.Add(time.Minute)},
+		{ID: "a", Created: now},
+	})
+	for i := 0; i < 10; i++ {
+		var ids []string
+		for _, job := range ps.list() {
+			ids = append(ids, job.ID)
+		}
+		ts.Equal([]string{"urgent", "a", "b", "late"}, ids)
+	}
+	ts.Equal(4, ps.count())
+}
@@ -0,0 +1,25 @@
+package workerpool
+
+// Pool is the part of a worker pool application code usually needs. Depend
+// on it rather than on *WorkerPool to swap in fakes, remote pools or a
+// MultiPool in tests and tooling.
+type Pool[T any, R any] interface {
+	// Submit adds a job to the batch every later Run processes, or reports
+	// why it was refused
+	Submit(job Job[T]) error
+
+	// Run processes the pool's jobs and returns their results
+	Run() ([]Result[R], error)
+
+	// Stop cancels the run in progress, if any
+	Stop()
+
+	// GetMetrics returns a snapshot of the pool's metrics
+	GetMetrics() Metrics
+}
+
+// Both pool types satisfy Pool
+var (
+	_ Pool[any, any] = (*WorkerPool[any, any])(nil)
+	_ Pool[any, any] = (*MultiPool[any, any])(nil)
+)
@@ -0,0 +1,65 @@
+package workerpool
+
+import (
+	"context"
+	"fmt"
+)
+
+// fakePool is the kind of test double Pool allows: it returns canned
+// results without running anything
+type fakePool struct {
+	submitted []Job[int]
+}
+
+func (f *fakePool) Submit(job Job[int]) error {
+	f.submitted = append(f.submitted, job)
+	return nil
+}
+
+func (f *fakePool) Run() ([]Result[int], error) {
+	results := make([]Result[int], len(f.submitted))
+	for i, job := range f.submitted {
+		results[i] = Result[int]{JobID: job.ID, Data: -job.Data}
+	}
+	return results, nil
+}
+
+func (f *fakePool) Stop() {}
+
+func (f *fakePool) GetMetrics() Metrics {
+	return Metrics{ProcessedJobs: len(f.submitted)}
+}
+
+// sumNegated is application code written against Pool
+func sumNegated(pool Pool[int, int], values ...int) (int, error) {
+	for i, v := range values {
+		if err := pool.Submit(Job[int]{ID: fmt.Sprint(i), Data: v}); err != nil {
+			return 0, err
+		}
+	}
+	results, err := pool.Run()
+	if err != nil {
+		return 0, err
+	}
+	sum := 0
+	for _, result := range results {
+		sum += result.Data
+	}
+	return sum, nil
+}
+
+func (ts *WorkerPoolTestSuite) TestPoolInterface() {
+	real := New[int, int]()
+	real.WithProcessor(func(ctx context.Context, job Job[int]) (int, error) {
+		return -job.Data, nil
+	})
+	fake := &fakePool{}
+
+	for _, pool := range []Pool[int, int]{real, fake} {
+		sum, err := sumNegated(pool, 1, 2, 3)
+		ts.NoError(err)
+		ts.Equal(-6, sum)
+		metrics := pool.GetMetrics()
+		ts.Equal(3, metrics.ProcessedJobs)
+	}
+}
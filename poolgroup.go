@@ -0,0 +1,104 @@
+package workerpool
+
+import (
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+)
+
+// GroupMember is what a PoolGroup needs from a pool. Every *WorkerPool
+// implements it, whatever its job and result types.
+type GroupMember interface {
+	Name() string
+	Health() Health
+	GetMetrics() Metrics
+	WorkerStats() []WorkerStats
+}
+
+// GroupMetrics is the metrics of a PoolGroup at one moment, aggregated
+// from its workers up to its pools and the group as a whole
+type GroupMetrics struct {
+	Name    string        `json:"name"`
+	Time    time.Time     `json:"time"`
+	Metrics *Metrics      `json:"metrics"` // Merged over every pool, as MultiPool merges them
+	Pools   []PoolMetrics `json:"pools"`   // Ordered by name
+}
+
+// PoolMetrics is one pool's entry in GroupMetrics
+type PoolMetrics struct {
+	Name    string        `json:"name"`
+	Health  Health        `json:"health"`
+	Metrics *Metrics      `json:"metrics"`
+	Workers []WorkerStats `json:"workers"`
+}
+
+// PoolGroup registers named pools of any job and result types and exports
+// their metrics together, for applications running many pools. It serves
+// its GroupMetrics as JSON on any GET request.
+type PoolGroup struct {
+	name  string
+	pools map[string]GroupMember
+	mu    sync.RWMutex
+}
+
+// NewPoolGroup creates an empty group
+func NewPoolGroup(name string) *PoolGroup {
+	return &PoolGroup{name: name, pools: make(map[string]GroupMember)}
+}
+
+// Register adds a pool to the group under its Name, replacing any pool
+// registered under the same name
+func (g *PoolGroup) Register(pool GroupMember) *PoolGroup {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.pools[pool.Name()] = pool
+	return g
+}
+
+// Unregister removes the pool registered under name
+func (g *PoolGroup) Unregister(name string) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	delete(g.pools, name)
+}
+
+// Pool returns the pool registered under name, or nil
+func (g *PoolGroup) Pool(name string) GroupMember {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.pools[name]
+}
+
+// Metrics returns the metrics of every pool and worker in the group, and
+// their sum
+func (g *PoolGroup) Metrics() GroupMetrics {
+	g.mu.RLock()
+	members := make([]GroupMember, 0, len(g.pools))
+	for _, pool := range g.pools {
+		members = append(members, pool)
+	}
+	g.mu.RUnlock()
+	sort.Slice(members, func(i, j int) bool { return members[i].Name() < members[j].Name() })
+
+	metrics := make([]Metrics, len(members))
+	pools := make([]PoolMetrics, len(members))
+	for i, pool := range members {
+		metrics[i] = pool.GetMetrics()
+		pools[i] = PoolMetrics{
+			Name:    pool.Name(),
+			Health:  pool.Health(),
+			Metrics: &metrics[i],
+			Workers: pool.WorkerStats(),
+		}
+	}
+	total := mergeMetrics(metrics)
+	return GroupMetrics{Name: g.name, Time: time.Now(), Metrics: &total, Pools: pools}
+}
+
+// ServeHTTP writes the group's metrics as JSON
+func (g *PoolGroup) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if allowMethod(w, r, http.MethodGet) {
+		writeJSON(w, http.StatusOK, g.Metrics())
+	}
+}
@@ -0,0 +1,75 @@
+package workerpool
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+)
+
+func (ts *WorkerPoolTestSuite) TestPoolGroupAggregatesMetrics() {
+	config := DefaultConfig()
+	config.Name = "emails"
+	config.NumWorkers = 2
+	config.MaxRetries = 0
+	emails := NewWithConfig[string, int](config)
+	emails.WithProcessor(func(ctx context.Context, job Job[string]) (int, error) {
+		if job.Data == "" {
+			return 0, errors.New("no recipient")
+		}
+		return len(job.Data), nil
+	})
+	emails.AddJobs([]Job[string]{{ID: "a", Data: "x@y"}, {ID: "b"}, {ID: "c", Data: "z@y"}})
+
+	config = DefaultConfig()
+	config.Name = "thumbnails"
+	config.NumWorkers = 3
+	thumbnails := NewWithConfig[int, []byte](config)
+	thumbnails.WithProcessor(func(ctx context.Context, job Job[int]) ([]byte, error) {
+		return make([]byte, job.Data), nil
+	})
+	thumbnails.AddJobs([]Job[int]{{ID: "1", Data: 1}, {ID: "2", Data: 2}})
+
+	group := NewPoolGroup("app").Register(thumbnails).Register(emails)
+	_, err := emails.Run()
+	ts.NoError(err)
+	_, err = thumbnails.Run()
+	ts.NoError(err)
+
+	m := group.Metrics()
+	ts.Equal("app", m.Name)
+	ts.Equal(5, m.Metrics.TotalJobs)
+	ts.Equal(4, m.Metrics.ProcessedJobs)
+	ts.Equal(1, m.Metrics.FailedJobs)
+	ts.Require().Len(m.Pools, 2)
+	ts.Equal("emails", m.Pools[0].Name)
+	ts.Equal(3, m.Pools[0].Metrics.TotalJobs)
+	ts.Len(m.Pools[0].Workers, 2)
+	ts.Equal("thumbnails", m.Pools[1].Name)
+	ts.Len(m.Pools[1].Workers, 3)
+
+	jobs := 0
+	for _, w := range m.Pools[1].Workers {
+		jobs += w.Jobs
+	}
+	ts.Equal(2, jobs)
+
+	// The group serves the same hierarchy as JSON
+	rec := httptest.NewRecorder()
+	group.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	ts.Equal(http.StatusOK, rec.Code)
+	var served GroupMetrics
+	ts.Require().NoError(json.Unmarshal(rec.Body.Bytes(), &served))
+	ts.Equal(5, served.Metrics.TotalJobs)
+	ts.Require().Len(served.Pools, 2)
+	ts.Equal(1, served.Pools[0].Metrics.FailedJobs)
+
+	rec = httptest.NewRecorder()
+	group.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/", nil))
+	ts.Equal(http.StatusMethodNotAllowed, rec.Code)
+
+	group.Unregister("emails")
+	ts.Nil(group.Pool("emails"))
+	ts.Equal(2, group.Metrics().Metrics.TotalJobs)
+}
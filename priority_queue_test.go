@@ -0,0 +1,69 @@
+package workerpool
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+func (ts *WorkerPoolTestSuite) TestPriorityQueueFIFOWithEqualTimestamps() {
+	pq := NewPriorityQueue[int]()
+	created := time.Now()
+
+	for i := 0; i < 500; i++ {
+		pq.Push(Job[int]{ID: fmt.Sprintf("%d", i), Data: i, Priority: i % 3, Created: created})
+	}
+
+	last := map[int]int{0: -1, 1: -1, 2: -1}
+	priority := 2
+	for !pq.IsEmpty() {
+		job, ok := pq.Pop()
+		ts.True(ok)
+		ts.LessOrEqual(job.Priority, priority, "priorities must not increase")
+		priority = job.Priority
+		ts.Greater(job.Data, last[job.Priority], "jobs of priority %d popped out of push order", job.Priority)
+		last[job.Priority] = job.Data
+	}
+}
+
+func (ts *WorkerPoolTestSuite) TestPriorityQueueCreatedBeforeSequence() {
+	pq := NewPriorityQueue[string]()
+	now := time.Now()
+
+	// An older Created timestamp still wins over an earlier push
+	pq.Push(Job[string]{ID: "newer", Created: now})
+	pq.Push(Job[string]{ID: "older", Created: now.Add(-time.Second)})
+	pq.Push(Job[string]{ID: "newer-2", Created: now})
+
+	var order []string
+	for !pq.IsEmpty() {
+		job, _ := pq.Pop()
+		order = append(order, job.ID)
+	}
+	ts.Equal([]string{"older", "newer", "newer-2"}, order)
+}
+
+func (ts *WorkerPoolTestSuite) TestPriorityBasedFIFOWithEqualTimestamps() {
+	config := DefaultConfig()
+	config.NumWorkers = 1
+	config.Strategy = PriorityBased
+	pool := NewWithConfig[int, int](config)
+
+	pool.WithProcessor(func(ctx context.Context, job Job[int]) (int, error) {
+		return job.Data, nil
+	})
+
+	created := time.Now()
+	var jobs []Job[int]
+	for i := 0; i < 50; i++ {
+		jobs = append(jobs, Job[int]{ID: fmt.Sprintf("%d", i), Data: i, Created: created})
+	}
+	pool.AddJobs(jobs)
+
+	results, err := pool.Run()
+	ts.NoError(err)
+	ts.Len(results, 50)
+	for i, r := range results {
+		ts.Equal(i, r.Data)
+	}
+}
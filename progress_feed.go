@@ -0,0 +1,104 @@
+package workerpool
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// ProgressEvent is the payload of a "progress" event on the progress feed
+type ProgressEvent struct {
+	Total     int `json:"total"`
+	Processed int `json:"processed"`
+	Failed    int `json:"failed"`
+	Expired   int `json:"expired"`
+	Skipped   int `json:"skipped"`
+}
+
+// ResultEvent is the payload of a "result" event on the progress feed
+type ResultEvent struct {
+	JobID    string        `json:"job_id"`
+	Worker   int           `json:"worker"`
+	Error    string        `json:"error,omitempty"`
+	Duration time.Duration `json:"duration_ns"`
+	Attempts int           `json:"attempts"`
+}
+
+// ProgressHandler returns an http.Handler that streams live progress as
+// Server-Sent Events, for dashboards over long-running batches. A client
+// receives a "progress" event on connect, then a "result" event followed by
+// a fresh "progress" event for every completed job. Result payloads are
+// summaries; job data is never sent. Mount it on any mux, e.g.
+//
+//	http.Handle("/progress", pool.ProgressHandler())
+func (wp *WorkerPool[T, R]) ProgressHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		flusher, ok := w.(http.Flusher)
+		if !ok {
+			http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+			return
+		}
+
+		watch := wp.WatchResults(nil, 0)
+		defer watch.Stop()
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Header().Set("Cache-Control", "no-cache")
+		w.Header().Set("Connection", "keep-alive")
+
+		if err := writeEvent(w, "progress", wp.progress()); err != nil {
+			return
+		}
+		flusher.Flush()
+
+		for {
+			select {
+			case <-r.Context().Done():
+				return
+			case result, ok := <-watch.C:
+				if !ok {
+					return
+				}
+				event := ResultEvent{
+					JobID:    result.JobID,
+					Worker:   result.Worker,
+					Duration: result.Duration,
+					Attempts: result.Attempts,
+				}
+				if result.Error != nil {
+					event.Error = result.Error.Error()
+				}
+				if err := writeEvent(w, "result", event); err != nil {
+					return
+				}
+				if err := writeEvent(w, "progress", wp.progress()); err != nil {
+					return
+				}
+				flusher.Flush()
+			}
+		}
+	})
+}
+
+// progress returns the current progress counters
+func (wp *WorkerPool[T, R]) progress() ProgressEvent {
+	m := wp.GetMetrics()
+	return ProgressEvent{
+		Total:     m.TotalJobs,
+		Processed: m.ProcessedJobs,
+		Failed:    m.FailedJobs,
+		Expired:   m.ExpiredJobs,
+		Skipped:   m.SkippedJobs,
+	}
+}
+
+// writeEvent writes one Server-Sent Event with a JSON payload
+func writeEvent(w http.ResponseWriter, name string, payload any) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", name, data)
+	return err
+}
@@ -0,0 +1,74 @@
+package workerpool
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+)
+
+func (ts *WorkerPoolTestSuite) TestProgressHandlerStreamsEvents() {
+	pool := New[string, string]()
+	pool.WithProcessor(func(ctx context.Context, job Job[string]) (string, error) {
+		return job.Data, nil
+	})
+
+	server := httptest.NewServer(pool.ProgressHandler())
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+	ts.Require().NoError(err)
+	resp, err := http.DefaultClient.Do(req)
+	ts.Require().NoError(err)
+	defer resp.Body.Close()
+	ts.Equal("text/event-stream", resp.Header.Get("Content-Type"))
+
+	events := make(chan [2]string)
+	go func() {
+		defer close(events)
+		scanner := bufio.NewScanner(resp.Body)
+		var name string
+		for scanner.Scan() {
+			line := scanner.Text()
+			switch {
+			case strings.HasPrefix(line, "event: "):
+				name = strings.TrimPrefix(line, "event: ")
+			case strings.HasPrefix(line, "data: "):
+				events <- [2]string{name, strings.TrimPrefix(line, "data: ")}
+			}
+		}
+	}()
+
+	// The connect event confirms the watch is registered before the run
+	first := <-events
+	ts.Equal("progress", first[0])
+
+	var jobs []Job[string]
+	for i := 0; i < 3; i++ {
+		jobs = append(jobs, Job[string]{ID: fmt.Sprintf("%d", i), Data: "x"})
+	}
+	pool.AddJobs(jobs)
+	_, err = pool.Run()
+	ts.NoError(err)
+
+	seen := make(map[string]bool)
+	var last ProgressEvent
+	for len(seen) < 3 || last.Processed < 3 {
+		event := <-events
+		switch event[0] {
+		case "result":
+			var result ResultEvent
+			ts.NoError(json.Unmarshal([]byte(event[1]), &result))
+			seen[result.JobID] = true
+		case "progress":
+			ts.NoError(json.Unmarshal([]byte(event[1]), &last))
+		}
+	}
+	ts.Equal(3, last.Total)
+	cancel()
+}
@@ -0,0 +1,369 @@
+package workerpool
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+)
+
+// Queue stores the pending jobs of queue-fed strategies. PriorityQueue,
+// LaneQueue, FairShareQueue, FIFOQueue and FileQueue implement it; set one
+// for PriorityBased runs with WithQueue. Implementations must be safe for
+// concurrent use: the dispatcher pops while Requeue, Reprioritize and
+// cancellation push and remove.
+type Queue[T any] interface {
+	Push(job Job[T])
+	Pop() (Job[T], bool)  // Removes the next job to dispatch
+	Peek() (Job[T], bool) // Returns the job Pop would, without removing it
+	Len() int
+	Remove(match func(Job[T]) bool) []Job[T] // Deletes matching jobs and returns them
+}
+
+// queueUpdater is implemented by queues that can change jobs in place,
+// keeping their position when the order does not depend on the change
+type queueUpdater[T any] interface {
+	Update(match func(Job[T]) bool, fn func(*Job[T])) int
+}
+
+// updateQueue applies fn to the queued jobs matching the predicate and
+// returns how many it changed. Queues without an Update method have the
+// jobs removed and pushed back.
+func updateQueue[T any](q Queue[T], match func(Job[T]) bool, fn func(*Job[T])) int {
+	if u, ok := q.(queueUpdater[T]); ok {
+		return u.Update(match, fn)
+	}
+	moved := q.Remove(match)
+	for i := range moved {
+		fn(&moved[i])
+		q.Push(moved[i])
+	}
+	return len(moved)
+}
+
+// WithQueue makes PriorityBased runs dispatch from the queue newQueue
+// returns, called once per run, instead of the PriorityQueue or the
+// LaneQueue of Config.PriorityLanes
+func (wp *WorkerPool[T, R]) WithQueue(newQueue func() Queue[T]) *WorkerPool[T, R] {
+	wp.mu.Lock()
+	defer wp.mu.Unlock()
+	wp.newQueue = newQueue
+	return wp
+}
+
+// FIFOQueue is a Queue that dispatches jobs in the order they were pushed,
+// ignoring priority, in a ring buffer that grows as needed
+type FIFOQueue[T any] struct {
+	ring []Job[T]
+	head int
+	size int
+	mu   sync.Mutex
+}
+
+// NewFIFOQueue creates an empty FIFO queue
+func NewFIFOQueue[T any]() *FIFOQueue[T] {
+	return &FIFOQueue[T]{ring: make([]Job[T], 16)}
+}
+
+// Push adds a job at the back
+func (q *FIFOQueue[T]) Push(job Job[T]) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.size == len(q.ring) {
+		grown := make([]Job[T], 2*len(q.ring))
+		for i := 0; i < q.size; i++ {
+			grown[i] = q.ring[(q.head+i)%len(q.ring)]
+		}
+		q.ring, q.head = grown, 0
+	}
+	q.ring[(q.head+q.size)%len(q.ring)] = job
+	q.size++
+}
+
+// Pop removes the job at the front
+func (q *FIFOQueue[T]) Pop() (Job[T], bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.size == 0 {
+		return Job[T]{}, false
+	}
+	job := q.ring[q.head]
+	q.ring[q.head] = Job[T]{}
+	q.head = (q.head + 1) % len(q.ring)
+	q.size--
+	return job, true
+}
+
+// Peek returns the job at the front without removing it
+func (q *FIFOQueue[T]) Peek() (Job[T], bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.size == 0 {
+		return Job[T]{}, false
+	}
+	return q.ring[q.head], true
+}
+
+// Len returns the number of queued jobs
+func (q *FIFOQueue[T]) Len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.size
+}
+
+// Remove deletes every queued job matching the predicate and returns them,
+// keeping the order of the rest
+func (q *FIFOQueue[T]) Remove(match func(Job[T]) bool) []Job[T] {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	var removed []Job[T]
+	kept := 0
+	for i := 0; i < q.size; i++ {
+		job := q.ring[(q.head+i)%len(q.ring)]
+		if match(job) {
+			removed = append(removed, job)
+			continue
+		}
+		q.ring[(q.head+kept)%len(q.ring)] = job
+		kept++
+	}
+	for i := kept; i < q.size; i++ {
+		q.ring[(q.head+i)%len(q.ring)] = Job[T]{}
+	}
+	q.size = kept
+	return removed
+}
+
+// FileQueue is a Queue that journals every change to a file, so the jobs
+// still queued survive a restart. Dispatch order is that of the queue it
+// wraps. A job leaves the journal when it is popped, before it runs, so a
+// crash loses the jobs executing at the time; jobs are delivered at most
+// once. Payloads must round-trip through encoding/json.
+type FileQueue[T any] struct {
+	inner     Queue[T]
+	file      *os.File
+	encryptor *Encryptor
+	err       error // First journal write error
+	mu        sync.Mutex
+}
+
+// fileQueueEntry is one line of a FileQueue journal
+type fileQueueEntry[T any] struct {
+	Push   *Job[T]  `json:"push,omitempty"`
+	Sealed []byte   `json:"sealed,omitempty"` // Data of the pushed job, sealed when the journal is encrypted
+	Pop    string   `json:"pop,omitempty"`
+	Remove []string `json:"remove,omitempty"`
+}
+
+// NewFileQueue opens the journal at path, creating it if needed, and pushes
+// the jobs it still holds into inner, e.g. a NewPriorityQueue. The journal
+// is compacted to just those jobs.
+func NewFileQueue[T any](path string, inner Queue[T]) (*FileQueue[T], error) {
+	return NewEncryptedFileQueue(path, inner, nil)
+}
+
+// NewEncryptedFileQueue is NewFileQueue with every job's Data sealed by e,
+// bound to the job ID, before it is journaled; the rest of the job, such as
+// its ID and priority, stays readable. Plaintext entries written earlier are
+// still replayed, and compaction seals them.
+func NewEncryptedFileQueue[T any](path string, inner Queue[T], e *Encryptor) (*FileQueue[T], error) {
+	jobs, err := replayQueueJournal[T](path, e)
+	if err != nil {
+		return nil, err
+	}
+
+	// Compact into a new file and swap it in, so a crash midway keeps the
+	// old journal
+	tmp := path + ".tmp"
+	file, err := os.OpenFile(tmp, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0o600)
+	if err != nil {
+		return nil, err
+	}
+	q := &FileQueue[T]{inner: inner, file: file, encryptor: e}
+	for i := range jobs {
+		q.append(fileQueueEntry[T]{Push: &jobs[i]})
+		inner.Push(jobs[i])
+	}
+	if q.err == nil {
+		q.err = file.Sync()
+	}
+	if q.err == nil {
+		q.err = os.Rename(tmp, path)
+	}
+	if q.err != nil {
+		file.Close()
+		return nil, q.err
+	}
+	return q, nil
+}
+
+// replayQueueJournal returns the jobs a journal leaves queued, in push order
+func replayQueueJournal[T any](path string, e *Encryptor) ([]Job[T], error) {
+	file, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var jobs []Job[T]
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(nil, 16<<20)
+	for line := 1; scanner.Scan(); line++ {
+		var entry fileQueueEntry[T]
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			return nil, fmt.Errorf("%s:%d: %w", path, line, err)
+		}
+		switch {
+		case entry.Push != nil:
+			if entry.Sealed != nil {
+				if err := openQueueEntry(entry, e); err != nil {
+					return nil, fmt.Errorf("%s:%d: %w", path, line, err)
+				}
+			}
+			jobs = append(jobs, *entry.Push)
+		case entry.Pop != "":
+			for i, job := range jobs {
+				if job.ID == entry.Pop {
+					jobs = append(jobs[:i], jobs[i+1:]...)
+					break
+				}
+			}
+		case len(entry.Remove) > 0:
+			removed := make(map[string]bool, len(entry.Remove))
+			for _, id := range entry.Remove {
+				removed[id] = true
+			}
+			kept := jobs[:0]
+			for _, job := range jobs {
+				if !removed[job.ID] {
+					kept = append(kept, job)
+				}
+			}
+			jobs = kept
+		}
+	}
+	return jobs, scanner.Err()
+}
+
+// append writes an entry to the journal and syncs it, remembering the first
+// error. Callers must hold q.mu or own q exclusively.
+func (q *FileQueue[T]) append(entry fileQueueEntry[T]) {
+	if q.err != nil {
+		return
+	}
+	var err error
+	if entry.Push != nil && q.encryptor != nil {
+		err = sealQueueEntry(&entry, q.encryptor)
+	}
+	var line []byte
+	if err == nil {
+		line, err = json.Marshal(entry)
+	}
+	if err == nil {
+		_, err = q.file.Write(append(line, '\n'))
+	}
+	if err == nil {
+		err = q.file.Sync()
+	}
+	q.err = err
+}
+
+// sealQueueEntry moves the Data of the job an entry pushes into Sealed
+func sealQueueEntry[T any](entry *fileQueueEntry[T], e *Encryptor) error {
+	data, err := json.Marshal(entry.Push.Data)
+	if err != nil {
+		return err
+	}
+	if entry.Sealed, err = e.Seal(data, []byte(entry.Push.ID)); err != nil {
+		return err
+	}
+	job := *entry.Push
+	var zero T
+	job.Data = zero
+	entry.Push = &job
+	return nil
+}
+
+// openQueueEntry restores the Data of the job an entry pushes from Sealed
+func openQueueEntry[T any](entry fileQueueEntry[T], e *Encryptor) error {
+	if e == nil {
+		return fmt.Errorf("%w: journal is encrypted", ErrDecrypt)
+	}
+	data, err := e.Open(entry.Sealed, []byte(entry.Push.ID))
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, &entry.Push.Data)
+}
+
+// Push journals the job and queues it
+func (q *FileQueue[T]) Push(job Job[T]) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.append(fileQueueEntry[T]{Push: &job})
+	q.inner.Push(job)
+}
+
+// Pop dequeues the next job and journals its removal
+func (q *FileQueue[T]) Pop() (Job[T], bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	job, ok := q.inner.Pop()
+	if ok {
+		q.append(fileQueueEntry[T]{Pop: job.ID})
+	}
+	return job, ok
+}
+
+// Peek returns the next job without removing it
+func (q *FileQueue[T]) Peek() (Job[T], bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.inner.Peek()
+}
+
+// Len returns the number of queued jobs
+func (q *FileQueue[T]) Len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.inner.Len()
+}
+
+// Remove deletes matching jobs and journals their removal
+func (q *FileQueue[T]) Remove(match func(Job[T]) bool) []Job[T] {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	removed := q.inner.Remove(match)
+	if len(removed) > 0 {
+		ids := make([]string, len(removed))
+		for i, job := range removed {
+			ids[i] = job.ID
+		}
+		q.append(fileQueueEntry[T]{Remove: ids})
+	}
+	return removed
+}
+
+// Err returns the first error writing the journal. After it the queue keeps
+// working in memory but no longer persists changes.
+func (q *FileQueue[T]) Err() error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.err
+}
+
+// Close closes the journal
+func (q *FileQueue[T]) Close() error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.file.Close()
+}
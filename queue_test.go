@@ -0,0 +1,144 @@
+package workerpool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+func (ts *WorkerPoolTestSuite) TestFIFOQueue() {
+	q := NewFIFOQueue[int]()
+	for i := 0; i < 40; i++ {
+		q.Push(Job[int]{ID: fmt.Sprint(i), Data: i, Priority: i % 3})
+	}
+	// Pop some so the ring wraps before growing again
+	for i := 0; i < 10; i++ {
+		job, ok := q.Pop()
+		ts.True(ok)
+		ts.Equal(i, job.Data)
+	}
+	for i := 40; i < 60; i++ {
+		q.Push(Job[int]{ID: fmt.Sprint(i), Data: i})
+	}
+	removed := q.Remove(func(j Job[int]) bool { return j.Data%2 == 1 })
+	ts.Len(removed, 25)
+	ts.Equal(25, q.Len())
+
+	next, ok := q.Peek()
+	ts.True(ok)
+	ts.Equal(10, next.Data)
+	for want := 10; want < 60; want += 2 {
+		job, ok := q.Pop()
+		ts.Require().True(ok)
+		ts.Equal(want, job.Data)
+	}
+	_, ok = q.Pop()
+	ts.False(ok)
+}
+
+func (ts *WorkerPoolTestSuite) TestQueuesPeekWhatTheyPop() {
+	queues := map[string]Queue[int]{
+		"priority":   NewPriorityQueue[int](),
+		"lanes":      NewLaneQueue[int]([]PriorityLane{{MinPriority: 10, Weight: 2}, {Weight: 1}}),
+		"fair_share": NewFairShareQueue[int](0),
+		"fifo":       NewFIFOQueue[int](),
+	}
+	for name, q := range queues {
+		for i := 0; i < 12; i++ {
+			q.Push(Job[int]{ID: fmt.Sprint(i), Priority: i * 3 % 20, Owner: fmt.Sprint(i % 3)})
+		}
+		ts.Equal(12, q.Len(), name)
+		for q.Len() > 0 {
+			peeked, _ := q.Peek()
+			popped, ok := q.Pop()
+			ts.True(ok)
+			ts.Equal(peeked.ID, popped.ID, name)
+		}
+	}
+}
+
+func (ts *WorkerPoolTestSuite) TestFileQueueSurvivesReopen() {
+	path := filepath.Join(ts.T().TempDir(), "queue.jsonl")
+	q, err := NewFileQueue[string](path, NewPriorityQueue[string]())
+	ts.Require().NoError(err)
+	for i, data := range []string{"a", "b", "c", "d"} {
+		q.Push(Job[string]{ID: data, Data: data, Priority: i})
+	}
+	job, _ := q.Pop()
+	ts.Equal("d", job.ID)
+	ts.Len(q.Remove(func(j Job[string]) bool { return j.ID == "a" }), 1)
+	ts.NoError(q.Err())
+	ts.NoError(q.Close())
+
+	reopened, err := NewFileQueue[string](path, NewFIFOQueue[string]())
+	ts.Require().NoError(err)
+	defer reopened.Close()
+	ts.Equal(2, reopened.Len())
+	for _, want := range []string{"b", "c"} {
+		job, ok := reopened.Pop()
+		ts.True(ok)
+		ts.Equal(want, job.Data)
+	}
+}
+
+func (ts *WorkerPoolTestSuite) TestEncryptedFileQueue() {
+	path := filepath.Join(ts.T().TempDir(), "queue.jsonl")
+	e := NewEncryptor(testKeys())
+
+	// A plaintext journal written earlier is sealed by compaction
+	plain, err := NewFileQueue[string](path, NewFIFOQueue[string]())
+	ts.Require().NoError(err)
+	plain.Push(Job[string]{ID: "a", Data: "ssn=123"})
+	ts.NoError(plain.Close())
+
+	q, err := NewEncryptedFileQueue[string](path, NewFIFOQueue[string](), e)
+	ts.Require().NoError(err)
+	q.Push(Job[string]{ID: "b", Data: "ssn=456"})
+	ts.NoError(q.Err())
+	ts.NoError(q.Close())
+
+	journal, err := os.ReadFile(path)
+	ts.Require().NoError(err)
+	ts.NotContains(string(journal), "ssn=")
+	ts.Contains(string(journal), `"ID":"b"`)
+
+	_, err = NewFileQueue[string](path, NewFIFOQueue[string]())
+	ts.True(errors.Is(err, ErrDecrypt), "a sealed journal needs its encryptor")
+
+	reopened, err := NewEncryptedFileQueue[string](path, NewFIFOQueue[string](), e)
+	ts.Require().NoError(err)
+	defer reopened.Close()
+	for _, want := range []string{"ssn=123", "ssn=456"} {
+		job, ok := reopened.Pop()
+		ts.True(ok)
+		ts.Equal(want, job.Data)
+	}
+}
+
+func (ts *WorkerPoolTestSuite) TestWithQueue() {
+	config := DefaultConfig()
+	config.NumWorkers = 1
+	config.Strategy = PriorityBased
+	pool := NewWithConfig[int, int](config)
+
+	var mu sync.Mutex
+	var order []int
+	pool.WithProcessor(func(ctx context.Context, job Job[int]) (int, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		order = append(order, job.Data)
+		return job.Data, nil
+	})
+	pool.WithQueue(func() Queue[int] { return NewFIFOQueue[int]() })
+	for i := 0; i < 5; i++ {
+		pool.AddJob(Job[int]{ID: fmt.Sprint(i), Data: i, Priority: i})
+	}
+	results, err := pool.Run()
+	ts.NoError(err)
+	ts.Len(results, 5)
+	ts.Equal([]int{0, 1, 2, 3, 4}, order, "pushed order, not priority order")
+	ts.Equal("custom", results[0].Queue)
+}
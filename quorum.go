@@ -0,0 +1,129 @@
+package workerpool
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// ErrNoQuorum is matched by the *QuorumError of a job whose replicas did
+// not agree often enough
+var ErrNoQuorum = errors.New("replicas did not reach quorum")
+
+// Quorum runs every job as several identical replicas and accepts a result
+// only once enough of them agree, for verification workloads that run
+// duplicate computations
+type Quorum[R any] struct {
+	Replicas int // Copies of every job to run
+	Agree    int // Successful replicas that must agree for the result to be accepted
+
+	// Equal reports whether two replicas agree; nil compares with
+	// reflect.DeepEqual
+	Equal func(a, b R) bool
+
+	// OnDisagreement, when set, is called with the replicas of every job
+	// whose successful replicas did not all agree, whether or not the
+	// quorum was reached. It runs on the result collector and must not block.
+	OnDisagreement func(jobID string, replicas []Result[R])
+}
+
+// QuorumError reports a job whose replicas did not reach quorum
+type QuorumError struct {
+	JobID    string
+	Replicas int // Replicas run
+	Needed   int // Replicas that had to agree
+	Agreed   int // Largest number of successful replicas that agreed
+}
+
+// Error summarizes the disagreement
+func (e *QuorumError) Error() string {
+	return fmt.Sprintf("job %s: %d of %d replicas agree, %d needed", e.JobID, e.Agreed, e.Replicas, e.Needed)
+}
+
+// Unwrap returns ErrNoQuorum
+func (e *QuorumError) Unwrap() error {
+	return ErrNoQuorum
+}
+
+// WithQuorum runs every job as q.Replicas replicas and reports one result
+// per job: the data most replicas agreed on, or a *QuorumError when fewer
+// than q.Agree did. Replicas are numbered after their job, e.g. "sum-7/0",
+// and their results are in Result.Parts; an IdempotencyKey is numbered the
+// same way, so a CompletionStore tells the replicas apart. WithQuorum is
+// built on splitting and combining, so it replaces any Splitter and
+// Combiner. Run fails if q needs fewer than two replicas or more agreeing
+// replicas than it runs.
+func (wp *WorkerPool[T, R]) WithQuorum(q Quorum[R]) *WorkerPool[T, R] {
+	replicate := func(job Job[T]) []Job[T] {
+		replicas := make([]Job[T], q.Replicas)
+		for i := range replicas {
+			replicas[i] = job
+			replicas[i].ID = ""
+			if job.IdempotencyKey != "" {
+				replicas[i].IdempotencyKey = fmt.Sprintf("%s/%d", job.IdempotencyKey, i)
+			}
+		}
+		return replicas
+	}
+	wp.WithSplitter(replicate)
+	wp.WithCombiner(q.combine, combineEveryPart)
+
+	wp.mu.Lock()
+	defer wp.mu.Unlock()
+	wp.quorumErr = q.validate()
+	return wp
+}
+
+// validate checks that the quorum can be reached
+func (q Quorum[R]) validate() error {
+	switch {
+	case q.Replicas <= 1:
+		return fmt.Errorf("quorum: %d replicas, at least 2 needed", q.Replicas)
+	case q.Agree > q.Replicas:
+		return fmt.Errorf("quorum: %d of %d replicas cannot agree", q.Agree, q.Replicas)
+	}
+	return nil
+}
+
+// combine picks the data the most successful replicas agree on
+func (q Quorum[R]) combine(jobID string, replicas []Result[R]) (R, error) {
+	equal := q.Equal
+	if equal == nil {
+		equal = func(a, b R) bool { return reflect.DeepEqual(a, b) }
+	}
+
+	// Group the successful replicas by the data they agree on
+	var groups [][]Result[R]
+	for _, replica := range replicas {
+		if replica.Error != nil {
+			continue
+		}
+		found := false
+		for i, group := range groups {
+			if equal(group[0].Data, replica.Data) {
+				groups[i] = append(group, replica)
+				found = true
+				break
+			}
+		}
+		if !found {
+			groups = append(groups, []Result[R]{replica})
+		}
+	}
+
+	var best []Result[R]
+	for _, group := range groups {
+		if len(group) > len(best) {
+			best = group
+		}
+	}
+	if len(groups) > 1 && q.OnDisagreement != nil {
+		q.OnDisagreement(jobID, replicas)
+	}
+
+	var zero R
+	if len(best) < q.Agree || len(best) == 0 {
+		return zero, &QuorumError{JobID: jobID, Replicas: q.Replicas, Needed: q.Agree, Agreed: len(best)}
+	}
+	return best[0].Data, nil
+}
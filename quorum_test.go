@@ -0,0 +1,100 @@
+package workerpool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+func (ts *WorkerPoolTestSuite) TestQuorum() {
+	config := DefaultConfig()
+	config.MaxRetries = 0
+	pool := NewWithConfig[string, int](config)
+
+	// Replica 2 of "flaky" computes a wrong answer and every replica of
+	// "broken" disagrees; replica 0 of "crash" fails
+	pool.WithProcessor(func(ctx context.Context, job Job[string]) (int, error) {
+		replica := job.ID[strings.LastIndex(job.ID, "/")+1:]
+		switch {
+		case job.ParentID == "flaky" && replica == "2":
+			return -1, nil
+		case job.ParentID == "broken":
+			return len(replica) + int(replica[0]), nil
+		case job.ParentID == "crash" && replica == "0":
+			return 0, errors.New("out of memory")
+		}
+		return len(job.Data), nil
+	})
+
+	var mu sync.Mutex
+	var flagged []string
+	pool.WithQuorum(Quorum[int]{
+		Replicas: 3,
+		Agree:    2,
+		OnDisagreement: func(jobID string, replicas []Result[int]) {
+			mu.Lock()
+			defer mu.Unlock()
+			flagged = append(flagged, fmt.Sprintf("%s:%d", jobID, len(replicas)))
+		},
+	})
+	pool.AddJobs([]Job[string]{
+		{ID: "flaky", Data: "abcd"},
+		{ID: "broken", Data: "abcd"},
+		{ID: "crash", Data: "abc"},
+	})
+
+	results, err := pool.Run()
+	ts.NoError(err)
+	byID := make(map[string]Result[int])
+	for _, result := range results {
+		byID[result.JobID] = result
+	}
+	ts.Len(byID, 3)
+
+	ts.NoError(byID["flaky"].Error)
+	ts.Equal(4, byID["flaky"].Data)
+	ts.Len(byID["flaky"].Parts, 3)
+
+	var quorumErr *QuorumError
+	ts.Require().True(errors.As(byID["broken"].Error, &quorumErr))
+	ts.True(errors.Is(byID["broken"].Error, ErrNoQuorum))
+	ts.Equal(QuorumError{JobID: "broken", Replicas: 3, Needed: 2, Agreed: 1}, *quorumErr)
+
+	// Two agreeing replicas are enough when the third fails
+	ts.NoError(byID["crash"].Error)
+	ts.Equal(3, byID["crash"].Data)
+
+	ts.ElementsMatch([]string{"flaky:3", "broken:3"}, flagged)
+}
+
+func (ts *WorkerPoolTestSuite) TestQuorumWithCompletionStore() {
+	pool := NewWithConfig[string, int](DefaultConfig())
+	pool.WithProcessor(func(ctx context.Context, job Job[string]) (int, error) {
+		return len(job.Data), nil
+	})
+	pool.WithCompletionStore(NewMemoryCompletionStore())
+	pool.WithQuorum(Quorum[int]{Replicas: 3, Agree: 3})
+	pool.AddJob(Job[string]{ID: "sum", Data: "abc", IdempotencyKey: "order-7"})
+
+	// Every replica runs under its own key
+	results, err := pool.Run()
+	ts.NoError(err)
+	ts.Require().Len(results, 1)
+	ts.NoError(results[0].Error)
+	ts.Equal(3, results[0].Data)
+}
+
+func (ts *WorkerPoolTestSuite) TestQuorumRejectsUnreachableSettings() {
+	for _, q := range []Quorum[int]{{Replicas: 1, Agree: 1}, {Replicas: 0}, {Replicas: 3, Agree: 4}} {
+		pool := NewWithConfig[string, int](DefaultConfig())
+		pool.WithProcessor(func(ctx context.Context, job Job[string]) (int, error) {
+			return 0, nil
+		})
+		pool.WithQuorum(q)
+		pool.AddJob(Job[string]{ID: "sum"})
+		_, err := pool.Run()
+		ts.ErrorContains(err, "quorum", fmt.Sprint(q.Replicas, q.Agree))
+	}
+}
@@ -0,0 +1,185 @@
+package workerpool
+
+import (
+	"fmt"
+	"time"
+)
+
+// configEventLimit is how many ConfigEvents a pool keeps
+const configEventLimit = 100
+
+// ConfigDelta lists settings to change with Reconfigure. Nil fields are left
+// as they are.
+type ConfigDelta struct {
+	NumWorkers        *int           // Takes effect at the next run
+	Timeout           *time.Duration // Takes effect at the next run
+	MaxRetries        *int           // Takes effect for jobs starting after the change
+	WorkerTimeout     *time.Duration // Takes effect for jobs starting after the change
+	HeartbeatTimeout  *time.Duration // Takes effect for jobs starting after the change
+	MaxConcurrentCost *int           // Takes effect immediately; zero removes the cap
+}
+
+// ConfigEvent records one setting changed by Reconfigure, or a change the
+// pool made to its own behavior such as holding back jobs under GC pressure
+type ConfigEvent struct {
+	Time     time.Time
+	Setting  string // Config field name, e.g. "NumWorkers", or the adjusted behavior, e.g. "GCPressure"
+	Old      string
+	New      string
+	Deferred bool   // Held until the run in progress ends
+	Reason   string // Why the pool changed the setting itself; empty for Reconfigure
+}
+
+// attemptPolicy is the retry and timeout configuration a job runs with
+type attemptPolicy struct {
+	maxRetries int
+	backoff    time.Duration // Wait before the first retry, growing linearly
+	timeout    time.Duration
+	heartbeat  time.Duration
+}
+
+// Reconfigure changes settings of a live pool without stopping it. Jobs
+// already executing keep the retry policy and timeouts they started with;
+// worker count and run timeout changes made during a run apply once it
+// ends. Invalid values are rejected and nothing is changed. Every change is
+// recorded as a ConfigEvent.
+func (wp *WorkerPool[T, R]) Reconfigure(delta ConfigDelta) error {
+	switch {
+	case delta.NumWorkers != nil && *delta.NumWorkers < 1:
+		return fmt.Errorf("reconfigure: NumWorkers must be at least 1, got %d", *delta.NumWorkers)
+	case delta.Timeout != nil && *delta.Timeout <= 0:
+		return fmt.Errorf("reconfigure: Timeout must be positive, got %s", *delta.Timeout)
+	case delta.MaxRetries != nil && *delta.MaxRetries < 0:
+		return fmt.Errorf("reconfigure: MaxRetries must not be negative, got %d", *delta.MaxRetries)
+	case delta.WorkerTimeout != nil && *delta.WorkerTimeout < 0:
+		return fmt.Errorf("reconfigure: WorkerTimeout must not be negative, got %s", *delta.WorkerTimeout)
+	case delta.HeartbeatTimeout != nil && *delta.HeartbeatTimeout < 0:
+		return fmt.Errorf("reconfigure: HeartbeatTimeout must not be negative, got %s", *delta.HeartbeatTimeout)
+	case delta.MaxConcurrentCost != nil && *delta.MaxConcurrentCost < 0:
+		return fmt.Errorf("reconfigure: MaxConcurrentCost must not be negative, got %d", *delta.MaxConcurrentCost)
+	}
+
+	wp.mu.Lock()
+	now := time.Now()
+	var events []ConfigEvent
+	change := func(setting string, old, new any, deferred bool) {
+		events = append(events, ConfigEvent{
+			Time:     now,
+			Setting:  setting,
+			Old:      fmt.Sprint(old),
+			New:      fmt.Sprint(new),
+			Deferred: deferred,
+		})
+	}
+
+	if delta.NumWorkers != nil {
+		change("NumWorkers", wp.config.NumWorkers, *delta.NumWorkers, wp.running)
+		if wp.running {
+			wp.deferred.NumWorkers = delta.NumWorkers
+		} else {
+			wp.config.NumWorkers = *delta.NumWorkers
+		}
+	}
+	if delta.Timeout != nil {
+		change("Timeout", wp.config.Timeout, *delta.Timeout, wp.running)
+		if wp.running {
+			wp.deferred.Timeout = delta.Timeout
+		} else {
+			wp.config.Timeout = *delta.Timeout
+		}
+	}
+	if delta.MaxRetries != nil {
+		change("MaxRetries", wp.config.MaxRetries, *delta.MaxRetries, false)
+		wp.config.MaxRetries = *delta.MaxRetries
+	}
+	if delta.WorkerTimeout != nil {
+		change("WorkerTimeout", wp.config.WorkerTimeout, *delta.WorkerTimeout, false)
+		wp.config.WorkerTimeout = *delta.WorkerTimeout
+	}
+	if delta.HeartbeatTimeout != nil {
+		change("HeartbeatTimeout", wp.config.HeartbeatTimeout, *delta.HeartbeatTimeout, false)
+		wp.config.HeartbeatTimeout = *delta.HeartbeatTimeout
+	}
+	if delta.MaxConcurrentCost != nil {
+		change("MaxConcurrentCost", wp.config.MaxConcurrentCost, *delta.MaxConcurrentCost, false)
+		wp.config.MaxConcurrentCost = *delta.MaxConcurrentCost
+		wp.costs.resize(*delta.MaxConcurrentCost)
+	}
+
+	handler := wp.appendEventsLocked(events)
+	wp.mu.Unlock()
+
+	if handler != nil {
+		for _, event := range events {
+			handler(event)
+		}
+	}
+	return nil
+}
+
+// recordEvent logs a change the pool made itself and passes it to the
+// reconfigure handler
+func (wp *WorkerPool[T, R]) recordEvent(event ConfigEvent) {
+	wp.mu.Lock()
+	handler := wp.appendEventsLocked([]ConfigEvent{event})
+	wp.mu.Unlock()
+
+	if handler != nil {
+		handler(event)
+	}
+}
+
+// appendEventsLocked adds events to the log and returns the handler to call
+// with them once wp.mu is released. Callers must hold wp.mu.
+func (wp *WorkerPool[T, R]) appendEventsLocked(events []ConfigEvent) func(ConfigEvent) {
+	wp.configEvents = append(wp.configEvents, events...)
+	if over := len(wp.configEvents) - configEventLimit; over > 0 {
+		wp.configEvents = append([]ConfigEvent(nil), wp.configEvents[over:]...)
+	}
+	return wp.onReconfigure
+}
+
+// WithReconfigureHandler sets a function called with every ConfigEvent,
+// e.g. to log it
+func (wp *WorkerPool[T, R]) WithReconfigureHandler(handler func(ConfigEvent)) *WorkerPool[T, R] {
+	wp.mu.Lock()
+	defer wp.mu.Unlock()
+	wp.onReconfigure = handler
+	return wp
+}
+
+// ConfigEvents returns the most recent ConfigEvents, oldest first
+func (wp *WorkerPool[T, R]) ConfigEvents() []ConfigEvent {
+	wp.mu.RLock()
+	defer wp.mu.RUnlock()
+	return append([]ConfigEvent(nil), wp.configEvents...)
+}
+
+// applyDeferredLocked applies changes Reconfigure held back during a run.
+// Callers must hold wp.mu.
+func (wp *WorkerPool[T, R]) applyDeferredLocked() {
+	if wp.deferred.NumWorkers != nil {
+		wp.config.NumWorkers = *wp.deferred.NumWorkers
+	}
+	if wp.deferred.Timeout != nil {
+		wp.config.Timeout = *wp.deferred.Timeout
+	}
+	wp.deferred = ConfigDelta{}
+}
+
+// attemptPolicy returns the retry and timeout settings for a job of the
+// given priority starting now
+func (wp *WorkerPool[T, R]) attemptPolicy(priority int) attemptPolicy {
+	wp.mu.RLock()
+	defer wp.mu.RUnlock()
+	policy := attemptPolicy{
+		maxRetries: wp.config.MaxRetries,
+		backoff:    defaultRetryBackoff,
+		timeout:    wp.config.WorkerTimeout,
+		heartbeat:  wp.config.HeartbeatTimeout,
+	}
+	if band, ok := retryBand(wp.config.RetryBands, priority); ok {
+		policy.maxRetries, policy.backoff = band.MaxRetries, band.Backoff
+	}
+	return policy
+}
@@ -0,0 +1,102 @@
+package workerpool
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+func (ts *WorkerPoolTestSuite) TestReconfigureIdlePool() {
+	pool := New[int, int]()
+	var logged []ConfigEvent
+	pool.WithReconfigureHandler(func(e ConfigEvent) { logged = append(logged, e) })
+
+	workers, retries := 2, 0
+	ts.NoError(pool.Reconfigure(ConfigDelta{NumWorkers: &workers, MaxRetries: &retries}))
+	ts.Equal(2, pool.GetNumWorkers())
+	ts.Equal(2, pool.Health().Workers)
+
+	events := pool.ConfigEvents()
+	ts.Equal(logged, events)
+	ts.Require().Len(events, 2)
+	ts.Equal("NumWorkers", events[0].Setting)
+	ts.Equal("4", events[0].Old)
+	ts.Equal("2", events[0].New)
+	ts.False(events[0].Deferred)
+
+	// Retries are read when a job starts
+	pool.WithProcessor(func(ctx context.Context, job Job[int]) (int, error) {
+		return 0, errors.New("boom")
+	})
+	pool.AddJob(Job[int]{ID: "a"})
+	results, err := pool.Run()
+	ts.NoError(err)
+	ts.Equal(1, results[0].Attempts)
+
+	bad := 0
+	ts.Error(pool.Reconfigure(ConfigDelta{NumWorkers: &bad, MaxRetries: &retries}))
+	ts.Len(pool.ConfigEvents(), 2, "rejected deltas change nothing")
+}
+
+func (ts *WorkerPoolTestSuite) TestReconfigureDuringRunDefersWorkers() {
+	pool := New[int, int]()
+	started, release := make(chan struct{}), make(chan struct{})
+	pool.WithProcessor(func(ctx context.Context, job Job[int]) (int, error) {
+		close(started)
+		<-release
+		return job.Data, nil
+	})
+	pool.AddJob(Job[int]{ID: "a"})
+
+	done := make(chan error)
+	go func() {
+		_, err := pool.Run()
+		done <- err
+	}()
+	<-started
+
+	workers := 8
+	timeout := time.Minute
+	ts.NoError(pool.Reconfigure(ConfigDelta{NumWorkers: &workers, WorkerTimeout: &timeout}))
+	ts.Equal(4, pool.GetNumWorkers())
+	events := pool.ConfigEvents()
+	ts.True(events[0].Deferred)
+	ts.False(events[1].Deferred)
+
+	close(release)
+	ts.NoError(<-done)
+	ts.Equal(8, pool.GetNumWorkers())
+	ts.Equal(time.Minute, pool.RunReport().Config.WorkerTimeout)
+}
+
+func (ts *WorkerPoolTestSuite) TestCostLimiterResize() {
+	l := newCostLimiter(2)
+	held, err := l.acquire(context.Background(), 2)
+	ts.NoError(err)
+
+	granted := make(chan int, 1)
+	go func() {
+		held, _ := l.acquire(context.Background(), 2)
+		granted <- held
+	}()
+	time.Sleep(5 * time.Millisecond)
+	ts.Len(granted, 0)
+
+	l.resize(4)
+	ts.Equal(2, <-granted)
+	ts.Equal(4, l.inFlight())
+
+	// Lowering the cap clamps what new jobs hold; removing it admits everything
+	l.resize(1)
+	go func() {
+		held, _ := l.acquire(context.Background(), 3)
+		granted <- held
+	}()
+	time.Sleep(5 * time.Millisecond)
+	ts.Len(granted, 0)
+	l.resize(0)
+	ts.Equal(1, <-granted)
+
+	l.release(held)
+	ts.Equal(3, l.inFlight())
+}
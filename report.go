@@ -0,0 +1,305 @@
+package workerpool
+
+import (
+	"encoding/json"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+)
+
+// metricsJSON is the JSON form of Metrics
+type metricsJSON struct {
+	TotalJobs        int                      `json:"total_jobs"`
+	ProcessedJobs    int                      `json:"processed_jobs"`
+	FailedJobs       int                      `json:"failed_jobs"`
+	ExpiredJobs      int                      `json:"expired_jobs"`
+	LateCompletions  int                      `json:"late_completions"`
+	SkippedJobs      int                      `json:"skipped_jobs"`
+	TotalDuration    time.Duration            `json:"total_duration_ns"`
+	AverageDuration  time.Duration            `json:"average_duration_ns"`
+	StartTime        time.Time                `json:"start_time"`
+	EndTime          time.Time                `json:"end_time"`
+	Tenants          map[string]TenantMetrics `json:"tenants,omitempty"`
+	Stealing         StealStats               `json:"stealing"`
+	ErrorsReported   int                      `json:"errors_reported"`
+	ErrorsSuppressed int                      `json:"errors_suppressed"`
+	FailureCauses    map[string]int           `json:"failure_causes,omitempty"`
+	Counters         map[string]float64       `json:"counters,omitempty"`
+	StarvedJobs      int                      `json:"starved_jobs"`
+	Adaptive         AdaptiveStats            `json:"adaptive"`
+	CPUTime          time.Duration            `json:"cpu_time_ns"`
+	ClassCPUTime     map[string]time.Duration `json:"class_cpu_time_ns,omitempty"`
+	DroppedResults   int                      `json:"dropped_results"`
+	DuplicateResults int                      `json:"duplicate_results"`
+}
+
+// MarshalJSON encodes the metrics with snake_case keys and durations in
+// nanoseconds. It has a pointer receiver because Metrics holds a mutex, so
+// marshal a pointer, e.g. json.Marshal(&metrics).
+func (m *Metrics) MarshalJSON() ([]byte, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return json.Marshal(metricsJSON{
+		TotalJobs:        m.TotalJobs,
+		ProcessedJobs:    m.ProcessedJobs,
+		FailedJobs:       m.FailedJobs,
+		ExpiredJobs:      m.ExpiredJobs,
+		LateCompletions:  m.LateCompletions,
+		SkippedJobs:      m.SkippedJobs,
+		TotalDuration:    m.TotalDuration,
+		AverageDuration:  m.AverageDuration,
+		StartTime:        m.StartTime,
+		EndTime:          m.EndTime,
+		Tenants:          m.Tenants,
+		Stealing:         m.Stealing,
+		ErrorsReported:   m.ErrorsReported,
+		ErrorsSuppressed: m.ErrorsSuppressed,
+		FailureCauses:    m.FailureCauses,
+		Counters:         m.Counters,
+		StarvedJobs:      m.StarvedJobs,
+		Adaptive:         m.Adaptive,
+		CPUTime:          m.CPUTime,
+		ClassCPUTime:     m.ClassCPUTime,
+		DroppedResults:   m.DroppedResults,
+		DuplicateResults: m.DuplicateResults,
+	})
+}
+
+// UnmarshalJSON decodes metrics encoded by MarshalJSON, e.g. as served by
+// the admin API
+func (m *Metrics) UnmarshalJSON(data []byte) error {
+	var v metricsJSON
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.TotalJobs = v.TotalJobs
+	m.ProcessedJobs = v.ProcessedJobs
+	m.FailedJobs = v.FailedJobs
+	m.ExpiredJobs = v.ExpiredJobs
+	m.LateCompletions = v.LateCompletions
+	m.SkippedJobs = v.SkippedJobs
+	m.TotalDuration = v.TotalDuration
+	m.AverageDuration = v.AverageDuration
+	m.StartTime = v.StartTime
+	m.EndTime = v.EndTime
+	m.Tenants = v.Tenants
+	m.Stealing = v.Stealing
+	m.ErrorsReported = v.ErrorsReported
+	m.ErrorsSuppressed = v.ErrorsSuppressed
+	m.FailureCauses = v.FailureCauses
+	m.Counters = v.Counters
+	m.StarvedJobs = v.StarvedJobs
+	m.Adaptive = v.Adaptive
+	m.CPUTime = v.CPUTime
+	m.ClassCPUTime = v.ClassCPUTime
+	m.DroppedResults = v.DroppedResults
+	m.DuplicateResults = v.DuplicateResults
+	return nil
+}
+
+// ConfigSummary is the part of a Config recorded in a RunReport
+type ConfigSummary struct {
+	Name          string        `json:"name,omitempty"`
+	NumWorkers    int           `json:"num_workers"`
+	BufferSize    int           `json:"buffer_size"`
+	Strategy      string        `json:"strategy"`
+	Timeout       time.Duration `json:"timeout_ns"`
+	WorkerTimeout time.Duration `json:"worker_timeout_ns"`
+	MaxRetries    int           `json:"max_retries"`
+}
+
+// Percentiles summarizes a distribution of job durations
+type Percentiles struct {
+	P50 time.Duration `json:"p50_ns"`
+	P90 time.Duration `json:"p90_ns"`
+	P99 time.Duration `json:"p99_ns"`
+	Max time.Duration `json:"max_ns"`
+}
+
+// PriorityStats is the outcome of one priority's jobs in a run
+type PriorityStats struct {
+	Priority   int         `json:"priority"`
+	Jobs       int         `json:"jobs"`
+	Succeeded  int         `json:"succeeded"`
+	Failed     int         `json:"failed"`
+	Skipped    int         `json:"skipped"`    // Skipped or expired without running
+	Latency    Percentiles `json:"latency"`    // Durations of the jobs that ran
+	Completion Percentiles `json:"completion"` // Time from the start of the run until the jobs that ran completed
+}
+
+// RunReport summarizes the most recent run, for storing next to its output
+// and diffing against other runs
+type RunReport struct {
+	Config        ConfigSummary   `json:"config"`
+	Metrics       *Metrics        `json:"metrics"`
+	Latency       Percentiles     `json:"latency"`        // Durations of every job that ran
+	Priorities    []PriorityStats `json:"priorities"`     // Highest priority first
+	Inversions    int             `json:"inversions"`     // Pairs of jobs that ran where the lower priority one completed first
+	FailureGroups []FailureCause  `json:"failure_groups"` // Most frequent first
+}
+
+// RunReport returns a summary of the pool's most recent run. Metrics cover
+// every run of the pool, as GetMetrics does; latency, per-priority stats and inversions cover
+// the latest. Per-priority completion times and the inversion count show
+// whether important jobs really finished first, e.g. under PriorityBased.
+func (wp *WorkerPool[T, R]) RunReport() RunReport {
+	metrics := wp.GetMetrics()
+	wp.mu.RLock()
+	report := RunReport{
+		Config: ConfigSummary{
+			Name:          wp.config.Name,
+			NumWorkers:    wp.config.NumWorkers,
+			BufferSize:    wp.config.BufferSize,
+			Strategy:      wp.config.Strategy.String(),
+			Timeout:       wp.config.Timeout,
+			WorkerTimeout: wp.config.WorkerTimeout,
+			MaxRetries:    wp.config.MaxRetries,
+		},
+		Metrics:       &metrics,
+		FailureGroups: metrics.TopFailureCauses(),
+	}
+	wp.mu.RUnlock()
+	report.Latency, report.Priorities = wp.lastRun.Load().summary()
+	report.Inversions = wp.lastRun.Load().inversions()
+	return report
+}
+
+// runLog records job outcomes by priority during a run
+type runLog struct {
+	start      time.Time
+	priorities map[string]int // Job priority by ID
+	byPriority map[int]*priorityLog
+	completed  []completion // Jobs that ran, in the order the collector saw them
+	mu         sync.Mutex
+}
+
+// priorityLog is what a runLog knows about one priority
+type priorityLog struct {
+	succeeded, failed, skipped int
+	durations                  []time.Duration
+	completions                []time.Duration // Since the start of the run
+}
+
+// completion is when a job of some priority completed
+type completion struct {
+	priority int
+	at       time.Time
+}
+
+// newRunLog creates a log of a run starting now, attributing results to
+// the given job priorities
+func newRunLog(priorities map[string]int) *runLog {
+	return &runLog{start: time.Now(), priorities: priorities, byPriority: make(map[int]*priorityLog)}
+}
+
+// jobPriorities adds the priority of every job to priorities, creating the
+// map if it is nil
+func jobPriorities[T any](jobs []Job[T], priorities map[string]int) map[string]int {
+	if priorities == nil {
+		priorities = make(map[string]int, len(jobs))
+	}
+	for _, job := range jobs {
+		priorities[job.ID] = job.Priority
+	}
+	return priorities
+}
+
+// record adds a job's outcome to the log
+func (l *runLog) record(jobID string, err error, duration time.Duration, completed time.Time) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	priority := l.priorities[jobID]
+	p := l.byPriority[priority]
+	if p == nil {
+		p = &priorityLog{}
+		l.byPriority[priority] = p
+	}
+	switch {
+	case isSkip(err) || errors.Is(err, ErrJobExpired):
+		p.skipped++
+		return
+	case err != nil:
+		p.failed++
+	default:
+		p.succeeded++
+	}
+	p.durations = append(p.durations, duration)
+	p.completions = append(p.completions, completed.Sub(l.start))
+	l.completed = append(l.completed, completion{priority, completed})
+}
+
+// summary returns the overall and per-priority latency of the run
+func (l *runLog) summary() (Percentiles, []PriorityStats) {
+	if l == nil {
+		return Percentiles{}, nil
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	var all []time.Duration
+	stats := make([]PriorityStats, 0, len(l.byPriority))
+	for priority, p := range l.byPriority {
+		all = append(all, p.durations...)
+		stats = append(stats, PriorityStats{
+			Priority:   priority,
+			Jobs:       p.succeeded + p.failed + p.skipped,
+			Succeeded:  p.succeeded,
+			Failed:     p.failed,
+			Skipped:    p.skipped,
+			Latency:    percentilesOf(p.durations),
+			Completion: percentilesOf(p.completions),
+		})
+	}
+	sort.Slice(stats, func(i, j int) bool { return stats[i].Priority > stats[j].Priority })
+	return percentilesOf(all), stats
+}
+
+// inversions counts the pairs of jobs that ran where the job with the lower
+// priority completed before the one with the higher priority
+func (l *runLog) inversions() int {
+	if l == nil {
+		return 0
+	}
+	l.mu.Lock()
+	completed := append([]completion(nil), l.completed...)
+	l.mu.Unlock()
+	sort.SliceStable(completed, func(i, j int) bool { return completed[i].at.Before(completed[j].at) })
+
+	// Rank the priorities, then count for every job the lower priority jobs
+	// completed before it with a Fenwick tree over the ranks
+	var ranks []int
+	for _, c := range completed {
+		ranks = append(ranks, c.priority)
+	}
+	sort.Ints(ranks)
+	tree := make([]int, len(ranks)+1)
+	inversions := 0
+	for _, c := range completed {
+		rank := sort.SearchInts(ranks, c.priority) // Jobs ranked below have a lower priority
+		for i := rank; i > 0; i -= i & -i {
+			inversions += tree[i]
+		}
+		for i := rank + 1; i < len(tree); i += i & -i {
+			tree[i]++
+		}
+	}
+	return inversions
+}
+
+// percentilesOf summarizes unsorted durations
+func percentilesOf(durations []time.Duration) Percentiles {
+	sorted := append([]time.Duration(nil), durations...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	return Percentiles{
+		P50: percentile(sorted, 0.50),
+		P90: percentile(sorted, 0.90),
+		P99: percentile(sorted, 0.99),
+		Max: percentile(sorted, 1),
+	}
+}
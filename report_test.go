@@ -0,0 +1,152 @@
+package workerpool
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+func (ts *WorkerPoolTestSuite) TestMetricsMarshalJSON() {
+	pool := New[int, int]()
+	pool.WithProcessor(func(ctx context.Context, job Job[int]) (int, error) {
+		ResultMetaFromContext(ctx).Add("rows", 2)
+		return job.Data, nil
+	})
+	pool.AddJobs([]Job[int]{{ID: "a", Data: 1}, {ID: "b", Data: 2}})
+	_, err := pool.Run()
+	ts.NoError(err)
+
+	metrics := pool.GetMetrics()
+	data, err := json.Marshal(&metrics)
+	ts.NoError(err)
+
+	var decoded map[string]any
+	ts.NoError(json.Unmarshal(data, &decoded))
+	ts.Equal(2.0, decoded["processed_jobs"])
+	ts.Equal(map[string]any{"rows": 4.0}, decoded["counters"])
+	ts.Contains(decoded, "total_duration_ns")
+	ts.NotContains(decoded, "failure_causes")
+}
+
+func (ts *WorkerPoolTestSuite) TestRunReport() {
+	config := DefaultConfig()
+	config.Name = "nightly"
+	config.MaxRetries = 0
+	pool := NewWithConfig[int, int](config)
+	pool.WithProcessor(func(ctx context.Context, job Job[int]) (int, error) {
+		if job.Data%3 == 0 {
+			return 0, fmt.Errorf("row %d rejected", job.Data)
+		}
+		time.Sleep(time.Duration(job.Data) * time.Millisecond)
+		return job.Data, nil
+	})
+
+	var jobs []Job[int]
+	for i := 1; i <= 9; i++ {
+		jobs = append(jobs, Job[int]{ID: fmt.Sprintf("%d", i), Data: i, Priority: i % 2 * 10})
+	}
+	pool.AddJobs(jobs)
+	_, err := pool.Run()
+	ts.NoError(err)
+
+	report := pool.RunReport()
+	ts.Equal("nightly", report.Config.Name)
+	ts.Equal("round_robin", report.Config.Strategy)
+	ts.Equal([]FailureCause{{Fingerprint: "row <n> rejected", Count: 3}}, report.FailureGroups)
+
+	ts.Require().Len(report.Priorities, 2)
+	high, low := report.Priorities[0], report.Priorities[1]
+	ts.Equal(10, high.Priority)
+	ts.Equal(5, high.Jobs) // 1, 3, 5, 7, 9
+	ts.Equal(2, high.Failed)
+	ts.Equal(0, low.Priority)
+	ts.Equal(4, low.Jobs) // 2, 4, 6, 8
+	ts.Equal(1, low.Failed)
+	ts.GreaterOrEqual(high.Latency.Max, 7*time.Millisecond) // Job 9 fails, 7 is the slowest success
+	ts.GreaterOrEqual(report.Latency.P99, report.Latency.P50)
+
+	data, err := json.Marshal(report)
+	ts.NoError(err)
+	ts.Contains(string(data), `"failure_groups":[{"fingerprint":"row \u003cn\u003e rejected","count":3}]`)
+	ts.Contains(string(data), `"failed_jobs":3`)
+}
+
+func (ts *WorkerPoolTestSuite) TestRunReportCountsSkippedJobs() {
+	pool := New[int, int]()
+	pool.WithProcessor(func(ctx context.Context, job Job[int]) (int, error) {
+		return 0, errors.New("boom")
+	})
+	pool.AddJobs([]Job[int]{
+		{ID: "a", Priority: 1},
+		{ID: "b", Priority: 1, Dependencies: []string{"a"}},
+	})
+	_, err := pool.Run()
+	ts.NoError(err)
+
+	report := pool.RunReport()
+	ts.Equal([]PriorityStats{{
+		Priority: 1,
+		Jobs:     2,
+		Failed:   1,
+		Skipped:  1,
+		Latency:  report.Priorities[0].Latency,
+
+		Completion: report.Priorities[0].Completion,
+	}}, report.Priorities)
+}
+
+func (ts *WorkerPoolTestSuite) TestRunReportPriorityCompletion() {
+	for _, strategy := range []DistributionStrategy{RoundRobin, PriorityBased} {
+		config := DefaultConfig()
+		config.NumWorkers = 1
+		config.Strategy = strategy
+		pool := NewWithConfig[int, int](config)
+		pool.WithProcessor(func(ctx context.Context, job Job[int]) (int, error) {
+			time.Sleep(2 * time.Millisecond)
+			return job.Data, nil
+		})
+
+		// Submitted low priority first, so only PriorityBased reorders them
+		var jobs []Job[int]
+		for i := 0; i < 3; i++ {
+			jobs = append(jobs, Job[int]{ID: fmt.Sprint("low", i), Priority: 1})
+		}
+		for i := 0; i < 2; i++ {
+			jobs = append(jobs, Job[int]{ID: fmt.Sprint("high", i), Priority: 5})
+		}
+		pool.AddJobs(jobs)
+		_, err := pool.Run()
+		ts.NoError(err)
+
+		report := pool.RunReport()
+		ts.Require().Len(report.Priorities, 2)
+		high, low := report.Priorities[0], report.Priorities[1]
+		ts.Greater(high.Completion.Max, time.Duration(0))
+		if strategy == PriorityBased {
+			ts.Zero(report.Inversions)
+			ts.Less(high.Completion.Max, low.Completion.P50)
+		} else {
+			ts.Equal(6, report.Inversions, "each high priority job completed after all three low ones")
+			ts.Greater(high.Completion.P50, low.Completion.Max)
+		}
+	}
+}
+
+func (ts *WorkerPoolTestSuite) TestRunLogInversions() {
+	log := newRunLog(map[string]int{"a": 3, "b": 1, "c": 2, "d": 3, "e": 1})
+	at := log.start
+	for i, id := range []string{"b", "c", "a", "e", "d"} {
+		log.record(id, nil, time.Millisecond, at.Add(time.Duration(i)*time.Millisecond))
+	}
+	// c after b; a after b and c; d after b, c and e
+	ts.Equal(6, log.inversions())
+	ts.Zero((*runLog)(nil).inversions())
+}
+
+func (ts *WorkerPoolTestSuite) TestRunReportBeforeRun() {
+	report := New[int, int]().RunReport()
+	ts.Empty(report.Priorities)
+	ts.Equal(Percentiles{}, report.Latency)
+}
@@ -0,0 +1,47 @@
+package workerpool
+
+// Reprioritize changes the priority of a pending job. While a PriorityBased run
+// is dispatching, the live priority queue is updated so the change takes effect
+// immediately; otherwise the queued job is updated for the next run.
+// It reports whether a pending job with the given ID was found.
+func (wp *WorkerPool[T, R]) Reprioritize(jobID string, newPriority int) bool {
+	return wp.updatePending(func(job Job[T]) bool {
+		return job.ID == jobID
+	}, func(job *Job[T]) {
+		job.Priority = newPriority
+	}) > 0
+}
+
+// BumpPriority adds delta to the priority of every pending job matching the
+// predicate and returns how many jobs were adjusted.
+func (wp *WorkerPool[T, R]) BumpPriority(predicate func(Job[T]) bool, delta int) int {
+	return wp.updatePending(predicate, func(job *Job[T]) {
+		job.Priority += delta
+	})
+}
+
+// updatePending applies fn to pending jobs matching the predicate
+func (wp *WorkerPool[T, R]) updatePending(match func(Job[T]) bool, fn func(*Job[T])) int {
+	wp.mu.Lock()
+	defer wp.mu.Unlock()
+
+	if wp.pending != nil {
+		wp.pending.update(match, fn)
+	}
+	if wp.queue != nil {
+		return updateQueue(wp.queue, match, fn)
+	}
+	if wp.running {
+		// Other strategies hand jobs to workers up front, so nothing is pending
+		return 0
+	}
+
+	updated := 0
+	for i := range wp.jobs {
+		if match(wp.jobs[i]) {
+			fn(&wp.jobs[i])
+			updated++
+		}
+	}
+	return updated
+}
@@ -0,0 +1,93 @@
+package workerpool
+
+import (
+	"context"
+	"strings"
+	"sync"
+)
+
+func (ts *WorkerPoolTestSuite) TestPriorityQueueUpdate() {
+	pq := NewPriorityQueue[string]()
+	pq.Push(Job[string]{ID: "a", Priority: 5})
+	pq.Push(Job[string]{ID: "b", Priority: 3})
+	pq.Push(Job[string]{ID: "c", Priority: 1})
+
+	updated := pq.Update(func(job Job[string]) bool {
+		return job.ID == "c"
+	}, func(job *Job[string]) {
+		job.Priority = 10
+	})
+	ts.Equal(1, updated)
+
+	job, ok := pq.Pop()
+	ts.True(ok)
+	ts.Equal("c", job.ID)
+	ts.Equal(0, pq.GetFairnessStats()[1])
+}
+
+func (ts *WorkerPoolTestSuite) TestReprioritizeBeforeRun() {
+	pool := New[string, string]()
+	pool.AddJobs([]Job[string]{
+		{ID: "1", Data: "a", Priority: 1},
+		{ID: "2", Data: "b", Priority: 1, TenantID: "acme"},
+		{ID: "3", Data: "c", Priority: 1, TenantID: "acme"},
+	})
+
+	ts.True(pool.Reprioritize("1", 9))
+	ts.False(pool.Reprioritize("missing", 9))
+	ts.Equal(2, pool.BumpPriority(func(job Job[string]) bool {
+		return job.TenantID == "acme"
+	}, 3))
+
+	ts.Equal(9, pool.jobs[0].Priority)
+	ts.Equal(4, pool.jobs[1].Priority)
+	ts.Equal(4, pool.jobs[2].Priority)
+}
+
+func (ts *WorkerPoolTestSuite) TestReprioritizeWhileRunning() {
+	config := DefaultConfig()
+	config.NumWorkers = 1
+	config.Strategy = PriorityBased
+	pool := NewWithConfig[string, string](config)
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	var once sync.Once
+	var mu sync.Mutex
+	var order []string
+
+	pool.WithProcessor(func(ctx context.Context, job Job[string]) (string, error) {
+		if job.ID == "first" {
+			once.Do(func() { close(started) })
+			<-release
+		}
+		mu.Lock()
+		order = append(order, job.ID)
+		mu.Unlock()
+		return strings.ToUpper(job.Data), nil
+	})
+
+	pool.AddJobs([]Job[string]{
+		{ID: "first", Data: "a", Priority: 10},
+		{ID: "low", Data: "b", Priority: 2},
+		{ID: "mid", Data: "c", Priority: 5},
+		{ID: "boosted", Data: "d", Priority: 1},
+	})
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		results, err := pool.Run()
+		ts.NoError(err)
+		ts.Len(results, 4)
+	}()
+
+	<-started
+	// The dispatcher may already hold the next job; boost one that cannot be held yet
+	ts.True(pool.Reprioritize("boosted", 20))
+	close(release)
+	<-done
+
+	ts.Equal("first", order[0])
+	ts.Equal("low", order[len(order)-1])
+}
@@ -0,0 +1,107 @@
+package workerpool
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// ErrJobNotFound is returned when no failed job matches a requeue request
+var ErrJobNotFound = errors.New("job not found")
+
+// Requeue puts a job whose last run failed back into the pending queue. The
+// job keeps its accumulated Attempts. During a PriorityBased run the job joins
+// the live priority queue; during other runs it is queued once the run ends.
+func (wp *WorkerPool[T, R]) Requeue(jobID string) error {
+	wp.mu.Lock()
+	defer wp.mu.Unlock()
+
+	job, ok := wp.failed[jobID]
+	if !ok {
+		return fmt.Errorf("requeue %s: %w", jobID, ErrJobNotFound)
+	}
+	if err := wp.requeueLocked(job); err != nil {
+		return fmt.Errorf("requeue %s: %w", jobID, err)
+	}
+	return nil
+}
+
+// RequeueWhere requeues every failed job matching the predicate and returns
+// how many were put back into the pending queue.
+func (wp *WorkerPool[T, R]) RequeueWhere(predicate func(Job[T]) bool) int {
+	wp.mu.Lock()
+	defer wp.mu.Unlock()
+
+	requeued := 0
+	for _, job := range wp.failed {
+		if predicate(job) && wp.requeueLocked(job) == nil {
+			requeued++
+		}
+	}
+	return requeued
+}
+
+// FailedJobs lists the jobs whose last run failed and that can be requeued,
+// ordered by ID
+func (wp *WorkerPool[T, R]) FailedJobs() []JobSummary {
+	wp.mu.RLock()
+	summaries := make([]JobSummary, 0, len(wp.failed))
+	for _, job := range wp.failed {
+		summaries = append(summaries, summarize(job))
+	}
+	wp.mu.RUnlock()
+
+	sort.Slice(summaries, func(i, j int) bool { return summaries[i].ID < summaries[j].ID })
+	return summaries
+}
+
+// requeueLocked moves a failed job back to pending. Callers must hold wp.mu.
+func (wp *WorkerPool[T, R]) requeueLocked(job Job[T]) error {
+	switch {
+	case wp.queue != nil:
+		if err := wp.tenants.admit(job.TenantID); err != nil {
+			return err
+		}
+		wp.pending.add(job)
+		wp.guard.expect(job.ID)
+		wp.queue.Push(job)
+		wp.metrics.mu.Lock()
+		wp.metrics.TotalJobs++
+		wp.metrics.mu.Unlock()
+	case wp.running:
+		wp.requeued = append(wp.requeued, job)
+	default:
+		wp.upsertJob(job)
+	}
+	delete(wp.failed, job.ID)
+	return nil
+}
+
+// recordFailure remembers a failed job so it can be requeued later
+func (wp *WorkerPool[T, R]) recordFailure(job Job[T]) {
+	wp.mu.Lock()
+	defer wp.mu.Unlock()
+	job.redelivered = false
+	wp.failed[job.ID] = job
+}
+
+// clearFailure forgets a job's earlier failure once it succeeds, so it is
+// no longer listed by FailedJobs or offered for replay
+func (wp *WorkerPool[T, R]) clearFailure(jobID string) {
+	wp.mu.Lock()
+	defer wp.mu.Unlock()
+	delete(wp.failed, jobID)
+}
+
+// upsertJob replaces the pending job with the same ID or appends it.
+// Callers must hold wp.mu.
+func (wp *WorkerPool[T, R]) upsertJob(job Job[T]) {
+	for i := range wp.jobs {
+		if wp.jobs[i].ID == job.ID {
+			wp.jobs[i] = job
+			return
+		}
+	}
+	wp.jobs = append(wp.jobs, job)
+	wp.metrics.TotalJobs = len(wp.jobs)
+}
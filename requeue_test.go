@@ -0,0 +1,121 @@
+package workerpool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+)
+
+func (ts *WorkerPoolTestSuite) TestRequeueFailedJob() {
+	config := DefaultConfig()
+	config.MaxRetries = 1
+	pool := NewWithConfig[string, string](config)
+
+	var fail atomic.Bool
+	fail.Store(true)
+	pool.WithProcessor(func(ctx context.Context, job Job[string]) (string, error) {
+		if job.ID == "flaky" && fail.Load() {
+			return "", fmt.Errorf("downstream unavailable")
+		}
+		return job.Data, nil
+	})
+	pool.AddJobs([]Job[string]{{ID: "ok", Data: "a"}, {ID: "flaky", Data: "b"}})
+
+	_, err := pool.Run()
+	ts.NoError(err)
+
+	ts.True(errors.Is(pool.Requeue("ok"), ErrJobNotFound))
+	ts.NoError(pool.Requeue("flaky"))
+	ts.True(errors.Is(pool.Requeue("flaky"), ErrJobNotFound))
+	ts.Equal(2, pool.jobs[1].Attempts)
+
+	fail.Store(false)
+	results, err := pool.Run()
+	ts.NoError(err)
+	for _, result := range results {
+		ts.NoError(result.Error)
+	}
+}
+
+func (ts *WorkerPoolTestSuite) TestRequeueWhileRunning() {
+	config := DefaultConfig()
+	config.NumWorkers = 1
+	config.MaxRetries = 0
+	config.Strategy = PriorityBased
+	pool := NewWithConfig[string, string](config)
+
+	var badRuns atomic.Int32
+	pool.WithProcessor(func(ctx context.Context, job Job[string]) (string, error) {
+		switch job.ID {
+		case "bad":
+			if badRuns.Add(1) == 1 {
+				return "", fmt.Errorf("transient")
+			}
+		case "trigger":
+			ts.Equal(1, pool.RequeueWhere(func(j Job[string]) bool { return j.ID == "bad" }))
+		}
+		return job.Data, nil
+	})
+	pool.AddJobs([]Job[string]{
+		{ID: "bad", Data: "a", Priority: 10},
+		{ID: "trigger", Data: "b", Priority: 5},
+		{ID: "tail", Data: "c", Priority: 1},
+	})
+
+	// Metrics are read while the requeue counts the job
+	done := make(chan struct{})
+	polled := make(chan struct{})
+	go func() {
+		defer close(polled)
+		for {
+			select {
+			case <-done:
+				return
+			default:
+				pool.GetMetrics()
+			}
+		}
+	}()
+	results, err := pool.Run()
+	close(done)
+	<-polled
+	ts.NoError(err)
+	ts.Len(results, 4)
+	ts.Equal(int32(2), badRuns.Load())
+
+	badResults := 0
+	for _, result := range results {
+		if result.JobID == "bad" {
+			badResults++
+		}
+	}
+	ts.Equal(2, badResults)
+}
+
+func (ts *WorkerPoolTestSuite) TestSucceededJobLeavesFailedJobs() {
+	config := DefaultConfig()
+	config.MaxRetries = 0
+	pool := NewWithConfig[string, string](config)
+
+	var fail atomic.Bool
+	fail.Store(true)
+	pool.WithProcessor(func(ctx context.Context, job Job[string]) (string, error) {
+		if fail.Load() {
+			return "", fmt.Errorf("downstream unavailable")
+		}
+		return job.Data, nil
+	})
+	pool.AddJob(Job[string]{ID: "flaky", Data: "b"})
+
+	_, err := pool.Run()
+	ts.NoError(err)
+	ts.Len(pool.FailedJobs(), 1)
+
+	// The next run of the batch succeeds, so there is nothing left to replay
+	fail.Store(false)
+	_, err = pool.Run()
+	ts.NoError(err)
+	ts.Empty(pool.FailedJobs())
+	ts.True(errors.Is(pool.Requeue("flaky"), ErrJobNotFound))
+}
@@ -0,0 +1,61 @@
+package workerpool
+
+import (
+	"context"
+	"maps"
+	"sync"
+)
+
+// resultMetaKey is the context key under which the ResultMeta is stored
+type resultMetaKey struct{}
+
+// ResultMeta collects annotations a processor attaches to its job's result,
+// such as a cache hit label or a bytes-processed counter. Labels and
+// counters surface on Result; counters are also summed into
+// Metrics.Counters. A job keeps one ResultMeta across its retries. All
+// methods are safe to call on a nil *ResultMeta.
+type ResultMeta struct {
+	labels   map[string]string
+	counters map[string]float64
+	mu       sync.Mutex
+}
+
+// ResultMetaFromContext returns the annotations of the job ctx belongs to,
+// or nil if ctx was not created by a pool
+func ResultMetaFromContext(ctx context.Context) *ResultMeta {
+	m, _ := ctx.Value(resultMetaKey{}).(*ResultMeta)
+	return m
+}
+
+// Set records a label on the result, replacing any earlier value
+func (m *ResultMeta) Set(key, value string) {
+	if m == nil {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.labels == nil {
+		m.labels = make(map[string]string)
+	}
+	m.labels[key] = value
+}
+
+// Add adds delta to a counter on the result
+func (m *ResultMeta) Add(key string, delta float64) {
+	if m == nil {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.counters == nil {
+		m.counters = make(map[string]float64)
+	}
+	m.counters[key] += delta
+}
+
+// snapshot returns copies of the labels and counters
+func (m *ResultMeta) snapshot() (map[string]string, map[string]float64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return maps.Clone(m.labels), maps.Clone(m.counters)
+}
@@ -0,0 +1,74 @@
+package workerpool
+
+import (
+	"context"
+	"fmt"
+)
+
+func (ts *WorkerPoolTestSuite) TestResultMetaAnnotatesResults() {
+	pool := New[int, int]()
+	pool.WithProcessor(func(ctx context.Context, job Job[int]) (int, error) {
+		meta := ResultMetaFromContext(ctx)
+		meta.Add("bytes", float64(job.Data))
+		if job.Data%2 == 0 {
+			meta.Set("cache", "hit")
+		} else {
+			meta.Set("cache", "miss")
+			meta.Add("cache_misses", 1)
+		}
+		return job.Data, nil
+	})
+
+	var jobs []Job[int]
+	for i := 1; i <= 10; i++ {
+		jobs = append(jobs, Job[int]{ID: fmt.Sprintf("%d", i), Data: i})
+	}
+	pool.AddJobs(jobs)
+	results, err := pool.Run()
+	ts.NoError(err)
+
+	for _, r := range results {
+		ts.Equal(float64(r.Data), r.Counters["bytes"])
+		if r.Data%2 == 0 {
+			ts.Equal("hit", r.Labels["cache"])
+		} else {
+			ts.Equal("miss", r.Labels["cache"])
+		}
+	}
+
+	m := pool.GetMetrics()
+	ts.Equal(map[string]float64{"bytes": 55, "cache_misses": 5}, m.Counters)
+}
+
+func (ts *WorkerPoolTestSuite) TestResultMetaKeptAcrossRetries() {
+	config := DefaultConfig()
+	config.MaxRetries = 2
+	pool := NewWithConfig[int, int](config)
+	pool.WithProcessor(func(ctx context.Context, job Job[int]) (int, error) {
+		meta := ResultMetaFromContext(ctx)
+		meta.Add("calls", 1)
+		if meta.snapshotCount("calls") < 2 {
+			return 0, fmt.Errorf("transient")
+		}
+		return job.Data, nil
+	})
+
+	pool.AddJobs([]Job[int]{{ID: "a", Data: 1}})
+	results, err := pool.Run()
+	ts.NoError(err)
+	ts.Require().Len(results, 1)
+	ts.Equal(float64(2), results[0].Counters["calls"])
+}
+
+func (ts *WorkerPoolTestSuite) TestResultMetaNilSafe() {
+	meta := ResultMetaFromContext(context.Background())
+	ts.Nil(meta)
+	meta.Set("k", "v")
+	meta.Add("n", 1)
+}
+
+// snapshotCount reads one counter for tests
+func (m *ResultMeta) snapshotCount(key string) float64 {
+	_, counters := m.snapshot()
+	return counters[key]
+}
@@ -0,0 +1,120 @@
+package workerpool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RetryAfterError asks the pool to wait Delay before retrying the job,
+// instead of the usual backoff. Processors calling rate-limited APIs return
+// it with the server's Retry-After; see ParseRetryAfter.
+type RetryAfterError struct {
+	Delay time.Duration
+	Err   error // The underlying failure; may be nil
+}
+
+// Error implements the error interface
+func (e *RetryAfterError) Error() string {
+	if e.Err == nil {
+		return fmt.Sprintf("retry after %v", e.Delay)
+	}
+	return fmt.Sprintf("%v (retry after %v)", e.Err, e.Delay)
+}
+
+// Unwrap returns the underlying failure
+func (e *RetryAfterError) Unwrap() error {
+	return e.Err
+}
+
+// ParseRetryAfter parses a Retry-After header value, either delay-seconds
+// or an HTTP date, into a delay from now. Dates in the past yield zero.
+func ParseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, false
+	}
+	if secs, err := strconv.Atoi(value); err == nil {
+		if secs < 0 {
+			return 0, false
+		}
+		return time.Duration(secs) * time.Second, true
+	}
+	at, err := http.ParseTime(value)
+	if err != nil {
+		return 0, false
+	}
+	if delay := at.Sub(now); delay > 0 {
+		return delay, true
+	}
+	return 0, true
+}
+
+// RetryAfterFromResponse wraps err in a RetryAfterError when resp carries a
+// valid Retry-After header, and returns err unchanged otherwise
+func RetryAfterFromResponse(resp *http.Response, err error) error {
+	if resp == nil {
+		return err
+	}
+	delay, ok := ParseRetryAfter(resp.Header.Get("Retry-After"), time.Now())
+	if !ok {
+		return err
+	}
+	return &RetryAfterError{Delay: delay, Err: err}
+}
+
+// defaultRetryBackoff is the wait before the first retry; every further
+// retry waits that much longer
+const defaultRetryBackoff = 100 * time.Millisecond
+
+// RetryBand is the retry policy of jobs with Priority >= MinPriority, in
+// Config.RetryBands. A job belongs to the band with the highest MinPriority
+// it reaches, so bands can be listed in any order.
+type RetryBand struct {
+	MinPriority int
+	MaxRetries  int           // Retry attempts allowed after the first; replaces Config.MaxRetries
+	Backoff     time.Duration // Wait before the first retry, growing by as much with every retry; zero retries immediately
+}
+
+// retryBand returns the band a job of priority belongs to, if any
+func retryBand(bands []RetryBand, priority int) (RetryBand, bool) {
+	var band RetryBand
+	found := false
+	for _, b := range bands {
+		if priority >= b.MinPriority && (!found || b.MinPriority > band.MinPriority) {
+			band, found = b, true
+		}
+	}
+	return band, found
+}
+
+// backoffDelay returns how long to wait before the retry following attempt
+// (zero-based) when the wait grows by base with every retry, honoring a
+// RetryAfterError in err
+func backoffDelay(attempt int, err error, base time.Duration) time.Duration {
+	var retryAfter *RetryAfterError
+	if errors.As(err, &retryAfter) {
+		return retryAfter.Delay
+	}
+	return time.Duration(attempt+1) * base
+}
+
+// sleepContext waits for d, returning early with the cancellation error if
+// ctx is done first
+func sleepContext(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return cancellationError(ctx)
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return cancellationError(ctx)
+	}
+}
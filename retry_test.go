@@ -0,0 +1,177 @@
+package workerpool
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+func (ts *WorkerPoolTestSuite) TestRetryAfterErrorDelaysRetry() {
+	config := DefaultConfig()
+	config.MaxRetries = 1
+	pool := NewWithConfig[int, int](config)
+
+	var calls []time.Time
+	pool.WithProcessor(func(ctx context.Context, job Job[int]) (int, error) {
+		calls = append(calls, time.Now())
+		if len(calls) == 1 {
+			return 0, &RetryAfterError{Delay: 250 * time.Millisecond, Err: errors.New("429 too many requests")}
+		}
+		return job.Data, nil
+	})
+
+	pool.AddJobs([]Job[int]{{ID: "a", Data: 1}})
+	results, err := pool.Run()
+	ts.NoError(err)
+	ts.Require().Len(results, 1)
+	ts.NoError(results[0].Error)
+	ts.Require().Len(calls, 2)
+	ts.GreaterOrEqual(calls[1].Sub(calls[0]), 250*time.Millisecond)
+
+	var retryAfter *RetryAfterError
+	ts.ErrorAs(results[0].AttemptErrors[0], &retryAfter)
+	ts.EqualError(retryAfter, "429 too many requests (retry after 250ms)")
+}
+
+func (ts *WorkerPoolTestSuite) TestStopInterruptsRetryBackoff() {
+	config := DefaultConfig()
+	config.NumWorkers = 1
+	config.MaxRetries = 3
+	pool := NewWithConfig[int, int](config)
+
+	failed := make(chan struct{}, 1)
+	var calls atomic.Int32
+	pool.WithProcessor(func(ctx context.Context, job Job[int]) (int, error) {
+		calls.Add(1)
+		failed <- struct{}{}
+		return 0, &RetryAfterError{Delay: time.Minute, Err: errors.New("busy")}
+	})
+	pool.AddJobs([]Job[int]{{ID: "a"}})
+
+	var results []Result[int]
+	done := make(chan error)
+	go func() {
+		var err error
+		results, err = pool.Run()
+		done <- err
+	}()
+	<-failed
+	start := time.Now()
+	pool.Stop()
+
+	select {
+	case err := <-done:
+		ts.ErrorIs(err, ErrPoolStopped)
+	case <-time.After(5 * time.Second):
+		ts.FailNow("Stop did not interrupt the retry backoff")
+	}
+	ts.Less(time.Since(start), time.Second)
+	ts.Nil(results)
+	ts.Equal(int32(1), calls.Load(), "the job was not retried after Stop")
+}
+
+func (ts *WorkerPoolTestSuite) TestSleepContext() {
+	ts.NoError(sleepContext(context.Background(), time.Millisecond))
+	ts.NoError(sleepContext(context.Background(), 0))
+
+	ctx, cancel := context.WithCancelCause(context.Background())
+	cancel(ErrPoolStopped)
+	start := time.Now()
+	err := sleepContext(ctx, time.Minute)
+	ts.ErrorIs(err, context.Canceled)
+	ts.ErrorIs(err, ErrPoolStopped)
+	ts.Less(time.Since(start), time.Second)
+	ts.ErrorIs(sleepContext(ctx, 0), ErrPoolStopped)
+
+	ctx, cancel2 := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel2()
+	ts.ErrorIs(sleepContext(ctx, time.Minute), context.DeadlineExceeded)
+}
+
+func (ts *WorkerPoolTestSuite) TestRetryBands() {
+	config := DefaultConfig()
+	config.MaxRetries = 0
+	config.RetryBands = []RetryBand{
+		{MinPriority: 1, MaxRetries: 2, Backoff: 30 * time.Millisecond},
+		{MinPriority: 10, MaxRetries: 5},
+	}
+	pool := NewWithConfig[int, int](config)
+
+	var mu sync.Mutex
+	calls := make(map[string][]time.Time)
+	pool.WithProcessor(func(ctx context.Context, job Job[int]) (int, error) {
+		mu.Lock()
+		calls[job.ID] = append(calls[job.ID], time.Now())
+		mu.Unlock()
+		return 0, errors.New("unavailable")
+	})
+	pool.AddJobs([]Job[int]{
+		{ID: "high", Priority: 50},
+		{ID: "low", Priority: 3},
+		{ID: "none", Priority: 0},
+	})
+	results, err := pool.Run()
+	ts.NoError(err)
+	ts.Len(results, 3)
+
+	ts.Len(calls["high"], 6)
+	ts.Less(calls["high"][5].Sub(calls["high"][0]), 30*time.Millisecond, "high priority retries immediately")
+	ts.Require().Len(calls["low"], 3)
+	ts.GreaterOrEqual(calls["low"][1].Sub(calls["low"][0]), 30*time.Millisecond)
+	ts.GreaterOrEqual(calls["low"][2].Sub(calls["low"][1]), 60*time.Millisecond)
+	ts.Len(calls["none"], 1)
+}
+
+func (ts *WorkerPoolTestSuite) TestRetryBand() {
+	bands := []RetryBand{{MinPriority: 10, MaxRetries: 5}, {MinPriority: -5, MaxRetries: 1}, {MinPriority: 0, MaxRetries: 2}}
+	for priority, want := range map[int]int{20: 5, 10: 5, 9: 2, 0: 2, -1: 1} {
+		band, ok := retryBand(bands, priority)
+		ts.True(ok)
+		ts.Equal(want, band.MaxRetries, "priority %d", priority)
+	}
+	_, ok := retryBand(bands, -6)
+	ts.False(ok)
+	_, ok = retryBand(nil, 0)
+	ts.False(ok)
+}
+
+func (ts *WorkerPoolTestSuite) TestRetryDelay() {
+	ts.Equal(100*time.Millisecond, backoffDelay(0, errors.New("x"), defaultRetryBackoff))
+	ts.Equal(300*time.Millisecond, backoffDelay(2, errors.New("x"), defaultRetryBackoff))
+	wrapped := errors.Join(errors.New("ctx"), &RetryAfterError{Delay: 5 * time.Second})
+	ts.Equal(5*time.Second, backoffDelay(0, wrapped, defaultRetryBackoff))
+}
+
+func (ts *WorkerPoolTestSuite) TestParseRetryAfter() {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	d, ok := ParseRetryAfter("120", now)
+	ts.True(ok)
+	ts.Equal(2*time.Minute, d)
+
+	d, ok = ParseRetryAfter(now.Add(30*time.Second).Format(http.TimeFormat), now)
+	ts.True(ok)
+	ts.Equal(30*time.Second, d)
+
+	d, ok = ParseRetryAfter(now.Add(-time.Minute).Format(http.TimeFormat), now)
+	ts.True(ok)
+	ts.Zero(d)
+
+	for _, bad := range []string{"", "soon", "-5"} {
+		_, ok = ParseRetryAfter(bad, now)
+		ts.False(ok, bad)
+	}
+
+	resp := &http.Response{Header: http.Header{"Retry-After": []string{"3"}}}
+	err := RetryAfterFromResponse(resp, errors.New("503"))
+	var retryAfter *RetryAfterError
+	ts.ErrorAs(err, &retryAfter)
+	ts.Equal(3*time.Second, retryAfter.Delay)
+
+	plain := errors.New("500")
+	ts.Equal(plain, RetryAfterFromResponse(&http.Response{Header: http.Header{}}, plain))
+	ts.Equal(plain, RetryAfterFromResponse(nil, plain))
+}
@@ -0,0 +1,201 @@
+package workerpool
+
+import (
+	"sync"
+	"time"
+)
+
+// runHistorySize is how many finished runs RunSummaries keeps
+const runHistorySize = 32
+
+// RunSummary is the metrics of a single run, from the counters the run
+// added to the pool's lifetime metrics
+type RunSummary struct {
+	RunID   uint64   `json:"run_id"`
+	Metrics *Metrics `json:"metrics"` // TotalJobs is the jobs the run took; gauges are as of its end
+	Running bool     `json:"running"` // Still in progress; Metrics cover the run so far
+}
+
+// runHistory scopes metrics to runs. Each run records a snapshot of the
+// lifetime metrics when it starts; its own metrics are what was added since.
+type runHistory struct {
+	lastID  uint64
+	base    *Metrics // Lifetime metrics when the current run started; nil between runs
+	jobs    int      // Jobs the current run took
+	current uint64
+	done    []RunSummary // Finished runs, oldest first
+	mu      sync.Mutex
+}
+
+// begin starts a run of jobs over lifetime metrics base
+func (h *runHistory) begin(base *Metrics, jobs int) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.lastID++
+	h.current = h.lastID
+	h.base = base
+	h.jobs = jobs
+}
+
+// end records the finished run given the lifetime metrics at its end
+func (h *runHistory) end(now *Metrics) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	summary := h.summaryLocked(now)
+	summary.Running = false
+	h.done = append(h.done, summary)
+	if len(h.done) > runHistorySize {
+		h.done = append(h.done[:0], h.done[len(h.done)-runHistorySize:]...)
+	}
+	h.base = nil
+}
+
+// rebase restarts the current run's counts from lifetime metrics base,
+// after they were reset
+func (h *runHistory) rebase(base *Metrics) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.base != nil {
+		h.base = base
+	}
+}
+
+// summaryLocked returns the current run's metrics given the lifetime ones
+func (h *runHistory) summaryLocked(now *Metrics) RunSummary {
+	m := metricsSince(now, h.base)
+	m.TotalJobs = h.jobs
+	m.StartTime = now.StartTime
+	if m.EndTime.Before(m.StartTime) {
+		// Still running; the lifetime end time is the previous run's
+		m.EndTime = time.Time{}
+		m.TotalDuration = time.Since(m.StartTime)
+	} else {
+		m.TotalDuration = m.EndTime.Sub(m.StartTime)
+	}
+	if m.ProcessedJobs > 0 {
+		m.AverageDuration = m.TotalDuration / time.Duration(m.ProcessedJobs)
+	}
+	return RunSummary{RunID: h.current, Metrics: m, Running: true}
+}
+
+// metricsSince returns the counters added to now since base. Stealing and
+// Adaptive stats, start and end times and tenant gauges are taken from now.
+func metricsSince(now, base *Metrics) *Metrics {
+	m := &Metrics{
+		ProcessedJobs:    now.ProcessedJobs - base.ProcessedJobs,
+		FailedJobs:       now.FailedJobs - base.FailedJobs,
+		ExpiredJobs:      now.ExpiredJobs - base.ExpiredJobs,
+		LateCompletions:  now.LateCompletions - base.LateCompletions,
+		SkippedJobs:      now.SkippedJobs - base.SkippedJobs,
+		StartTime:        now.StartTime,
+		EndTime:          now.EndTime,
+		Stealing:         now.Stealing,
+		ErrorsReported:   now.ErrorsReported - base.ErrorsReported,
+		ErrorsSuppressed: now.ErrorsSuppressed - base.ErrorsSuppressed,
+		StarvedJobs:      now.StarvedJobs - base.StarvedJobs,
+		Adaptive:         now.Adaptive,
+		CPUTime:          now.CPUTime - base.CPUTime,
+		DroppedResults:   now.DroppedResults - base.DroppedResults,
+		DuplicateResults: now.DuplicateResults - base.DuplicateResults,
+	}
+	for cause, n := range now.FailureCauses {
+		if n -= base.FailureCauses[cause]; n != 0 {
+			if m.FailureCauses == nil {
+				m.FailureCauses = make(map[string]int)
+			}
+			m.FailureCauses[cause] = n
+		}
+	}
+	for key, v := range now.Counters {
+		if v -= base.Counters[key]; v != 0 {
+			if m.Counters == nil {
+				m.Counters = make(map[string]float64)
+			}
+			m.Counters[key] = v
+		}
+	}
+	for class, d := range now.ClassCPUTime {
+		if d -= base.ClassCPUTime[class]; d != 0 {
+			if m.ClassCPUTime == nil {
+				m.ClassCPUTime = make(map[string]time.Duration)
+			}
+			m.ClassCPUTime[class] = d
+		}
+	}
+	for tenant, t := range now.Tenants {
+		b := base.Tenants[tenant]
+		t.Processed -= b.Processed
+		t.Failed -= b.Failed
+		t.Rejected -= b.Rejected
+		t.CPUTime -= b.CPUTime
+		if m.Tenants == nil {
+			m.Tenants = make(map[string]TenantMetrics)
+		}
+		m.Tenants[tenant] = t
+	}
+	return m
+}
+
+// RunID returns the ID of the current or most recent run, numbered from 1
+// in the order runs started; zero before the first run
+func (wp *WorkerPool[T, R]) RunID() uint64 {
+	wp.runs.mu.Lock()
+	defer wp.runs.mu.Unlock()
+	return wp.runs.lastID
+}
+
+// RunMetrics returns the metrics of the current or most recent run, unlike
+// GetMetrics which sums every run since the pool was created or its metrics
+// were last reset
+func (wp *WorkerPool[T, R]) RunMetrics() RunSummary {
+	now := wp.GetMetrics()
+	wp.runs.mu.Lock()
+	defer wp.runs.mu.Unlock()
+	if wp.runs.base != nil {
+		return wp.runs.summaryLocked(&now)
+	}
+	if n := len(wp.runs.done); n > 0 {
+		return wp.runs.done[n-1]
+	}
+	return RunSummary{Metrics: &Metrics{}}
+}
+
+// RunSummaries returns the metrics of the most recent finished runs, oldest
+// first
+func (wp *WorkerPool[T, R]) RunSummaries() []RunSummary {
+	wp.runs.mu.Lock()
+	defer wp.runs.mu.Unlock()
+	return append([]RunSummary(nil), wp.runs.done...)
+}
+
+// ResetMetrics zeroes the lifetime counters reported by GetMetrics, leaving
+// TotalJobs at the number of queued jobs and tenant gauges as they are. Run
+// summaries are kept; a run in progress counts from the reset onwards.
+// Setting Config.ResetMetricsPerRun resets them as every run starts.
+func (wp *WorkerPool[T, R]) ResetMetrics() {
+	wp.mu.RLock()
+	wp.metrics.mu.Lock()
+	wp.metrics.TotalJobs = len(wp.jobs)
+	wp.metrics.ProcessedJobs = 0
+	wp.metrics.FailedJobs = 0
+	wp.metrics.ExpiredJobs = 0
+	wp.metrics.LateCompletions = 0
+	wp.metrics.SkippedJobs = 0
+	wp.metrics.TotalDuration = 0
+	wp.metrics.AverageDuration = 0
+	wp.metrics.ErrorsReported = 0
+	wp.metrics.ErrorsSuppressed = 0
+	wp.metrics.FailureCauses = nil
+	wp.metrics.Counters = nil
+	wp.metrics.StarvedJobs = 0
+	wp.metrics.CPUTime = 0
+	wp.metrics.ClassCPUTime = nil
+	wp.metrics.DroppedResults = 0
+	wp.metrics.DuplicateResults = 0
+	wp.metrics.mu.Unlock()
+	wp.mu.RUnlock()
+	wp.tenants.resetCounters()
+
+	base := wp.GetMetrics()
+	wp.runs.rebase(&base)
+}
@@ -0,0 +1,101 @@
+package workerpool
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+func (ts *WorkerPoolTestSuite) TestRunMetricsArePerRun() {
+	pool := NewWithConfig[int, int](DefaultConfig())
+	pool.WithProcessor(func(ctx context.Context, job Job[int]) (int, error) {
+		if job.Data < 0 {
+			return 0, errors.New("negative")
+		}
+		return job.Data, nil
+	})
+	ts.Zero(pool.RunID())
+
+	pool.SetJobs([]Job[int]{{ID: "a", Data: 1}, {ID: "b", Data: -1}, {ID: "c", Data: 3}})
+	_, err := pool.Run()
+	ts.NoError(err)
+	pool.SetJobs([]Job[int]{{ID: "d", Data: 4}})
+	_, err = pool.Run()
+	ts.NoError(err)
+
+	ts.Equal(uint64(2), pool.RunID())
+	latest := pool.RunMetrics()
+	ts.Equal(uint64(2), latest.RunID)
+	ts.False(latest.Running)
+	ts.Equal(1, latest.Metrics.TotalJobs)
+	ts.Equal(1, latest.Metrics.ProcessedJobs)
+	ts.Zero(latest.Metrics.FailedJobs)
+	ts.Empty(latest.Metrics.FailureCauses)
+
+	summaries := pool.RunSummaries()
+	ts.Require().Len(summaries, 2)
+	ts.Equal(uint64(1), summaries[0].RunID)
+	ts.Equal(3, summaries[0].Metrics.TotalJobs)
+	ts.Equal(2, summaries[0].Metrics.ProcessedJobs)
+	ts.Equal(1, summaries[0].Metrics.FailedJobs)
+
+	// Lifetime metrics keep counting across runs until reset
+	lifetime := pool.GetMetrics()
+	ts.Equal(3, lifetime.ProcessedJobs)
+	ts.Equal(1, lifetime.FailedJobs)
+
+	pool.ResetMetrics()
+	lifetime = pool.GetMetrics()
+	ts.Zero(lifetime.ProcessedJobs)
+	ts.Zero(lifetime.FailedJobs)
+	ts.Equal(1, lifetime.TotalJobs)
+	ts.Len(pool.RunSummaries(), 2)
+}
+
+func (ts *WorkerPoolTestSuite) TestResetMetricsPerRun() {
+	config := DefaultConfig()
+	config.ResetMetricsPerRun = true
+	pool := NewWithConfig[int, int](config)
+	pool.WithProcessor(func(ctx context.Context, job Job[int]) (int, error) {
+		return job.Data, nil
+	})
+
+	pool.SetJobs([]Job[int]{{ID: "a", Data: 1}, {ID: "b", Data: 2}})
+	_, err := pool.Run()
+	ts.NoError(err)
+	_, err = pool.Run()
+	ts.NoError(err)
+
+	ts.Equal(2, pool.GetMetrics().ProcessedJobs)
+	ts.Equal(2, pool.RunMetrics().Metrics.ProcessedJobs)
+}
+
+func (ts *WorkerPoolTestSuite) TestRunMetricsWhileRunning() {
+	pool := NewWithConfig[int, int](DefaultConfig())
+	release := make(chan struct{})
+	pool.WithProcessor(func(ctx context.Context, job Job[int]) (int, error) {
+		if job.ID == "held" {
+			<-release
+		}
+		return job.Data, nil
+	})
+	pool.SetJobs([]Job[int]{{ID: "a"}, {ID: "held"}})
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_, _ = pool.Run()
+	}()
+	ts.Eventually(func() bool {
+		m := pool.RunMetrics()
+		return m.Running && m.Metrics.ProcessedJobs == 1
+	}, time.Second, 5*time.Millisecond)
+	current := pool.RunMetrics()
+	ts.Equal(2, current.Metrics.TotalJobs)
+	ts.True(current.Metrics.EndTime.IsZero())
+
+	close(release)
+	<-done
+	ts.False(pool.RunMetrics().Running)
+	ts.Equal(2, pool.RunMetrics().Metrics.ProcessedJobs)
+}
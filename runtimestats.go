@@ -0,0 +1,140 @@
+package workerpool
+
+import (
+	"math"
+	"runtime/metrics"
+	"time"
+)
+
+// Runtime metrics read by ReadRuntimeStats. GC pauses moved to a new name
+// in Go 1.22; gcPauseMetrics lists both.
+const (
+	goroutinesMetric   = "/sched/goroutines:goroutines"
+	gomaxprocsMetric   = "/sched/gomaxprocs:threads"
+	schedLatencyMetric = "/sched/latencies:seconds"
+	gcCyclesMetric     = "/gc/cycles/total:gc-cycles"
+)
+
+// RuntimeStats are Go runtime values that explain pool slowness caused by
+// the process rather than by jobs. Latency distributions cover the life of
+// the process and are accurate to the runtime's histogram buckets.
+type RuntimeStats struct {
+	Goroutines   int         `json:"goroutines"`
+	GOMAXPROCS   int         `json:"gomaxprocs"`
+	SchedLatency Percentiles `json:"sched_latency"` // How long runnable goroutines waited for a thread
+	GCPause      Percentiles `json:"gc_pause"`      // Stop-the-world pauses for garbage collection
+	GCCycles     uint64      `json:"gc_cycles"`
+	HeapBytes    uint64      `json:"heap_bytes"` // Live and not yet swept heap objects
+}
+
+// PoolSnapshot is a pool's state and the Go runtime's at one moment, for
+// correlating pool slowness with runtime saturation
+type PoolSnapshot struct {
+	Name    string        `json:"name,omitempty"`
+	Time    time.Time     `json:"time"`
+	Health  Health        `json:"health"`
+	Metrics *Metrics      `json:"metrics"`
+	Workers []WorkerStats `json:"workers"`
+	Runtime RuntimeStats  `json:"runtime"`
+}
+
+// Snapshot returns the pool's health, metrics and worker stats together
+// with the Go runtime's scheduler, GC and heap stats
+func (wp *WorkerPool[T, R]) Snapshot() PoolSnapshot {
+	metrics := wp.GetMetrics()
+	return PoolSnapshot{
+		Name:    wp.Name(),
+		Time:    time.Now(),
+		Health:  wp.Health(),
+		Metrics: &metrics,
+		Workers: wp.WorkerStats(),
+		Runtime: ReadRuntimeStats(),
+	}
+}
+
+// ReadRuntimeStats reads the current runtime stats from runtime/metrics.
+// Values the running Go version does not provide are left zero.
+func ReadRuntimeStats() RuntimeStats {
+	supported := make(map[string]bool)
+	for _, d := range metrics.All() {
+		supported[d.Name] = true
+	}
+	names := []string{goroutinesMetric, gomaxprocsMetric, schedLatencyMetric, gcCyclesMetric, heapObjectsMetric}
+	for _, name := range gcPauseMetrics {
+		if supported[name] {
+			names = append(names, name)
+			break
+		}
+	}
+	samples := make([]metrics.Sample, 0, len(names))
+	for _, name := range names {
+		if supported[name] {
+			samples = append(samples, metrics.Sample{Name: name})
+		}
+	}
+	metrics.Read(samples)
+
+	var stats RuntimeStats
+	for _, s := range samples {
+		switch s.Name {
+		case goroutinesMetric:
+			stats.Goroutines = int(sampleUint64(s))
+		case gomaxprocsMetric:
+			stats.GOMAXPROCS = int(sampleUint64(s))
+		case gcCyclesMetric:
+			stats.GCCycles = sampleUint64(s)
+		case heapObjectsMetric:
+			stats.HeapBytes = sampleUint64(s)
+		case schedLatencyMetric:
+			stats.SchedLatency = histogramPercentiles(s)
+		default:
+			stats.GCPause = histogramPercentiles(s)
+		}
+	}
+	return stats
+}
+
+// sampleUint64 returns a sample's value, or zero if it is not an integer
+func sampleUint64(s metrics.Sample) uint64 {
+	if s.Value.Kind() != metrics.KindUint64 {
+		return 0
+	}
+	return s.Value.Uint64()
+}
+
+// histogramPercentiles summarizes a histogram of seconds. Each percentile
+// is the upper bound of the bucket it falls in, or the lower bound for the
+// open-ended last bucket.
+func histogramPercentiles(s metrics.Sample) Percentiles {
+	if s.Value.Kind() != metrics.KindFloat64Histogram {
+		return Percentiles{}
+	}
+	h := s.Value.Float64Histogram()
+	var total uint64
+	for _, c := range h.Counts {
+		total += c
+	}
+	if total == 0 {
+		return Percentiles{}
+	}
+
+	bound := func(i int) time.Duration {
+		b := h.Buckets[i+1]
+		if math.IsInf(b, 1) {
+			b = h.Buckets[i]
+		}
+		return time.Duration(b * float64(time.Second))
+	}
+	quantile := func(q float64) time.Duration {
+		rank := uint64(math.Ceil(q * float64(total)))
+		var seen uint64
+		for i, c := range h.Counts {
+			seen += c
+			if seen >= rank && c > 0 {
+				return bound(i)
+			}
+		}
+		return 0
+	}
+	return Percentiles{P50: quantile(0.50), P90: quantile(0.90), P99: quantile(0.99), Max: quantile(1)}
+}
@@ -0,0 +1,53 @@
+package workerpool
+
+import (
+	"context"
+	"encoding/json"
+	"runtime"
+	"runtime/metrics"
+)
+
+func (ts *WorkerPoolTestSuite) TestSnapshotIncludesRuntimeStats() {
+	runtime.GC()
+	config := DefaultConfig()
+	config.Name = "snap"
+	pool := NewWithConfig[int, int](config).WithProcessor(func(ctx context.Context, job Job[int]) (int, error) {
+		return job.Data, nil
+	})
+	pool.AddJob(Job[int]{ID: "a"})
+	_, err := pool.Run()
+	ts.NoError(err)
+
+	snap := pool.Snapshot()
+	ts.Equal("snap", snap.Name)
+	ts.Equal(1, snap.Metrics.ProcessedJobs)
+	ts.Equal(runtime.GOMAXPROCS(0), snap.Runtime.GOMAXPROCS)
+	ts.Positive(snap.Runtime.Goroutines)
+	ts.Positive(snap.Runtime.GCCycles)
+	ts.Positive(snap.Runtime.HeapBytes)
+	ts.Positive(snap.Runtime.SchedLatency.Max)
+	ts.LessOrEqual(snap.Runtime.SchedLatency.P50, snap.Runtime.SchedLatency.P99)
+
+	data, err := json.Marshal(snap)
+	ts.NoError(err)
+	var decoded struct {
+		Runtime map[string]any `json:"runtime"`
+		Metrics map[string]any `json:"metrics"`
+	}
+	ts.NoError(json.Unmarshal(data, &decoded))
+	ts.Contains(decoded.Runtime, "sched_latency")
+	ts.Contains(decoded.Metrics, "processed_jobs")
+}
+
+func (ts *WorkerPoolTestSuite) TestHistogramPercentiles() {
+	var s metrics.Sample
+	ts.Zero(histogramPercentiles(s))
+
+	s.Name = schedLatencyMetric
+	samples := []metrics.Sample{s}
+	metrics.Read(samples)
+	p := histogramPercentiles(samples[0])
+	ts.LessOrEqual(p.P50, p.P90)
+	ts.LessOrEqual(p.P90, p.P99)
+	ts.LessOrEqual(p.P99, p.Max)
+}
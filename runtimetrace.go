@@ -0,0 +1,30 @@
+package workerpool
+
+import (
+	"context"
+	"runtime/trace"
+)
+
+// traceJob starts the runtime/trace task of a job with Config.RuntimeTrace,
+// logging the job ID and the pool and worker running it. It returns the
+// task's context and a function ending the task.
+func (wp *WorkerPool[T, R]) traceJob(ctx context.Context, workerID int, job Job[T]) (context.Context, func()) {
+	if !wp.config.RuntimeTrace {
+		return ctx, func() {}
+	}
+	ctx, task := trace.NewTask(ctx, "workerpool.job")
+	trace.Log(ctx, "job", job.ID)
+	trace.Logf(ctx, "worker", "%s/worker-%d", wp.config.Name, workerID)
+	return ctx, task.End
+}
+
+// traceAttempt starts the runtime/trace region of one attempt of a job and
+// returns a function ending it. The region must end on the goroutine that
+// started it.
+func (wp *WorkerPool[T, R]) traceAttempt(ctx context.Context, attempt int, executionID string) func() {
+	if !wp.config.RuntimeTrace {
+		return func() {}
+	}
+	trace.Logf(ctx, "attempt", "%d %s", attempt, executionID)
+	return trace.StartRegion(ctx, "workerpool.attempt").End
+}
@@ -0,0 +1,38 @@
+package workerpool
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"runtime/trace"
+)
+
+func (ts *WorkerPoolTestSuite) TestRuntimeTraceTasksAndRegions() {
+	config := DefaultConfig()
+	config.RuntimeTrace = true
+	config.MaxRetries = 1
+	pool := NewWithConfig[int, int](config)
+	failed := false
+	pool.WithProcessor(func(ctx context.Context, job Job[int]) (int, error) {
+		if !failed {
+			failed = true
+			return 0, errors.New("transient")
+		}
+		return job.Data, nil
+	})
+	pool.AddJob(Job[int]{ID: "traced-job", Data: 1})
+
+	var buf bytes.Buffer
+	if err := trace.Start(&buf); err != nil {
+		ts.T().Skip("a trace is already being collected")
+	}
+	results, err := pool.Run()
+	trace.Stop()
+	ts.NoError(err)
+	ts.Equal(2, results[0].Attempts)
+
+	// Task, region and log strings are recorded verbatim in the trace
+	for _, s := range []string{"workerpool.job", "workerpool.attempt", "traced-job"} {
+		ts.True(bytes.Contains(buf.Bytes(), []byte(s)), s)
+	}
+}
@@ -0,0 +1,132 @@
+package workerpool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrQuorumNotReached is returned by ScatterGather when too few branches
+// succeeded to satisfy the gather policy
+var ErrQuorumNotReached = errors.New("scatter-gather quorum not reached")
+
+// GatherPolicy decides when ScatterGather has gathered enough results
+type GatherPolicy int
+
+const (
+	GatherAll          GatherPolicy = iota // Wait for every branch; any failure fails the call
+	GatherFirstSuccess                     // Return as soon as one branch succeeds
+	GatherQuorum                           // Return as soon as ScatterOptions.Quorum branches succeed
+)
+
+// Branch is one processor a ScatterGather input is fanned out to
+type Branch[T any, R any] struct {
+	Name      string // Reported as the JobID of the branch's result
+	Processor Processor[T, R]
+}
+
+// ScatterOptions configures ScatterGather
+type ScatterOptions struct {
+	Policy        GatherPolicy
+	Quorum        int           // Successes required by GatherQuorum
+	BranchTimeout time.Duration // Per-branch timeout; zero uses Config.WorkerTimeout
+
+	// Config for the pool running the branches. With NumWorkers zero every
+	// branch gets its own worker and failed branches are not retried.
+	Config Config
+}
+
+// ScatterGather fans input out to every branch on a pool's workers and
+// gathers their results according to opts.Policy. Once the policy is
+// satisfied the remaining branches are cancelled and the results gathered
+// so far are returned, successes and failures alike. If the policy cannot
+// be satisfied the results are returned with ErrQuorumNotReached, or with
+// the branch errors under GatherAll. Cancelling ctx cancels every branch.
+func ScatterGather[T any, R any](ctx context.Context, input T, branches []Branch[T, R], opts ScatterOptions) ([]Result[R], error) {
+	if len(branches) == 0 {
+		return nil, fmt.Errorf("scatter-gather: no branches")
+	}
+	needed := len(branches)
+	switch opts.Policy {
+	case GatherFirstSuccess:
+		needed = 1
+	case GatherQuorum:
+		if opts.Quorum <= 0 || opts.Quorum > len(branches) {
+			return nil, fmt.Errorf("scatter-gather: quorum %d out of range for %d branches", opts.Quorum, len(branches))
+		}
+		needed = opts.Quorum
+	}
+
+	config := opts.Config
+	if config.NumWorkers == 0 {
+		config = DefaultConfig()
+		config.NumWorkers = len(branches)
+		config.MaxRetries = 0
+	}
+	if opts.BranchTimeout > 0 {
+		config.WorkerTimeout = opts.BranchTimeout
+	}
+
+	processors := make(map[string]Processor[T, R], len(branches))
+	jobs := make([]Job[T], len(branches))
+	for i, b := range branches {
+		if _, dup := processors[b.Name]; dup {
+			return nil, fmt.Errorf("scatter-gather: duplicate branch %q", b.Name)
+		}
+		processors[b.Name] = b.Processor
+		jobs[i] = Job[T]{ID: b.Name, Data: input}
+	}
+
+	pool := NewWithConfig[T, R](config)
+	pool.WithProcessor(func(jobCtx context.Context, job Job[T]) (R, error) {
+		branchCtx, cancel := context.WithCancel(jobCtx)
+		defer cancel()
+		defer context.AfterFunc(ctx, cancel)()
+		return processors[job.ID](branchCtx, job)
+	})
+	pool.AddJobs(jobs)
+
+	var results []Result[R]
+	var errs []error
+	succeeded, done := 0, false
+	_, runErr := pool.run(context.Background(), func(result Result[R]) {
+		if done {
+			return // A branch cancelled after the policy was satisfied
+		}
+		results = append(results, result)
+		if result.Error != nil {
+			errs = append(errs, fmt.Errorf("branch %s: %w", result.JobID, result.Error))
+		} else {
+			succeeded++
+		}
+
+		switch {
+		case ctx.Err() != nil:
+			done = true
+		case opts.Policy == GatherAll:
+			done = len(results) == len(branches)
+		default:
+			// Stop once the policy is met or can no longer be met
+			done = succeeded >= needed || needed-succeeded > len(branches)-len(results)
+		}
+		if done {
+			pool.Stop()
+		}
+	})
+
+	switch {
+	case ctx.Err() != nil:
+		return results, context.Cause(ctx)
+	case opts.Policy == GatherAll:
+		if len(errs) > 0 || len(results) < len(branches) {
+			return results, errors.Join(append(errs, runErr)...)
+		}
+		return results, nil
+	case succeeded >= needed:
+		return results, nil
+	default:
+		return results, fmt.Errorf("%w: %d of %d branches succeeded, %d needed: %w",
+			ErrQuorumNotReached, succeeded, len(branches), needed, errors.Join(errs...))
+	}
+}
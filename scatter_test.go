@@ -0,0 +1,95 @@
+package workerpool
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// provider returns a branch that answers after delay, or fails with err
+func provider(name string, delay time.Duration, err error) Branch[string, string] {
+	return Branch[string, string]{
+		Name: name,
+		Processor: func(ctx context.Context, job Job[string]) (string, error) {
+			select {
+			case <-time.After(delay):
+			case <-ctx.Done():
+				return "", ctx.Err()
+			}
+			if err != nil {
+				return "", err
+			}
+			return name + ":" + job.Data, nil
+		},
+	}
+}
+
+func (ts *WorkerPoolTestSuite) TestScatterGatherAll() {
+	results, err := ScatterGather(context.Background(), "q", []Branch[string, string]{
+		provider("a", 0, nil),
+		provider("b", 10*time.Millisecond, nil),
+		provider("c", 5*time.Millisecond, nil),
+	}, ScatterOptions{})
+	ts.NoError(err)
+	ts.Len(results, 3)
+
+	_, err = ScatterGather(context.Background(), "q", []Branch[string, string]{
+		provider("a", 0, nil),
+		provider("b", 0, errors.New("provider down")),
+	}, ScatterOptions{Policy: GatherAll})
+	ts.ErrorContains(err, "provider down")
+}
+
+func (ts *WorkerPoolTestSuite) TestScatterGatherFirstSuccess() {
+	start := time.Now()
+	results, err := ScatterGather(context.Background(), "q", []Branch[string, string]{
+		provider("slow", 5*time.Second, nil),
+		provider("broken", 0, errors.New("boom")),
+		provider("fast", 10*time.Millisecond, nil),
+	}, ScatterOptions{Policy: GatherFirstSuccess})
+	ts.NoError(err)
+	ts.Less(time.Since(start), 2*time.Second)
+
+	var winner string
+	for _, r := range results {
+		if r.Error == nil {
+			winner = r.Data
+		}
+	}
+	ts.Equal("fast:q", winner)
+}
+
+func (ts *WorkerPoolTestSuite) TestScatterGatherQuorumAndBranchTimeout() {
+	branches := []Branch[string, string]{
+		provider("a", 0, nil),
+		provider("b", 5*time.Millisecond, nil),
+		provider("hung", 5*time.Second, nil),
+	}
+	results, err := ScatterGather(context.Background(), "q", branches, ScatterOptions{Policy: GatherQuorum, Quorum: 2})
+	ts.NoError(err)
+	ts.Len(results, 2)
+
+	// The hung branch times out, so a quorum of three cannot be reached
+	results, err = ScatterGather(context.Background(), "q", branches, ScatterOptions{
+		Policy:        GatherQuorum,
+		Quorum:        3,
+		BranchTimeout: 50 * time.Millisecond,
+	})
+	ts.ErrorIs(err, ErrQuorumNotReached)
+	ts.Len(results, 3)
+
+	_, err = ScatterGather(context.Background(), "q", branches, ScatterOptions{Policy: GatherQuorum, Quorum: 4})
+	ts.Error(err)
+}
+
+func (ts *WorkerPoolTestSuite) TestScatterGatherCancelled() {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	_, err := ScatterGather(ctx, "q", []Branch[string, string]{
+		provider("a", 5*time.Second, nil),
+		provider("b", 5*time.Second, nil),
+	}, ScatterOptions{})
+	ts.ErrorIs(err, context.DeadlineExceeded)
+	ts.Less(time.Since(start), 2*time.Second)
+}
@@ -0,0 +1,61 @@
+package workerpool
+
+import (
+	"context"
+	"fmt"
+)
+
+// String returns the strategy's name as recorded in Result.Strategy
+func (s DistributionStrategy) String() string {
+	switch s {
+	case RoundRobin:
+		return "round_robin"
+	case Chunked:
+		return "chunked"
+	case WorkStealing:
+		return "work_stealing"
+	case PriorityBased:
+		return "priority_based"
+	case Adaptive:
+		return "adaptive"
+	case FairShare:
+		return "fair_share"
+	default:
+		return fmt.Sprintf("DistributionStrategy(%d)", int(s))
+	}
+}
+
+// dispatchKey is the context key under which a worker's dispatchInfo is stored
+type dispatchKey struct{}
+
+// dispatchInfo describes how jobs reach a worker, for Result's scheduling fields
+type dispatchInfo struct {
+	strategy string // Strategy running the job; nested strategies are joined with "/"
+	queue    string // Queue the job was taken from
+	stolen   bool   // Taken from another worker's queue
+}
+
+// withStrategy records that jobs run under ctx are dispatched by strategy.
+// A strategy delegating to another, as Adaptive does, yields "adaptive/chunked".
+func withStrategy(ctx context.Context, strategy DistributionStrategy) context.Context {
+	info := dispatchFrom(ctx)
+	if info.strategy != "" {
+		info.strategy += "/" + strategy.String()
+	} else {
+		info.strategy = strategy.String()
+	}
+	return context.WithValue(ctx, dispatchKey{}, info)
+}
+
+// withQueue records the queue jobs run under ctx are taken from
+func withQueue(ctx context.Context, queue string, stolen bool) context.Context {
+	info := dispatchFrom(ctx)
+	info.queue, info.stolen = queue, stolen
+	return context.WithValue(ctx, dispatchKey{}, info)
+}
+
+// dispatchFrom returns the dispatch details recorded in ctx
+func dispatchFrom(ctx context.Context) dispatchInfo {
+	info, _ := ctx.Value(dispatchKey{}).(dispatchInfo)
+	return info
+}
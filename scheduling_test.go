@@ -0,0 +1,97 @@
+package workerpool
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+func (ts *WorkerPoolTestSuite) TestResultRecordsStrategyAndQueue() {
+	cases := []struct {
+		strategy DistributionStrategy
+		name     string
+		queue    string
+	}{
+		{RoundRobin, "round_robin", "worker-"},
+		{Chunked, "chunked", "chunk-"},
+		{PriorityBased, "priority_based", "priority"},
+		{FairShare, "fair_share", "fair_share"},
+	}
+	for _, c := range cases {
+		config := DefaultConfig()
+		config.NumWorkers = 2
+		config.Strategy = c.strategy
+		pool := NewWithConfig[int, int](config)
+		pool.WithProcessor(func(ctx context.Context, job Job[int]) (int, error) {
+			return job.Data, nil
+		})
+
+		var jobs []Job[int]
+		for i := 0; i < 6; i++ {
+			jobs = append(jobs, Job[int]{ID: fmt.Sprintf("%d", i), Data: i})
+		}
+		pool.AddJobs(jobs)
+		results, err := pool.Run()
+		ts.NoError(err)
+		ts.Len(results, 6)
+		for _, r := range results {
+			ts.Equal(c.name, r.Strategy)
+			ts.True(strings.HasPrefix(r.Queue, c.queue), r.Queue)
+			ts.False(r.Stolen)
+			ts.Equal(1, r.DispatchAttempt)
+		}
+	}
+}
+
+func (ts *WorkerPoolTestSuite) TestResultRecordsStolenJobs() {
+	config := DefaultConfig()
+	config.NumWorkers = 2
+	config.Strategy = WorkStealing
+	pool := NewWithConfig[int, int](config)
+	pool.WithProcessor(func(ctx context.Context, job Job[int]) (int, error) {
+		// Worker 0's jobs are slow, so worker 1 steals some of them
+		if job.Data%2 == 0 {
+			time.Sleep(20 * time.Millisecond)
+		}
+		return job.Data, nil
+	})
+
+	var jobs []Job[int]
+	for i := 0; i < 10; i++ {
+		jobs = append(jobs, Job[int]{ID: fmt.Sprintf("%d", i), Data: i})
+	}
+	pool.AddJobs(jobs)
+	results, err := pool.Run()
+	ts.NoError(err)
+
+	stolen := 0
+	for _, r := range results {
+		ts.Equal("work_stealing", r.Strategy)
+		if r.Stolen {
+			stolen++
+			ts.NotEqual(fmt.Sprintf("deque-%d", r.Worker), r.Queue)
+		} else {
+			ts.Equal(fmt.Sprintf("deque-%d", r.Worker), r.Queue)
+		}
+	}
+	ts.Positive(stolen)
+}
+
+func (ts *WorkerPoolTestSuite) TestAdaptiveRecordsDelegateStrategy() {
+	config := DefaultConfig()
+	config.NumWorkers = 4
+	config.Strategy = Adaptive
+	pool := NewWithConfig[int, int](config)
+	pool.WithProcessor(func(ctx context.Context, job Job[int]) (int, error) {
+		return job.Data, nil
+	})
+
+	pool.AddJobs([]Job[int]{{ID: "a", Data: 1}})
+	results, err := pool.Run()
+	ts.NoError(err)
+	ts.Require().Len(results, 1)
+	ts.Equal("adaptive/round_robin", results[0].Strategy)
+	ts.Equal("round_robin", RoundRobin.String())
+	ts.Equal("DistributionStrategy(42)", DistributionStrategy(42).String())
+}
@@ -0,0 +1,78 @@
+package workerpool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// ErrPoolShutdown is reported when Shutdown ends a run before every job started
+var ErrPoolShutdown = errors.New("worker pool shut down")
+
+// ShutdownResult describes the work a shut down pool did not get to
+type ShutdownResult[T any] struct {
+	Remaining []Job[T] // Jobs that were never started, by priority then creation, ready to persist or resubmit
+}
+
+// Shutdown stops the pool from starting new jobs, waits for in-flight jobs to
+// finish and returns every job that was never started. If ctx expires first,
+// in-flight jobs are cancelled with ErrPoolShutdown as the cause and ctx's
+// error is returned. The pool's job list is cleared either way.
+//
+// A Run interrupted by Shutdown returns the results it collected together with
+// an error wrapping ErrPoolShutdown.
+func (wp *WorkerPool[T, R]) Shutdown(ctx context.Context) (ShutdownResult[T], error) {
+	wp.mu.Lock()
+	if !wp.running {
+		remaining := wp.jobs
+		wp.clearJobsLocked()
+		wp.publishRemoved(remaining, ErrPoolShutdown)
+		wp.mu.Unlock()
+		return ShutdownResult[T]{Remaining: remaining}, nil
+	}
+	if !wp.draining {
+		wp.draining = true
+		close(wp.drained)
+	}
+	done := wp.runDone
+	wp.mu.Unlock()
+
+	var err error
+	select {
+	case <-done:
+	case <-ctx.Done():
+		wp.StopWithCause(ErrPoolShutdown)
+		<-done
+		err = ctx.Err()
+	}
+
+	wp.mu.Lock()
+	defer wp.mu.Unlock()
+
+	remaining := wp.remaining
+	wp.remaining = nil
+	wp.clearJobsLocked()
+	wp.publishRemoved(remaining, ErrPoolShutdown)
+	return ShutdownResult[T]{Remaining: remaining}, err
+}
+
+// drainError reports the jobs a Shutdown left unstarted, or nil
+func (wp *WorkerPool[T, R]) drainError() error {
+	wp.mu.RLock()
+	defer wp.mu.RUnlock()
+
+	if !wp.draining || wp.pending == nil {
+		return nil
+	}
+	if n := wp.pending.count(); n > 0 {
+		return fmt.Errorf("%w: %d jobs not started", ErrPoolShutdown, n)
+	}
+	return nil
+}
+
+// clearJobsLocked empties the job list. Callers must hold wp.mu.
+func (wp *WorkerPool[T, R]) clearJobsLocked() {
+	wp.jobs = nil
+	wp.metrics.TotalJobs = 0
+	wp.tenants.resetQueued()
+}
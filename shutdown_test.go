@@ -0,0 +1,95 @@
+package workerpool
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+func (ts *WorkerPoolTestSuite) TestShutdownBeforeRun() {
+	pool := New[string, string]()
+	pool.AddJobs([]Job[string]{{ID: "1"}, {ID: "2"}})
+
+	res, err := pool.Shutdown(context.Background())
+	ts.NoError(err)
+	ts.Len(res.Remaining, 2)
+	ts.Empty(pool.PendingJobs())
+}
+
+func (ts *WorkerPoolTestSuite) TestShutdownDrainsUnstartedJobs() {
+	config := DefaultConfig()
+	config.NumWorkers = 2
+	pool := NewWithConfig[string, string](config)
+
+	started := make(chan struct{}, 10)
+	release := make(chan struct{})
+	pool.WithProcessor(func(ctx context.Context, job Job[string]) (string, error) {
+		started <- struct{}{}
+		<-release
+		return job.Data, nil
+	})
+
+	var jobs []Job[string]
+	for i := 0; i < 10; i++ {
+		jobs = append(jobs, Job[string]{ID: fmt.Sprintf("%d", i), Data: "x"})
+	}
+	pool.AddJobs(jobs)
+
+	var wg sync.WaitGroup
+	var results []Result[string]
+	var runErr error
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		results, runErr = pool.Run()
+	}()
+
+	<-started
+	<-started
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		close(release)
+	}()
+
+	res, err := pool.Shutdown(context.Background())
+	ts.NoError(err)
+	wg.Wait()
+
+	ts.ErrorIs(runErr, ErrPoolShutdown)
+	ts.Len(results, 2)
+	ts.Len(res.Remaining, 8)
+	for _, result := range results {
+		ts.NoError(result.Error)
+	}
+	ts.Empty(pool.PendingJobs())
+}
+
+func (ts *WorkerPoolTestSuite) TestShutdownDeadlineCancelsInFlight() {
+	config := DefaultConfig()
+	config.NumWorkers = 1
+	pool := NewWithConfig[string, string](config)
+
+	started := make(chan struct{}, 3)
+	pool.WithProcessor(func(ctx context.Context, job Job[string]) (string, error) {
+		started <- struct{}{}
+		<-ctx.Done()
+		return "", context.Cause(ctx)
+	})
+	pool.AddJobs([]Job[string]{{ID: "1"}, {ID: "2"}, {ID: "3"}})
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_, err := pool.Run()
+		ts.ErrorIs(err, ErrPoolShutdown)
+	}()
+
+	<-started
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	res, err := pool.Shutdown(ctx)
+	ts.ErrorIs(err, context.DeadlineExceeded)
+	ts.Len(res.Remaining, 2)
+	<-done
+}
@@ -0,0 +1,85 @@
+package workerpool
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+)
+
+// SignalShutdown is the outcome of a Shutdown triggered by HandleSignals
+type SignalShutdown[T any] struct {
+	Signal    os.Signal // Signal that triggered the shutdown
+	Remaining []Job[T]  // Jobs that were never started, ready to persist or resubmit
+	Err       error     // Shutdown's error, e.g. context.DeadlineExceeded when in-flight jobs were cut off
+}
+
+// HandleSignals shuts pool down gracefully when one of signals arrives,
+// os.Interrupt and SIGTERM if none are given. In-flight jobs get
+// Config.ShutdownTimeout to finish, or as long as they need when it is zero;
+// a second signal cancels them right away. The outcome is sent on the
+// returned channel, which is then closed. Calling stop before a signal
+// arrives closes the channel without shutting down.
+//
+// Signals are relayed to the pool only until the shutdown completes, so a
+// signal arriving afterwards gets its default behavior again.
+//
+//	shutdown, stop := workerpool.HandleSignals(pool, syscall.SIGTERM, syscall.SIGINT)
+//	defer stop()
+//	results, err := pool.Run()
+//	if s, ok := <-shutdown; ok {
+//		persist(s.Remaining)
+//	}
+func HandleSignals[T, R any](pool *WorkerPool[T, R], signals ...os.Signal) (shutdown <-chan SignalShutdown[T], stop func()) {
+	if len(signals) == 0 {
+		signals = []os.Signal{os.Interrupt, syscall.SIGTERM}
+	}
+	sigs := make(chan os.Signal, 2)
+	signal.Notify(sigs, signals...)
+
+	quit := make(chan struct{})
+	var once sync.Once
+	stop = func() { once.Do(func() { close(quit) }) }
+	return handleSignals(pool, sigs, quit, func() { signal.Stop(sigs) }), stop
+}
+
+// handleSignals shuts pool down on the first value from sigs and cancels
+// in-flight jobs on the second, calling release once it stops listening
+func handleSignals[T, R any](pool *WorkerPool[T, R], sigs <-chan os.Signal, quit <-chan struct{}, release func()) <-chan SignalShutdown[T] {
+	out := make(chan SignalShutdown[T], 1)
+	go func() {
+		defer close(out)
+		defer release()
+
+		var sig os.Signal
+		select {
+		case sig = <-sigs:
+		case <-quit:
+			return
+		}
+
+		pool.mu.RLock()
+		timeout := pool.config.ShutdownTimeout
+		pool.mu.RUnlock()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		if timeout > 0 {
+			var cancelTimeout context.CancelFunc
+			ctx, cancelTimeout = context.WithTimeout(ctx, timeout)
+			defer cancelTimeout()
+		}
+		go func() {
+			select {
+			case <-sigs:
+				cancel()
+			case <-ctx.Done():
+			}
+		}()
+
+		res, err := pool.Shutdown(ctx)
+		out <- SignalShutdown[T]{Signal: sig, Remaining: res.Remaining, Err: err}
+	}()
+	return out
+}
@@ -0,0 +1,94 @@
+package workerpool
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"syscall"
+	"time"
+)
+
+func (ts *WorkerPoolTestSuite) TestHandleSignalsDrains() {
+	config := DefaultConfig()
+	config.NumWorkers = 1
+	pool := NewWithConfig[int, int](config)
+	started, release := make(chan struct{}, 5), make(chan struct{})
+	pool.WithProcessor(func(ctx context.Context, job Job[int]) (int, error) {
+		started <- struct{}{}
+		<-release
+		return job.Data, nil
+	})
+	for i := 0; i < 5; i++ {
+		pool.AddJob(Job[int]{ID: fmt.Sprint(i), Data: i})
+	}
+
+	sigs := make(chan os.Signal, 2)
+	released := make(chan struct{})
+	shutdown := handleSignals(pool, sigs, nil, func() { close(released) })
+
+	done := make(chan error)
+	go func() {
+		_, err := pool.Run()
+		done <- err
+	}()
+	<-started
+	sigs <- syscall.SIGTERM
+	time.AfterFunc(10*time.Millisecond, func() { close(release) })
+
+	s, ok := <-shutdown
+	ts.True(ok)
+	ts.Equal(syscall.SIGTERM, s.Signal)
+	ts.NoError(s.Err)
+	ts.Len(s.Remaining, 4)
+	ts.ErrorIs(<-done, ErrPoolShutdown)
+	<-released
+	_, ok = <-shutdown
+	ts.False(ok)
+}
+
+func (ts *WorkerPoolTestSuite) TestHandleSignalsDeadlineAndSecondSignal() {
+	for _, second := range []bool{false, true} {
+		config := DefaultConfig()
+		config.NumWorkers = 1
+		if !second {
+			config.ShutdownTimeout = 10 * time.Millisecond
+		}
+		pool := NewWithConfig[int, int](config)
+		started := make(chan struct{}, 1)
+		pool.WithProcessor(func(ctx context.Context, job Job[int]) (int, error) {
+			started <- struct{}{}
+			<-ctx.Done()
+			return 0, context.Cause(ctx)
+		})
+		pool.AddJob(Job[int]{ID: "a"})
+
+		sigs := make(chan os.Signal, 2)
+		shutdown := handleSignals(pool, sigs, nil, func() {})
+		go pool.Run()
+		<-started
+		sigs <- os.Interrupt
+		if second {
+			sigs <- os.Interrupt
+		}
+
+		s := <-shutdown
+		if second {
+			ts.ErrorIs(s.Err, context.Canceled)
+		} else {
+			ts.ErrorIs(s.Err, context.DeadlineExceeded)
+		}
+		ts.Empty(s.Remaining)
+	}
+}
+
+func (ts *WorkerPoolTestSuite) TestHandleSignalsStop() {
+	pool := New[int, int]()
+	pool.AddJob(Job[int]{ID: "a"})
+	shutdown, stop := HandleSignals(pool)
+	stop()
+	stop()
+
+	_, ok := <-shutdown
+	ts.False(ok)
+	ts.Len(pool.PendingJobs(), 1, "stopping the handler leaves the pool alone")
+}
@@ -0,0 +1,217 @@
+package workerpool
+
+import (
+	"time"
+)
+
+// Simulation is the predicted schedule of a batch under one strategy
+type Simulation struct {
+	Strategy    string            // Strategy name; Adaptive reports the one it would suggest, e.g. "adaptive/chunked"
+	Makespan    time.Duration     // Time until the last job would finish
+	Utilization float64           // Average fraction of the makespan workers would be busy
+	Workers     []SimulatedWorker // Predicted assignment, indexed by worker ID
+}
+
+// SimulatedWorker is the predicted workload of one worker
+type SimulatedWorker struct {
+	Jobs        []string      // IDs of the jobs it would run, in order
+	Busy        time.Duration // Summed estimates of those jobs
+	Utilization float64       // Busy time as a fraction of the makespan
+}
+
+// simulatedStrategies are the strategies Simulate predicts, in report order
+var simulatedStrategies = []DistributionStrategy{RoundRobin, Chunked, WorkStealing, PriorityBased, Adaptive, FairShare}
+
+// Simulate predicts how each strategy would schedule jobs under config,
+// given an estimate of every job's processing time, without running any
+// processor. It is meant for capacity planning: the model assumes every
+// estimate is exact and ignores retries, dependencies, execution windows,
+// quotas and dispatch overhead. WorkStealing thieves are assumed to pick the
+// fullest deque.
+func Simulate[T any](jobs []Job[T], config Config, estimate func(Job[T]) time.Duration) []Simulation {
+	numWorkers := max(1, config.NumWorkers)
+	sims := make([]Simulation, 0, len(simulatedStrategies))
+	for _, strategy := range simulatedStrategies {
+		name := strategy.String()
+		if strategy == Adaptive {
+			pool := &WorkerPool[T, struct{}]{config: config}
+			pool.config.NumWorkers = numWorkers
+			strategy = strategyNamed(pool.analyzeWorkload(jobs))
+			name += "/" + strategy.String()
+		}
+		next := simulatedDispatch(strategy, jobs, numWorkers, config, estimate)
+		sim := simulateSchedule(numWorkers, next, estimate)
+		sim.Strategy = name
+		sims = append(sims, sim)
+	}
+	return sims
+}
+
+// simulateSchedule runs a list schedule: whichever worker frees up first
+// asks next for its job until no worker gets one
+func simulateSchedule[T any](numWorkers int, next func(worker int) (Job[T], bool), estimate func(Job[T]) time.Duration) Simulation {
+	workers := make([]SimulatedWorker, numWorkers)
+	free := make([]time.Duration, numWorkers)
+	done := make([]bool, numWorkers)
+	for {
+		w := -1
+		for i := range workers {
+			if !done[i] && (w < 0 || free[i] < free[w]) {
+				w = i
+			}
+		}
+		if w < 0 {
+			break
+		}
+		job, ok := next(w)
+		if !ok {
+			done[w] = true
+			continue
+		}
+		d := estimate(job)
+		free[w] += d
+		workers[w].Busy += d
+		workers[w].Jobs = append(workers[w].Jobs, job.ID)
+	}
+
+	sim := Simulation{Workers: workers}
+	for _, f := range free {
+		if f > sim.Makespan {
+			sim.Makespan = f
+		}
+	}
+	if sim.Makespan > 0 {
+		for i := range workers {
+			workers[i].Utilization = float64(workers[i].Busy) / float64(sim.Makespan)
+			sim.Utilization += workers[i].Utilization / float64(numWorkers)
+		}
+	}
+	return sim
+}
+
+// simulatedDispatch returns how strategy hands jobs to idle workers,
+// mirroring the queues the strategy uses at run time
+func simulatedDispatch[T any](strategy DistributionStrategy, jobs []Job[T], numWorkers int, config Config, estimate func(Job[T]) time.Duration) func(worker int) (Job[T], bool) {
+	switch strategy {
+	case Chunked:
+		if size := config.StrategyOptions.ChunkSize; size > 0 {
+			// Idle workers pull the next fixed-size chunk
+			var chunks [][]Job[T]
+			for start := 0; start < len(jobs); start += size {
+				chunks = append(chunks, jobs[start:min(start+size, len(jobs))])
+			}
+			current := make([][]Job[T], numWorkers)
+			return func(w int) (Job[T], bool) {
+				if len(current[w]) == 0 {
+					if len(chunks) == 0 {
+						return Job[T]{}, false
+					}
+					current[w], chunks = chunks[0], chunks[1:]
+				}
+				job := current[w][0]
+				current[w] = current[w][1:]
+				return job, true
+			}
+		}
+		chunkSize := len(jobs) / numWorkers
+		remainder := len(jobs) % numWorkers
+		slices := make([][]Job[T], numWorkers)
+		start := 0
+		for i := range slices {
+			end := start + chunkSize
+			if i < remainder {
+				end++
+			}
+			if start < len(jobs) {
+				slices[i] = jobs[start:end]
+			}
+			start = end
+		}
+		return ownQueues(slices)
+
+	case WorkStealing:
+		deques := make([]*WorkStealingDeque[T], numWorkers)
+		for i := range deques {
+			deques[i] = NewWorkStealingDeque[T](len(jobs)/numWorkers + 1)
+		}
+		for i, job := range jobs {
+			deques[i%numWorkers].Push(job)
+		}
+		return func(w int) (Job[T], bool) {
+			if job, ok := deques[w].Pop(); ok {
+				return job, true
+			}
+			victim := -1
+			for i, d := range deques {
+				if d.Size() > 0 && (victim < 0 || d.Size() > deques[victim].Size()) {
+					victim = i
+				}
+			}
+			if victim < 0 {
+				return Job[T]{}, false
+			}
+			return deques[victim].Steal()
+		}
+
+	case PriorityBased:
+		var queue Queue[T] = NewPriorityQueue[T]()
+		if len(config.PriorityLanes) > 0 {
+			queue = NewLaneQueue[T](config.PriorityLanes)
+		}
+		now := time.Now()
+		for _, job := range jobs {
+			if job.Created.IsZero() {
+				job.Created = now
+			}
+			queue.Push(job)
+		}
+		return func(int) (Job[T], bool) { return queue.Pop() }
+
+	case FairShare:
+		// Serve the owner with the least estimated time assigned so far
+		owners := make(map[string]*PriorityQueue[T])
+		var order []string
+		for _, job := range jobs {
+			owner := job.OwnerKey()
+			if owners[owner] == nil {
+				owners[owner] = NewPriorityQueue[T]()
+				order = append(order, owner)
+			}
+			owners[owner].Push(job)
+		}
+		used := make(map[string]time.Duration)
+		return func(int) (Job[T], bool) {
+			best := -1
+			for i, owner := range order {
+				if !owners[owner].IsEmpty() && (best < 0 || used[owner] < used[order[best]]) {
+					best = i
+				}
+			}
+			if best < 0 {
+				return Job[T]{}, false
+			}
+			job, _ := owners[order[best]].Pop()
+			used[order[best]] += estimate(job)
+			return job, true
+		}
+
+	default:
+		queues := make([][]Job[T], numWorkers)
+		for i, job := range jobs {
+			queues[i%numWorkers] = append(queues[i%numWorkers], job)
+		}
+		return ownQueues(queues)
+	}
+}
+
+// ownQueues dispatches each worker's jobs in order, with no sharing
+func ownQueues[T any](queues [][]Job[T]) func(worker int) (Job[T], bool) {
+	return func(w int) (Job[T], bool) {
+		if len(queues[w]) == 0 {
+			return Job[T]{}, false
+		}
+		job := queues[w][0]
+		queues[w] = queues[w][1:]
+		return job, true
+	}
+}
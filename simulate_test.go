@@ -0,0 +1,76 @@
+package workerpool
+
+import (
+	"fmt"
+	"time"
+)
+
+func (ts *WorkerPoolTestSuite) TestSimulatePredictsSchedules() {
+	// Alternating long and short jobs pile the long ones onto one worker
+	// under RoundRobin; stealing rebalances them
+	jobs := []Job[int]{{ID: "0", Data: 4}, {ID: "1", Data: 1}, {ID: "2", Data: 4}, {ID: "3", Data: 1}}
+	config := DefaultConfig()
+	config.NumWorkers = 2
+	estimate := func(job Job[int]) time.Duration { return time.Duration(job.Data) * time.Second }
+
+	sims := make(map[string]Simulation)
+	for _, sim := range Simulate(jobs, config, estimate) {
+		sims[sim.Strategy] = sim
+		total := 0
+		for _, w := range sim.Workers {
+			total += len(w.Jobs)
+		}
+		ts.Equal(len(jobs), total, sim.Strategy)
+	}
+	ts.Len(sims, 6)
+
+	rr := sims["round_robin"]
+	ts.Equal(8*time.Second, rr.Makespan)
+	ts.Equal([]string{"0", "2"}, rr.Workers[0].Jobs)
+	ts.Equal(1.0, rr.Workers[0].Utilization)
+	ts.InDelta(0.625, rr.Utilization, 1e-9)
+
+	ws := sims["work_stealing"]
+	ts.Equal(6*time.Second, ws.Makespan)
+	ts.Equal([]string{"3", "1", "0"}, ws.Workers[1].Jobs)
+
+	ts.Equal(5*time.Second, sims["chunked"].Makespan)
+	ts.Contains(sims, "adaptive/round_robin")
+}
+
+func (ts *WorkerPoolTestSuite) TestSimulatePriorityAndChunkQueue() {
+	var jobs []Job[int]
+	for i := 0; i < 6; i++ {
+		jobs = append(jobs, Job[int]{ID: fmt.Sprintf("%d", i), Priority: i})
+	}
+	config := DefaultConfig()
+	config.NumWorkers = 2
+	config.StrategyOptions.ChunkSize = 4
+	estimate := func(Job[int]) time.Duration { return time.Second }
+
+	sims := Simulate(jobs, config, estimate)
+	for _, sim := range sims {
+		switch sim.Strategy {
+		case "priority_based":
+			ts.Equal([]string{"5", "3", "1"}, sim.Workers[0].Jobs)
+			ts.Equal(3*time.Second, sim.Makespan)
+		case "chunked":
+			ts.Equal([]string{"0", "1", "2", "3"}, sim.Workers[0].Jobs)
+			ts.Equal(4*time.Second, sim.Makespan)
+		}
+	}
+}
+
+func (ts *WorkerPoolTestSuite) TestSimulateFairShareAlternatesOwners() {
+	jobs := []Job[int]{
+		{ID: "a1", Owner: "a"}, {ID: "a2", Owner: "a"}, {ID: "a3", Owner: "a"},
+		{ID: "b1", Owner: "b"},
+	}
+	config := DefaultConfig()
+	config.NumWorkers = 1
+	for _, sim := range Simulate(jobs, config, func(Job[int]) time.Duration { return time.Second }) {
+		if sim.Strategy == "fair_share" {
+			ts.Equal([]string{"a1", "b1", "a2", "a3"}, sim.Workers[0].Jobs)
+		}
+	}
+}
@@ -0,0 +1,329 @@
+package workerpool
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+// defaultSinkBuffer is how many results a sink may fall behind before the
+// pool waits for it
+const defaultSinkBuffer = 1024
+
+// ResultSink receives every result of a run as it completes. Each sink is
+// fed from its own goroutine, so a slow or failing sink does not hold up the
+// others. Sinks that implement io.Closer are not closed by the pool.
+type ResultSink[R any] interface {
+	Write(result Result[R]) error
+}
+
+// SinkMetrics describes how a sink kept up with the results of a run
+type SinkMetrics struct {
+	Written   int           // Results the sink accepted
+	Failed    int           // Results the sink returned an error for
+	Pending   int           // Results queued for the sink but not yet written
+	Lag       time.Duration // Delay between a result completing and the sink receiving it, for the latest result
+	MaxLag    time.Duration // Largest lag observed
+	LastError error         // Most recent write error
+}
+
+// SinkOptions tunes how results are delivered to a sink
+type SinkOptions[R any] struct {
+	Buffer  int                    // Results the sink may fall behind before the pool waits; zero means 1024
+	OnError func(Result[R], error) // Called when the sink fails to write a result
+}
+
+// sinkEntry is a registered sink and its metrics
+type sinkEntry[R any] struct {
+	name    string
+	sink    ResultSink[R]
+	options SinkOptions[R]
+	metrics SinkMetrics
+	mu      sync.Mutex
+}
+
+// WithSink attaches a result sink under name, replacing any sink of that name.
+// Results still reach Run's return value or All's iterator as well.
+func (wp *WorkerPool[T, R]) WithSink(name string, sink ResultSink[R]) *WorkerPool[T, R] {
+	return wp.WithSinkOptions(name, sink, SinkOptions[R]{})
+}
+
+// WithSinkOptions attaches a result sink with delivery options
+func (wp *WorkerPool[T, R]) WithSinkOptions(name string, sink ResultSink[R], options SinkOptions[R]) *WorkerPool[T, R] {
+	if options.Buffer <= 0 {
+		options.Buffer = defaultSinkBuffer
+	}
+	entry := &sinkEntry[R]{name: name, sink: sink, options: options}
+
+	wp.mu.Lock()
+	defer wp.mu.Unlock()
+	for i, s := range wp.sinks {
+		if s.name == name {
+			wp.sinks[i] = entry
+			return wp
+		}
+	}
+	wp.sinks = append(wp.sinks, entry)
+	return wp
+}
+
+// SinkMetrics returns the delivery metrics of every attached sink by name
+func (wp *WorkerPool[T, R]) SinkMetrics() map[string]SinkMetrics {
+	wp.mu.RLock()
+	sinks := wp.sinks
+	wp.mu.RUnlock()
+
+	stats := make(map[string]SinkMetrics, len(sinks))
+	for _, s := range sinks {
+		s.mu.Lock()
+		stats[s.name] = s.metrics
+		s.mu.Unlock()
+	}
+	return stats
+}
+
+// fanOut feeds results to every sink attached when the run started. The
+// returned send function queues a result for all sinks; wait closes the
+// queues and blocks until every sink has caught up.
+func (wp *WorkerPool[T, R]) fanOut() (send func(Result[R]), wait func()) {
+	wp.mu.RLock()
+	sinks := wp.sinks
+	wp.mu.RUnlock()
+
+	if len(sinks) == 0 {
+		return func(Result[R]) {}, func() {}
+	}
+
+	var wg sync.WaitGroup
+	queues := make([]chan Result[R], len(sinks))
+	for i, s := range sinks {
+		queues[i] = make(chan Result[R], s.options.Buffer)
+		wg.Add(1)
+		go func(id int, s *sinkEntry[R], queue <-chan Result[R]) {
+			defer wg.Done()
+			wp.labelGoroutine(context.Background(), fmt.Sprintf("sink-%d", id))
+			for result := range queue {
+				s.deliver(result)
+			}
+		}(i, s, queues[i])
+	}
+
+	send = func(result Result[R]) {
+		for i, s := range sinks {
+			s.mu.Lock()
+			s.metrics.Pending++
+			s.mu.Unlock()
+			queues[i] <- result
+		}
+	}
+	wait = func() {
+		for _, queue := range queues {
+			close(queue)
+		}
+		wg.Wait()
+	}
+	return send, wait
+}
+
+// deliver writes one result to the sink and updates its metrics
+func (s *sinkEntry[R]) deliver(result Result[R]) {
+	lag := time.Duration(0)
+	if !result.Completed.IsZero() {
+		lag = time.Since(result.Completed)
+	}
+	err := s.sink.Write(result)
+
+	s.mu.Lock()
+	s.metrics.Pending--
+	s.metrics.Lag = lag
+	if lag > s.metrics.MaxLag {
+		s.metrics.MaxLag = lag
+	}
+	if err != nil {
+		s.metrics.Failed++
+		s.metrics.LastError = err
+	} else {
+		s.metrics.Written++
+	}
+	s.mu.Unlock()
+
+	if err != nil && s.options.OnError != nil {
+		s.options.OnError(result, err)
+	}
+}
+
+// SliceSink collects results in memory
+type SliceSink[R any] struct {
+	results []Result[R]
+	mu      sync.Mutex
+}
+
+// Write appends the result
+func (s *SliceSink[R]) Write(result Result[R]) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.results = append(s.results, result)
+	return nil
+}
+
+// Results returns a copy of the collected results
+func (s *SliceSink[R]) Results() []Result[R] {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Result[R](nil), s.results...)
+}
+
+// JSONLSink writes each result as one line of JSON. ReadJSONL reads the
+// results back.
+type JSONLSink[R any] struct {
+	enc         *json.Encoder
+	compression *Compression
+	encryptor   *Encryptor
+	mu          sync.Mutex
+}
+
+// jsonlRecord is the JSON form of a result written by JSONLSink. Data holds
+// the payload unless it was compressed into CompressedData or sealed into
+// EncryptedData.
+type jsonlRecord struct {
+	JobID          string          `json:"job_id"`
+	Data           json.RawMessage `json:"data,omitempty"`
+	CompressedData []byte          `json:"data_compressed,omitempty"`
+	EncryptedData  []byte          `json:"data_encrypted,omitempty"`
+	Encoding       string          `json:"data_encoding,omitempty"` // Codec name of compressed data, sealed or not
+	Error          string          `json:"error,omitempty"`
+	Worker         int             `json:"worker"`
+	Started        time.Time       `json:"started"`
+	Completed      time.Time       `json:"completed"`
+	Duration       time.Duration   `json:"duration_ns"`
+	Attempts       int             `json:"attempts"`
+}
+
+// NewJSONLSink creates a sink writing JSON lines to w
+func NewJSONLSink[R any](w io.Writer) *JSONLSink[R] {
+	return &JSONLSink[R]{enc: json.NewEncoder(w)}
+}
+
+// WithCompression compresses result payloads at least c.MinSize bytes long
+// as JSON. Each compressed payload is stored base64-encoded together with
+// the codec name, so ReadJSONL decompresses it transparently.
+func (s *JSONLSink[R]) WithCompression(c Compression) *JSONLSink[R] {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.compression = &c
+	return s
+}
+
+// WithEncryption seals every result payload, after any compression, with e.
+// Payloads are bound to their job ID. Read the output back with
+// ReadEncryptedJSONL.
+func (s *JSONLSink[R]) WithEncryption(e *Encryptor) *JSONLSink[R] {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.encryptor = e
+	return s
+}
+
+// Write encodes the result as a JSON line
+func (s *JSONLSink[R]) Write(result Result[R]) error {
+	data, err := json.Marshal(result.Data)
+	if err != nil {
+		return err
+	}
+	record := jsonlRecord{
+		JobID:     result.JobID,
+		Data:      data,
+		Worker:    result.Worker,
+		Started:   result.Started,
+		Completed: result.Completed,
+		Duration:  result.Duration,
+		Attempts:  result.Attempts,
+	}
+	if result.Error != nil {
+		record.Error = result.Error.Error()
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if c := s.compression; c != nil && len(data) >= c.MinSize {
+		codec := c.codec()
+		compressed, err := compress(codec, c.Level, data)
+		if err != nil {
+			return err
+		}
+		record.Data, record.CompressedData, record.Encoding = nil, compressed, codec.Name()
+	}
+	if s.encryptor != nil {
+		plaintext := []byte(record.Data)
+		if record.Encoding != "" {
+			plaintext = record.CompressedData
+		}
+		sealed, err := s.encryptor.Seal(plaintext, []byte(record.JobID))
+		if err != nil {
+			return err
+		}
+		record.Data, record.CompressedData, record.EncryptedData = nil, nil, sealed
+	}
+	return s.enc.Encode(record)
+}
+
+// ReadJSONL reads results written by JSONLSink, decompressing payloads with
+// the codec they were written with. Errors are restored as plain errors
+// carrying the recorded message.
+func ReadJSONL[R any](r io.Reader) ([]Result[R], error) {
+	return ReadEncryptedJSONL[R](r, nil)
+}
+
+// ReadEncryptedJSONL is ReadJSONL for sinks with encryption, opening sealed
+// payloads with e
+func ReadEncryptedJSONL[R any](r io.Reader, e *Encryptor) ([]Result[R], error) {
+	var results []Result[R]
+	dec := json.NewDecoder(r)
+	for {
+		var record jsonlRecord
+		if err := dec.Decode(&record); err == io.EOF {
+			return results, nil
+		} else if err != nil {
+			return results, err
+		}
+
+		data := []byte(record.Data)
+		if record.EncryptedData != nil {
+			if e == nil {
+				return results, fmt.Errorf("job %s: payload is encrypted", record.JobID)
+			}
+			plaintext, err := e.Open(record.EncryptedData, []byte(record.JobID))
+			if err != nil {
+				return results, fmt.Errorf("job %s: %w", record.JobID, err)
+			}
+			data, record.CompressedData = plaintext, plaintext
+		}
+		if record.Encoding != "" {
+			var err error
+			if data, err = Decompress(record.Encoding, record.CompressedData); err != nil {
+				return results, fmt.Errorf("job %s: %w", record.JobID, err)
+			}
+		}
+		result := Result[R]{
+			JobID:     record.JobID,
+			Worker:    record.Worker,
+			Started:   record.Started,
+			Completed: record.Completed,
+			Duration:  record.Duration,
+			Attempts:  record.Attempts,
+		}
+		if len(data) > 0 {
+			if err := json.Unmarshal(data, &result.Data); err != nil {
+				return results, fmt.Errorf("job %s: %w", record.JobID, err)
+			}
+		}
+		if record.Error != "" {
+			result.Error = errors.New(record.Error)
+		}
+		results = append(results, result)
+	}
+}
@@ -0,0 +1,77 @@
+package workerpool
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"time"
+)
+
+// flakySink fails every other write and is slow to respond
+type flakySink struct {
+	writes int32
+}
+
+func (s *flakySink) Write(result Result[string]) error {
+	time.Sleep(2 * time.Millisecond)
+	if atomic.AddInt32(&s.writes, 1)%2 == 0 {
+		return errors.New("webhook unavailable")
+	}
+	return nil
+}
+
+func (ts *WorkerPoolTestSuite) TestMultipleSinks() {
+	pool := New[string, string]()
+	pool.WithProcessor(func(ctx context.Context, job Job[string]) (string, error) {
+		return job.Data + "!", nil
+	})
+
+	memory := &SliceSink[string]{}
+	var buf bytes.Buffer
+	var onError int32
+	pool.WithSink("memory", memory).
+		WithSink("jsonl", NewJSONLSink[string](&buf)).
+		WithSinkOptions("webhook", &flakySink{}, SinkOptions[string]{
+			Buffer: 1,
+			OnError: func(Result[string], error) {
+				atomic.AddInt32(&onError, 1)
+			},
+		})
+
+	var jobs []Job[string]
+	for i := 0; i < 10; i++ {
+		jobs = append(jobs, Job[string]{ID: fmt.Sprintf("%d", i), Data: "x"})
+	}
+	pool.AddJobs(jobs)
+
+	results, err := pool.Run()
+	ts.NoError(err)
+	ts.Len(results, 10)
+	ts.Len(memory.Results(), 10)
+
+	lines := 0
+	scanner := bufio.NewScanner(&buf)
+	for scanner.Scan() {
+		var record map[string]any
+		ts.NoError(json.Unmarshal(scanner.Bytes(), &record))
+		ts.Equal("x!", record["data"])
+		lines++
+	}
+	ts.Equal(10, lines)
+
+	metrics := pool.SinkMetrics()
+	ts.Equal(10, metrics["memory"].Written)
+	ts.Equal(10, metrics["jsonl"].Written)
+	ts.Equal(5, metrics["webhook"].Written)
+	ts.Equal(5, metrics["webhook"].Failed)
+	ts.EqualError(metrics["webhook"].LastError, "webhook unavailable")
+	ts.Equal(int32(5), atomic.LoadInt32(&onError))
+	for name, m := range metrics {
+		ts.Zero(m.Pending, name)
+	}
+	ts.Greater(metrics["webhook"].MaxLag, time.Duration(0))
+}
@@ -0,0 +1,194 @@
+package workerpool
+
+import (
+	"bytes"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"runtime"
+	"runtime/trace"
+	"sync/atomic"
+	"time"
+)
+
+// defaultSlowJobCaptures is how many slow jobs are captured per run by default
+const defaultSlowJobCaptures = 10
+
+// SlowJobProfiling captures diagnostics of jobs that run longer than
+// Threshold: every goroutine's stack at the moment the job became slow and,
+// if Trace is set, an execution trace of the following Trace duration.
+// Captures go to the sink set with WithSlowJobSink and are taken in the
+// background, so the slow job is not held up. The execution trace covers the
+// whole process, and is skipped while another trace is running. A zero
+// Threshold, or no sink, disables capture.
+type SlowJobProfiling struct {
+	Threshold   time.Duration // Run time after which a job is captured
+	Trace       time.Duration // Length of the execution trace to record; zero records none
+	MaxCaptures int           // Captures per run, to bound the cost of a slow dependency; defaults to 10
+}
+
+// SlowJobCapture is what was captured about one slow job
+type SlowJobCapture struct {
+	Job       JobSummary
+	Worker    int
+	Elapsed   time.Duration // How long the job had been running when captured
+	Captured  time.Time
+	Goroutine []byte // Stack of the worker goroutine running the job, if it was found
+	Stacks    []byte // Stacks of every goroutine
+	Trace     []byte // runtime/trace output; nil when not requested or unavailable
+}
+
+// SlowJobSink stores slow job captures
+type SlowJobSink interface {
+	WriteSlowJob(capture SlowJobCapture) error
+}
+
+// SlowJobSinkFunc adapts a function to a SlowJobSink
+type SlowJobSinkFunc func(SlowJobCapture) error
+
+// WriteSlowJob calls f
+func (f SlowJobSinkFunc) WriteSlowJob(capture SlowJobCapture) error {
+	return f(capture)
+}
+
+// DirSlowJobSink writes each capture to files in a directory, named after
+// the job and the capture time: JOB-TIME.stacks.txt, and JOB-TIME.trace
+// when a trace was recorded
+type DirSlowJobSink string
+
+// WriteSlowJob writes the capture's files
+func (d DirSlowJobSink) WriteSlowJob(capture SlowJobCapture) error {
+	base := filepath.Join(string(d), fmt.Sprintf("%s-%s",
+		url.PathEscape(capture.Job.ID), capture.Captured.UTC().Format("20060102T150405.000000000")))
+
+	var stacks bytes.Buffer
+	fmt.Fprintf(&stacks, "job %s on worker %d, running for %s\n\n", capture.Job.ID, capture.Worker, capture.Elapsed)
+	if capture.Goroutine != nil {
+		fmt.Fprintf(&stacks, "%s\n\nall goroutines:\n\n", capture.Goroutine)
+	}
+	stacks.Write(capture.Stacks)
+	if err := os.WriteFile(base+".stacks.txt", stacks.Bytes(), 0o644); err != nil {
+		return err
+	}
+	if capture.Trace != nil {
+		return os.WriteFile(base+".trace", capture.Trace, 0o644)
+	}
+	return nil
+}
+
+// WithSlowJobSink sets where Config.SlowJobs captures are stored. Sink
+// errors go to the handler set with WithSlowJobErrorHandler, or are dropped.
+func (wp *WorkerPool[T, R]) WithSlowJobSink(sink SlowJobSink) *WorkerPool[T, R] {
+	wp.mu.Lock()
+	defer wp.mu.Unlock()
+	wp.slowSink = sink
+	return wp
+}
+
+// WithSlowJobErrorHandler sets a function called with every capture the
+// slow job sink failed to store. It runs on the capturing goroutine.
+func (wp *WorkerPool[T, R]) WithSlowJobErrorHandler(handler func(SlowJobCapture, error)) *WorkerPool[T, R] {
+	wp.mu.Lock()
+	defer wp.mu.Unlock()
+	wp.onSlowError = handler
+	return wp
+}
+
+// watchSlow arms a capture of job if it is still running after the
+// threshold. The returned function disarms it when the job finishes.
+func (wp *WorkerPool[T, R]) watchSlow(workerID int, job Job[T]) (done func()) {
+	cfg := wp.config.SlowJobs
+	wp.mu.RLock()
+	sink, onError := wp.slowSink, wp.onSlowError
+	wp.mu.RUnlock()
+	if cfg.Threshold <= 0 || sink == nil {
+		return func() {}
+	}
+
+	started := time.Now()
+	goroutine := currentGoroutine()
+	timer := time.AfterFunc(cfg.Threshold, func() {
+		limit := cfg.MaxCaptures
+		if limit <= 0 {
+			limit = defaultSlowJobCaptures
+		}
+		if wp.slowCaptures.Add(1) > int32(limit) {
+			return
+		}
+		now := time.Now()
+		stacks := allStacks()
+		capture := SlowJobCapture{
+			Job:       summarize(job),
+			Worker:    workerID,
+			Elapsed:   now.Sub(started),
+			Captured:  now,
+			Goroutine: goroutineStack(stacks, goroutine),
+			Stacks:    stacks,
+		}
+		if cfg.Trace > 0 {
+			capture.Trace = recordTrace(cfg.Trace)
+		}
+		if err := sink.WriteSlowJob(capture); err != nil && onError != nil {
+			onError(capture, err)
+		}
+	})
+	return func() { timer.Stop() }
+}
+
+// currentGoroutine returns the header line identifying the calling
+// goroutine in stack dumps, e.g. "goroutine 42 "
+func currentGoroutine() []byte {
+	buf := make([]byte, 64)
+	buf = buf[:runtime.Stack(buf, false)]
+	if i := bytes.IndexByte(buf, '['); i > 0 {
+		return buf[:i]
+	}
+	return nil
+}
+
+// allStacks returns the stacks of every goroutine
+func allStacks() []byte {
+	buf := make([]byte, 1<<16)
+	for {
+		n := runtime.Stack(buf, true)
+		if n < len(buf) {
+			return buf[:n]
+		}
+		buf = make([]byte, 2*len(buf))
+	}
+}
+
+// goroutineStack picks one goroutine's stack out of a dump of all of them
+func goroutineStack(stacks, header []byte) []byte {
+	if header == nil {
+		return nil
+	}
+	for _, stack := range bytes.Split(stacks, []byte("\n\n")) {
+		if bytes.HasPrefix(stack, header) {
+			return stack
+		}
+	}
+	return nil
+}
+
+// traceActive prevents captures of concurrently slow jobs from each trying
+// to start an execution trace
+var traceActive atomic.Bool
+
+// recordTrace records an execution trace of the whole process for d, or
+// returns nil if another trace is running
+func recordTrace(d time.Duration) []byte {
+	if !traceActive.CompareAndSwap(false, true) {
+		return nil
+	}
+	defer traceActive.Store(false)
+
+	var buf bytes.Buffer
+	if err := trace.Start(&buf); err != nil {
+		return nil
+	}
+	time.Sleep(d)
+	trace.Stop()
+	return buf.Bytes()
+}
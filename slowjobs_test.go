@@ -0,0 +1,116 @@
+package workerpool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+func (ts *WorkerPoolTestSuite) TestSlowJobCapture() {
+	config := DefaultConfig()
+	config.NumWorkers = 2
+	config.SlowJobs = SlowJobProfiling{Threshold: 20 * time.Millisecond, Trace: 5 * time.Millisecond, MaxCaptures: 2}
+	pool := NewWithConfig[int, int](config)
+
+	var mu sync.Mutex
+	var captures []SlowJobCapture
+	pool.WithSlowJobSink(SlowJobSinkFunc(func(c SlowJobCapture) error {
+		mu.Lock()
+		defer mu.Unlock()
+		captures = append(captures, c)
+		return nil
+	}))
+	pool.WithProcessor(func(ctx context.Context, job Job[int]) (int, error) {
+		time.Sleep(time.Duration(job.Data) * time.Millisecond)
+		return job.Data, nil
+	})
+	pool.AddJob(Job[int]{ID: "fast", Data: 1})
+	for i := 0; i < 3; i++ {
+		pool.AddJob(Job[int]{ID: fmt.Sprint("slow", i), Data: 100})
+	}
+
+	_, err := pool.Run()
+	ts.NoError(err)
+
+	// Captures are written in the background
+	ts.Eventually(func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(captures) == 2
+	}, 5*time.Second, time.Millisecond)
+	time.Sleep(50 * time.Millisecond)
+	mu.Lock()
+	defer mu.Unlock()
+	ts.Len(captures, 2, "MaxCaptures bounds captures per run")
+	for _, c := range captures {
+		ts.True(strings.HasPrefix(c.Job.ID, "slow"))
+		ts.GreaterOrEqual(c.Elapsed, 20*time.Millisecond)
+		ts.Contains(string(c.Goroutine), "time.Sleep")
+		ts.Contains(string(c.Stacks), "goroutine ")
+	}
+	// Only one trace runs at a time, but the first capture always gets one
+	traced := 0
+	for _, c := range captures {
+		if c.Trace != nil {
+			traced++
+		}
+	}
+	ts.GreaterOrEqual(traced, 1)
+}
+
+func (ts *WorkerPoolTestSuite) TestDirSlowJobSink() {
+	dir := ts.T().TempDir()
+	capture := SlowJobCapture{
+		Job:       JobSummary{ID: "stage/1"},
+		Worker:    3,
+		Elapsed:   time.Second,
+		Captured:  time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		Goroutine: []byte("goroutine 7 [sleep]:"),
+		Stacks:    []byte("goroutine 1 [running]:"),
+		Trace:     []byte("trace"),
+	}
+	ts.NoError(DirSlowJobSink(dir).WriteSlowJob(capture))
+
+	base := filepath.Join(dir, "stage%2F1-20240102T030405.000000000")
+	stacks, err := os.ReadFile(base + ".stacks.txt")
+	ts.NoError(err)
+	ts.Contains(string(stacks), "job stage/1 on worker 3, running for 1s")
+	ts.Contains(string(stacks), "goroutine 7 [sleep]:")
+	trace, err := os.ReadFile(base + ".trace")
+	ts.NoError(err)
+	ts.Equal("trace", string(trace))
+}
+
+func (ts *WorkerPoolTestSuite) TestSlowJobSinkErrors() {
+	config := DefaultConfig()
+	config.SlowJobs = SlowJobProfiling{Threshold: time.Millisecond}
+	pool := NewWithConfig[int, int](config)
+
+	failed := make(chan error, 1)
+	pool.WithSlowJobSink(SlowJobSinkFunc(func(c SlowJobCapture) error {
+		return errors.New("disk full")
+	}))
+	pool.WithSlowJobErrorHandler(func(c SlowJobCapture, err error) {
+		ts.Equal("slow", c.Job.ID)
+		failed <- err
+	})
+	pool.WithProcessor(func(ctx context.Context, job Job[int]) (int, error) {
+		time.Sleep(20 * time.Millisecond)
+		return 0, nil
+	})
+	pool.AddJob(Job[int]{ID: "slow"})
+
+	_, err := pool.Run()
+	ts.NoError(err)
+	select {
+	case err := <-failed:
+		ts.EqualError(err, "disk full")
+	case <-time.After(5 * time.Second):
+		ts.Fail("sink error not reported")
+	}
+}
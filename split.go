@@ -0,0 +1,115 @@
+package workerpool
+
+import "fmt"
+
+// Splitter breaks an oversized job, such as a million-row file, into smaller
+// jobs before a run dispatches it. It returns the child jobs, or nil (or a
+// single job) to run the job as it is. The parent itself does not run: each
+// child produces its own result, with Result.ParentID set to the parent's ID.
+type Splitter[T any] func(Job[T]) []Job[T]
+
+// WithSplitter sets a splitter applied to every job as a run starts.
+// Children left without an ID are numbered after their parent, e.g.
+// "file-7/0", and fields left zero are inherited from the parent: priority,
+// tenant, owner, class, creation time, expiry, deadline and dependencies.
+// Jobs depending on a split job wait for all of its children.
+func (wp *WorkerPool[T, R]) WithSplitter(split Splitter[T]) *WorkerPool[T, R] {
+	wp.mu.Lock()
+	defer wp.mu.Unlock()
+	wp.splitter = split
+	wp.quorumErr = nil // A quorum set earlier no longer applies
+	return wp
+}
+
+// splitJobs replaces every job split breaks up with its children, and
+// returns the parent ID of every child in the run by child ID. Children of
+// an earlier run, e.g. requeued after failing, are not split again. onSplit,
+// if set, is called with every parent that was split and its children.
+func splitJobs[T any](split Splitter[T], jobs []Job[T], onSplit func(parent Job[T], children []Job[T])) ([]Job[T], map[string]string) {
+	parents := make(map[string]string)
+	for _, job := range jobs {
+		if job.ParentID != "" {
+			parents[job.ID] = job.ParentID
+		}
+	}
+	if split == nil {
+		return jobs, parents
+	}
+
+	var out []Job[T]
+	children := make(map[string][]string) // Child IDs by split parent ID
+	for _, parent := range jobs {
+		var parts []Job[T]
+		if parent.ParentID == "" {
+			parts = split(parent)
+		}
+		if len(parts) <= 1 {
+			out = append(out, parent)
+			continue
+		}
+		for i, child := range parts {
+			child = inheritFromParent(child, parent)
+			if child.ID == "" {
+				child.ID = fmt.Sprintf("%s/%d", parent.ID, i)
+			}
+			child.ParentID = parent.ID
+			parents[child.ID] = parent.ID
+			children[parent.ID] = append(children[parent.ID], child.ID)
+			out = append(out, child)
+			parts[i] = child
+		}
+		if onSplit != nil {
+			onSplit(parent, parts)
+		}
+	}
+	if len(children) == 0 {
+		return jobs, parents
+	}
+
+	// Dependents of a split job wait for every child instead
+	for i := range out {
+		var deps []string
+		rewritten := false
+		for _, dep := range out[i].Dependencies {
+			if ids, ok := children[dep]; ok {
+				deps = append(deps, ids...)
+				rewritten = true
+				continue
+			}
+			deps = append(deps, dep)
+		}
+		if rewritten {
+			out[i].Dependencies = deps
+		}
+	}
+	return out, parents
+}
+
+// inheritFromParent fills the fields a child job left zero from its parent
+func inheritFromParent[T any](child, parent Job[T]) Job[T] {
+	if child.Priority == 0 {
+		child.Priority = parent.Priority
+	}
+	if child.TenantID == "" {
+		child.TenantID = parent.TenantID
+	}
+	if child.Owner == "" {
+		child.Owner = parent.Owner
+	}
+	if child.Class == "" {
+		child.Class = parent.Class
+	}
+	if child.Created.IsZero() {
+		child.Created = parent.Created
+	}
+	if child.TTL == 0 && child.ExpiresAt.IsZero() {
+		child.TTL, child.ExpiresAt = parent.TTL, parent.ExpiresAt
+	}
+	if child.Deadline.IsZero() {
+		child.Deadline = parent.Deadline
+	}
+	if child.Dependencies == nil {
+		child.Dependencies = parent.Dependencies
+	}
+	return child
+}
@@ -0,0 +1,118 @@
+package workerpool
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+func (ts *WorkerPoolTestSuite) TestSplitterSplitsOversizedJobs() {
+	pool := NewWithConfig[[]int, int](DefaultConfig())
+	pool.WithSplitter(func(job Job[[]int]) []Job[[]int] {
+		var parts []Job[[]int]
+		for start := 0; start < len(job.Data); start += 2 {
+			parts = append(parts, Job[[]int]{Data: job.Data[start:min(start+2, len(job.Data))]})
+		}
+		return parts
+	})
+
+	var mu sync.Mutex
+	var order []string
+	pool.WithProcessor(func(ctx context.Context, job Job[[]int]) (int, error) {
+		mu.Lock()
+		order = append(order, job.ID)
+		mu.Unlock()
+		sum := 0
+		for _, v := range job.Data {
+			sum += v
+		}
+		return sum, nil
+	})
+	pool.AddJobs([]Job[[]int]{
+		{ID: "big", Data: []int{1, 2, 3, 4, 5}, Priority: 7},
+		{ID: "small", Data: []int{10}},
+		{ID: "after", Data: nil, Dependencies: []string{"big"}},
+	})
+
+	results, err := pool.Run()
+	ts.NoError(err)
+	ts.Len(results, 5)
+
+	sums := make(map[string]int)
+	for _, result := range results {
+		ts.NoError(result.Error)
+		if result.ParentID != "" {
+			ts.Equal("big", result.ParentID)
+			ts.True(strings.HasPrefix(result.JobID, "big/"))
+		}
+		sums[result.JobID] = result.Data
+	}
+	ts.Equal(map[string]int{"big/0": 3, "big/1": 7, "big/2": 5, "small": 10, "after": 0}, sums)
+
+	// The dependent ran after every child
+	ts.Equal("after", order[len(order)-1])
+}
+
+func (ts *WorkerPoolTestSuite) TestSplitChildrenQueueForTheirTenant() {
+	config := DefaultConfig()
+	config.NumWorkers = 1
+	pool := NewWithConfig[int, int](config)
+	pool.WithSplitter(func(job Job[int]) []Job[int] {
+		return make([]Job[int], job.Data)
+	})
+
+	var queued []int
+	pool.WithProcessor(func(ctx context.Context, job Job[int]) (int, error) {
+		queued = append(queued, pool.GetMetrics().Tenants["acme"].Queued)
+		return 0, nil
+	})
+	ts.NoError(pool.Submit(Job[int]{ID: "big", Data: 4, TenantID: "acme"}))
+
+	results, err := pool.Run()
+	ts.NoError(err)
+	ts.Len(results, 4)
+	// Each child leaves the queue as it starts, after the parent's place
+	// was taken by all four
+	ts.Equal([]int{3, 2, 1, 0}, queued)
+	ts.Equal(4, pool.GetMetrics().Tenants["acme"].Processed)
+}
+
+func (ts *WorkerPoolTestSuite) TestSplitJobsInheritsParentFields() {
+	split := func(job Job[int]) []Job[int] {
+		if job.Data < 2 {
+			return nil
+		}
+		parts := make([]Job[int], job.Data)
+		parts[0].ID = "custom"
+		parts[0].Priority = 1
+		return parts
+	}
+	jobs, parents := splitJobs(split, []Job[int]{
+		{ID: "p", Data: 3, Priority: 5, TenantID: "t", Class: "c", Dependencies: []string{"x"}},
+		{ID: "x", Data: 1},
+		{ID: "old", Data: 4, ParentID: "earlier"},
+	}, nil)
+
+	var ids []string
+	for _, job := range jobs {
+		ids = append(ids, job.ID)
+	}
+	ts.Equal([]string{"custom", "p/1", "p/2", "x", "old"}, ids)
+	ts.Equal(1, jobs[0].Priority)
+	ts.Equal(5, jobs[1].Priority)
+	for _, child := range jobs[:3] {
+		ts.Equal("p", child.ParentID)
+		ts.Equal("t", child.TenantID)
+		ts.Equal("c", child.Class)
+		ts.Equal([]string{"x"}, child.Dependencies)
+	}
+
+	keys := make([]string, 0, len(parents))
+	for id, parent := range parents {
+		keys = append(keys, fmt.Sprint(id, "<-", parent))
+	}
+	sort.Strings(keys)
+	ts.Equal([]string{"custom<-p", "old<-earlier", "p/1<-p", "p/2<-p"}, keys)
+}
@@ -0,0 +1,147 @@
+package workerpool
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// workerResourceKey is the context key under which a worker's resource is stored
+type workerResourceKey struct{}
+
+// Health reports the readiness of a pool
+type Health struct {
+	Workers     int  `json:"workers"`      // Config.NumWorkers
+	WarmWorkers int  `json:"warm_workers"` // Workers whose OnWorkerStart initializer has completed
+	Warm        bool `json:"warm"`         // Every worker is initialized; always true without OnWorkerStart
+	Running     bool `json:"running"`      // A run is in progress
+	Draining    bool `json:"draining"`     // Shutdown has begun
+	Paused      bool `json:"paused"`       // A blackout keeps jobs from starting
+}
+
+// workerSlot holds the resource OnWorkerStart created for one worker
+type workerSlot struct {
+	value any
+	ready atomic.Bool
+	mu    sync.Mutex // Held while the initializer runs
+}
+
+// workerResources creates and caches per-worker resources
+type workerResources struct {
+	init  func(ctx context.Context, workerID int) (any, error)
+	slots map[int]*workerSlot
+	mu    sync.Mutex
+}
+
+// OnWorkerStart sets an initializer run once per worker ID before the
+// worker's first job, typically to open a connection. The value it returns
+// is available to the processor through WorkerResource and is kept across
+// runs. A failed initializer fails the job that triggered it and is retried
+// with the worker's next job.
+//
+// Initializers run lazily unless Config.WarmStandby is set, in which case
+// every worker is initialized in the background right away; Warm does the
+// same synchronously.
+func (wp *WorkerPool[T, R]) OnWorkerStart(init func(ctx context.Context, workerID int) (any, error)) *WorkerPool[T, R] {
+	wp.resources.mu.Lock()
+	wp.resources.init = init
+	wp.resources.slots = make(map[int]*workerSlot)
+	wp.resources.mu.Unlock()
+
+	if wp.config.WarmStandby {
+		go wp.Warm(context.Background())
+	}
+	return wp
+}
+
+// Warm initializes every worker that is not initialized yet, so the first
+// batch does not pay connection-setup latency. It returns the first
+// initializer error.
+func (wp *WorkerPool[T, R]) Warm(ctx context.Context) error {
+	errs := make([]error, wp.GetNumWorkers())
+	var wg sync.WaitGroup
+	for id := range errs {
+		wg.Add(1)
+		go func(id int) {
+			defer wg.Done()
+			_, errs[id] = wp.resources.get(ctx, id)
+		}(id)
+	}
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Health reports whether the pool's workers are warm and whether it is running
+func (wp *WorkerPool[T, R]) Health() Health {
+	wp.mu.RLock()
+	h := Health{
+		Workers:  wp.config.NumWorkers,
+		Running:  wp.running,
+		Draining: wp.draining,
+	}
+	h.Paused, _ = wp.blackouts.until(time.Now())
+	wp.mu.RUnlock()
+	h.WarmWorkers, h.Warm = wp.resources.warm(h.Workers)
+	return h
+}
+
+// WorkerResource returns the value OnWorkerStart created for the worker
+// running the job ctx belongs to, or nil
+func WorkerResource(ctx context.Context) any {
+	return ctx.Value(workerResourceKey{})
+}
+
+// get returns the resource of a worker, initializing it if needed
+func (r *workerResources) get(ctx context.Context, id int) (any, error) {
+	r.mu.Lock()
+	init := r.init
+	if init == nil {
+		r.mu.Unlock()
+		return nil, nil
+	}
+	slot := r.slots[id]
+	if slot == nil {
+		slot = &workerSlot{}
+		r.slots[id] = slot
+	}
+	r.mu.Unlock()
+
+	if slot.ready.Load() {
+		return slot.value, nil
+	}
+	slot.mu.Lock()
+	defer slot.mu.Unlock()
+	if slot.ready.Load() {
+		return slot.value, nil
+	}
+	value, err := init(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("worker %d start: %w", id, err)
+	}
+	slot.value = value
+	slot.ready.Store(true)
+	return value, nil
+}
+
+// warm counts initialized workers among the first n
+func (r *workerResources) warm(n int) (int, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.init == nil {
+		return n, true
+	}
+	count := 0
+	for id := 0; id < n; id++ {
+		if slot := r.slots[id]; slot != nil && slot.ready.Load() {
+			count++
+		}
+	}
+	return count, count == n
+}
@@ -0,0 +1,86 @@
+package workerpool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"time"
+)
+
+func (ts *WorkerPoolTestSuite) TestOnWorkerStartRunsOncePerWorker() {
+	config := DefaultConfig()
+	config.NumWorkers = 3
+	pool := NewWithConfig[int, string](config)
+
+	var starts atomic.Int32
+	pool.OnWorkerStart(func(ctx context.Context, workerID int) (any, error) {
+		starts.Add(1)
+		return fmt.Sprintf("conn-%d", workerID), nil
+	})
+	pool.WithProcessor(func(ctx context.Context, job Job[int]) (string, error) {
+		return WorkerResource(ctx).(string), nil
+	})
+
+	var jobs []Job[int]
+	for i := 0; i < 12; i++ {
+		jobs = append(jobs, Job[int]{ID: fmt.Sprintf("%d", i)})
+	}
+	pool.AddJobs(jobs)
+	for run := 0; run < 2; run++ {
+		results, err := pool.Run()
+		ts.NoError(err)
+		for _, r := range results {
+			ts.Equal(fmt.Sprintf("conn-%d", r.Worker), r.Data)
+		}
+	}
+	ts.Equal(int32(3), starts.Load())
+}
+
+func (ts *WorkerPoolTestSuite) TestWarmStandbyInitializesBeforeFirstJob() {
+	config := DefaultConfig()
+	config.NumWorkers = 2
+	config.WarmStandby = true
+	pool := NewWithConfig[int, int](config)
+	ts.True(pool.Health().Warm)
+
+	release := make(chan struct{})
+	pool.OnWorkerStart(func(ctx context.Context, workerID int) (any, error) {
+		<-release
+		return workerID, nil
+	})
+	h := pool.Health()
+	ts.False(h.Warm)
+	ts.Equal(2, h.Workers)
+	ts.Equal(0, h.WarmWorkers)
+
+	close(release)
+	ts.Eventually(func() bool { return pool.Health().Warm }, time.Second, 5*time.Millisecond)
+	ts.Equal(2, pool.Health().WarmWorkers)
+	ts.False(pool.Health().Running)
+}
+
+func (ts *WorkerPoolTestSuite) TestWorkerStartFailureFailsJobAndRetries() {
+	config := DefaultConfig()
+	config.NumWorkers = 1
+	pool := NewWithConfig[int, int](config)
+
+	var attempts atomic.Int32
+	pool.OnWorkerStart(func(ctx context.Context, workerID int) (any, error) {
+		if attempts.Add(1) == 1 {
+			return nil, errors.New("connection refused")
+		}
+		return "ok", nil
+	})
+	ts.ErrorContains(pool.Warm(context.Background()), "connection refused")
+
+	pool.WithProcessor(func(ctx context.Context, job Job[int]) (int, error) {
+		return job.Data, nil
+	})
+	pool.AddJobs([]Job[int]{{ID: "a", Data: 1}})
+	results, err := pool.Run()
+	ts.NoError(err)
+	ts.Require().Len(results, 1)
+	ts.NoError(results[0].Error)
+	ts.True(pool.Health().Warm)
+}
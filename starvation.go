@@ -0,0 +1,155 @@
+package workerpool
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+)
+
+// StarvationDetection flags pending jobs that have waited far longer than
+// is typical, which usually means a priority configuration is starving
+// them. A zero Multiple disables detection.
+type StarvationDetection struct {
+	Multiple   float64       // Flag jobs waiting longer than Multiple times the median queue wait
+	MinWait    time.Duration // Never flag jobs that have waited less than this
+	MinSamples int           // Started jobs needed before the median is trusted; defaults to 5
+	Interval   time.Duration // How often pending jobs are checked; defaults to 100ms
+	Boost      int           // Priority added to a starved job; zero only reports it
+}
+
+// StarvationEvent describes a job flagged by starvation detection
+type StarvationEvent struct {
+	Job     JobSummary
+	Wait    time.Duration // How long the job had been waiting
+	Median  time.Duration // Median queue wait of the jobs started so far
+	Boosted bool          // Whether the job's priority was raised
+}
+
+const (
+	defaultStarvationSamples  = 5
+	defaultStarvationInterval = 100 * time.Millisecond
+	starvationWindow          = 512 // Queue waits kept for the median
+)
+
+// starvationDetector tracks queue waits during a run
+type starvationDetector struct {
+	waits   []time.Duration // Ring of recent queue waits
+	next    int
+	flagged map[string]bool // Jobs already reported this run
+	mu      sync.Mutex
+}
+
+// WithStarvationHandler sets a function called for every job flagged by
+// Config.Starvation. It runs on the detector goroutine and should not block.
+func (wp *WorkerPool[T, R]) WithStarvationHandler(handler func(StarvationEvent)) *WorkerPool[T, R] {
+	wp.mu.Lock()
+	defer wp.mu.Unlock()
+	wp.onStarved = handler
+	return wp
+}
+
+// record adds the queue wait of a job that just started
+func (d *starvationDetector) record(wait time.Duration) {
+	if d == nil {
+		return
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if len(d.waits) < starvationWindow {
+		d.waits = append(d.waits, wait)
+		return
+	}
+	d.waits[d.next] = wait
+	d.next = (d.next + 1) % starvationWindow
+}
+
+// median returns the median recorded wait and how many waits it is based on
+func (d *starvationDetector) median() (time.Duration, int) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if len(d.waits) == 0 {
+		return 0, 0
+	}
+	sorted := append([]time.Duration(nil), d.waits...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	return sorted[len(sorted)/2], len(sorted)
+}
+
+// flag marks a job as reported, returning false if it already was
+func (d *starvationDetector) flag(id string) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.flagged[id] {
+		return false
+	}
+	d.flagged[id] = true
+	return true
+}
+
+// watchStarvation checks pending jobs until ctx is done. Jobs waiting on
+// dependencies or on a closed class window are waiting by design and are
+// not considered.
+func (wp *WorkerPool[T, R]) watchStarvation(ctx context.Context, d *starvationDetector) {
+	cfg := wp.config.Starvation
+	interval := cfg.Interval
+	if interval <= 0 {
+		interval = defaultStarvationInterval
+	}
+	minSamples := cfg.MinSamples
+	if minSamples <= 0 {
+		minSamples = defaultStarvationSamples
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		median, samples := d.median()
+		if samples < minSamples {
+			continue
+		}
+		limit := time.Duration(float64(median) * cfg.Multiple)
+		if limit < cfg.MinWait {
+			limit = cfg.MinWait
+		}
+
+		wp.mu.RLock()
+		pending, handler := wp.pending, wp.onStarved
+		wp.mu.RUnlock()
+		if pending == nil {
+			continue
+		}
+
+		now := time.Now()
+		for _, job := range pending.list() {
+			if len(job.Dependencies) > 0 || job.Created.IsZero() {
+				continue
+			}
+			if window, ok := wp.config.ClassWindows[job.Class]; ok && !window.Open(now) {
+				continue
+			}
+			wait := now.Sub(job.Created)
+			if wait <= limit || !d.flag(job.ID) {
+				continue
+			}
+
+			event := StarvationEvent{Job: summarize(job), Wait: wait, Median: median}
+			if cfg.Boost != 0 {
+				id := job.ID
+				event.Boosted = wp.BumpPriority(func(j Job[T]) bool { return j.ID == id }, cfg.Boost) > 0
+			}
+			wp.metrics.mu.Lock()
+			wp.metrics.StarvedJobs++
+			wp.metrics.mu.Unlock()
+			if handler != nil {
+				handler(event)
+			}
+		}
+	}
+}
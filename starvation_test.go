@@ -0,0 +1,77 @@
+package workerpool
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+func (ts *WorkerPoolTestSuite) TestStarvationDetectorBoostsStarvedJob() {
+	config := DefaultConfig()
+	config.NumWorkers = 1
+	config.Strategy = PriorityBased
+	config.Starvation = StarvationDetection{
+		Multiple: 2,
+		MinWait:  500 * time.Millisecond,
+		Interval: 5 * time.Millisecond,
+		Boost:    100,
+	}
+	pool := NewWithConfig[int, int](config)
+
+	var mu sync.Mutex
+	var order []string
+	var events []StarvationEvent
+	pool.WithProcessor(func(ctx context.Context, job Job[int]) (int, error) {
+		mu.Lock()
+		order = append(order, job.ID)
+		mu.Unlock()
+		time.Sleep(10 * time.Millisecond)
+		return job.Data, nil
+	})
+	pool.WithStarvationHandler(func(e StarvationEvent) {
+		mu.Lock()
+		defer mu.Unlock()
+		events = append(events, e)
+	})
+
+	// The low-priority job has been queued for a long time already
+	jobs := []Job[int]{{ID: "starved", Priority: 0, Created: time.Now().Add(-time.Second)}}
+	for i := 0; i < 40; i++ {
+		jobs = append(jobs, Job[int]{ID: fmt.Sprintf("hot-%d", i), Priority: 10})
+	}
+	pool.AddJobs(jobs)
+	_, err := pool.Run()
+	ts.NoError(err)
+
+	ts.Require().Len(events, 1)
+	ts.Equal("starved", events[0].Job.ID)
+	ts.True(events[0].Boosted)
+	ts.Greater(events[0].Wait, time.Second)
+	ts.Equal(1, pool.GetMetrics().StarvedJobs)
+
+	// Boosted, it overtook the remaining high-priority jobs
+	for i, id := range order {
+		if id == "starved" {
+			ts.Less(i, len(order)-5)
+		}
+	}
+}
+
+func (ts *WorkerPoolTestSuite) TestStarvationDetectorMedian() {
+	d := &starvationDetector{flagged: make(map[string]bool)}
+	_, n := d.median()
+	ts.Zero(n)
+	for _, ms := range []int{50, 10, 30, 20, 40} {
+		d.record(time.Duration(ms) * time.Millisecond)
+	}
+	median, n := d.median()
+	ts.Equal(5, n)
+	ts.Equal(30*time.Millisecond, median)
+
+	ts.True(d.flag("a"))
+	ts.False(d.flag("a"))
+
+	var nilDetector *starvationDetector
+	nilDetector.record(time.Second)
+}
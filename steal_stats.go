@@ -0,0 +1,62 @@
+package workerpool
+
+import "sync/atomic"
+
+// StealStats describes how the WorkStealing strategy balanced work in the last run
+type StealStats struct {
+	Attempts  int64              `json:"attempts"`  // Steal attempts against a victim deque
+	Successes int64              `json:"successes"` // Attempts that returned a job
+	Failures  int64              `json:"failures"`  // Attempts that found the victim empty
+	Workers   []WorkerStealStats `json:"workers"`   // Per-worker breakdown, indexed by worker ID
+}
+
+// WorkerStealStats counts where a single worker's jobs came from
+type WorkerStealStats struct {
+	Own    int64 `json:"own"`    // Jobs popped from the worker's own deque
+	Stolen int64 `json:"stolen"` // Jobs stolen from other workers
+}
+
+// SuccessRate returns the fraction of steal attempts that found work
+func (s StealStats) SuccessRate() float64 {
+	if s.Attempts == 0 {
+		return 0
+	}
+	return float64(s.Successes) / float64(s.Attempts)
+}
+
+// stealCounters collects StealStats concurrently during a run
+type stealCounters struct {
+	attempts  atomic.Int64
+	successes atomic.Int64
+	own       []atomic.Int64
+	stolen    []atomic.Int64
+}
+
+// newStealCounters creates counters for the given number of workers
+func newStealCounters(numWorkers int) *stealCounters {
+	return &stealCounters{
+		own:    make([]atomic.Int64, numWorkers),
+		stolen: make([]atomic.Int64, numWorkers),
+	}
+}
+
+// snapshot converts the counters into StealStats
+func (c *stealCounters) snapshot() StealStats {
+	if c == nil {
+		return StealStats{}
+	}
+
+	stats := StealStats{
+		Attempts:  c.attempts.Load(),
+		Successes: c.successes.Load(),
+		Workers:   make([]WorkerStealStats, len(c.own)),
+	}
+	stats.Failures = stats.Attempts - stats.Successes
+	for i := range c.own {
+		stats.Workers[i] = WorkerStealStats{
+			Own:    c.own[i].Load(),
+			Stolen: c.stolen[i].Load(),
+		}
+	}
+	return stats
+}
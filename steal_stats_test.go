@@ -0,0 +1,48 @@
+package workerpool
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+func (ts *WorkerPoolTestSuite) TestWorkStealingStats() {
+	config := DefaultConfig()
+	config.NumWorkers = 2
+	config.Strategy = WorkStealing
+	pool := NewWithConfig[string, string](config)
+
+	// Worker 0's jobs are slow, so worker 1 runs out and steals
+	pool.WithProcessor(func(ctx context.Context, job Job[string]) (string, error) {
+		if job.Data == "slow" {
+			time.Sleep(5 * time.Millisecond)
+		}
+		return job.Data, nil
+	})
+
+	var jobs []Job[string]
+	for i := 0; i < 20; i++ {
+		data := "fast"
+		if i%2 == 0 {
+			data = "slow"
+		}
+		jobs = append(jobs, Job[string]{ID: fmt.Sprintf("%d", i), Data: data})
+	}
+	pool.AddJobs(jobs)
+
+	results, err := pool.Run()
+	ts.NoError(err)
+	ts.Len(results, 20)
+
+	stats := pool.GetMetrics().Stealing
+	ts.Len(stats.Workers, 2)
+	var total int64
+	for _, w := range stats.Workers {
+		total += w.Own + w.Stolen
+	}
+	ts.Equal(int64(20), total)
+	ts.Equal(stats.Workers[0].Stolen+stats.Workers[1].Stolen, stats.Successes)
+	ts.Positive(stats.Successes)
+	ts.Equal(stats.Attempts-stats.Successes, stats.Failures)
+	ts.Greater(stats.SuccessRate(), 0.0)
+}
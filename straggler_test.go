@@ -0,0 +1,62 @@
+package workerpool
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+func (ts *WorkerPoolTestSuite) TestStragglerWindowKeepsNearlyFinishedJobs() {
+	config := DefaultConfig()
+	config.NumWorkers = 1
+	config.Timeout = 50 * time.Millisecond
+	config.StragglerWindow = 200 * time.Millisecond
+	config.PartialResults = true
+	pool := NewWithConfig[string, string](config)
+
+	pool.WithProcessor(func(ctx context.Context, job Job[string]) (string, error) {
+		// Finishes shortly after the timeout, well within the window
+		select {
+		case <-time.After(80 * time.Millisecond):
+			return job.Data, nil
+		case <-ctx.Done():
+			return "", ctx.Err()
+		}
+	})
+	pool.AddJobs([]Job[string]{{ID: "straggler", Data: "a"}, {ID: "queued", Data: "b"}})
+
+	results, err := pool.Run()
+	ts.ErrorIs(err, ErrPoolTimeout)
+
+	var partial *PartialRunError[string]
+	ts.True(errors.As(err, &partial))
+	ts.Len(partial.Unfinished, 1)
+	ts.Equal("queued", partial.Unfinished[0].ID)
+
+	ts.Len(results, 1)
+	ts.NoError(results[0].Error)
+	ts.True(results[0].Late)
+	ts.Equal(1, pool.GetMetrics().LateCompletions)
+}
+
+func (ts *WorkerPoolTestSuite) TestStragglerWindowExpires() {
+	config := DefaultConfig()
+	config.Timeout = 20 * time.Millisecond
+	config.StragglerWindow = 20 * time.Millisecond
+	config.PartialResults = true
+	pool := NewWithConfig[string, string](config)
+
+	pool.WithProcessor(func(ctx context.Context, job Job[string]) (string, error) {
+		<-ctx.Done()
+		return "", ctx.Err()
+	})
+	pool.AddJob(Job[string]{ID: "stuck"})
+
+	start := time.Now()
+	results, err := pool.Run()
+	ts.ErrorIs(err, ErrPoolTimeout)
+	ts.Less(time.Since(start), time.Second)
+	ts.Len(results, 1)
+	ts.Error(results[0].Error)
+	ts.False(results[0].Late)
+}
@@ -0,0 +1,48 @@
+package workerpool
+
+import "time"
+
+// defaultStealBackoff is the pause after a failed steal round when
+// StrategyOptions.StealBackoff is not set
+const defaultStealBackoff = time.Millisecond
+
+// StrategyOptions tunes the distribution strategies.
+// A zero value for any field keeps the built-in behavior.
+type StrategyOptions struct {
+	ChunkSize        int           // Jobs per chunk for Chunked; idle workers pull the next chunk. Zero gives each worker one equal slice
+	StealAttempts    int           // Victims a WorkStealing worker tries before backing off; zero means twice the worker count
+	StealBackoff     time.Duration // Pause after an unsuccessful steal round; zero means 1ms
+	StealDomainSize  int           // Consecutive workers sharing a locality domain (e.g. NUMA node); thieves exhaust their own domain first. Zero means one domain
+	DispatchBatch    int           // Jobs the PriorityBased/FairShare dispatcher may hand off ahead of idle workers; zero keeps them reorderable until a worker is free
+	RoundRobinBuffer int           // Per-worker channel buffer for RoundRobin; zero sizes it to each worker's share of the jobs
+
+	// AdaptiveSwitching lets Adaptive change strategy during a run: a run
+	// started Chunked moves the jobs it has not started to WorkStealing once
+	// stragglers leave workers idle. It does not apply with ChunkSize set,
+	// since idle workers already pull the next chunk.
+	AdaptiveSwitching bool
+}
+
+// stealAttempts returns how many victims to try per steal round
+func (o StrategyOptions) stealAttempts(numWorkers int) int {
+	if o.StealAttempts > 0 {
+		return o.StealAttempts
+	}
+	return numWorkers * 2
+}
+
+// stealBackoff returns the pause after an unsuccessful steal round
+func (o StrategyOptions) stealBackoff() time.Duration {
+	if o.StealBackoff > 0 {
+		return o.StealBackoff
+	}
+	return defaultStealBackoff
+}
+
+// roundRobinBuffer returns the channel buffer for each round-robin worker
+func (o StrategyOptions) roundRobinBuffer(numJobs, numWorkers int) int {
+	if o.RoundRobinBuffer > 0 {
+		return o.RoundRobinBuffer
+	}
+	return max(1, numJobs/numWorkers+1)
+}
@@ -0,0 +1,73 @@
+package workerpool
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+func (ts *WorkerPoolTestSuite) TestStrategyOptionsChunkSize() {
+	config := DefaultConfig()
+	config.NumWorkers = 2
+	config.Strategy = Chunked
+	config.StrategyOptions.ChunkSize = 3
+	pool := NewWithConfig[int, int](config)
+
+	pool.WithProcessor(func(ctx context.Context, job Job[int]) (int, error) {
+		return job.Data, nil
+	})
+
+	var jobs []Job[int]
+	for i := 0; i < 10; i++ {
+		jobs = append(jobs, Job[int]{ID: fmt.Sprintf("%d", i), Data: i})
+	}
+	pool.AddJobs(jobs)
+
+	results, err := pool.Run()
+	ts.NoError(err)
+	ts.Len(results, 10)
+
+	// Every job of a chunk is processed by the worker that pulled the chunk
+	workers := make(map[int]int)
+	for _, r := range results {
+		chunk := r.Data / 3
+		if w, ok := workers[chunk]; ok {
+			ts.Equal(w, r.Worker, "chunk %d split across workers", chunk)
+		}
+		workers[chunk] = r.Worker
+	}
+	ts.Len(workers, 4)
+}
+
+func (ts *WorkerPoolTestSuite) TestStrategyOptionsAllStrategiesComplete() {
+	options := StrategyOptions{
+		ChunkSize:        2,
+		StealAttempts:    1,
+		StealBackoff:     100 * time.Microsecond,
+		DispatchBatch:    4,
+		RoundRobinBuffer: 1,
+	}
+
+	for _, strategy := range []DistributionStrategy{RoundRobin, Chunked, WorkStealing, PriorityBased, FairShare} {
+		config := DefaultConfig()
+		config.NumWorkers = 3
+		config.Strategy = strategy
+		config.StrategyOptions = options
+		pool := NewWithConfig[int, int](config)
+
+		pool.WithProcessor(func(ctx context.Context, job Job[int]) (int, error) {
+			time.Sleep(time.Duration(job.Data%3) * time.Millisecond)
+			return job.Data * 2, nil
+		})
+
+		var jobs []Job[int]
+		for i := 0; i < 25; i++ {
+			jobs = append(jobs, Job[int]{ID: fmt.Sprintf("%d", i), Data: i, Priority: i % 5})
+		}
+		pool.AddJobs(jobs)
+
+		results, err := pool.Run()
+		ts.NoError(err, "strategy %d", strategy)
+		ts.Len(results, 25, "strategy %d", strategy)
+	}
+}
@@ -0,0 +1,68 @@
+package workerpool
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+)
+
+var (
+	// ErrTemplateNotFound is returned when spawning from an unregistered template
+	ErrTemplateNotFound = errors.New("pool template not found")
+
+	// ErrTemplateTypeMismatch is returned when a template's processor has different job or result types
+	ErrTemplateTypeMismatch = errors.New("pool template type mismatch")
+)
+
+// poolTemplate is a registered configuration and processor
+type poolTemplate struct {
+	config    Config
+	processor any // Processor[T, R] for the types it was registered with
+}
+
+var (
+	templates   = make(map[string]poolTemplate)
+	templatesMu sync.RWMutex
+)
+
+// RegisterTemplate stores a named configuration and processor that pools can
+// be spawned from. Registering an existing name replaces it. Pools spawned
+// from the template are named after it unless config.Name is set.
+func RegisterTemplate[T any, R any](name string, config Config, processor Processor[T, R]) {
+	if config.Name == "" {
+		config.Name = name
+	}
+
+	templatesMu.Lock()
+	defer templatesMu.Unlock()
+	templates[name] = poolTemplate{config: config, processor: processor}
+}
+
+// SpawnFromTemplate creates a new pool from a registered template
+func SpawnFromTemplate[T any, R any](name string) (*WorkerPool[T, R], error) {
+	templatesMu.RLock()
+	tmpl, ok := templates[name]
+	templatesMu.RUnlock()
+
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrTemplateNotFound, name)
+	}
+	processor, ok := tmpl.processor.(Processor[T, R])
+	if !ok {
+		return nil, fmt.Errorf("%w: %s has processor %T", ErrTemplateTypeMismatch, name, tmpl.processor)
+	}
+
+	return NewWithConfig[T, R](tmpl.config).WithProcessor(processor), nil
+}
+
+// TemplateNames returns the names of all registered templates
+func TemplateNames() []string {
+	templatesMu.RLock()
+	defer templatesMu.RUnlock()
+
+	names := make([]string, 0, len(templates))
+	for name := range templates {
+		names = append(names, name)
+	}
+	return names
+}
@@ -0,0 +1,43 @@
+package workerpool
+
+import (
+	"context"
+	"strconv"
+	"strings"
+)
+
+func (ts *WorkerPoolTestSuite) TestSpawnFromTemplate() {
+	config := DefaultConfig()
+	config.NumWorkers = 3
+	RegisterTemplate("upper", config, func(ctx context.Context, job Job[string]) (string, error) {
+		return strings.ToUpper(job.Data), nil
+	})
+	ts.Contains(TemplateNames(), "upper")
+
+	pool, err := SpawnFromTemplate[string, string]("upper")
+	ts.NoError(err)
+	ts.Equal("upper", pool.Name())
+	ts.Equal(3, pool.GetNumWorkers())
+
+	pool.AddJob(Job[string]{ID: "1", Data: "hi"})
+	results, err := pool.Run()
+	ts.NoError(err)
+	ts.Equal("HI", results[0].Data)
+
+	// Each spawn is an independent pool
+	other, err := SpawnFromTemplate[string, string]("upper")
+	ts.NoError(err)
+	ts.NotSame(pool, other)
+	ts.Empty(other.PendingJobs())
+}
+
+func (ts *WorkerPoolTestSuite) TestSpawnFromTemplateErrors() {
+	_, err := SpawnFromTemplate[string, string]("missing")
+	ts.ErrorIs(err, ErrTemplateNotFound)
+
+	RegisterTemplate("itoa", DefaultConfig(), func(ctx context.Context, job Job[int]) (string, error) {
+		return strconv.Itoa(job.Data), nil
+	})
+	_, err = SpawnFromTemplate[string, string]("itoa")
+	ts.ErrorIs(err, ErrTemplateTypeMismatch)
+}
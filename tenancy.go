@@ -0,0 +1,206 @@
+package workerpool
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrTenantQueueFull is returned when a tenant already has MaxQueued jobs waiting
+var ErrTenantQueueFull = errors.New("tenant queue quota exceeded")
+
+// TenantQuota limits how much of a shared pool a single tenant may use.
+// A zero value for either field means unlimited.
+type TenantQuota struct {
+	MaxInFlight int // Maximum jobs of the tenant executing at the same time
+	MaxQueued   int // Maximum jobs of the tenant waiting to be processed
+}
+
+// TenantMetrics holds per-tenant counters
+type TenantMetrics struct {
+	Queued    int `json:"queued"`    // Jobs admitted but not yet started
+	InFlight  int `json:"in_flight"` // Jobs currently executing
+	Processed int `json:"processed"` // Jobs completed successfully
+	Failed    int `json:"failed"`    // Jobs completed with an error
+	Rejected  int `json:"rejected"`  // Jobs refused because MaxQueued was reached
+
+	CPUTime time.Duration `json:"cpu_time_ns"` // Processor CPU time, with Config.CPUAccounting
+}
+
+// tenantTracker enforces tenant quotas and collects per-tenant metrics
+type tenantTracker struct {
+	quotas       map[string]TenantQuota
+	defaultQuota TenantQuota
+	slots        map[string]chan struct{}
+	metrics      map[string]*TenantMetrics
+	mu           sync.Mutex
+}
+
+// newTenantTracker creates a tracker for the quotas in config
+func newTenantTracker(config Config) *tenantTracker {
+	quotas := make(map[string]TenantQuota, len(config.TenantQuotas))
+	for tenant, quota := range config.TenantQuotas {
+		quotas[tenant] = quota
+	}
+	return &tenantTracker{
+		quotas:       quotas,
+		defaultQuota: config.DefaultTenantQuota,
+		slots:        make(map[string]chan struct{}),
+		metrics:      make(map[string]*TenantMetrics),
+	}
+}
+
+// quota returns the quota that applies to a tenant
+func (t *tenantTracker) quota(tenant string) TenantQuota {
+	if q, ok := t.quotas[tenant]; ok {
+		return q
+	}
+	return t.defaultQuota
+}
+
+// tenantMetrics returns the mutable metrics entry for a tenant, creating it if needed.
+// Callers must hold t.mu.
+func (t *tenantTracker) tenantMetrics(tenant string) *TenantMetrics {
+	m, ok := t.metrics[tenant]
+	if !ok {
+		m = &TenantMetrics{}
+		t.metrics[tenant] = m
+	}
+	return m
+}
+
+// admit reserves a queue slot for a tenant's job
+func (t *tenantTracker) admit(tenant string) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	m := t.tenantMetrics(tenant)
+	if q := t.quota(tenant); q.MaxQueued > 0 && m.Queued >= q.MaxQueued {
+		m.Rejected++
+		return ErrTenantQueueFull
+	}
+	m.Queued++
+	return nil
+}
+
+// enqueue counts a queued job of a tenant without checking its quota, for
+// jobs taking the place of one already admitted
+func (t *tenantTracker) enqueue(tenant string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.tenantMetrics(tenant).Queued++
+}
+
+// resetQueued forgets all queued jobs, used when the job list is replaced
+func (t *tenantTracker) resetQueued() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for _, m := range t.metrics {
+		m.Queued = 0
+	}
+}
+
+// resetCounters zeroes the processed, failed and rejected counts and CPU
+// time of every tenant, keeping the queued and in-flight gauges
+func (t *tenantTracker) resetCounters() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for _, m := range t.metrics {
+		*m = TenantMetrics{Queued: m.Queued, InFlight: m.InFlight}
+	}
+}
+
+// acquire blocks until the tenant is below its in-flight limit
+func (t *tenantTracker) acquire(ctx context.Context, tenant string) error {
+	t.mu.Lock()
+	slots, ok := t.slots[tenant]
+	if !ok {
+		if q := t.quota(tenant); q.MaxInFlight > 0 {
+			slots = make(chan struct{}, q.MaxInFlight)
+			t.slots[tenant] = slots
+		}
+	}
+	t.mu.Unlock()
+
+	if slots != nil {
+		select {
+		case slots <- struct{}{}:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	t.mu.Lock()
+	m := t.tenantMetrics(tenant)
+	if m.Queued > 0 {
+		m.Queued--
+	}
+	m.InFlight++
+	t.mu.Unlock()
+	return nil
+}
+
+// requeue frees the tenant's in-flight slot of a job going back on the
+// queue, without recording an outcome
+func (t *tenantTracker) requeue(tenant string) {
+	t.mu.Lock()
+	m := t.tenantMetrics(tenant)
+	m.InFlight--
+	m.Queued++
+	slots := t.slots[tenant]
+	t.mu.Unlock()
+
+	if slots != nil {
+		<-slots
+	}
+}
+
+// dequeue releases a queued job's slot without running it
+func (t *tenantTracker) dequeue(tenant string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if m := t.tenantMetrics(tenant); m.Queued > 0 {
+		m.Queued--
+	}
+}
+
+// release frees the tenant's in-flight slot and records the outcome
+func (t *tenantTracker) release(tenant string, err error) {
+	t.mu.Lock()
+	m := t.tenantMetrics(tenant)
+	m.InFlight--
+	if err != nil {
+		m.Failed++
+	} else {
+		m.Processed++
+	}
+	slots := t.slots[tenant]
+	t.mu.Unlock()
+
+	if slots != nil {
+		<-slots
+	}
+}
+
+// chargeCPU adds CPU time used by one of the tenant's jobs
+func (t *tenantTracker) chargeCPU(tenant string, cpu time.Duration) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.tenantMetrics(tenant).CPUTime += cpu
+}
+
+// snapshot returns a copy of all per-tenant metrics
+func (t *tenantTracker) snapshot() map[string]TenantMetrics {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	stats := make(map[string]TenantMetrics, len(t.metrics))
+	for tenant, m := range t.metrics {
+		stats[tenant] = *m
+	}
+	return stats
+}
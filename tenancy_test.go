@@ -0,0 +1,68 @@
+package workerpool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"time"
+)
+
+func (ts *WorkerPoolTestSuite) TestTenantQueueQuota() {
+	config := DefaultConfig()
+	config.TenantQuotas = map[string]TenantQuota{
+		"noisy": {MaxQueued: 2},
+	}
+	pool := NewWithConfig[string, string](config)
+
+	ts.NoError(pool.Submit(Job[string]{ID: "1", Data: "a", TenantID: "noisy"}))
+	ts.NoError(pool.Submit(Job[string]{ID: "2", Data: "b", TenantID: "noisy"}))
+	err := pool.Submit(Job[string]{ID: "3", Data: "c", TenantID: "noisy"})
+	ts.True(errors.Is(err, ErrTenantQueueFull))
+	ts.NoError(pool.Submit(Job[string]{ID: "4", Data: "d", TenantID: "quiet"}))
+
+	ts.Len(pool.jobs, 3)
+	tenants := pool.GetMetrics().Tenants
+	ts.Equal(2, tenants["noisy"].Queued)
+	ts.Equal(1, tenants["noisy"].Rejected)
+	ts.Equal(1, tenants["quiet"].Queued)
+}
+
+func (ts *WorkerPoolTestSuite) TestTenantInFlightQuota() {
+	config := DefaultConfig()
+	config.NumWorkers = 4
+	config.TenantQuotas = map[string]TenantQuota{
+		"noisy": {MaxInFlight: 1},
+	}
+	pool := NewWithConfig[string, string](config)
+
+	var running, peak int32
+	pool.WithProcessor(func(ctx context.Context, job Job[string]) (string, error) {
+		n := atomic.AddInt32(&running, 1)
+		for {
+			p := atomic.LoadInt32(&peak)
+			if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+		atomic.AddInt32(&running, -1)
+		return job.Data, nil
+	})
+
+	var jobs []Job[string]
+	for i := 0; i < 8; i++ {
+		jobs = append(jobs, Job[string]{ID: fmt.Sprintf("%d", i), Data: "x", TenantID: "noisy"})
+	}
+	pool.AddJobs(jobs)
+
+	results, err := pool.Run()
+	ts.NoError(err)
+	ts.Len(results, 8)
+	ts.Equal(int32(1), atomic.LoadInt32(&peak))
+
+	tenant := pool.GetMetrics().Tenants["noisy"]
+	ts.Equal(8, tenant.Processed)
+	ts.Equal(0, tenant.InFlight)
+	ts.Equal(0, tenant.Queued)
+}
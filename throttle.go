@@ -0,0 +1,195 @@
+package workerpool
+
+import (
+	"bufio"
+	"context"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// cgroupCPUStatPaths are where cpu.stat lives under cgroup v2 and v1
+var cgroupCPUStatPaths = []string{
+	"/sys/fs/cgroup/cpu.stat",
+	"/sys/fs/cgroup/cpu/cpu.stat",
+	"/sys/fs/cgroup/cpu,cpuacct/cpu.stat",
+}
+
+// CPUThrottling lowers the number of jobs executing at once while the
+// container's CPU cgroup is being throttled, so a CPU-limited pod sheds
+// concurrency instead of letting latency collapse. Every Interval during a
+// run, the fraction of CFS periods throttled since the last sample is read
+// from cpu.stat. At Threshold or above, concurrency drops by a quarter;
+// at Release or below, it grows back by one worker up to NumWorkers.
+// A zero Threshold disables the monitor, as does a missing cpu.stat.
+type CPUThrottling struct {
+	Threshold  float64       // Throttled fraction of periods (0-1) that lowers concurrency
+	Release    float64       // Throttled fraction at which concurrency grows back; defaults to Threshold/2
+	Interval   time.Duration // How often cpu.stat is sampled; defaults to 1s
+	MinWorkers int           // Concurrency is never lowered below this; defaults to 1
+	StatPath   string        // cpu.stat to read; defaults to the cgroup v2, then v1, location
+}
+
+// cpuThrottle applies CPUThrottling through a slot limiter every job holds
+// one slot of while it executes
+type cpuThrottle struct {
+	cfg   CPUThrottling
+	slots *costLimiter
+	limit int // Current concurrency limit; zero outside runs
+	mu    sync.Mutex
+}
+
+// newCPUThrottle creates a throttle for cfg, or nil when it is disabled
+func newCPUThrottle(cfg CPUThrottling) *cpuThrottle {
+	if cfg.Threshold <= 0 {
+		return nil
+	}
+	if cfg.Release <= 0 || cfg.Release > cfg.Threshold {
+		cfg.Release = cfg.Threshold / 2
+	}
+	if cfg.Interval <= 0 {
+		cfg.Interval = time.Second
+	}
+	if cfg.MinWorkers <= 0 {
+		cfg.MinWorkers = 1
+	}
+	if cfg.StatPath == "" {
+		for _, path := range cgroupCPUStatPaths {
+			if _, err := os.Stat(path); err == nil {
+				cfg.StatPath = path
+				break
+			}
+		}
+	}
+	return &cpuThrottle{cfg: cfg, slots: newCostLimiter(0)}
+}
+
+// EffectiveWorkers returns how many jobs may execute at once: NumWorkers,
+// or less while CPUThrottling has lowered concurrency during a run
+func (wp *WorkerPool[T, R]) EffectiveWorkers() int {
+	workers := wp.GetNumWorkers()
+	t := wp.throttle
+	if t == nil {
+		return workers
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.limit > 0 && t.limit < workers {
+		return t.limit
+	}
+	return workers
+}
+
+// start samples cpu.stat in the background, adjusting the limit between
+// MinWorkers and workers, until the returned function is called. That
+// function lifts the limit once sampling has stopped.
+func (t *cpuThrottle) start(workers int) (stop func()) {
+	if t == nil || t.cfg.StatPath == "" {
+		return func() {}
+	}
+	periods, throttled, err := readCPUStat(t.cfg.StatPath)
+	if err != nil {
+		return func() {}
+	}
+	t.setLimit(workers)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		t.watch(ctx, workers, periods, throttled)
+	}()
+	return func() {
+		cancel()
+		<-done
+		t.setLimit(0)
+	}
+}
+
+// watch samples cpu.stat until ctx is done, starting from the given counters
+func (t *cpuThrottle) watch(ctx context.Context, workers int, periods, throttled uint64) {
+	ticker := time.NewTicker(t.cfg.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		p, th, err := readCPUStat(t.cfg.StatPath)
+		if err != nil {
+			continue
+		}
+		var ratio float64
+		if p > periods {
+			ratio = float64(th-throttled) / float64(p-periods)
+		}
+		periods, throttled = p, th
+
+		t.mu.Lock()
+		limit := t.limit
+		t.mu.Unlock()
+		switch {
+		case ratio >= t.cfg.Threshold:
+			limit = max(min(limit-1, limit*3/4), t.cfg.MinWorkers)
+		case ratio <= t.cfg.Release && limit < workers:
+			limit++
+		}
+		t.setLimit(limit)
+	}
+}
+
+// setLimit changes the concurrency limit; zero lifts it
+func (t *cpuThrottle) setLimit(limit int) {
+	t.mu.Lock()
+	t.limit = limit
+	t.mu.Unlock()
+	t.slots.resize(limit)
+}
+
+// acquire waits for an execution slot and returns how many slots to release
+func (t *cpuThrottle) acquire(ctx context.Context) (int, error) {
+	if t == nil {
+		return 0, nil
+	}
+	return t.slots.acquire(ctx, 1)
+}
+
+// release frees slots taken by acquire
+func (t *cpuThrottle) release(slots int) {
+	if t == nil {
+		return
+	}
+	t.slots.release(slots)
+}
+
+// readCPUStat returns the period and throttled-period counters of a cgroup
+// cpu.stat file
+func readCPUStat(path string) (periods, throttled uint64, err error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return 0, 0, err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		key, value, ok := strings.Cut(scanner.Text(), " ")
+		if !ok {
+			continue
+		}
+		n, err := strconv.ParseUint(strings.TrimSpace(value), 10, 64)
+		if err != nil {
+			continue
+		}
+		switch key {
+		case "nr_periods":
+			periods = n
+		case "nr_throttled":
+			throttled = n
+		}
+	}
+	return periods, throttled, scanner.Err()
+}
@@ -0,0 +1,94 @@
+package workerpool
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"time"
+)
+
+// writeCPUStat replaces a cgroup v2 cpu.stat with one holding the given counters
+func writeCPUStat(path string, periods, throttled int) error {
+	stat := fmt.Sprintf("usage_usec 1000\nnr_periods %d\nnr_throttled %d\nthrottled_usec 500\n", periods, throttled)
+	if err := os.WriteFile(path+".tmp", []byte(stat), 0o644); err != nil {
+		return err
+	}
+	return os.Rename(path+".tmp", path)
+}
+
+func (ts *WorkerPoolTestSuite) TestReadCPUStat() {
+	path := filepath.Join(ts.T().TempDir(), "cpu.stat")
+	ts.NoError(writeCPUStat(path, 120, 30))
+
+	periods, throttled, err := readCPUStat(path)
+	ts.NoError(err)
+	ts.Equal(uint64(120), periods)
+	ts.Equal(uint64(30), throttled)
+
+	_, _, err = readCPUStat(filepath.Join(ts.T().TempDir(), "missing"))
+	ts.Error(err)
+}
+
+func (ts *WorkerPoolTestSuite) TestCPUThrottlingLowersConcurrency() {
+	path := filepath.Join(ts.T().TempDir(), "cpu.stat")
+	ts.NoError(writeCPUStat(path, 0, 0))
+
+	config := DefaultConfig()
+	config.CPUThrottling = CPUThrottling{Threshold: 0.5, Interval: 2 * time.Millisecond, StatPath: path}
+	pool := NewWithConfig[int, int](config)
+
+	// Every sample sees all periods throttled until the pool is down to one worker
+	var periods int
+	var lowered atomic.Bool
+	pool.WithProcessor(func(ctx context.Context, job Job[int]) (int, error) {
+		if pool.EffectiveWorkers() == 1 {
+			lowered.Store(true)
+		}
+		time.Sleep(time.Millisecond)
+		return job.Data, nil
+	})
+	for i := 0; i < 200; i++ {
+		pool.AddJob(Job[int]{ID: fmt.Sprint(i), Data: i})
+	}
+	stop := make(chan struct{})
+	go func() {
+		for {
+			select {
+			case <-stop:
+				return
+			case <-time.After(time.Millisecond):
+			}
+			periods += 100
+			writeCPUStat(path, periods, periods)
+		}
+	}()
+
+	results, err := pool.Run()
+	close(stop)
+	ts.NoError(err)
+	ts.Len(results, 200)
+	ts.True(lowered.Load())
+	ts.Equal(4, pool.EffectiveWorkers(), "the limit is lifted when the run ends")
+}
+
+func (ts *WorkerPoolTestSuite) TestCPUThrottleLimit() {
+	ts.Nil(newCPUThrottle(CPUThrottling{}))
+
+	t := newCPUThrottle(CPUThrottling{Threshold: 0.2})
+	ts.Equal(0.1, t.cfg.Release)
+	t.setLimit(1)
+	slot, err := t.acquire(context.Background())
+	ts.NoError(err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Millisecond)
+	defer cancel()
+	_, err = t.acquire(ctx)
+	ts.ErrorIs(err, context.DeadlineExceeded)
+
+	t.setLimit(2)
+	_, err = t.acquire(context.Background())
+	ts.NoError(err)
+	t.release(slot)
+}
@@ -0,0 +1,89 @@
+package workerpool
+
+import (
+	"context"
+	"time"
+)
+
+// YieldError is returned by a processor giving up its worker before its job
+// is done; build it with Yield. The job goes back on the queue with
+// Job.Continuation set, and the processor resumes from there when the job
+// is next dispatched. A yield is neither a failure nor a retry.
+type YieldError struct {
+	Continuation string // Where the processor left off, e.g. a cursor or offset
+}
+
+// Error describes the yield
+func (e *YieldError) Error() string {
+	return "job yielded its time slice"
+}
+
+// Yield returns the error a processor returns to hand its worker to other
+// jobs, typically once ShouldYield reports true. continuation is passed
+// back in Job.Continuation when the job resumes.
+//
+//	for offset := parseOffset(job.Continuation); offset < size; offset += chunk {
+//		if workerpool.ShouldYield(ctx) {
+//			return result, workerpool.Yield(strconv.Itoa(offset))
+//		}
+//		process(offset)
+//	}
+func Yield(continuation string) error {
+	return &YieldError{Continuation: continuation}
+}
+
+// slicedTime is the processing a job spent in the time slices it yielded,
+// carried across redeliveries so its result covers the whole job
+type slicedTime struct {
+	started  time.Time // When the first slice started
+	duration time.Duration
+	cpu      time.Duration
+}
+
+// add returns the totals with one more slice
+func (s slicedTime) add(started time.Time, duration, cpu time.Duration) slicedTime {
+	if s.started.IsZero() {
+		s.started = started
+	}
+	s.duration += duration
+	s.cpu += cpu
+	return s
+}
+
+// timeSliceKey is the context key under which a job's timeSlice is stored
+type timeSliceKey struct{}
+
+// timeSlice is the share of a worker a job may use before it should yield
+type timeSlice struct {
+	end     time.Time
+	waiting func() bool // Reports whether other jobs wait for a worker
+}
+
+// ShouldYield reports whether the job ctx belongs to has used up its
+// Config.TimeSlice while other jobs wait for a worker, so a long-running
+// processor should return Yield to let them run. Time slicing interleaves
+// jobs through the live queue, so it applies under PriorityBased and
+// FairShare; under other strategies, and outside a pool, ShouldYield
+// always reports false.
+func ShouldYield(ctx context.Context) bool {
+	slice, ok := ctx.Value(timeSliceKey{}).(*timeSlice)
+	return ok && !time.Now().Before(slice.end) && slice.waiting()
+}
+
+// withTimeSlice starts the time slice of an attempt when Config.TimeSlice is
+// set and jobs are dispatched from a live queue
+func (wp *WorkerPool[T, R]) withTimeSlice(ctx context.Context) context.Context {
+	if wp.config.TimeSlice <= 0 {
+		return ctx
+	}
+	wp.mu.RLock()
+	waiting := wp.queueWaiting
+	wp.mu.RUnlock()
+	if waiting == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, timeSliceKey{}, &timeSlice{
+		end:     time.Now().Add(wp.config.TimeSlice),
+		waiting: waiting,
+	})
+}
@@ -0,0 +1,151 @@
+package workerpool
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"time"
+)
+
+func (ts *WorkerPoolTestSuite) TestTimeSlicingInterleavesLongJobs() {
+	config := DefaultConfig()
+	config.NumWorkers = 1
+	config.Strategy = PriorityBased
+	config.TimeSlice = time.Millisecond
+	pool := NewWithConfig[int, int](config)
+
+	var mu sync.Mutex
+	var trace []string
+	pool.WithProcessor(func(ctx context.Context, job Job[int]) (int, error) {
+		step := 0
+		if job.Continuation != "" {
+			step, _ = strconv.Atoi(job.Continuation)
+		}
+		for ; step < job.Data; step++ {
+			if ShouldYield(ctx) {
+				return 0, Yield(strconv.Itoa(step))
+			}
+			mu.Lock()
+			trace = append(trace, job.ID)
+			mu.Unlock()
+			time.Sleep(2 * time.Millisecond)
+		}
+		return step, nil
+	})
+	pool.AddJobs([]Job[int]{{ID: "a", Data: 3}, {ID: "b", Data: 3}})
+
+	results, err := pool.Run()
+	ts.NoError(err)
+	ts.Require().Len(results, 2)
+	for _, result := range results {
+		ts.NoError(result.Error)
+		ts.Equal(3, result.Data)
+		ts.Equal(1, result.Attempts, "a yield is not a retry")
+	}
+	// Each step runs once, with the jobs taking turns
+	ts.Equal([]string{"a", "b", "a", "b", "a", "b"}, trace)
+	ts.Equal(2, pool.GetMetrics().ProcessedJobs)
+	ts.Zero(pool.GetMetrics().FailedJobs)
+}
+
+func (ts *WorkerPoolTestSuite) TestShouldYieldOutsideQueuedRuns() {
+	ts.False(ShouldYield(context.Background()))
+
+	config := DefaultConfig()
+	config.NumWorkers = 1
+	config.Strategy = RoundRobin
+	config.TimeSlice = time.Nanosecond
+	pool := NewWithConfig[int, bool](config)
+	pool.WithProcessor(func(ctx context.Context, job Job[int]) (bool, error) {
+		time.Sleep(time.Millisecond)
+		return ShouldYield(ctx), nil
+	})
+	pool.AddJobs([]Job[int]{{ID: "a"}, {ID: "b"}})
+	results, err := pool.Run()
+	ts.NoError(err)
+	for _, result := range results {
+		ts.False(result.Data)
+	}
+}
+
+func (ts *WorkerPoolTestSuite) TestTimeSlicedJobsAccountEverySlice() {
+	config := DefaultConfig()
+	config.NumWorkers = 1
+	config.Strategy = PriorityBased
+	config.TimeSlice = time.Millisecond
+	_, cpuAccounting := threadCPUTime()
+	config.CPUAccounting = cpuAccounting
+	pool := NewWithConfig[int, int](config)
+
+	pool.WithProcessor(func(ctx context.Context, job Job[int]) (int, error) {
+		step := 0
+		if job.Continuation != "" {
+			step, _ = strconv.Atoi(job.Continuation)
+		}
+		for ; step < job.Data; step++ {
+			if ShouldYield(ctx) {
+				return 0, Yield(strconv.Itoa(step))
+			}
+			// Spin for 5ms of wall and, where measurable, CPU time
+			start := time.Now()
+			for time.Since(start) < 5*time.Millisecond {
+			}
+		}
+		return step, nil
+	})
+	pool.AddJobs([]Job[int]{{ID: "a", Data: 3, Owner: "acme"}, {ID: "b", Data: 3, Owner: "acme"}})
+
+	results, err := pool.Run()
+	ts.NoError(err)
+	ts.Require().Len(results, 2)
+	for _, result := range results {
+		ts.NoError(result.Error)
+		ts.GreaterOrEqual(result.Duration, 15*time.Millisecond, "the result covers all three slices")
+		ts.True(result.Completed.Sub(result.Started) >= result.Duration, "started with the first slice")
+		if cpuAccounting {
+			ts.GreaterOrEqual(result.CPUTime, 12*time.Millisecond, "the result covers all three slices")
+		}
+	}
+	ts.GreaterOrEqual(pool.usage.consumed("acme", time.Now()), 30*time.Millisecond, "every slice is charged to the owner")
+	if cpuAccounting {
+		ts.GreaterOrEqual(pool.GetMetrics().CPUTime, 24*time.Millisecond)
+	}
+}
+
+func (ts *WorkerPoolTestSuite) TestYieldedJobsAreAdmittedOnce() {
+	config := DefaultConfig()
+	config.NumWorkers = 1
+	config.Strategy = PriorityBased
+	config.TimeSlice = time.Millisecond
+	config.RunBudget = Budget{MaxCost: 2}
+	pool := NewWithConfig[int, int](config)
+
+	pool.WithProcessor(func(ctx context.Context, job Job[int]) (int, error) {
+		step := 0
+		if job.Continuation != "" {
+			step, _ = strconv.Atoi(job.Continuation)
+		}
+		for ; step < job.Data; step++ {
+			if ShouldYield(ctx) {
+				return 0, Yield(strconv.Itoa(step))
+			}
+			time.Sleep(5 * time.Millisecond)
+		}
+		return step, nil
+	})
+	// Each job resumes after its TTL lapsed, and its slices together would
+	// cost more than the budget if every delivery were charged
+	pool.AddJobs([]Job[int]{
+		{ID: "a", Data: 3, Cost: 1, TTL: 8 * time.Millisecond},
+		{ID: "b", Data: 3, Cost: 1, TTL: 8 * time.Millisecond},
+	})
+
+	results, err := pool.Run()
+	ts.NoError(err)
+	ts.Require().Len(results, 2)
+	for _, result := range results {
+		ts.NoError(result.Error)
+		ts.Equal(3, result.Data)
+	}
+	ts.Equal(2, pool.budgets.total.cost)
+}
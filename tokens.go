@@ -0,0 +1,135 @@
+package workerpool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ErrUnknownTokenPool is returned by AcquireToken for a token pool that was
+// not declared with WithResourceTokens
+var ErrUnknownTokenPool = errors.New("unknown resource token pool")
+
+// TokenStats describes the use of one token pool across runs
+type TokenStats struct {
+	Capacity int           `json:"capacity"`    // Tokens in the pool; zero means unlimited
+	InUse    int           `json:"in_use"`      // Tokens held right now
+	Acquired int           `json:"acquired"`    // Tokens handed out
+	Waited   int           `json:"waited"`      // Acquisitions that had to wait for a token
+	WaitTime time.Duration `json:"wait_ns"`     // Time spent waiting for tokens, summed
+	MaxWait  time.Duration `json:"max_wait_ns"` // Longest wait for a token
+}
+
+// tokenPool is a named semaphore with its wait statistics
+type tokenPool struct {
+	limiter *costLimiter
+	stats   TokenStats // Counters only; Capacity and InUse come from limiter
+	mu      sync.Mutex
+}
+
+// resourceTokens holds the token pools of a worker pool by name
+type resourceTokens struct {
+	pools map[string]*tokenPool
+	mu    sync.RWMutex
+}
+
+// resourceTokensKey is the context key under which a pool's resourceTokens
+// are stored
+type resourceTokensKey struct{}
+
+// WithResourceTokens declares a pool of n tokens, e.g. the 3 connections a
+// legacy database allows, that processors acquire with AcquireToken. Tokens
+// bound a resource independently of the number of workers: a worker
+// waiting for a token keeps its job. Declaring a name again resizes its
+// pool; n of zero or less makes it unlimited.
+func (wp *WorkerPool[T, R]) WithResourceTokens(name string, n int) *WorkerPool[T, R] {
+	t := &wp.tokens
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if pool, ok := t.pools[name]; ok {
+		pool.limiter.resize(n)
+		return wp
+	}
+	if t.pools == nil {
+		t.pools = make(map[string]*tokenPool)
+	}
+	t.pools[name] = &tokenPool{limiter: newCostLimiter(n)}
+	return wp
+}
+
+// AcquireToken blocks until a token of the named pool of the pool running
+// ctx's job is free, or ctx is done. The processor must call release once
+// it is done with the resource, before returning; release may be called
+// more than once.
+//
+//	release, err := workerpool.AcquireToken(ctx, "legacy-db")
+//	if err != nil {
+//		return result, err
+//	}
+//	defer release()
+func AcquireToken(ctx context.Context, name string) (release func(), err error) {
+	t, _ := ctx.Value(resourceTokensKey{}).(*resourceTokens)
+	var pool *tokenPool
+	if t != nil {
+		t.mu.RLock()
+		pool = t.pools[name]
+		t.mu.RUnlock()
+	}
+	if pool == nil {
+		return nil, fmt.Errorf("token %q: %w", name, ErrUnknownTokenPool)
+	}
+
+	held, ok := pool.limiter.tryAcquire(1)
+	if ok {
+		pool.record(0)
+	} else {
+		start := time.Now()
+		if held, err = pool.limiter.acquire(ctx, 1); err != nil {
+			return nil, err
+		}
+		pool.record(time.Since(start))
+	}
+
+	var once sync.Once
+	return func() {
+		once.Do(func() { pool.limiter.release(held) })
+	}, nil
+}
+
+// record counts an acquisition, which waited for wait if that is positive
+func (p *tokenPool) record(wait time.Duration) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.stats.Acquired++
+	if wait <= 0 {
+		return
+	}
+	p.stats.Waited++
+	p.stats.WaitTime += wait
+	if wait > p.stats.MaxWait {
+		p.stats.MaxWait = wait
+	}
+}
+
+// TokenStats returns the capacity, usage and wait times of every token
+// pool declared with WithResourceTokens, by name
+func (wp *WorkerPool[T, R]) TokenStats() map[string]TokenStats {
+	t := &wp.tokens
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	stats := make(map[string]TokenStats, len(t.pools))
+	for name, pool := range t.pools {
+		pool.mu.Lock()
+		s := pool.stats
+		pool.mu.Unlock()
+		pool.limiter.mu.Lock()
+		s.Capacity = pool.limiter.capacity
+		s.InUse = pool.limiter.used
+		pool.limiter.mu.Unlock()
+		stats[name] = s
+	}
+	return stats
+}
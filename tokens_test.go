@@ -0,0 +1,71 @@
+package workerpool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"time"
+)
+
+func (ts *WorkerPoolTestSuite) TestResourceTokens() {
+	config := DefaultConfig()
+	config.NumWorkers = 6
+	pool := NewWithConfig[int, int](config)
+	pool.WithResourceTokens("legacy-db", 2)
+
+	var connections, peak atomic.Int32
+	pool.WithProcessor(func(ctx context.Context, job Job[int]) (int, error) {
+		release, err := AcquireToken(ctx, "legacy-db")
+		if err != nil {
+			return 0, err
+		}
+		defer release()
+		defer release() // Releasing twice is harmless
+
+		n := connections.Add(1)
+		defer connections.Add(-1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		return job.Data, nil
+	})
+	for i := 0; i < 6; i++ {
+		pool.AddJob(Job[int]{ID: fmt.Sprint(i), Data: i})
+	}
+
+	results, err := pool.Run()
+	ts.NoError(err)
+	ts.Len(results, 6)
+	for _, result := range results {
+		ts.NoError(result.Error)
+	}
+	ts.Equal(int32(2), peak.Load(), "six workers share two connections")
+
+	stats := pool.TokenStats()["legacy-db"]
+	ts.Equal(2, stats.Capacity)
+	ts.Equal(0, stats.InUse)
+	ts.Equal(6, stats.Acquired)
+	ts.GreaterOrEqual(stats.Waited, 4)
+	ts.GreaterOrEqual(stats.MaxWait, 20*time.Millisecond)
+	ts.GreaterOrEqual(stats.WaitTime, stats.MaxWait)
+}
+
+func (ts *WorkerPoolTestSuite) TestUnknownResourceToken() {
+	_, err := AcquireToken(context.Background(), "legacy-db")
+	ts.True(errors.Is(err, ErrUnknownTokenPool))
+
+	pool := New[int, int]()
+	pool.WithProcessor(func(ctx context.Context, job Job[int]) (int, error) {
+		_, err := AcquireToken(ctx, "undeclared")
+		return 0, err
+	})
+	pool.AddJob(Job[int]{ID: "1"})
+	results, err := pool.Run()
+	ts.NoError(err)
+	ts.True(errors.Is(results[0].Error, ErrUnknownTokenPool))
+}
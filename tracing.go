@@ -0,0 +1,101 @@
+package workerpool
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"log/slog"
+	"strconv"
+	"sync/atomic"
+	"time"
+)
+
+// executionKey is the context key under which an attempt's Execution is stored
+type executionKey struct{}
+
+// Execution identifies one processor call. Every attempt of every job gets
+// a new ID, unique across pools and processes, which surfaces on
+// Result.ExecutionIDs. Processors find it in their context, so log lines
+// written while a job runs can be matched to the pool's own records.
+type Execution struct {
+	ID      string
+	JobID   string
+	Attempt int // Starting at 1
+	Worker  int
+}
+
+// ExecutionFromContext returns the execution ctx belongs to, and false if
+// ctx was not created by a pool
+func ExecutionFromContext(ctx context.Context) (Execution, bool) {
+	e, ok := ctx.Value(executionKey{}).(Execution)
+	return e, ok
+}
+
+// LogValue logs an execution as a group of its fields
+func (e Execution) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("id", e.ID),
+		slog.String("job_id", e.JobID),
+		slog.Int("attempt", e.Attempt),
+		slog.Int("worker", e.Worker),
+	)
+}
+
+// NewExecutionHandler wraps a slog handler so that every record logged with
+// a job's context, e.g. through slog.InfoContext, carries an "execution"
+// group with the execution ID, job ID, attempt and worker
+func NewExecutionHandler(next slog.Handler) slog.Handler {
+	return executionHandler{next}
+}
+
+// executionHandler is the handler returned by NewExecutionHandler
+type executionHandler struct {
+	slog.Handler
+}
+
+// Handle adds the context's execution to the record
+func (h executionHandler) Handle(ctx context.Context, r slog.Record) error {
+	if e, ok := ExecutionFromContext(ctx); ok {
+		r = r.Clone()
+		r.AddAttrs(slog.Any("execution", e))
+	}
+	return h.Handler.Handle(ctx, r)
+}
+
+// WithAttrs keeps derived handlers adding the execution
+func (h executionHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return executionHandler{h.Handler.WithAttrs(attrs)}
+}
+
+// WithGroup keeps derived handlers adding the execution
+func (h executionHandler) WithGroup(name string) slog.Handler {
+	return executionHandler{h.Handler.WithGroup(name)}
+}
+
+// executionPrefix makes execution IDs unique across processes; the counter
+// makes them unique within one
+var (
+	executionPrefix = randomHex(6)
+	executionSeq    atomic.Uint64
+)
+
+// newExecutionID returns a new execution ID
+func newExecutionID() string {
+	return executionPrefix + "-" + strconv.FormatUint(executionSeq.Add(1), 16)
+}
+
+// randomHex returns n random bytes in hex. Should the system's random
+// source fail, the clock stands in, which still tells processes apart.
+func randomHex(n int) string {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return strconv.FormatInt(time.Now().UnixNano(), 16)
+	}
+	return hex.EncodeToString(b)
+}
+
+// withExecution returns ctx carrying a new execution of job's attempt
+func withExecution(ctx context.Context, jobID string, attempt, worker int) (context.Context, string) {
+	id := newExecutionID()
+	return context.WithValue(ctx, executionKey{}, Execution{ID: id, JobID: jobID, Attempt: attempt, Worker: worker}), id
+}
@@ -0,0 +1,58 @@
+package workerpool
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"strings"
+)
+
+func (ts *WorkerPoolTestSuite) TestExecutionIDsCorrelateLogs() {
+	var buf bytes.Buffer
+	logger := slog.New(NewExecutionHandler(slog.NewJSONHandler(&buf, nil))).With("component", "test")
+
+	config := DefaultConfig()
+	config.NumWorkers = 1
+	config.RetryBands = []RetryBand{{MaxRetries: 1, Backoff: 1}}
+	pool := NewWithConfig[int, int](config)
+	failed := false
+	pool.WithProcessor(func(ctx context.Context, job Job[int]) (int, error) {
+		logger.InfoContext(ctx, "processing")
+		if !failed {
+			failed = true
+			return 0, errors.New("transient")
+		}
+		return job.Data, nil
+	})
+	pool.AddJob(Job[int]{ID: "a", Data: 1})
+	results, err := pool.Run()
+	ts.NoError(err)
+	ts.Require().Len(results, 1)
+
+	ids := results[0].ExecutionIDs
+	ts.Require().Len(ids, 2)
+	ts.NotEqual(ids[0], ids[1])
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	ts.Require().Len(lines, 2)
+	for i, line := range lines {
+		var record struct {
+			Component string `json:"component"`
+			Execution struct {
+				ID      string `json:"id"`
+				JobID   string `json:"job_id"`
+				Attempt int    `json:"attempt"`
+			} `json:"execution"`
+		}
+		ts.Require().NoError(json.Unmarshal([]byte(line), &record))
+		ts.Equal("test", record.Component)
+		ts.Equal(ids[i], record.Execution.ID)
+		ts.Equal("a", record.Execution.JobID)
+		ts.Equal(i+1, record.Execution.Attempt)
+	}
+
+	_, ok := ExecutionFromContext(context.Background())
+	ts.False(ok)
+}
@@ -0,0 +1,27 @@
+package workerpool
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrJobExpired is reported in a job's result when it was still queued past its expiry
+var ErrJobExpired = errors.New("job expired before processing")
+
+// ExpiresAtTime returns when the job expires, or the zero time if it never does.
+// An explicit ExpiresAt takes precedence over TTL measured from Created.
+func (j Job[T]) ExpiresAtTime() time.Time {
+	if !j.ExpiresAt.IsZero() {
+		return j.ExpiresAt
+	}
+	if j.TTL > 0 && !j.Created.IsZero() {
+		return j.Created.Add(j.TTL)
+	}
+	return time.Time{}
+}
+
+// Expired reports whether the job has passed its expiry at the given time
+func (j Job[T]) Expired(now time.Time) bool {
+	expiry := j.ExpiresAtTime()
+	return !expiry.IsZero() && now.After(expiry)
+}
@@ -0,0 +1,58 @@
+package workerpool
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+func (ts *WorkerPoolTestSuite) TestJobExpiry() {
+	now := time.Now()
+	ts.False(Job[string]{}.Expired(now))
+	ts.True(Job[string]{Created: now.Add(-time.Minute), TTL: time.Second}.Expired(now))
+	ts.False(Job[string]{Created: now, TTL: time.Minute}.Expired(now))
+	ts.True(Job[string]{ExpiresAt: now.Add(-time.Second), TTL: time.Hour, Created: now}.Expired(now))
+}
+
+func (ts *WorkerPoolTestSuite) TestExpiredJobsAreNotProcessed() {
+	pool := New[string, string]()
+
+	processed := make(chan string, 2)
+	pool.WithProcessor(func(ctx context.Context, job Job[string]) (string, error) {
+		processed <- job.ID
+		return job.Data, nil
+	})
+
+	pool.AddJobs([]Job[string]{
+		{ID: "fresh", Data: "a", TTL: time.Minute},
+		{ID: "stale", Data: "b", ExpiresAt: time.Now().Add(-time.Second)},
+	})
+
+	results, err := pool.Run()
+	ts.NoError(err)
+	ts.Len(results, 2)
+	close(processed)
+
+	for _, result := range results {
+		if result.JobID == "stale" {
+			ts.True(errors.Is(result.Error, ErrJobExpired))
+		} else {
+			ts.NoError(result.Error)
+		}
+	}
+	ts.Equal([]string{"fresh"}, drain(processed))
+
+	metrics := pool.GetMetrics()
+	ts.Equal(1, metrics.ExpiredJobs)
+	ts.Equal(1, metrics.ProcessedJobs)
+	ts.Equal(0, metrics.FailedJobs)
+}
+
+// drain collects the remaining values of a closed channel
+func drain[V any](ch <-chan V) []V {
+	var out []V
+	for v := range ch {
+		out = append(out, v)
+	}
+	return out
+}
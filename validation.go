@@ -0,0 +1,311 @@
+package workerpool
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// ErrInvalidPayload is returned when a job is refused by the pool's validator
+var ErrInvalidPayload = errors.New("invalid job payload")
+
+// WithValidator sets a function every job's payload must pass when it is
+// added, after the job mutator has run. Refused jobs are never queued:
+// Submit returns the validator's error wrapped in ErrInvalidPayload and
+// AddJobs drops them, so malformed jobs fail at the edge rather than deep
+// in a processor.
+func (wp *WorkerPool[T, R]) WithValidator(validate func(T) error) *WorkerPool[T, R] {
+	wp.mu.Lock()
+	defer wp.mu.Unlock()
+	wp.validator = validate
+	return wp
+}
+
+// JSONSchema compiles a JSON Schema into a validator for pools whose
+// payloads are raw JSON, for use with WithValidator:
+//
+//	validate, err := workerpool.JSONSchema[json.RawMessage](schema)
+//	pool.WithValidator(validate)
+//
+// It supports the commonly used subset of the specification: type, enum,
+// const, properties, required, additionalProperties (as a boolean or a
+// schema), items, minItems, maxItems, minLength, maxLength, pattern,
+// minimum, maximum, exclusiveMinimum and exclusiveMaximum (as numbers).
+// Other keywords, including $ref, are ignored.
+func JSONSchema[T ~[]byte | ~string](schema []byte) (func(T) error, error) {
+	s, err := compileSchema(schema)
+	if err != nil {
+		return nil, fmt.Errorf("json schema: %w", err)
+	}
+	return func(payload T) error {
+		dec := json.NewDecoder(bytes.NewReader([]byte(payload)))
+		dec.UseNumber()
+		var v any
+		if err := dec.Decode(&v); err != nil {
+			return fmt.Errorf("payload is not JSON: %w", err)
+		}
+		return s.validate("$", v)
+	}, nil
+}
+
+// jsonSchema is a compiled JSON Schema
+type jsonSchema struct {
+	types                []string
+	enum                 []any
+	properties           map[string]*jsonSchema
+	required             []string
+	additional           *jsonSchema // Schema for properties not in properties
+	noAdditional         bool
+	items                *jsonSchema
+	minItems, maxItems   *int
+	minLength, maxLength *int
+	pattern              *regexp.Regexp
+	minimum, maximum     *float64
+	exclusiveMin         *float64
+	exclusiveMax         *float64
+}
+
+// schemaDoc is the JSON form of the keywords jsonSchema supports
+type schemaDoc struct {
+	Type                 json.RawMessage            `json:"type"`
+	Enum                 []any                      `json:"enum"`
+	Const                json.RawMessage            `json:"const"`
+	Properties           map[string]json.RawMessage `json:"properties"`
+	Required             []string                   `json:"required"`
+	AdditionalProperties json.RawMessage            `json:"additionalProperties"`
+	Items                json.RawMessage            `json:"items"`
+	MinItems             *int                       `json:"minItems"`
+	MaxItems             *int                       `json:"maxItems"`
+	MinLength            *int                       `json:"minLength"`
+	MaxLength            *int                       `json:"maxLength"`
+	Pattern              *string                    `json:"pattern"`
+	Minimum              *float64                   `json:"minimum"`
+	Maximum              *float64                   `json:"maximum"`
+	ExclusiveMinimum     *float64                   `json:"exclusiveMinimum"`
+	ExclusiveMaximum     *float64                   `json:"exclusiveMaximum"`
+}
+
+// compileSchema parses a schema document
+func compileSchema(raw []byte) (*jsonSchema, error) {
+	if trimmed := bytes.TrimSpace(raw); bytes.Equal(trimmed, []byte("true")) {
+		return &jsonSchema{}, nil
+	}
+	var doc schemaDoc
+	if err := json.Unmarshal(raw, &doc); err != nil {
+		return nil, err
+	}
+
+	s := &jsonSchema{
+		enum:      doc.Enum,
+		required:  doc.Required,
+		minItems:  doc.MinItems,
+		maxItems:  doc.MaxItems,
+		minLength: doc.MinLength,
+		maxLength: doc.MaxLength,
+		minimum:   doc.Minimum,
+		maximum:   doc.Maximum,
+
+		exclusiveMin: doc.ExclusiveMinimum,
+		exclusiveMax: doc.ExclusiveMaximum,
+	}
+	if len(doc.Type) > 0 {
+		var one string
+		if err := json.Unmarshal(doc.Type, &one); err == nil {
+			s.types = []string{one}
+		} else if err := json.Unmarshal(doc.Type, &s.types); err != nil {
+			return nil, fmt.Errorf("type: %w", err)
+		}
+	}
+	if len(doc.Const) > 0 {
+		var c any
+		if err := json.Unmarshal(doc.Const, &c); err != nil {
+			return nil, fmt.Errorf("const: %w", err)
+		}
+		s.enum = []any{c}
+	}
+	if len(doc.Properties) > 0 {
+		s.properties = make(map[string]*jsonSchema, len(doc.Properties))
+		for name, raw := range doc.Properties {
+			prop, err := compileSchema(raw)
+			if err != nil {
+				return nil, fmt.Errorf("properties.%s: %w", name, err)
+			}
+			s.properties[name] = prop
+		}
+	}
+	switch trimmed := bytes.TrimSpace(doc.AdditionalProperties); {
+	case len(trimmed) == 0 || bytes.Equal(trimmed, []byte("true")):
+	case bytes.Equal(trimmed, []byte("false")):
+		s.noAdditional = true
+	default:
+		additional, err := compileSchema(trimmed)
+		if err != nil {
+			return nil, fmt.Errorf("additionalProperties: %w", err)
+		}
+		s.additional = additional
+	}
+	if len(doc.Items) > 0 {
+		items, err := compileSchema(doc.Items)
+		if err != nil {
+			return nil, fmt.Errorf("items: %w", err)
+		}
+		s.items = items
+	}
+	if doc.Pattern != nil {
+		re, err := regexp.Compile(*doc.Pattern)
+		if err != nil {
+			return nil, fmt.Errorf("pattern: %w", err)
+		}
+		s.pattern = re
+	}
+	return s, nil
+}
+
+// validate checks v, decoded with UseNumber, against the schema. path
+// locates v in the payload for error messages.
+func (s *jsonSchema) validate(path string, v any) error {
+	if len(s.types) > 0 && !s.typeMatches(v) {
+		return fmt.Errorf("%s: expected %s, got %s", path, strings.Join(s.types, " or "), jsonType(v))
+	}
+	if len(s.enum) > 0 && !s.inEnum(v) {
+		return fmt.Errorf("%s: value not allowed", path)
+	}
+
+	switch v := v.(type) {
+	case string:
+		n := utf8.RuneCountInString(v)
+		if s.minLength != nil && n < *s.minLength {
+			return fmt.Errorf("%s: shorter than %d characters", path, *s.minLength)
+		}
+		if s.maxLength != nil && n > *s.maxLength {
+			return fmt.Errorf("%s: longer than %d characters", path, *s.maxLength)
+		}
+		if s.pattern != nil && !s.pattern.MatchString(v) {
+			return fmt.Errorf("%s: does not match %s", path, s.pattern)
+		}
+
+	case json.Number:
+		f, err := v.Float64()
+		if err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+		switch {
+		case s.minimum != nil && f < *s.minimum:
+			return fmt.Errorf("%s: less than %v", path, *s.minimum)
+		case s.maximum != nil && f > *s.maximum:
+			return fmt.Errorf("%s: greater than %v", path, *s.maximum)
+		case s.exclusiveMin != nil && f <= *s.exclusiveMin:
+			return fmt.Errorf("%s: not greater than %v", path, *s.exclusiveMin)
+		case s.exclusiveMax != nil && f >= *s.exclusiveMax:
+			return fmt.Errorf("%s: not less than %v", path, *s.exclusiveMax)
+		}
+
+	case []any:
+		if s.minItems != nil && len(v) < *s.minItems {
+			return fmt.Errorf("%s: fewer than %d items", path, *s.minItems)
+		}
+		if s.maxItems != nil && len(v) > *s.maxItems {
+			return fmt.Errorf("%s: more than %d items", path, *s.maxItems)
+		}
+		if s.items != nil {
+			for i, item := range v {
+				if err := s.items.validate(fmt.Sprintf("%s[%d]", path, i), item); err != nil {
+					return err
+				}
+			}
+		}
+
+	case map[string]any:
+		for _, name := range s.required {
+			if _, ok := v[name]; !ok {
+				return fmt.Errorf("%s: missing required property %q", path, name)
+			}
+		}
+		// Check properties in a fixed order so the reported error is stable
+		names := make([]string, 0, len(v))
+		for name := range v {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			prop, ok := s.properties[name]
+			switch {
+			case ok:
+			case s.noAdditional:
+				return fmt.Errorf("%s: unexpected property %q", path, name)
+			case s.additional != nil:
+				prop = s.additional
+			default:
+				continue
+			}
+			if err := prop.validate(path+"."+name, v[name]); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// typeMatches reports whether v has one of the schema's types
+func (s *jsonSchema) typeMatches(v any) bool {
+	actual := jsonType(v)
+	for _, t := range s.types {
+		if t == actual || (t == "number" && actual == "integer") {
+			return true
+		}
+	}
+	return false
+}
+
+// inEnum reports whether v equals one of the enum values
+func (s *jsonSchema) inEnum(v any) bool {
+	for _, allowed := range s.enum {
+		if jsonEqual(v, allowed) {
+			return true
+		}
+	}
+	return false
+}
+
+// jsonType names the JSON type of a decoded value; whole numbers are "integer"
+func jsonType(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case string:
+		return "string"
+	case json.Number:
+		if f, err := v.Float64(); err == nil && f == math.Trunc(f) {
+			return "integer"
+		}
+		return "number"
+	case []any:
+		return "array"
+	default:
+		return "object"
+	}
+}
+
+// jsonEqual compares decoded JSON values, treating numbers by value
+func jsonEqual(a, b any) bool {
+	na, aNum := a.(json.Number)
+	if aNum {
+		fa, _ := na.Float64()
+		fb, ok := b.(float64)
+		return ok && fa == fb
+	}
+	ja, err := json.Marshal(a)
+	if err != nil {
+		return false
+	}
+	jb, err := json.Marshal(b)
+	return err == nil && bytes.Equal(ja, jb)
+}
@@ -0,0 +1,79 @@
+package workerpool
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+)
+
+func (ts *WorkerPoolTestSuite) TestWithValidator() {
+	pool := New[string, string]()
+	pool.WithJobMutator(func(job Job[string]) Job[string] {
+		job.Data = strings.TrimSpace(job.Data)
+		return job
+	})
+	pool.WithValidator(func(data string) error {
+		if data == "" {
+			return errors.New("empty payload")
+		}
+		return nil
+	})
+
+	// Validation sees the mutated payload
+	err := pool.Submit(Job[string]{ID: "blank", Data: "   "})
+	ts.ErrorIs(err, ErrInvalidPayload)
+	ts.EqualError(err, "job blank: invalid job payload: empty payload")
+	ts.NoError(pool.Submit(Job[string]{ID: "ok", Data: " x "}))
+
+	pool.SetJobs([]Job[string]{{ID: "a", Data: "a"}, {ID: "b"}, {ID: "c", Data: "c"}})
+	ts.Len(pool.PendingJobs(), 2)
+	ts.Equal(2, pool.GetMetrics().TotalJobs)
+}
+
+func (ts *WorkerPoolTestSuite) TestJSONSchemaValidator() {
+	schema := []byte(`{
+		"type": "object",
+		"required": ["id", "kind"],
+		"additionalProperties": false,
+		"properties": {
+			"id": {"type": "integer", "minimum": 1},
+			"kind": {"enum": ["resize", "crop"]},
+			"tags": {"type": "array", "maxItems": 2, "items": {"type": "string", "pattern": "^[a-z]+$"}},
+			"scale": {"type": ["number", "null"], "exclusiveMaximum": 10}
+		}
+	}`)
+	validate, err := JSONSchema[json.RawMessage](schema)
+	ts.Require().NoError(err)
+
+	for payload, want := range map[string]string{
+		`{"id": 1, "kind": "crop"}`:                                "",
+		`{"id": 2, "kind": "resize", "tags": ["a"], "scale": 2.5}`: "",
+		`{"id": 2, "kind": "resize", "scale": null}`:               "",
+		`{"id": 1}`:                                          `$: missing required property "kind"`,
+		`{"id": 1.5, "kind": "crop"}`:                        "$.id: expected integer, got number",
+		`{"id": 0, "kind": "crop"}`:                          "$.id: less than 1",
+		`{"id": 1, "kind": "rotate"}`:                        "$.kind: value not allowed",
+		`{"id": 1, "kind": "crop", "x": 1}`:                  `$: unexpected property "x"`,
+		`{"id": 1, "kind": "crop", "tags": ["a", "B"]}`:      "$.tags[1]: does not match ^[a-z]+$",
+		`{"id": 1, "kind": "crop", "tags": ["a", "b", "c"]}`: "$.tags: more than 2 items",
+		`{"id": 1, "kind": "crop", "scale": 10}`:             "$.scale: not less than 10",
+		`[]`:                                                 "$: expected object, got array",
+		`{"id`:                                               "payload is not JSON: unexpected EOF",
+	} {
+		err := validate(json.RawMessage(payload))
+		if want == "" {
+			ts.NoError(err, payload)
+		} else {
+			ts.EqualError(err, want, payload)
+		}
+	}
+
+	_, err = JSONSchema[string]([]byte(`{"pattern": "("}`))
+	ts.ErrorContains(err, "json schema: pattern")
+
+	pool := New[string, string]()
+	validateString, err := JSONSchema[string](schema)
+	ts.Require().NoError(err)
+	pool.WithValidator(validateString)
+	ts.ErrorIs(pool.Submit(Job[string]{ID: "bad", Data: `{"id": 1}`}), ErrInvalidPayload)
+}
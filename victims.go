@@ -0,0 +1,69 @@
+package workerpool
+
+import (
+	"math/rand"
+	"sort"
+)
+
+// victimSelector orders the workers a thief tries to steal from, nearest first.
+// Workers in the thief's locality domain come before other domains, and within
+// a domain closer worker IDs come first. Victims at the same distance are
+// shuffled every round so neighbors do not all pile onto the same deque.
+type victimSelector struct {
+	groups [][]int // Victims grouped by distance, nearest group first
+	order  []int
+	rng    *rand.Rand
+}
+
+// newVictimSelector builds the victim order for worker id. domainSize is the
+// number of consecutive workers that share a locality domain (e.g. a NUMA
+// node); zero treats all workers as one domain.
+func newVictimSelector(id, numWorkers, domainSize int, seed int64) *victimSelector {
+	type victim struct{ id, domain, distance int }
+
+	victims := make([]victim, 0, max(0, numWorkers-1))
+	for v := 0; v < numWorkers; v++ {
+		if v == id {
+			continue
+		}
+		d := v - id
+		if d < 0 {
+			d = -d
+		}
+		crossDomain := 0
+		if domainSize > 0 && v/domainSize != id/domainSize {
+			crossDomain = 1
+		}
+		victims = append(victims, victim{id: v, domain: crossDomain, distance: min(d, numWorkers-d)})
+	}
+	sort.SliceStable(victims, func(i, j int) bool {
+		if victims[i].domain != victims[j].domain {
+			return victims[i].domain < victims[j].domain
+		}
+		return victims[i].distance < victims[j].distance
+	})
+
+	s := &victimSelector{
+		order: make([]int, 0, len(victims)),
+		rng:   rand.New(rand.NewSource(seed)),
+	}
+	for i, v := range victims {
+		if i == 0 || v.domain != victims[i-1].domain || v.distance != victims[i-1].distance {
+			s.groups = append(s.groups, nil)
+		}
+		s.groups[len(s.groups)-1] = append(s.groups[len(s.groups)-1], v.id)
+	}
+	return s
+}
+
+// next returns the victims to try this round, nearest first with ties shuffled
+func (s *victimSelector) next() []int {
+	s.order = s.order[:0]
+	for _, group := range s.groups {
+		start := len(s.order)
+		s.order = append(s.order, group...)
+		tied := s.order[start:]
+		s.rng.Shuffle(len(tied), func(i, j int) { tied[i], tied[j] = tied[j], tied[i] })
+	}
+	return s.order
+}
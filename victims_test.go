@@ -0,0 +1,37 @@
+package workerpool
+
+func (ts *WorkerPoolTestSuite) TestVictimOrderNearestFirst() {
+	s := newVictimSelector(0, 8, 0, 1)
+
+	for round := 0; round < 10; round++ {
+		order := s.next()
+		ts.Len(order, 7)
+		ts.NotContains(order, 0)
+		// Ring neighbors 1 and 7 first, then 2 and 6, 3 and 5, and 4 last
+		ts.ElementsMatch([]int{1, 7}, order[0:2])
+		ts.ElementsMatch([]int{2, 6}, order[2:4])
+		ts.ElementsMatch([]int{3, 5}, order[4:6])
+		ts.Equal(4, order[6])
+	}
+}
+
+func (ts *WorkerPoolTestSuite) TestVictimOrderShufflesTies() {
+	s := newVictimSelector(0, 8, 0, 1)
+
+	first := make(map[int]bool)
+	for round := 0; round < 50; round++ {
+		first[s.next()[0]] = true
+	}
+	ts.Equal(map[int]bool{1: true, 7: true}, first)
+}
+
+func (ts *WorkerPoolTestSuite) TestVictimOrderPrefersOwnDomain() {
+	// Two domains of four workers: 0-3 and 4-7
+	s := newVictimSelector(2, 8, 4, 1)
+
+	order := s.next()
+	ts.ElementsMatch([]int{0, 1, 3}, order[0:3])
+	ts.ElementsMatch([]int{4, 5, 6, 7}, order[3:])
+
+	ts.Empty(newVictimSelector(0, 1, 0, 1).next())
+}
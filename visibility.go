@@ -0,0 +1,114 @@
+package workerpool
+
+import (
+	"errors"
+	"fmt"
+	"runtime/debug"
+)
+
+// ErrVisibilityTimeout is the cancellation cause of a delivery that neither
+// completed nor heartbeated within Config.VisibilityTimeout
+var ErrVisibilityTimeout = errors.New("job visibility timeout lapsed")
+
+// ErrWorkerPanic matches the error of a delivery whose processor panicked
+var ErrWorkerPanic = errors.New("worker panicked")
+
+// panicRedeliveries caps the redeliveries of a panicking job when
+// Config.MaxRedeliveries leaves them unlimited, since a job that panics
+// every time would otherwise be redelivered forever
+const panicRedeliveries = 3
+
+// PanicError is the error of a delivery whose processor panicked. It
+// matches ErrWorkerPanic, and unwraps to the panic value if that is an error.
+type PanicError struct {
+	Value any    // Value passed to panic
+	Stack []byte // Stack of the panicking goroutine
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("worker panicked: %v", e.Value)
+}
+
+// Is reports whether target is ErrWorkerPanic
+func (e *PanicError) Is(target error) bool {
+	return target == ErrWorkerPanic
+}
+
+// Unwrap returns the panic value if it is an error
+func (e *PanicError) Unwrap() error {
+	err, _ := e.Value.(error)
+	return err
+}
+
+// invoke runs the processor for one attempt. With a visibility timeout the
+// processor runs on its own goroutine, so the worker can abandon an attempt
+// that is lost and move on; the abandoned call sees its context cancelled.
+// An attempt whose processor panicked is lost too, with a *PanicError.
+func (wp *WorkerPool[T, R]) invoke(ctx *jobContext, job Job[T]) (result R, lost bool, err error) {
+	if wp.config.VisibilityTimeout <= 0 {
+		return wp.call(ctx, job)
+	}
+
+	type outcome struct {
+		result   R
+		panicked bool
+		err      error
+	}
+	done := make(chan outcome, 1)
+	go func() {
+		result, panicked, err := wp.call(ctx, job)
+		done <- outcome{result, panicked, err}
+	}()
+
+	select {
+	case o := <-done:
+		return o.result, o.panicked, o.err
+	case <-ctx.ctl.lost:
+	}
+	// Keep a success that raced with the timeout
+	select {
+	case o := <-done:
+		if o.err == nil {
+			return o.result, false, nil
+		}
+	default:
+	}
+	return result, true, ErrVisibilityTimeout
+}
+
+// call runs the processor, recovering a panic into a *PanicError so the
+// worker survives and the job can be handed to another one
+func (wp *WorkerPool[T, R]) call(ctx *jobContext, job Job[T]) (result R, panicked bool, err error) {
+	defer func() {
+		if v := recover(); v != nil {
+			panicked, err = true, &PanicError{Value: v, Stack: debug.Stack()}
+		}
+	}()
+	result, err = wp.process(ctx, job)
+	return result, false, err
+}
+
+// redeliveryLimit returns how many times a job lost with err may be
+// redelivered, zero meaning unlimited
+func (wp *WorkerPool[T, R]) redeliveryLimit(err error) int {
+	if wp.config.MaxRedeliveries == 0 && errors.Is(err, ErrWorkerPanic) {
+		return panicRedeliveries
+	}
+	return wp.config.MaxRedeliveries
+}
+
+// redeliver makes a lost job pending again. While a live queue is dispatching
+// the job goes back on it for any worker to pick up; otherwise it reports
+// true, and the worker that lost the job delivers it again.
+func (wp *WorkerPool[T, R]) redeliver(job Job[T]) bool {
+	wp.mu.Lock()
+	defer wp.mu.Unlock()
+	if wp.pending != nil {
+		wp.pending.add(job)
+	}
+	if wp.queue != nil && !wp.draining {
+		wp.queue.Push(job)
+		return false
+	}
+	return true
+}
@@ -0,0 +1,182 @@
+package workerpool
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"sync/atomic"
+	"time"
+)
+
+func (ts *WorkerPoolTestSuite) TestVisibilityTimeoutRedelivers() {
+	config := DefaultConfig()
+	config.NumWorkers = 2
+	config.Strategy = PriorityBased
+	config.VisibilityTimeout = 20 * time.Millisecond
+	pool := NewWithConfig[string, string](config)
+
+	var deliveries int32
+	pool.WithProcessor(func(ctx context.Context, job Job[string]) (string, error) {
+		if job.ID == "lost" && atomic.AddInt32(&deliveries, 1) == 1 {
+			// The first delivery hangs until the pool gives up on it
+			<-ctx.Done()
+			return "", ctx.Err()
+		}
+		return job.Data, nil
+	})
+
+	pool.AddJobs([]Job[string]{{ID: "lost", Data: "a"}, {ID: "fine", Data: "b"}})
+	results, err := pool.Run()
+	ts.NoError(err)
+	ts.Len(results, 2)
+
+	for _, r := range results {
+		ts.NoError(r.Error)
+		if r.JobID == "lost" {
+			ts.Equal(1, r.Redeliveries)
+			ts.Equal("a", r.Data)
+		} else {
+			ts.Zero(r.Redeliveries)
+		}
+	}
+	ts.Equal(int32(2), atomic.LoadInt32(&deliveries))
+}
+
+func (ts *WorkerPoolTestSuite) TestMaxRedeliveries() {
+	config := DefaultConfig()
+	config.VisibilityTimeout = 10 * time.Millisecond
+	config.MaxRedeliveries = 1
+	pool := NewWithConfig[string, string](config)
+
+	pool.WithProcessor(func(ctx context.Context, job Job[string]) (string, error) {
+		<-ctx.Done()
+		return "", ctx.Err()
+	})
+
+	pool.AddJob(Job[string]{ID: "hangs", Data: "a"})
+	results, err := pool.Run()
+	ts.NoError(err)
+	ts.Len(results, 1)
+	ts.ErrorIs(results[0].Error, ErrVisibilityTimeout)
+	ts.Equal(1, results[0].Redeliveries)
+}
+
+func (ts *WorkerPoolTestSuite) TestHeartbeatKeepsJobVisible() {
+	config := DefaultConfig()
+	config.VisibilityTimeout = 20 * time.Millisecond
+	pool := NewWithConfig[string, string](config)
+
+	pool.WithProcessor(func(ctx context.Context, job Job[string]) (string, error) {
+		for i := 0; i < 12; i++ {
+			JobControlFromContext(ctx).Heartbeat()
+			if err := sleepOrDone(ctx, 5*time.Millisecond); err != nil {
+				return "", err
+			}
+		}
+		return job.Data, nil
+	})
+
+	pool.AddJob(Job[string]{ID: "slow", Data: "a"})
+	results, err := pool.Run()
+	ts.NoError(err)
+	ts.Len(results, 1)
+	ts.NoError(results[0].Error)
+	ts.Zero(results[0].Redeliveries)
+}
+
+func (ts *WorkerPoolTestSuite) TestPanicHandsJobToAnotherDelivery() {
+	config := DefaultConfig()
+	config.NumWorkers = 2
+	config.Strategy = PriorityBased
+	pool := NewWithConfig[string, string](config)
+
+	var deliveries int32
+	pool.WithProcessor(func(ctx context.Context, job Job[string]) (string, error) {
+		if job.ID == "crash" && atomic.AddInt32(&deliveries, 1) == 1 {
+			panic("bad worker")
+		}
+		return job.Data, nil
+	})
+
+	pool.AddJobs([]Job[string]{{ID: "crash", Data: "a"}, {ID: "fine", Data: "b"}})
+	results, err := pool.Run()
+	ts.NoError(err)
+	ts.Require().Len(results, 2)
+	for _, r := range results {
+		ts.NoError(r.Error)
+		if r.JobID == "crash" {
+			ts.Equal(1, r.Redeliveries)
+			ts.Equal("a", r.Data)
+		}
+	}
+	ts.Equal(int32(2), atomic.LoadInt32(&deliveries))
+}
+
+func (ts *WorkerPoolTestSuite) TestPanicRedeliveriesAreCapped() {
+	for _, visibility := range []time.Duration{0, time.Second} {
+		config := DefaultConfig()
+		config.VisibilityTimeout = visibility
+		pool := NewWithConfig[string, string](config)
+
+		var deliveries int32
+		cause := errors.New("corrupt input")
+		pool.WithProcessor(func(ctx context.Context, job Job[string]) (string, error) {
+			atomic.AddInt32(&deliveries, 1)
+			panic(cause)
+		})
+
+		pool.AddJob(Job[string]{ID: "crash", Data: "a"})
+		results, err := pool.Run()
+		ts.NoError(err)
+		ts.Require().Len(results, 1)
+		ts.ErrorIs(results[0].Error, ErrWorkerPanic)
+		ts.ErrorIs(results[0].Error, cause)
+		ts.Equal(panicRedeliveries, results[0].Redeliveries)
+		ts.Equal(int32(panicRedeliveries+1), atomic.LoadInt32(&deliveries))
+
+		var panicErr *PanicError
+		ts.Require().ErrorAs(results[0].Error, &panicErr)
+		ts.Equal(cause, panicErr.Value)
+		ts.Contains(string(panicErr.Stack), "TestPanicRedeliveriesAreCapped")
+	}
+
+	config := DefaultConfig()
+	config.MaxRedeliveries = 1
+	pool := NewWithConfig[string, string](config)
+	pool.WithProcessor(func(ctx context.Context, job Job[string]) (string, error) {
+		panic("always")
+	})
+	pool.AddJob(Job[string]{ID: "crash"})
+	results, err := pool.Run()
+	ts.NoError(err)
+	ts.Require().Len(results, 1)
+	ts.EqualError(results[0].Error, "worker panicked: always after 1 redeliveries")
+}
+
+func (ts *WorkerPoolTestSuite) TestRedeliveryWithoutQueueKeepsStackFlat() {
+	config := DefaultConfig()
+	config.NumWorkers = 1
+	config.MaxRedeliveries = 50
+	pool := NewWithConfig[string, string](config)
+
+	// Without a live queue the worker delivers the job again itself
+	var depths []int
+	pool.WithProcessor(func(ctx context.Context, job Job[string]) (string, error) {
+		depths = append(depths, runtime.Callers(0, make([]uintptr, 512)))
+		if job.Redeliveries < 50 {
+			panic("flaky")
+		}
+		return job.Data, nil
+	})
+
+	pool.AddJob(Job[string]{ID: "crash", Data: "a"})
+	results, err := pool.Run()
+	ts.NoError(err)
+	ts.Require().Len(results, 1)
+	ts.NoError(results[0].Error)
+	ts.Equal(50, results[0].Redeliveries)
+	ts.Require().Len(depths, 51)
+	for _, depth := range depths {
+		ts.Equal(depths[0], depth, "redelivery must not grow the stack")
+	}
+}
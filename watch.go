@@ -0,0 +1,235 @@
+package workerpool
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// defaultWatchBuffer is the channel buffer of a ResultWatch
+const defaultWatchBuffer = 256
+
+// jobWatchBuffer is the channel buffer of a Watch
+const jobWatchBuffer = 8
+
+// ResultWatch streams the results of a pool that match a filter. It is the
+// transport-neutral core of server-streaming APIs such as a gRPC
+// WatchResults RPC: the handler ranges over C and sends each result to the
+// remote caller.
+type ResultWatch[R any] struct {
+	C <-chan Result[R] // Matching results; closed by Stop
+
+	ch      chan Result[R]
+	filter  func(Result[R]) bool
+	dropped atomic.Int64
+	stop    func()
+}
+
+// Stop ends the watch and closes C. It is safe to call more than once.
+func (w *ResultWatch[R]) Stop() {
+	w.stop()
+}
+
+// Dropped returns how many matching results were discarded because the
+// watcher fell more than its buffer behind
+func (w *ResultWatch[R]) Dropped() int64 {
+	return w.dropped.Load()
+}
+
+// resultWatchers is the set of active watches of a pool
+type resultWatchers[R any] struct {
+	watches map[*ResultWatch[R]]struct{}
+	hooks   map[*resultHook[R]]struct{}
+	mu      sync.Mutex
+}
+
+// resultHook is an internal subscriber to the published results
+type resultHook[R any] struct {
+	fn func(Result[R])
+}
+
+// subscribe calls fn with every result published from now on until the
+// returned function is called. fn runs on the collector and must not block.
+func (ws *resultWatchers[R]) subscribe(fn func(Result[R])) func() {
+	h := &resultHook[R]{fn: fn}
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	if ws.hooks == nil {
+		ws.hooks = make(map[*resultHook[R]]struct{})
+	}
+	ws.hooks[h] = struct{}{}
+	return func() {
+		ws.mu.Lock()
+		defer ws.mu.Unlock()
+		delete(ws.hooks, h)
+	}
+}
+
+// WatchResults streams every result matching filter, across runs, until the
+// watch is stopped; a nil filter matches everything. buffer bounds how far a
+// watcher may fall behind (zero means 256); a slow watcher misses results
+// rather than stalling the pool.
+func (wp *WorkerPool[T, R]) WatchResults(filter func(Result[R]) bool, buffer int) *ResultWatch[R] {
+	if buffer <= 0 {
+		buffer = defaultWatchBuffer
+	}
+	ch := make(chan Result[R], buffer)
+	w := &ResultWatch[R]{C: ch, ch: ch, filter: filter}
+
+	ws := &wp.watchers
+	var once sync.Once
+	w.stop = func() {
+		once.Do(func() {
+			ws.mu.Lock()
+			defer ws.mu.Unlock()
+			delete(ws.watches, w)
+			close(ch)
+		})
+	}
+
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	if ws.watches == nil {
+		ws.watches = make(map[*ResultWatch[R]]struct{})
+	}
+	ws.watches[w] = struct{}{}
+	return w
+}
+
+// publish offers a result to every matching watch without blocking
+func (ws *resultWatchers[R]) publish(result Result[R]) {
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+
+	for h := range ws.hooks {
+		h.fn(result)
+	}
+	for w := range ws.watches {
+		if w.filter != nil && !w.filter(result) {
+			continue
+		}
+		select {
+		case w.ch <- result:
+		default:
+			w.dropped.Add(1)
+		}
+	}
+}
+
+// JobState is a stage in the lifecycle of a job, as reported by Watch
+type JobState int
+
+const (
+	JobQueued    JobState = iota // Accepted into the queue
+	JobStarted                   // Taken up by a worker
+	JobRetrying                  // An attempt failed; another follows after the backoff
+	JobCompleted                 // Finished, successfully or not; the last event of a job that ran
+	JobRemoved                   // Left the pool without running, through RemovePending or Shutdown; always the last event
+)
+
+// String returns the state's name
+func (s JobState) String() string {
+	switch s {
+	case JobQueued:
+		return "queued"
+	case JobStarted:
+		return "started"
+	case JobRetrying:
+		return "retrying"
+	case JobCompleted:
+		return "completed"
+	case JobRemoved:
+		return "removed"
+	default:
+		return fmt.Sprintf("JobState(%d)", int(s))
+	}
+}
+
+// JobEvent is one transition of a watched job
+type JobEvent[R any] struct {
+	JobID   string
+	State   JobState
+	Time    time.Time
+	Worker  int        // Worker executing the job; -1 for JobQueued and JobRemoved
+	Attempt int        // Attempts made so far, for JobRetrying
+	Err     error      // Error of the failed attempt, for JobRetrying; ErrPoolShutdown for a job Shutdown handed back
+	Result  *Result[R] // Final result, for JobCompleted
+}
+
+// jobWatchers are the Watch channels of a pool, by job ID
+type jobWatchers[R any] struct {
+	watches map[string][]chan JobEvent[R]
+	mu      sync.Mutex
+}
+
+// Watch returns a channel receiving the lifecycle transitions of the job
+// with the given ID: queued, started, retrying after every failed attempt
+// and completed, with its result. A job that leaves the pool without running,
+// removed with RemovePending or handed back by Shutdown, ends with removed
+// instead. The channel is closed after the final event, so a
+// request/response service can submit a job and wait for its result without
+// scanning the result stream. Watch before submitting the job: only
+// transitions after the call are reported. A watcher that falls behind misses
+// intermediate events but always receives the final one.
+func (wp *WorkerPool[T, R]) Watch(jobID string) <-chan JobEvent[R] {
+	ch := make(chan JobEvent[R], jobWatchBuffer)
+	jw := &wp.jobWatch
+	jw.mu.Lock()
+	defer jw.mu.Unlock()
+	if jw.watches == nil {
+		jw.watches = make(map[string][]chan JobEvent[R])
+	}
+	jw.watches[jobID] = append(jw.watches[jobID], ch)
+	return ch
+}
+
+// publish sends an event to the watches of its job without blocking,
+// closing them after the job's final event
+func (jw *jobWatchers[R]) publish(event JobEvent[R]) {
+	jw.mu.Lock()
+	defer jw.mu.Unlock()
+
+	watches := jw.watches[event.JobID]
+	if len(watches) == 0 {
+		return
+	}
+	if event.Time.IsZero() {
+		event.Time = time.Now()
+	}
+	for _, ch := range watches {
+		select {
+		case ch <- event:
+			continue
+		default:
+		}
+		if !event.State.final() {
+			continue
+		}
+		// Make room for the final event; only publish sends on ch, and it
+		// holds jw.mu
+		select {
+		case <-ch:
+		default:
+		}
+		ch <- event
+	}
+	if event.State.final() {
+		for _, ch := range watches {
+			close(ch)
+		}
+		delete(jw.watches, event.JobID)
+	}
+}
+
+// final reports whether no event follows the state
+func (s JobState) final() bool {
+	return s == JobCompleted || s == JobRemoved
+}
+
+// publishRemoved ends the watches of jobs that left the pool without running
+func (wp *WorkerPool[T, R]) publishRemoved(jobs []Job[T], err error) {
+	for _, job := range jobs {
+		wp.jobWatch.publish(JobEvent[R]{JobID: job.ID, State: JobRemoved, Worker: -1, Err: err})
+	}
+}
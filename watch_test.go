@@ -0,0 +1,153 @@
+package workerpool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+func (ts *WorkerPoolTestSuite) TestWatchResultsFilters() {
+	pool := New[int, int]()
+	pool.WithProcessor(func(ctx context.Context, job Job[int]) (int, error) {
+		return job.Data, nil
+	})
+
+	evens := pool.WatchResults(func(r Result[int]) bool { return r.Data%2 == 0 }, 0)
+	all := pool.WatchResults(nil, 0)
+
+	var jobs []Job[int]
+	for i := 0; i < 10; i++ {
+		jobs = append(jobs, Job[int]{ID: fmt.Sprintf("%d", i), Data: i})
+	}
+	pool.AddJobs(jobs)
+	_, err := pool.Run()
+	ts.NoError(err)
+
+	evens.Stop()
+	all.Stop()
+	all.Stop() // Stopping twice is harmless
+
+	var got []int
+	for r := range evens.C {
+		ts.Zero(r.Data % 2)
+		got = append(got, r.Data)
+	}
+	ts.Len(got, 5)
+
+	count := 0
+	for range all.C {
+		count++
+	}
+	ts.Equal(10, count)
+}
+
+func (ts *WorkerPoolTestSuite) TestWatchResultsDropsWhenBehind() {
+	pool := New[int, int]()
+	pool.WithProcessor(func(ctx context.Context, job Job[int]) (int, error) {
+		return job.Data, nil
+	})
+
+	w := pool.WatchResults(nil, 2)
+	defer w.Stop()
+
+	var jobs []Job[int]
+	for i := 0; i < 10; i++ {
+		jobs = append(jobs, Job[int]{ID: fmt.Sprintf("%d", i), Data: i})
+	}
+	pool.AddJobs(jobs)
+	results, err := pool.Run()
+	ts.NoError(err)
+	ts.Len(results, 10)
+
+	ts.Len(w.C, 2)
+	ts.Equal(int64(8), w.Dropped())
+}
+
+func (ts *WorkerPoolTestSuite) TestWatchJobLifecycle() {
+	config := DefaultConfig()
+	config.RetryBands = []RetryBand{{MinPriority: 0, MaxRetries: 1, Backoff: 1}}
+	pool := NewWithConfig[int, int](config)
+	failed := false
+	pool.WithProcessor(func(ctx context.Context, job Job[int]) (int, error) {
+		if job.ID == "flaky" && !failed {
+			failed = true
+			return 0, errors.New("transient")
+		}
+		return job.Data, nil
+	})
+
+	events := pool.Watch("flaky")
+	other := pool.Watch("other")
+	pool.AddJobs([]Job[int]{{ID: "flaky", Data: 7}, {ID: "other"}})
+	_, err := pool.Run()
+	ts.NoError(err)
+
+	var states []JobState
+	var last JobEvent[int]
+	for event := range events {
+		ts.Equal("flaky", event.JobID)
+		states = append(states, event.State)
+		if event.State == JobRetrying {
+			ts.Equal(1, event.Attempt)
+			ts.EqualError(event.Err, "transient")
+		}
+		last = event
+	}
+	ts.Equal([]JobState{JobQueued, JobStarted, JobRetrying, JobCompleted}, states)
+	ts.Require().NotNil(last.Result)
+	ts.Equal(7, last.Result.Data)
+	ts.NoError(last.Result.Error)
+
+	count := 0
+	for range other {
+		count++
+	}
+	ts.Equal(3, count)
+	ts.Equal("retrying", JobRetrying.String())
+}
+
+func (ts *WorkerPoolTestSuite) TestWatchEndsForJobsThatNeverRun() {
+	pool := New[int, int]()
+	removed := pool.Watch("removed")
+	handedBack := pool.Watch("handed-back")
+	pool.AddJobs([]Job[int]{{ID: "removed"}, {ID: "handed-back"}})
+	ts.Equal(1, pool.RemovePending(func(job Job[int]) bool { return job.ID == "removed" }))
+	_, err := pool.Shutdown(context.Background())
+	ts.NoError(err)
+
+	var events []JobEvent[int]
+	for event := range removed {
+		events = append(events, event)
+	}
+	ts.Require().Len(events, 2)
+	ts.Equal(JobQueued, events[0].State)
+	ts.Equal(-1, events[0].Worker)
+	ts.Equal(JobRemoved, events[1].State)
+	ts.NoError(events[1].Err)
+
+	events = nil
+	for event := range handedBack {
+		events = append(events, event)
+	}
+	ts.Require().Len(events, 2)
+	ts.Equal(JobRemoved, events[1].State)
+	ts.ErrorIs(events[1].Err, ErrPoolShutdown)
+	ts.Equal("removed", JobRemoved.String())
+}
+
+func (ts *WorkerPoolTestSuite) TestWatchAlwaysDeliversCompletion() {
+	var jw jobWatchers[int]
+	jw.watches = map[string][]chan JobEvent[int]{"a": {make(chan JobEvent[int], 1)}}
+	ch := jw.watches["a"][0]
+
+	jw.publish(JobEvent[int]{JobID: "a", State: JobStarted})
+	jw.publish(JobEvent[int]{JobID: "a", State: JobRetrying}) // Dropped: the watcher is behind
+	jw.publish(JobEvent[int]{JobID: "a", State: JobCompleted})
+
+	event, ok := <-ch
+	ts.True(ok)
+	ts.Equal(JobCompleted, event.State)
+	_, ok = <-ch
+	ts.False(ok)
+	ts.Empty(jw.watches)
+}
@@ -0,0 +1,129 @@
+package workerpool
+
+import "time"
+
+// ExecutionWindow is a daily time range during which jobs of a class may start.
+// Offsets are measured from midnight in Location. A window whose End is before
+// its Start wraps past midnight; Start equal to End means always open.
+type ExecutionWindow struct {
+	Start    time.Duration  // Offset from midnight when the window opens
+	End      time.Duration  // Offset from midnight when the window closes
+	Location *time.Location // Time zone of the offsets; nil means UTC
+}
+
+// WindowState describes a class's execution window at a point in time
+type WindowState struct {
+	Open       bool      // Whether jobs of the class may start now
+	NextChange time.Time // When the window next opens or closes
+	Pending    int       // Jobs of the class not yet started in the current run
+}
+
+// Open reports whether t falls inside the window
+func (w ExecutionWindow) Open(t time.Time) bool {
+	if w.Start == w.End {
+		return true
+	}
+	t = t.In(w.location())
+	offset := t.Sub(midnight(t))
+	if w.Start < w.End {
+		return offset >= w.Start && offset < w.End
+	}
+	return offset >= w.Start || offset < w.End
+}
+
+// NextOpen returns t if the window is open, otherwise when it next opens
+func (w ExecutionWindow) NextOpen(t time.Time) time.Time {
+	if w.Open(t) {
+		return t
+	}
+	return w.next(t, w.Start)
+}
+
+// NextClose returns when the window next closes, or the zero time if it never does
+func (w ExecutionWindow) NextClose(t time.Time) time.Time {
+	if w.Start == w.End {
+		return time.Time{}
+	}
+	return w.next(t, w.End)
+}
+
+// next returns the first time after t at the given offset from midnight
+func (w ExecutionWindow) next(t time.Time, offset time.Duration) time.Time {
+	day := midnight(t.In(w.location()))
+	at := day.Add(offset)
+	if !at.After(t) {
+		at = day.AddDate(0, 0, 1).Add(offset)
+	}
+	return at
+}
+
+// location returns the window's time zone
+func (w ExecutionWindow) location() *time.Location {
+	if w.Location == nil {
+		return time.UTC
+	}
+	return w.Location
+}
+
+// midnight returns the start of t's day in t's location
+func midnight(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, t.Location())
+}
+
+// Windows returns the state of every configured class window. Pending counts
+// the class's unstarted jobs in the current run, or zero when idle.
+func (wp *WorkerPool[T, R]) Windows() map[string]WindowState {
+	now := time.Now()
+
+	wp.mu.RLock()
+	var pending []Job[T]
+	if wp.pending != nil {
+		pending = wp.pending.list()
+	}
+	wp.mu.RUnlock()
+
+	states := make(map[string]WindowState, len(wp.config.ClassWindows))
+	for class, window := range wp.config.ClassWindows {
+		state := WindowState{Open: window.Open(now)}
+		if state.Open {
+			state.NextChange = window.NextClose(now)
+		} else {
+			state.NextChange = window.NextOpen(now)
+		}
+		for _, job := range pending {
+			if job.Class == class {
+				state.Pending++
+			}
+		}
+		states[class] = state
+	}
+	return states
+}
+
+// holdForWindows splits jobs into those that may start at now and those
+// whose class window is closed
+func (wp *WorkerPool[T, R]) holdForWindows(jobs []Job[T], now time.Time) (ready, held []Job[T]) {
+	if len(wp.config.ClassWindows) == 0 {
+		return jobs, nil
+	}
+	for _, job := range jobs {
+		if window, ok := wp.config.ClassWindows[job.Class]; ok && !window.Open(now) {
+			held = append(held, job)
+			continue
+		}
+		ready = append(ready, job)
+	}
+	return ready, held
+}
+
+// nextWindowOpen returns the earliest time one of the held jobs may start
+func (wp *WorkerPool[T, R]) nextWindowOpen(held []Job[T], now time.Time) time.Time {
+	var next time.Time
+	for _, job := range held {
+		if open := wp.config.ClassWindows[job.Class].NextOpen(now); next.IsZero() || open.Before(next) {
+			next = open
+		}
+	}
+	return next
+}
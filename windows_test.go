@@ -0,0 +1,136 @@
+package workerpool
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// windowFrom returns a UTC window opening after delay and staying open for length
+func windowFrom(delay, length time.Duration) ExecutionWindow {
+	now := time.Now().UTC()
+	start := (now.Sub(midnight(now)) + delay) % (24 * time.Hour)
+	return ExecutionWindow{Start: start, End: (start + length) % (24 * time.Hour)}
+}
+
+func (ts *WorkerPoolTestSuite) TestExecutionWindowOpen() {
+	night := ExecutionWindow{Start: 22 * time.Hour, End: 6 * time.Hour}
+	day := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	ts.True(night.Open(day.Add(23 * time.Hour)))
+	ts.True(night.Open(day.Add(2 * time.Hour)))
+	ts.False(night.Open(day.Add(6 * time.Hour)))
+	ts.False(night.Open(day.Add(12 * time.Hour)))
+
+	noon := day.Add(12 * time.Hour)
+	ts.Equal(day.Add(22*time.Hour), night.NextOpen(noon))
+	ts.Equal(day.Add(30*time.Hour), night.NextClose(noon))
+	ts.Equal(noon, ExecutionWindow{}.NextOpen(noon))
+	ts.True(ExecutionWindow{}.NextClose(noon).IsZero())
+
+	// Offsets are interpreted in the window's location
+	est := time.FixedZone("EST", -5*60*60)
+	morning := ExecutionWindow{Start: 9 * time.Hour, End: 17 * time.Hour, Location: est}
+	ts.True(morning.Open(day.Add(15 * time.Hour)))
+	ts.False(morning.Open(day.Add(9 * time.Hour)))
+}
+
+func (ts *WorkerPoolTestSuite) TestClassWindowHoldsJobsUntilOpen() {
+	config := DefaultConfig()
+	config.NumWorkers = 2
+	config.ClassWindows = map[string]ExecutionWindow{
+		"export": windowFrom(100*time.Millisecond, time.Hour),
+	}
+	pool := NewWithConfig[string, string](config)
+
+	var duringLight map[string]WindowState
+	pool.WithProcessor(func(ctx context.Context, job Job[string]) (string, error) {
+		if job.ID == "light-1" {
+			duringLight = pool.Windows()
+		}
+		return job.Data, nil
+	})
+
+	pool.AddJobs([]Job[string]{
+		{ID: "export-1", Data: "a", Class: "export"},
+		{ID: "light-1", Data: "b"},
+		{ID: "export-2", Data: "c", Class: "export"},
+	})
+
+	start := time.Now()
+	results, err := pool.Run()
+	ts.NoError(err)
+	ts.Len(results, 3)
+
+	ts.False(duringLight["export"].Open)
+	ts.Equal(2, duringLight["export"].Pending)
+	ts.True(duringLight["export"].NextChange.After(start))
+
+	for _, r := range results {
+		if r.JobID == "light-1" {
+			ts.Less(r.Started.Sub(start), 50*time.Millisecond)
+		} else {
+			ts.GreaterOrEqual(r.Started.Sub(start), 50*time.Millisecond)
+		}
+	}
+	ts.True(pool.Windows()["export"].Open)
+	ts.Zero(pool.Windows()["export"].Pending)
+}
+
+func (ts *WorkerPoolTestSuite) TestClassWindowTimeoutLeavesJobsUnfinished() {
+	config := DefaultConfig()
+	config.Timeout = 50 * time.Millisecond
+	config.PartialResults = true
+	config.ClassWindows = map[string]ExecutionWindow{
+		"export": windowFrom(time.Hour, time.Hour),
+	}
+	pool := NewWithConfig[string, string](config)
+	pool.WithProcessor(func(ctx context.Context, job Job[string]) (string, error) {
+		return job.Data, nil
+	})
+
+	pool.AddJobs([]Job[string]{
+		{ID: "export-1", Data: "a", Class: "export"},
+		{ID: "light-1", Data: "b"},
+	})
+
+	results, err := pool.Run()
+	ts.True(errors.Is(err, ErrPoolTimeout))
+	ts.Len(results, 1)
+
+	var partial *PartialRunError[string]
+	ts.True(errors.As(err, &partial))
+	ts.Len(partial.Unfinished, 1)
+	ts.Equal("export-1", partial.Unfinished[0].ID)
+}
+
+func (ts *WorkerPoolTestSuite) TestShutdownReturnsHeldJobs() {
+	config := DefaultConfig()
+	config.ClassWindows = map[string]ExecutionWindow{
+		"export": windowFrom(time.Hour, time.Hour),
+	}
+	pool := NewWithConfig[string, string](config)
+
+	started := make(chan struct{})
+	pool.WithProcessor(func(ctx context.Context, job Job[string]) (string, error) {
+		close(started)
+		return job.Data, nil
+	})
+	pool.AddJobs([]Job[string]{
+		{ID: "export-1", Data: "a", Class: "export"},
+		{ID: "light-1", Data: "b"},
+	})
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := pool.Run()
+		done <- err
+	}()
+
+	<-started
+	result, err := pool.Shutdown(context.Background())
+	ts.NoError(err)
+	ts.Len(result.Remaining, 1)
+	ts.Equal("export-1", result.Remaining[0].ID)
+	ts.True(errors.Is(<-done, ErrPoolShutdown))
+}
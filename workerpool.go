@@ -12,8 +12,11 @@ package workerpool
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"maps"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
@@ -23,6 +26,32 @@ type Job[T any] struct {
 	Data     T         // The actual data to be processed
 	Priority int       // Job priority (higher = more important)
 	Created  time.Time // When the job was created
+	TenantID string    // Tenant that owns the job, used for quota enforcement
+	Owner    string    // Fair-share accounting key; falls back to TenantID when empty
+	Attempts int       // Processing attempts consumed by earlier runs (kept across Requeue)
+	Class    string    // Job class, used to look up execution windows and budgets
+	Cost     int       // Cost units charged against budgets and MaxConcurrentCost, unless a cost estimator is set
+
+	TTL       time.Duration // How long the job may wait in the queue after Created
+	ExpiresAt time.Time     // Absolute expiry; overrides TTL when set
+
+	Redeliveries int // Times the job was redelivered after its visibility timeout lapsed or its processor panicked
+
+	IdempotencyKey string // Deduplication key for a CompletionStore; defaults to ID
+
+	Dependencies []string // IDs of jobs in the same run that must succeed first; see DependsOn
+
+	Version int // Schema version of Data, for upgrading jobs written by older binaries; see WithMigrations
+
+	Deadline time.Time // When the job should have completed, tracked against Config.DeadlineSLO but not enforced
+
+	Continuation string // Where the processor left off when the job last yielded its time slice; see Yield
+
+	ParentID string // ID of the job this one was split from by a Splitter; empty for submitted jobs
+
+	sliced      slicedTime     // Processing spent in the time slices the job already yielded
+	redelivered bool           // The job yielded or was lost in this run, so its expiry was checked and its cost charged
+	enriching   *enrichment[T] // Hydration started for the job when its run began
 }
 
 // Result wraps the processing result of a job
@@ -34,6 +63,26 @@ type Result[R any] struct {
 	Started   time.Time     // When processing started
 	Completed time.Time     // When processing completed
 	Duration  time.Duration // How long processing took
+
+	Attempts         int             // Processor invocations for this job in this run
+	AttemptErrors    []error         // Error returned by each attempt; nil for the successful one
+	AttemptDurations []time.Duration // How long each attempt took, excluding backoff
+	ExecutionIDs     []string        // Execution ID of each attempt, as found by ExecutionFromContext
+	Late             bool            // Completed within the straggler window after the run timed out
+	Redeliveries     int             // Times the job was redelivered after its visibility timeout lapsed or its processor panicked
+
+	Labels   map[string]string  // Annotations set by the processor through ResultMeta
+	Counters map[string]float64 // Counters added by the processor through ResultMeta
+
+	Strategy        string // Strategy that dispatched the job, e.g. "work_stealing" or "adaptive/chunked"
+	Queue           string // Queue the job was taken from, e.g. "worker-2", "deque-0" or "priority"
+	Stolen          bool   // Taken from another worker's deque by WorkStealing
+	DispatchAttempt int    // Delivery of the job this result came from, starting at 1
+
+	CPUTime time.Duration // CPU time the processor used over all attempts, with Config.CPUAccounting
+
+	ParentID string      // ID of the job a Splitter split this result's job from; empty for unsplit jobs
+	Parts    []Result[R] // Results of the parts of a split job, in a result merged by a Combiner
 }
 
 // Processor defines how to process a job
@@ -48,6 +97,7 @@ const (
 	WorkStealing
 	PriorityBased
 	Adaptive
+	FairShare
 )
 
 // Strategy defines the interface for job distribution strategies
@@ -62,17 +112,112 @@ type Strategy[T any, R any] interface {
 
 // Config holds configuration for the worker pool
 type Config struct {
+	Name          string               // Pool name used to label metrics
 	NumWorkers    int                  // Number of worker goroutines
 	BufferSize    int                  // Buffer size for job channels
 	Strategy      DistributionStrategy // How to distribute jobs
 	Timeout       time.Duration        // Overall timeout for the pool
-	WorkerTimeout time.Duration        // Timeout per individual worker
-	MaxRetries    int                  // Maximum retry attempts for failed jobs
+	WorkerTimeout time.Duration        // Timeout per individual worker; processors may extend it through JobControl
+	MaxRetries    int                  // Maximum retry attempts for failed jobs; see also RetryBands
 	EnableMetrics bool                 // Whether to collect performance metrics
+
+	HeartbeatTimeout  time.Duration // Attempts that go this long without a JobControl heartbeat are cancelled with ErrJobStuck; zero disables
+	VisibilityTimeout time.Duration // Deliveries that go this long without completing or heartbeating are abandoned and redelivered; zero disables
+	MaxRedeliveries   int           // Redeliveries allowed per job before it fails with ErrVisibilityTimeout or a *PanicError; zero means unlimited, or 3 for panics
+
+	PartialResults  bool          // On timeout or cancellation, return completed results with a *PartialRunError
+	StragglerWindow time.Duration // Grace period after Timeout for in-flight jobs to finish; no new jobs start
+	ShutdownTimeout time.Duration // Time HandleSignals gives in-flight jobs after a signal before cancelling them; zero waits for them
+
+	TenantQuotas       map[string]TenantQuota // Per-tenant quotas keyed by Job.TenantID
+	DefaultTenantQuota TenantQuota            // Quota for tenants without an explicit entry
+
+	PriorityLanes []PriorityLane // Weighted lanes for PriorityBased; empty means strict priority order
+
+	FairShareWindow time.Duration // Sliding window for FairShare usage accounting; zero keeps all history
+
+	JobClasses   map[string]JobClass        // Routing declared once per Job.Class; see ClassifiedJob and ClassTable
+	ClassWindows map[string]ExecutionWindow // Daily windows outside which jobs of a Job.Class are held back
+
+	MaxConcurrentCost int            // Cap on the summed cost of executing jobs; zero means unlimited
+	ClassConcurrency  map[string]int // Cap on executing jobs per Job.Class, within the shared workers; absent or zero means unlimited
+
+	RunBudget    Budget            // Limits for a whole run; exhausting it skips the remaining jobs
+	ClassBudgets map[string]Budget // Limits per Job.Class within a run
+
+	StrategyOptions StrategyOptions // Per-strategy tuning knobs; zero values keep the built-in behavior
+
+	RetryBands   []RetryBand  // Retry limits and backoff by priority band; jobs below every band use MaxRetries
+	RetryDamping RetryDamping // Stretches backoff and limits retries while the pool-wide failure rate is high
+
+	WarmStandby bool // Run OnWorkerStart initializers for every worker as soon as they are set
+
+	Starvation StarvationDetection // Flags, and optionally boosts, jobs waiting far longer than the median
+
+	CPUThrottling CPUThrottling // Lowers concurrency while the container's CPU cgroup is throttled
+	GCPressure    GCPressure    // Holds back allocation-heavy job classes while the GC is under pressure
+
+	LatencyAnomaly LatencyAnomalyDetection // Flags jobs far slower or faster than their class baseline
+
+	// CPUAccounting measures the CPU time of every processor call and adds it
+	// to Result.CPUTime and the CPU metrics. Each call is pinned to its OS
+	// thread while it runs, and only that thread is measured, so CPU used by
+	// goroutines the processor starts is missed. Supported on Linux; other
+	// platforms record zero.
+	CPUAccounting bool
+
+	SlowJobs SlowJobProfiling // Captures stacks and an execution trace of jobs running past a threshold
+
+	// RuntimeTrace records a runtime/trace task for every job and a region
+	// for every attempt, so go tool trace shows how jobs interleave on
+	// workers. Tasks and regions cost next to nothing unless a trace is
+	// being collected.
+	RuntimeTrace bool
+
+	DeadlineSLO DeadlineSLO // Objective for jobs with a Deadline, from which DeadlineStats computes burn rates
+
+	Collector      ResultCollector // How workers hand results to the collector; MPSCCollector suits very small jobs
+	ResultBuffer   int             // Results the ChannelCollector buffers per wave; zero uses BufferSize
+	ResultOverflow ResultOverflow  // What workers do when the result buffer is full; BlockOnFull waits
+
+	// ResetMetricsPerRun zeroes the metrics GetMetrics reports as every run
+	// starts, so they cover only the current or latest run. By default they
+	// accumulate over every run; RunMetrics and RunSummaries report single
+	// runs either way.
+	ResetMetricsPerRun bool
+
+	// TimeSlice is how long a job may keep a worker before ShouldYield asks
+	// its processor to yield to waiting jobs, so a few workers interleave
+	// many long jobs fairly. Processors opt in by checking ShouldYield and
+	// returning Yield. Zero disables slicing.
+	TimeSlice time.Duration
+
+	// StopAfterSuccesses ends a run once that many jobs have succeeded,
+	// cancelling the rest with ErrEnoughSuccesses as the cause, e.g. to query
+	// redundant providers or probe mirrors; FirstSuccessWins stops at the
+	// first. Run returns the results delivered up to then, failures
+	// included, and no error. Zero runs every job.
+	StopAfterSuccesses int
 }
 
-// DefaultConfig returns sensible default configuration
+// FirstSuccessWins is the Config.StopAfterSuccesses that ends a run with its
+// first successful job
+const FirstSuccessWins = 1
+
+// DefaultConfig returns the process-wide default configuration, which is
+// the built-in defaults unless overridden with SetDefaults
 func DefaultConfig() Config {
+	defaultsMu.RLock()
+	defer defaultsMu.RUnlock()
+
+	if defaults == nil {
+		return builtinDefaults()
+	}
+	return defaults.clone()
+}
+
+// builtinDefaults returns sensible default configuration
+func builtinDefaults() Config {
 	return Config{
 		NumWorkers:    4,
 		BufferSize:    100,
@@ -84,17 +229,105 @@ func DefaultConfig() Config {
 	}
 }
 
-// WorkerPool manages a pool of workers for processing jobs
+// WorkerPool manages a pool of workers for processing jobs. Its methods are
+// safe for concurrent use. Jobs added during a run are processed by the next
+// one, and settings changed during a run apply no later than the next one.
 type WorkerPool[T any, R any] struct {
-	config    Config
-	processor Processor[T, R]
-	jobs      []Job[T]
-	results   chan Result[R]
-	ctx       context.Context
-	cancel    context.CancelFunc
-	metrics   *Metrics
-	mu        sync.RWMutex
-	ctxMu     sync.RWMutex // Protects ctx and cancel fields
+	config     Config
+	processor  atomic.Pointer[Processor[T, R]] // Set by WithProcessor; read by every attempt
+	mutator    func(Job[T]) Job[T]
+	priorityOf func(T) int           // Derives the priority of jobs submitted without one
+	validator  func(T) error         // Rejects malformed payloads at submission; nil accepts all
+	migrations *PayloadMigrations[T] // Upgrades payloads of older versions at submission
+	enricher   Enricher[T]
+	enrichOpt  EnrichmentOptions
+	jobs       []Job[T]
+	results    chan Result[R]
+	ctx        context.Context
+	cancel     context.CancelCauseFunc
+	metrics    *Metrics
+	tenants    *tenantTracker
+	usage      *ownerUsage
+	budgets    *budgetTracker
+	costs      *costLimiter
+	classSlots classLimits                   // Applies Config.ClassConcurrency
+	estimator  func(Job[T]) int              // Prices jobs for costs and budgets; nil uses Job.Cost
+	steals     atomic.Pointer[stealCounters] // Counters of the most recent WorkStealing run
+	queue      Queue[T]                      // Live queue while a PriorityBased run dispatches
+	newQueue   func() Queue[T]               // Set by WithQueue; nil uses the built-in queues
+	splitter   Splitter[T]                   // Breaks up oversized jobs as a run starts; nil splits none
+	running    bool
+	pending    *pendingSet[T]    // Jobs of the current run that have not started
+	draining   bool              // Set by Shutdown: workers stop starting jobs
+	remaining  []Job[T]          // Unstarted jobs left by a drained run
+	runDone    chan struct{}     // Closed when the current run finishes
+	drained    chan struct{}     // Closed by Shutdown so a run stops waiting for held jobs
+	blackouts  blackouts         // Maintenance windows during which no job starts
+	failed     map[string]Job[T] // Jobs whose last run ended in error, available to Requeue
+	requeued   []Job[T]          // Jobs requeued during a run, added to the queue when it ends
+	mu         sync.RWMutex
+	execCtx    context.Context // Context jobs run under; outlives ctx by Config.StragglerWindow
+	ctxMu      sync.RWMutex    // Protects ctx, execCtx and cancel fields
+
+	resultQueue   *mpscQueue[R]         // Collects the current wave with MPSCCollector; nil uses results
+	onDropped     func(Result[R])       // Receives results dropped under DropOnFull
+	onUndelivered func([]Job[T], error) // Receives the jobs a run ended early without starting
+
+	onDuplicate func(first, duplicate Result[R]) // Receives results suppressed as duplicates
+	guard       *resultGuard[R]                  // First results of the current run's jobs
+
+	completions CompletionStore // Deduplicates jobs by idempotency key; nil disables
+	keyLocks    keyMutex        // Serializes jobs sharing an idempotency key
+
+	sinks    []*sinkEntry[R]   // Result sinks fed during every run
+	watchers resultWatchers[R] // Active WatchResults subscriptions
+	jobWatch jobWatchers[R]    // Active Watch channels, by job ID
+
+	reporter func(Result[R], ErrorReport) // Receives sampled failures; nil disables
+	sampler  *errorSampler                // Decides which failures reach reporter
+
+	fingerprinter Fingerprinter // Groups failures into causes; nil uses DefaultFingerprint
+
+	quorumErr error // Why the Quorum set by WithQuorum cannot be reached; Run refuses to start
+
+	gate     func(Job[T]) error // Consulted when a job's dependencies are met; an error skips the job
+	gateAll  func(Job[T]) bool  // Reports whether a job waits for its dependencies to finish whatever the outcome, leaving the decision to gate
+	damper   *retryDamper       // Applies Config.RetryDamping; nil when disabled
+	throttle *cpuThrottle       // Applies Config.CPUThrottling; nil when disabled
+	gc       *gcMonitor         // Applies Config.GCPressure; nil when disabled
+
+	resources workerResources // Per-worker values created by OnWorkerStart
+	tokens    resourceTokens  // Token pools declared with WithResourceTokens
+
+	onStarved  func(StarvationEvent) // Receives jobs flagged by Config.Starvation
+	starvation *starvationDetector   // Queue waits of the current run; nil when detection is off
+
+	alerts *alertMonitor // Evaluates rules set by WithAlerts; nil when none are
+
+	anomalies *anomalyDetector     // Latency baselines per class; nil when detection is off
+	onAnomaly func(LatencyAnomaly) // Receives jobs flagged by Config.LatencyAnomaly
+
+	slowSink     SlowJobSink                 // Receives Config.SlowJobs captures; nil disables capture
+	onSlowError  func(SlowJobCapture, error) // Receives captures the sink failed to store
+	slowCaptures atomic.Int32                // Captures taken in the current run
+
+	deadlines *deadlineTracker // Deadline hits and misses by class, across runs
+	workers   workerGauges     // In-flight jobs and busy time of every worker, across runs
+
+	adaptive adaptiveLearner // What the Adaptive strategy has learned across runs
+
+	lastRun atomic.Pointer[runLog] // Outcomes by priority of the most recent run
+	runs    runHistory             // Run IDs and per-run metrics
+	history durationHistory        // Recent job durations across runs, for Advise
+
+	queueWaiting func() bool // Reports whether jobs wait on the live queue, for ShouldYield; nil without one
+
+	combiner    Combiner[R]        // Merges the results of split jobs' parts; nil delivers every part
+	splitPolicy SplitFailurePolicy // How combined results treat failed parts
+
+	deferred      ConfigDelta       // Reconfigure changes held until the current run ends
+	configEvents  []ConfigEvent     // Recent changes made by Reconfigure
+	onReconfigure func(ConfigEvent) // Receives every change made by Reconfigure
 }
 
 // Metrics holds performance metrics for the worker pool
@@ -102,11 +335,29 @@ type Metrics struct {
 	TotalJobs       int
 	ProcessedJobs   int
 	FailedJobs      int
+	ExpiredJobs     int
+	LateCompletions int // Jobs that finished in the straggler window after a timeout
+	SkippedJobs     int // Jobs skipped because a budget was exhausted, they already completed, a dependency failed or a workflow branch was not taken
 	TotalDuration   time.Duration
 	AverageDuration time.Duration
 	StartTime       time.Time
 	EndTime         time.Time
-	mu              sync.RWMutex
+	Tenants         map[string]TenantMetrics
+	Stealing        StealStats // Populated by the WorkStealing strategy
+
+	ErrorsReported   int                // Failures passed to the error reporter
+	ErrorsSuppressed int                // Failures withheld from the error reporter by sampling
+	FailureCauses    map[string]int     // Failed jobs by error fingerprint
+	Counters         map[string]float64 // ResultMeta counters summed over all results
+	StarvedJobs      int                // Jobs flagged by starvation detection
+	Adaptive         AdaptiveStats      // Decisions of the Adaptive strategy
+
+	CPUTime      time.Duration            // Processor CPU time, with Config.CPUAccounting
+	ClassCPUTime map[string]time.Duration // Processor CPU time by Job.Class
+
+	DroppedResults   int // Results dropped because the result buffer was full, under DropOnFull
+	DuplicateResults int // Results suppressed because their job had already produced one
+	mu               sync.RWMutex
 }
 
 // New creates a new worker pool with default configuration
@@ -129,74 +380,295 @@ func NewWithConfig[T any, R any](config Config) *WorkerPool[T, R] {
 	if config.BufferSize < 10 {
 		config.BufferSize = 10
 	}
+	config = config.withJobClasses()
 
 	return &WorkerPool[T, R]{
-		config:  config,
-		results: make(chan Result[R], config.BufferSize),
-		ctx:     nil, // Will be set in Run()
-		cancel:  nil, // Will be set in Run()
-		metrics: &Metrics{},
+		config:     config,
+		results:    make(chan Result[R], config.BufferSize),
+		ctx:        nil, // Will be set in Run()
+		cancel:     nil, // Will be set in Run()
+		metrics:    &Metrics{},
+		tenants:    newTenantTracker(config),
+		usage:      newOwnerUsage(config.FairShareWindow),
+		budgets:    newBudgetTracker(config),
+		costs:      newCostLimiter(config.MaxConcurrentCost),
+		classSlots: newClassLimits(config.ClassConcurrency),
+		failed:     make(map[string]Job[T]),
+		damper:     newRetryDamper(config.RetryDamping),
+		throttle:   newCPUThrottle(config.CPUThrottling),
+		gc:         newGCMonitor(config.GCPressure),
+
+		anomalies: newAnomalyDetector(config.LatencyAnomaly),
+		deadlines: newDeadlineTracker(config.DeadlineSLO),
 	}
 }
 
-// WithProcessor sets the processing function for the worker pool
+// WithProcessor sets the processing function for the worker pool. Set
+// during a run, it applies to attempts starting afterwards.
 func (wp *WorkerPool[T, R]) WithProcessor(p Processor[T, R]) *WorkerPool[T, R] {
-	wp.processor = p
+	wp.processor.Store(&p)
 	return wp
 }
 
-// AddJobs adds jobs to the worker pool
+// processorFunc returns the processing function, or nil if none is set
+func (wp *WorkerPool[T, R]) processorFunc() Processor[T, R] {
+	if p := wp.processor.Load(); p != nil {
+		return *p
+	}
+	return nil
+}
+
+// WithJobMutator sets a function applied to every job when it is added, so
+// normalization such as assigning IDs or default priorities lives in one place
+func (wp *WorkerPool[T, R]) WithJobMutator(m func(Job[T]) Job[T]) *WorkerPool[T, R] {
+	wp.mu.Lock()
+	defer wp.mu.Unlock()
+	wp.mutator = m
+	return wp
+}
+
+// WithPriorityFunc sets a function deriving a job's priority from its
+// payload, e.g. from order value or user tier, so producers need not set
+// Job.Priority. It applies to jobs submitted with a zero priority after the
+// job mutator, and takes precedence over the JobClasses default.
+func (wp *WorkerPool[T, R]) WithPriorityFunc(priority func(T) int) *WorkerPool[T, R] {
+	wp.mu.Lock()
+	defer wp.mu.Unlock()
+	wp.priorityOf = priority
+	return wp
+}
+
+// AddJobs appends jobs to the worker pool, so concurrent producers never
+// drop each other's jobs. Jobs that are refused, e.g. over their tenant's
+// queue quota or failing the validator, are left out; use Submit to learn why.
+//
+// The pool's jobs are a batch that every Run processes, and a run does not
+// remove them: jobs added after a run are processed by the next run along
+// with the earlier ones. Use SetJobs to replace the batch between runs.
 func (wp *WorkerPool[T, R]) AddJobs(jobs []Job[T]) *WorkerPool[T, R] {
 	wp.mu.Lock()
 	defer wp.mu.Unlock()
+	wp.appendJobsLocked(jobs)
+	return wp
+}
 
-	wp.jobs = make([]Job[T], len(jobs))
-	copy(wp.jobs, jobs)
+// SetJobs replaces the pool's jobs with jobs, e.g. to run a new batch
+// without rerunning the previous one
+func (wp *WorkerPool[T, R]) SetJobs(jobs []Job[T]) *WorkerPool[T, R] {
+	wp.mu.Lock()
+	defer wp.mu.Unlock()
+	wp.clearJobsLocked()
+	wp.appendJobsLocked(jobs)
+	return wp
+}
 
-	// Set creation time for new jobs
+// appendJobsLocked admits jobs and appends them to the job list. Callers
+// must hold wp.mu.
+func (wp *WorkerPool[T, R]) appendJobsLocked(jobs []Job[T]) {
 	now := time.Now()
-	for i := range wp.jobs {
-		if wp.jobs[i].Created.IsZero() {
-			wp.jobs[i].Created = now
+	for _, job := range jobs {
+		admitted, err := wp.admitLocked(job, now)
+		if err != nil {
+			continue
 		}
+		wp.jobs = append(wp.jobs, admitted)
+		wp.jobWatch.publish(JobEvent[R]{JobID: admitted.ID, State: JobQueued, Worker: -1})
 	}
-
 	wp.metrics.TotalJobs = len(wp.jobs)
-	return wp
 }
 
-// AddJob adds a single job to the worker pool
+// AddJob adds a single job to the worker pool. A refused job is left out as
+// with AddJobs; use Submit to learn why.
 func (wp *WorkerPool[T, R]) AddJob(job Job[T]) *WorkerPool[T, R] {
 	wp.mu.Lock()
 	defer wp.mu.Unlock()
+	wp.appendJobsLocked([]Job[T]{job})
+	return wp
+}
 
+// admitLocked normalizes a submitted job and reserves its queue slot.
+// Callers must hold wp.mu.
+func (wp *WorkerPool[T, R]) admitLocked(job Job[T], now time.Time) (Job[T], error) {
+	if wp.mutator != nil {
+		job = wp.mutator(job)
+	}
+	if wp.priorityOf != nil && job.Priority == 0 {
+		job.Priority = wp.priorityOf(job.Data)
+	}
+	job = wp.classify(job)
+	job.redelivered = false
+	job.enriching = nil
 	if job.Created.IsZero() {
-		job.Created = time.Now()
+		job.Created = now
+	}
+	if wp.migrations != nil {
+		data, err := wp.migrations.Migrate(job.Version, job.Data)
+		if err != nil {
+			return job, err
+		}
+		job.Data, job.Version = data, wp.migrations.Current()
+	}
+	if wp.validator != nil {
+		if err := wp.validator(job.Data); err != nil {
+			return job, fmt.Errorf("%w: %w", ErrInvalidPayload, err)
+		}
+	}
+	if err := wp.tenants.admit(job.TenantID); err != nil {
+		return job, err
+	}
+	return job, nil
+}
+
+// Submit adds a single job to the worker pool, reporting why it was refused.
+// Jobs over their tenant's MaxQueued quota are rejected with ErrTenantQueueFull,
+// and jobs failing the validator with ErrInvalidPayload. Like AddJobs, it adds
+// the job to the batch every later Run processes.
+func (wp *WorkerPool[T, R]) Submit(job Job[T]) error {
+	wp.mu.Lock()
+	defer wp.mu.Unlock()
+
+	admitted, err := wp.admitLocked(job, time.Now())
+	if err != nil {
+		return fmt.Errorf("job %s: %w", admitted.ID, err)
 	}
 
-	wp.jobs = append(wp.jobs, job)
+	wp.jobs = append(wp.jobs, admitted)
 	wp.metrics.TotalJobs = len(wp.jobs)
-	return wp
+	wp.jobWatch.publish(JobEvent[R]{JobID: admitted.ID, State: JobQueued, Worker: -1})
+	return nil
 }
 
-// Run executes the worker pool with the configured strategy
+// Run executes the worker pool with the configured strategy. It processes
+// every job added to the pool so far, including those processed by earlier
+// runs, and leaves them in place for the next run.
 func (wp *WorkerPool[T, R]) Run() ([]Result[R], error) {
-	if wp.processor == nil {
+	return wp.run(context.Background(), nil)
+}
+
+// RunContext executes the worker pool under ctx. Cancelling ctx stops the
+// run as Stop does, with ctx's cause as the run's cause, and a deadline on
+// ctx earlier than Config.Timeout ends the run at that deadline.
+func (wp *WorkerPool[T, R]) RunContext(ctx context.Context) ([]Result[R], error) {
+	return wp.run(ctx, nil)
+}
+
+// run executes the worker pool under parent. When stream is non-nil every
+// result is passed to it as it completes instead of being collected into the
+// returned slice.
+func (wp *WorkerPool[T, R]) run(parent context.Context, stream func(Result[R])) ([]Result[R], error) {
+	if wp.processorFunc() == nil {
 		return nil, fmt.Errorf("no processor configured")
 	}
+
+	// Claim the pool for this run. A run still winding down after Stop or a
+	// timeout is waited for, so the pool can be run again right after Stop.
+	wp.mu.Lock()
+	for wp.running {
+		done := wp.runDone
+		wp.mu.Unlock()
+		if !wp.ending() {
+			return nil, ErrPoolRunning
+		}
+		<-done
+		wp.mu.Lock()
+	}
 	if len(wp.jobs) == 0 {
+		wp.mu.Unlock()
 		return nil, fmt.Errorf("no jobs to process")
 	}
+	if wp.quorumErr != nil {
+		wp.mu.Unlock()
+		return nil, wp.quorumErr
+	}
+	wp.running = true
+
+	// Create context with timeout for this run. Both cancellation paths record
+	// a cause so callers can tell a Stop from a timeout. The context is
+	// published while the pool is claimed, so a Stop from then on reaches it.
+	timeout := wp.config.Timeout
+	base, cancel := context.WithCancelCause(parent)
+	ctx, cancelTimeout := context.WithTimeoutCause(base, timeout, ErrPoolTimeout)
+	defer cancelTimeout()
+
+	// In-flight jobs may keep running for the straggler window after the
+	// timeout stops dispatch
+	execCtx := ctx
+	if wp.config.StragglerWindow > 0 {
+		var cancelExec context.CancelFunc
+		execCtx, cancelExec = context.WithTimeoutCause(base, timeout+wp.config.StragglerWindow, ErrPoolTimeout)
+		defer cancelExec()
+	}
 
-	// Create context with timeout for this run
-	ctx, cancel := context.WithTimeout(context.Background(), wp.config.Timeout)
 	wp.ctxMu.Lock()
 	wp.ctx = ctx
+	wp.execCtx = execCtx
 	wp.cancel = cancel
 	wp.ctxMu.Unlock()
 
+	jobs := make([]Job[T], len(wp.jobs))
+	copy(jobs, wp.jobs)
+	splitter, combiner, splitPolicy := wp.splitter, wp.combiner, wp.splitPolicy
+	stopAfter := wp.config.StopAfterSuccesses
+	onDuplicate := wp.onDuplicate
+	runDone := make(chan struct{})
+	wp.runDone = runDone
+	drained := make(chan struct{})
+	wp.drained = drained
+	wp.mu.Unlock()
+	defer func() {
+		wp.mu.Lock()
+		wp.running = false
+		if wp.draining {
+			// Keep unstarted and requeued jobs for Shutdown to hand back
+			wp.remaining = append(wp.pending.list(), wp.requeued...)
+			wp.draining = false
+		} else {
+			for _, job := range wp.requeued {
+				wp.upsertJob(job)
+			}
+		}
+		wp.pending = nil
+		wp.guard = nil
+		wp.requeued = nil
+		wp.applyDeferredLocked()
+		wp.mu.Unlock()
+		close(runDone)
+	}()
+
+	// Watch for CPU throttling and GC pressure while the run lasts
+	stopThrottle := wp.throttle.start(wp.GetNumWorkers())
+	defer stopThrottle()
+	stopGC := wp.gc.start(wp.recordEvent)
+	defer stopGC()
+
+	// Break oversized jobs up before anything is counted or dispatched; the
+	// children take the parent's place in its tenant's queue
+	jobs, parents := splitJobs(splitter, jobs, func(parent Job[T], children []Job[T]) {
+		wp.tenants.dequeue(parent.TenantID)
+		for _, child := range children {
+			wp.tenants.enqueue(child.TenantID)
+		}
+	})
+	splits := newSplitResults(combiner, splitPolicy, parents)
+	guard := newResultGuard[T, R](jobs, onDuplicate != nil)
+
+	if wp.config.ResetMetricsPerRun {
+		wp.ResetMetrics()
+	}
+	wp.metrics.mu.Lock()
 	wp.metrics.StartTime = time.Now()
+	wp.metrics.mu.Unlock()
+	lifetime := wp.GetMetrics()
+	wp.runs.begin(&lifetime, len(jobs))
+	defer func() {
+		now := wp.GetMetrics()
+		wp.runs.end(&now)
+	}()
+	wp.workers.startRun(wp.GetNumWorkers())
+	defer wp.workers.endRun()
 	defer func() {
+		wp.metrics.mu.Lock()
+		defer wp.metrics.mu.Unlock()
 		wp.metrics.EndTime = time.Now()
 		wp.metrics.TotalDuration = wp.metrics.EndTime.Sub(wp.metrics.StartTime)
 		if wp.metrics.ProcessedJobs > 0 {
@@ -204,98 +676,312 @@ func (wp *WorkerPool[T, R]) Run() ([]Result[R], error) {
 		}
 	}()
 
-	// Execute the selected strategy
-	var err error
-	switch wp.config.Strategy {
-	case RoundRobin:
-		err = wp.runRoundRobin(ctx)
-	case Chunked:
-		err = wp.runChunked(ctx)
-	case WorkStealing:
-		err = wp.runWorkStealing(ctx)
-	case PriorityBased:
-		err = wp.runPriorityBased(ctx)
-	case Adaptive:
-		err = wp.runAdaptive(ctx)
-	default:
-		err = wp.runRoundRobin(ctx)
+	// Hydrate jobs on the enrichment workers while they are dispatched
+	stopEnrich := wp.enrich(ctx, jobs)
+	defer stopEnrich()
+
+	// Prerequisites of important jobs run at their dependents' priority
+	inheritPriorities(jobs)
+	runLog := newRunLog(jobPriorities(jobs, nil))
+	wp.lastRun.Store(runLog)
+
+	wp.mu.Lock()
+	wp.pending = newPendingSet(jobs)
+	wp.guard = guard
+	wp.budgets.reset()
+	wp.slowCaptures.Store(0)
+	wp.starvation = nil
+	if wp.config.Starvation.Multiple > 0 {
+		wp.starvation = &starvationDetector{flagged: make(map[string]bool)}
+		watchCtx, stopWatch := context.WithCancel(ctx)
+		defer stopWatch()
+		go wp.watchStarvation(watchCtx, wp.starvation)
 	}
-	if err != nil {
-		// Clean up context on error
-		wp.ctxMu.Lock()
-		if wp.cancel != nil {
-			wp.cancel()
-			wp.cancel = nil
-			wp.ctx = nil
-		}
-		wp.ctxMu.Unlock()
-		return nil, err
+	alerts := wp.alerts
+	if alerts != nil {
+		watchCtx, stopWatch := context.WithCancel(ctx)
+		defer stopWatch()
+		go alerts.watch(watchCtx, wp.queueDepth)
 	}
+	wp.mu.Unlock()
 
-	// Collect results
-	var results []Result[R]
-	for result := range wp.results {
-		// Check for context cancellation
-		select {
-		case <-wp.ctx.Done():
-			return nil, wp.ctx.Err()
-		default:
+	deps := newDependencyTracker(jobs)
+
+	// Collect results while the strategy runs so workers never block on a
+	// full results channel. Every dispatch wave has its own results channel,
+	// or queue with MPSCCollector.
+	waves := make(chan resultWave[R])
+	waveDone := make(chan struct{})
+	collected := make(chan []Result[R], 1)
+	sink, waitSinks := wp.fanOut()
+	go func() {
+		wp.labelGoroutine(ctx, "collector")
+		var results []Result[R]
+		successes := 0
+		deliver := func(result Result[R]) {
+			sink(result)
+			wp.watchers.publish(result)
+			wp.reportError(result)
+			if stopAfter > 0 && successes >= stopAfter {
+				return // Cancelled, or finished regardless, after the run had enough
+			}
+			if stream != nil {
+				stream(result)
+			} else {
+				results = append(results, result)
+			}
+			if result.Error == nil {
+				successes++
+				if successes == stopAfter {
+					wp.StopWithCause(ErrEnoughSuccesses)
+				}
+			}
+		}
+		emit := func(result Result[R]) {
+			if first, ok := guard.admit(result); !ok {
+				wp.duplicateResult(onDuplicate, first, result)
+				return
+			}
+			result.ParentID = parents[result.JobID]
+			deps.record(result.JobID, result.Error == nil)
+			wp.recordResult(result)
+			runLog.record(result.JobID, result.Error, result.Duration, result.Completed)
+			wp.history.record(result.Error, result.Duration)
+			alerts.record(result.Error, result.Duration, wp.queueDepth)
+			wp.jobWatch.publish(JobEvent[R]{JobID: result.JobID, State: JobCompleted, Worker: result.Worker, Result: &result})
+			if splits == nil || result.ParentID == "" {
+				deliver(result)
+				return
+			}
+			// Parts of split jobs are delivered combined, once all are in
+			if combined, ok := splits.add(result); ok {
+				wp.jobWatch.publish(JobEvent[R]{JobID: combined.JobID, State: JobCompleted, Worker: combined.Worker, Result: &combined})
+				deliver(combined)
+			}
+		}
+		for wave := range waves {
+			wave(emit)
+			waveDone <- struct{}{}
 		}
+		if splits != nil {
+			for _, combined := range splits.flush() {
+				wp.jobWatch.publish(JobEvent[R]{JobID: combined.JobID, State: JobCompleted, Worker: combined.Worker, Result: &combined})
+				deliver(combined)
+			}
+		}
+		collected <- results
+	}()
 
-		results = append(results, result)
-		if result.Error != nil {
-			wp.metrics.FailedJobs++
-		} else {
-			wp.metrics.ProcessedJobs++
+	// Jobs whose class window is closed, or whose dependencies have not all
+	// finished, wait for later waves. Each wave is fully collected before the
+	// next is formed so that dependents see their dependencies' outcomes.
+	var err error
+	remaining := jobs
+	for err == nil && len(remaining) > 0 {
+		ready, held := wp.holdForWindows(remaining, time.Now())
+		ready, waiting, skipped := wp.resolveDependencies(deps, ready)
+		if len(skipped) > 0 {
+			sendWave(waves, skipped)
+			<-waveDone
+		}
+		if len(ready) > 0 {
+			err = wp.dispatch(ctx, ready, waves)
+			<-waveDone
+		}
+
+		remaining = append(held, waiting...)
+		if err != nil || len(remaining) == 0 {
+			break
+		}
+		if len(ready) > 0 || len(skipped) > 0 {
+			// Outcomes changed, so more jobs may be eligible, unless Shutdown began
+			select {
+			case <-drained:
+				remaining = nil
+			default:
+			}
+			continue
+		}
+		if len(held) == 0 {
+			// Nothing is running or held, so the waiting jobs depend on each other
+			cycle := make([]Result[R], len(waiting))
+			for i, job := range waiting {
+				cycle[i] = wp.skipJob(job, fmt.Errorf("%w: dependency cycle", ErrDependencyFailed))
+			}
+			sendWave(waves, cycle)
+			<-waveDone
+			remaining = nil
+			continue
+		}
+
+		timer := time.NewTimer(time.Until(wp.nextWindowOpen(held, time.Now())))
+		select {
+		case <-timer.C:
+		case <-drained:
+			timer.Stop()
+			remaining = nil
+		case <-ctx.Done():
+			timer.Stop()
+			err = cancellationError(ctx)
 		}
 	}
+	close(waves)
+
+	// Strategies close each wave's results channel once every worker has exited
+	results := <-collected
+	waitSinks()
 
 	// Clean up context
 	wp.ctxMu.Lock()
 	if wp.cancel != nil {
-		wp.cancel()
+		wp.cancel(nil)
 		wp.cancel = nil
 		wp.ctx = nil
+		wp.execCtx = nil
 	}
 	wp.ctxMu.Unlock()
 
+	if errors.Is(err, ErrEnoughSuccesses) {
+		err = nil
+	}
+	if err != nil {
+		err = wp.earlyEndError(err)
+		if wp.config.PartialResults {
+			return results, err
+		}
+		return nil, err
+	}
+
+	// A graceful Shutdown returns what finished and reports what never started
+	if err := wp.drainError(); err != nil {
+		return results, err
+	}
+
 	return results, nil
 }
 
+// dispatch runs the selected strategy over one wave of jobs
+func (wp *WorkerPool[T, R]) dispatch(ctx context.Context, jobs []Job[T], waves chan<- resultWave[R]) error {
+	if len(jobs) == 0 {
+		return nil
+	}
+
+	// Strategies close the results when done, so every wave needs fresh ones
+	var wave resultWave[R]
+	wp.mu.Lock()
+	if wp.config.Collector == MPSCCollector {
+		queue := newMPSCQueue[R]()
+		wp.resultQueue = queue
+		wave = queue.drain
+	} else {
+		results := make(chan Result[R], wp.resultBuffer())
+		wp.results, wp.resultQueue = results, nil
+		wave = channelWave(results)
+	}
+	wp.mu.Unlock()
+	waves <- wave
+
+	switch wp.config.Strategy {
+	case RoundRobin:
+		return wp.runRoundRobin(ctx, jobs)
+	case Chunked:
+		return wp.runChunked(ctx, jobs)
+	case WorkStealing:
+		return wp.runWorkStealing(ctx, jobs)
+	case PriorityBased:
+		return wp.runPriorityBased(ctx, jobs)
+	case Adaptive:
+		return wp.runAdaptive(ctx, jobs)
+	case FairShare:
+		return wp.runFairShare(ctx, jobs)
+	default:
+		return wp.runRoundRobin(ctx, jobs)
+	}
+}
+
+// isSkip reports whether err means the job was skipped rather than run
+func isSkip(err error) bool {
+	return errors.Is(err, ErrBudgetExceeded) || errors.Is(err, ErrAlreadyCompleted) ||
+		errors.Is(err, ErrDependencyFailed) || errors.Is(err, ErrBranchNotTaken)
+}
+
+// recordResult updates the metrics counters for a completed job
+func (wp *WorkerPool[T, R]) recordResult(result Result[R]) {
+	var cause string
+	if result.Error != nil {
+		cause = wp.fingerprint(result.Error)
+	}
+
+	wp.metrics.mu.Lock()
+	defer wp.metrics.mu.Unlock()
+
+	if errors.Is(result.Error, ErrJobExpired) {
+		wp.metrics.ExpiredJobs++
+	} else if isSkip(result.Error) {
+		wp.metrics.SkippedJobs++
+	} else if result.Error != nil {
+		wp.metrics.FailedJobs++
+		if wp.metrics.FailureCauses == nil {
+			wp.metrics.FailureCauses = make(map[string]int)
+		}
+		wp.metrics.FailureCauses[cause]++
+	} else {
+		wp.metrics.ProcessedJobs++
+	}
+	if result.Late {
+		wp.metrics.LateCompletions++
+	}
+	for key, v := range result.Counters {
+		if wp.metrics.Counters == nil {
+			wp.metrics.Counters = make(map[string]float64)
+		}
+		wp.metrics.Counters[key] += v
+	}
+}
+
 // runAdaptive uses the adaptive strategy to automatically select the best distribution method
-func (wp *WorkerPool[T, R]) runAdaptive(ctx context.Context) error {
-	// Analyze workload and select best strategy
-	workloadType := wp.analyzeWorkload()
-
-	// Execute the selected strategy based on workload analysis
-	switch workloadType {
-	case "priority_based":
-		return wp.runPriorityBased(ctx)
-	case "chunked":
-		return wp.runChunked(ctx)
-	case "work_stealing":
-		return wp.runWorkStealing(ctx)
+func (wp *WorkerPool[T, R]) runAdaptive(ctx context.Context, jobs []Job[T]) error {
+	// Analyze workload and let the learner pick, starting from the analysis
+	choice := wp.adaptive.choose(strategyNamed(wp.analyzeWorkload(jobs)))
+	ctx = withStrategy(ctx, Adaptive)
+
+	// Execute the selected strategy and learn from how it performed
+	start := time.Now()
+	var err error
+	switch {
+	case choice == Chunked && wp.config.StrategyOptions.AdaptiveSwitching && wp.config.StrategyOptions.ChunkSize <= 0:
+		err = wp.runChunkedThenSteal(ctx, jobs)
+	case choice == PriorityBased:
+		err = wp.runPriorityBased(ctx, jobs)
+	case choice == Chunked:
+		err = wp.runChunked(ctx, jobs)
+	case choice == WorkStealing:
+		err = wp.runWorkStealing(ctx, jobs)
 	default:
-		return wp.runRoundRobin(ctx)
+		err = wp.runRoundRobin(ctx, jobs)
+	}
+	if err == nil {
+		wp.adaptive.observe(choice, len(jobs), time.Since(start))
 	}
+	return err
 }
 
 // analyzeWorkload determines the type of workload based on job characteristics
-func (wp *WorkerPool[T, R]) analyzeWorkload() string {
-	if len(wp.jobs) == 0 {
+func (wp *WorkerPool[T, R]) analyzeWorkload(jobs []Job[T]) string {
+	if len(jobs) == 0 {
 		return "round_robin"
 	}
 
 	// Analyze job priorities
 	highPriorityCount := 0
-	for _, job := range wp.jobs {
+	for _, job := range jobs {
 		if job.Priority > 5 {
 			highPriorityCount++
 		}
 	}
 
 	// Analyze job distribution
-	jobCount := len(wp.jobs)
+	jobCount := len(jobs)
 	workerCount := wp.config.NumWorkers
 
 	// Determine workload type based on characteristics
@@ -311,25 +997,27 @@ func (wp *WorkerPool[T, R]) analyzeWorkload() string {
 }
 
 // runRoundRobin distributes jobs evenly across workers in round-robin fashion
-func (wp *WorkerPool[T, R]) runRoundRobin(ctx context.Context) error {
+func (wp *WorkerPool[T, R]) runRoundRobin(ctx context.Context, jobs []Job[T]) error {
 	var wg sync.WaitGroup
+	ctx = withStrategy(ctx, RoundRobin)
 
 	// Create separate job channels for each worker
 	jobChannels := make([]chan Job[T], wp.config.NumWorkers)
 	for i := 0; i < wp.config.NumWorkers; i++ {
-		bufferSize := max(1, len(wp.jobs)/wp.config.NumWorkers+1)
+		bufferSize := wp.config.StrategyOptions.roundRobinBuffer(len(jobs), wp.config.NumWorkers)
 		jobChannels[i] = make(chan Job[T], bufferSize)
 		wg.Add(1)
-		go wp.worker(i, jobChannels[i], &wg, ctx)
+		go wp.worker(i, jobChannels[i], &wg, withQueue(ctx, fmt.Sprintf("worker-%d", i), false))
 	}
 
-	// Distribute jobs round-robin
-	for i, job := range wp.jobs {
+	// Distribute jobs round-robin, stopping early if the run is cancelled
+distribute:
+	for i, job := range jobs {
 		workerIndex := i % wp.config.NumWorkers
 		select {
 		case jobChannels[workerIndex] <- job:
 		case <-ctx.Done():
-			return ctx.Err()
+			break distribute
 		}
 	}
 
@@ -339,101 +1027,250 @@ func (wp *WorkerPool[T, R]) runRoundRobin(ctx context.Context) error {
 	}
 
 	wg.Wait()
-	close(wp.results)
+	wp.closeResults()
 
 	// Check if context was cancelled during execution
 	select {
 	case <-ctx.Done():
-		return ctx.Err()
+		return cancellationError(ctx)
 	default:
 		return nil
 	}
 }
 
 // runChunked distributes jobs in chunks to workers
-func (wp *WorkerPool[T, R]) runChunked(ctx context.Context) error {
+func (wp *WorkerPool[T, R]) runChunked(ctx context.Context, jobs []Job[T]) error {
+	ctx = withStrategy(ctx, Chunked)
+	if wp.config.StrategyOptions.ChunkSize > 0 {
+		return wp.runChunkQueue(ctx, jobs, wp.config.StrategyOptions.ChunkSize)
+	}
+
+	wp.runChunkedPhase(ctx, jobs, false)
+	wp.closeResults()
+
+	// Check if context was cancelled during execution
+	select {
+	case <-ctx.Done():
+		return cancellationError(ctx)
+	default:
+		return nil
+	}
+}
+
+// runChunkedPhase gives each worker one equal slice of jobs and returns the
+// jobs no worker started. With resumable set the phase ends early when a
+// worker that has finished its slice sees a straggler: another worker with
+// jobs still queued whose current job has run more than twice the finished
+// worker's average, so the queued jobs can be redistributed.
+func (wp *WorkerPool[T, R]) runChunkedPhase(ctx context.Context, jobs []Job[T], resumable bool) []Job[T] {
 	var wg sync.WaitGroup
 
-	chunkSize := max(1, len(wp.jobs)/wp.config.NumWorkers)
-	remainder := len(wp.jobs) % wp.config.NumWorkers
+	chunkSize := len(jobs) / wp.config.NumWorkers
+	remainder := len(jobs) % wp.config.NumWorkers
 
+	slices := make([][]Job[T], wp.config.NumWorkers)
 	start := 0
-	for i := 0; i < wp.config.NumWorkers; i++ {
+	for i := range slices {
 		end := start + chunkSize
 		if i < remainder {
 			end++
 		}
-
-		if start < len(wp.jobs) {
-			wg.Add(1)
-			go wp.workerWithSlice(i, wp.jobs[start:end], &wg, ctx)
+		if start < len(jobs) {
+			slices[i] = jobs[start:end]
 		}
 		start = end
 	}
 
+	// Each worker advances its own cursor and records when its current job
+	// started; others only read them
+	cursors := make([]atomic.Int64, len(slices))
+	started := make([]atomic.Int64, len(slices))
+	var yield atomic.Bool
+	for i, slice := range slices {
+		if len(slice) == 0 {
+			continue
+		}
+		wg.Add(1)
+		go func(id int, slice []Job[T], ctx context.Context) {
+			defer wg.Done()
+			wp.labelWorker(ctx, id)
+			begin, processed := time.Now(), 0
+			for !yield.Load() && ctx.Err() == nil {
+				next := int(cursors[id].Add(1)) - 1
+				if next >= len(slice) {
+					break
+				}
+				started[id].Store(time.Now().UnixNano())
+				wp.processJob(id, slice[next], ctx)
+				processed++
+			}
+			if !resumable || processed == 0 {
+				return
+			}
+
+			// Watch the other workers until they are done or one straggles
+			limit := 2 * time.Since(begin) / time.Duration(processed)
+			ticker := time.NewTicker(wp.config.StrategyOptions.stealBackoff())
+			defer ticker.Stop()
+			for !yield.Load() && ctx.Err() == nil {
+				waiting := false
+				for other := range slices {
+					if len(slices[other])-int(cursors[other].Load()) <= 0 {
+						continue
+					}
+					waiting = true
+					if since := started[other].Load(); since != 0 && time.Since(time.Unix(0, since)) > limit {
+						yield.Store(true)
+						return
+					}
+				}
+				if !waiting {
+					return
+				}
+				<-ticker.C
+			}
+		}(i, slice, withQueue(ctx, fmt.Sprintf("chunk-%d", i), false))
+	}
+	wg.Wait()
+
+	var unstarted []Job[T]
+	for i, slice := range slices {
+		unstarted = append(unstarted, slice[min(int(cursors[i].Load()), len(slice)):]...)
+	}
+	return unstarted
+}
+
+// runChunkQueue splits jobs into fixed-size chunks that idle workers pull in order
+func (wp *WorkerPool[T, R]) runChunkQueue(ctx context.Context, jobs []Job[T], chunkSize int) error {
+	var wg sync.WaitGroup
+
+	chunks := make(chan []Job[T], (len(jobs)+chunkSize-1)/chunkSize)
+	for start := 0; start < len(jobs); start += chunkSize {
+		chunks <- jobs[start:min(start+chunkSize, len(jobs))]
+	}
+	close(chunks)
+	ctx = withQueue(ctx, "chunk_queue", false)
+
+	for i := 0; i < wp.config.NumWorkers; i++ {
+		wg.Add(1)
+		go func(id int) {
+			defer wg.Done()
+			wp.labelWorker(ctx, id)
+			for chunk := range chunks {
+				for _, job := range chunk {
+					if ctx.Err() != nil {
+						return
+					}
+					wp.processJob(id, job, ctx)
+				}
+			}
+		}(i)
+	}
+
 	wg.Wait()
-	close(wp.results)
+	wp.closeResults()
 
 	// Check if context was cancelled during execution
 	select {
 	case <-ctx.Done():
-		return ctx.Err()
+		return cancellationError(ctx)
 	default:
 		return nil
 	}
 }
 
 // runWorkStealing implements work stealing using Chase-Lev work stealing deques
-func (wp *WorkerPool[T, R]) runWorkStealing(ctx context.Context) error {
+func (wp *WorkerPool[T, R]) runWorkStealing(ctx context.Context, jobs []Job[T]) error {
 	var wg sync.WaitGroup
+	ctx = withStrategy(ctx, WorkStealing)
 
 	// Create work stealing deques for each worker
 	deques := make([]*WorkStealingDeque[T], wp.config.NumWorkers)
 	for i := 0; i < wp.config.NumWorkers; i++ {
-		deques[i] = NewWorkStealingDeque[T](len(wp.jobs)/wp.config.NumWorkers + 1)
+		deques[i] = NewWorkStealingDeque[T](len(jobs)/wp.config.NumWorkers + 1)
 	}
 
 	// Distribute jobs initially across worker deques (round-robin)
-	for i, job := range wp.jobs {
+	for i, job := range jobs {
 		workerIndex := i % wp.config.NumWorkers
 		deques[workerIndex].Push(job)
 	}
 
 	// Start work stealing workers
+	counters := newStealCounters(wp.config.NumWorkers)
+	wp.steals.Store(counters)
 	for i := 0; i < wp.config.NumWorkers; i++ {
 		wg.Add(1)
-		go wp.workStealingWorker(i, deques, &wg, ctx)
+		go wp.workStealingWorker(i, deques, counters, &wg, ctx)
 	}
 
 	wg.Wait()
-	close(wp.results)
+	wp.closeResults()
 
 	// Check if context was cancelled during execution
 	select {
 	case <-ctx.Done():
-		return ctx.Err()
+		return cancellationError(ctx)
 	default:
 		return nil
 	}
 }
 
 // runPriorityBased processes jobs based on priority using a priority queue with fair scheduling
-func (wp *WorkerPool[T, R]) runPriorityBased(ctx context.Context) error {
-	var wg sync.WaitGroup
+func (wp *WorkerPool[T, R]) runPriorityBased(ctx context.Context, jobs []Job[T]) error {
+	ctx = withStrategy(ctx, PriorityBased)
+
+	// Create priority queue (weighted lanes when configured)
+	wp.mu.RLock()
+	newQueue := wp.newQueue
+	wp.mu.RUnlock()
+	if newQueue != nil {
+		return wp.runQueued(withQueue(ctx, "custom", false), newQueue(), jobs)
+	}
+	if len(wp.config.PriorityLanes) > 0 {
+		return wp.runQueued(withQueue(ctx, "lanes", false), NewLaneQueue[T](wp.config.PriorityLanes), jobs)
+	}
+	return wp.runQueued(withQueue(ctx, "priority", false), NewPriorityQueue[T](), jobs)
+}
 
-	// Create priority queue and populate it with jobs
-	priorityQueue := NewPriorityQueue[T]()
+// runFairShare dispatches the job whose owner has consumed the least processing time
+func (wp *WorkerPool[T, R]) runFairShare(ctx context.Context, jobs []Job[T]) error {
+	ctx = withStrategy(ctx, FairShare)
+	return wp.runQueued(withQueue(ctx, "fair_share", false), newFairShareQueue[T](wp.usage), jobs)
+}
+
+// runQueued feeds workers from a shared queue through a single dispatcher
+func (wp *WorkerPool[T, R]) runQueued(ctx context.Context, priorityQueue Queue[T], jobs []Job[T]) error {
+	var wg sync.WaitGroup
 
 	// Set creation time for fair scheduling and add jobs to priority queue
-	for _, job := range wp.jobs {
+	for _, job := range jobs {
 		if job.Created.IsZero() {
 			job.Created = time.Now()
 		}
 		priorityQueue.Push(job)
 	}
 
-	// Create shared work queue for workers to consume from
-	workQueue := make(chan Job[T], wp.config.BufferSize)
+	// Create shared work queue for workers to consume from. It is unbuffered by
+	// default so jobs stay in the priority queue, where they can still be
+	// reordered, until a worker is ready for them; DispatchBatch trades that
+	// for fewer dispatcher handoffs.
+	workQueue := make(chan Job[T], wp.config.StrategyOptions.DispatchBatch)
+	var handing atomic.Bool // The dispatcher holds a job no worker has taken yet
+
+	// Publish the queue so pending jobs can be reprioritized while dispatching
+	wp.mu.Lock()
+	wp.queue = priorityQueue
+	wp.queueWaiting = func() bool {
+		return handing.Load() || len(workQueue) > 0 || priorityQueue.Len() > 0
+	}
+	wp.mu.Unlock()
+	defer func() {
+		wp.mu.Lock()
+		wp.queue = nil
+		wp.queueWaiting = nil
+		wp.mu.Unlock()
+	}()
 
 	// Start workers
 	for i := 0; i < wp.config.NumWorkers; i++ {
@@ -444,16 +1281,19 @@ func (wp *WorkerPool[T, R]) runPriorityBased(ctx context.Context) error {
 	// Priority dispatcher: continuously feeds high-priority jobs to workers
 	go func() {
 		defer close(workQueue)
+		wp.labelGoroutine(ctx, "dispatcher")
 
-		for !priorityQueue.IsEmpty() {
-			job, ok := priorityQueue.Pop()
+		for {
+			job, ok := wp.popQueued(priorityQueue)
 			if !ok {
-				break
+				return
 			}
 
+			handing.Store(true)
 			select {
 			case workQueue <- job:
 				// Job sent to worker
+				handing.Store(false)
 			case <-ctx.Done():
 				return
 			}
@@ -461,36 +1301,67 @@ func (wp *WorkerPool[T, R]) runPriorityBased(ctx context.Context) error {
 	}()
 
 	wg.Wait()
-	close(wp.results)
+	wp.closeResults()
 
 	// Check if context was cancelled during execution
 	select {
 	case <-ctx.Done():
-		return ctx.Err()
+		return cancellationError(ctx)
 	default:
 		return nil
 	}
 }
 
-// worker processes jobs from a dedicated channel
-func (wp *WorkerPool[T, R]) worker(id int, jobs <-chan Job[T], wg *sync.WaitGroup, ctx context.Context) {
-	defer wg.Done()
+// popQueued takes the next job from the live priority queue. When the queue is
+// drained it is unpublished under wp.mu so a concurrent Requeue is never lost.
+func (wp *WorkerPool[T, R]) popQueued(pq Queue[T]) (Job[T], bool) {
+	if job, ok := pq.Pop(); ok {
+		return job, true
+	}
 
-	for job := range jobs {
-		select {
-		case <-ctx.Done():
-			return
-		default:
-			wp.processJob(id, job, ctx)
-		}
+	wp.mu.Lock()
+	defer wp.mu.Unlock()
+
+	if job, ok := pq.Pop(); ok {
+		return job, true
 	}
+	if wp.queue == pq {
+		wp.queue = nil
+	}
+	return Job[T]{}, false
+}
+
+// execContext returns the context jobs of the current run execute under
+func (wp *WorkerPool[T, R]) execContext(ctx context.Context) context.Context {
+	wp.ctxMu.RLock()
+	defer wp.ctxMu.RUnlock()
+
+	if wp.execCtx != nil {
+		return wp.execCtx
+	}
+	return ctx
 }
 
-// workerWithSlice processes a slice of jobs directly
-func (wp *WorkerPool[T, R]) workerWithSlice(id int, jobSlice []Job[T], wg *sync.WaitGroup, ctx context.Context) {
+// claimPending marks a job as started, reporting false if it was removed or
+// the pool is draining
+func (wp *WorkerPool[T, R]) claimPending(id string) bool {
+	wp.mu.RLock()
+	pending, draining := wp.pending, wp.draining
+	wp.mu.RUnlock()
+
+	if draining {
+		// Leave the job pending so Shutdown can hand it back
+		return false
+	}
+	return pending == nil || pending.claim(id)
+}
+
+// worker processes jobs from a dedicated channel
+func (wp *WorkerPool[T, R]) worker(id int, jobs <-chan Job[T], wg *sync.WaitGroup, ctx context.Context) {
 	defer wg.Done()
+	wp.labelWorker(ctx, id)
 
-	for _, job := range jobSlice {
+	for job := range jobs {
 		select {
 		case <-ctx.Done():
 			return
@@ -501,11 +1372,16 @@ func (wp *WorkerPool[T, R]) workerWithSlice(id int, jobSlice []Job[T], wg *sync.
 }
 
 // workStealingWorker implements work stealing behavior
-func (wp *WorkerPool[T, R]) workStealingWorker(id int, deques []*WorkStealingDeque[T], wg *sync.WaitGroup, ctx context.Context) {
+func (wp *WorkerPool[T, R]) workStealingWorker(id int, deques []*WorkStealingDeque[T], counters *stealCounters, wg *sync.WaitGroup, ctx context.Context) {
 	defer wg.Done()
+	wp.labelWorker(ctx, id)
 
 	myDeque := deques[id]
 	numWorkers := len(deques)
+	maxAttempts := wp.config.StrategyOptions.stealAttempts(numWorkers)
+	backoff := wp.config.StrategyOptions.stealBackoff()
+	victims := newVictimSelector(id, numWorkers, wp.config.StrategyOptions.StealDomainSize, time.Now().UnixNano()+int64(id))
+	ownCtx := withQueue(ctx, fmt.Sprintf("deque-%d", id), false)
 
 	for {
 		// Check for context cancellation
@@ -517,21 +1393,23 @@ func (wp *WorkerPool[T, R]) workStealingWorker(id int, deques []*WorkStealingDeq
 
 		// Try to get work from own deque first (LIFO for better cache locality)
 		if job, ok := myDeque.Pop(); ok {
-			wp.processJob(id, job, ctx)
+			counters.own[id].Add(1)
+			wp.processJob(id, job, ownCtx)
 			continue
 		}
 
 		// No work in own deque, try to steal from other workers (FIFO)
 		stolen := false
-		for attempts := 0; attempts < numWorkers*2; attempts++ {
-			// Pick a random victim (avoid bias)
-			victimID := (id + attempts + 1) % numWorkers
-			if victimID == id {
-				continue // Don't steal from yourself
-			}
+		victimOrder := victims.next()
+		for attempts := 0; attempts < maxAttempts && len(victimOrder) > 0; attempts++ {
+			// Nearby victims first, ties in random order
+			victimID := victimOrder[attempts%len(victimOrder)]
 
+			counters.attempts.Add(1)
 			if job, ok := deques[victimID].Steal(); ok {
-				wp.processJob(id, job, ctx)
+				counters.successes.Add(1)
+				counters.stolen[id].Add(1)
+				wp.processJob(id, job, withQueue(ctx, fmt.Sprintf("deque-%d", victimID), true))
 				stolen = true
 				break
 			}
@@ -553,50 +1431,356 @@ func (wp *WorkerPool[T, R]) workStealingWorker(id int, deques []*WorkStealingDeq
 			}
 
 			// Brief pause before trying again to avoid busy waiting
-			time.Sleep(1 * time.Millisecond)
+			select {
+			case <-time.After(backoff):
+			case <-ctx.Done():
+				return
+			}
 		}
 	}
 }
 
-// processJob handles the actual job processing with retries and metrics
+// processJob handles the actual job processing with retries and metrics.
+// A lost or yielded job that cannot go back on a live queue is delivered
+// again by the same worker, looping rather than recursing so the stack
+// stays flat however often that happens.
 func (wp *WorkerPool[T, R]) processJob(workerID int, job Job[T], ctx context.Context) {
-	startTime := time.Now()
+	for again := true; again; {
+		job, again = wp.deliverJob(workerID, job, ctx)
+	}
+}
 
-	var result R
-	var err error
+// deliverJob makes one delivery of a job. It returns the job and true when
+// the worker must deliver it again.
+func (wp *WorkerPool[T, R]) deliverJob(workerID int, job Job[T], ctx context.Context) (Job[T], bool) {
+	// Wait out maintenance blackouts and GC pressure; the job stays pending meanwhile
+	if wp.awaitBlackout(ctx) != nil {
+		return job, false
+	}
+	if wp.awaitGCPressure(ctx, job) != nil {
+		return job, false
+	}
+
+	// Wait for the job's enrichment; the job stays pending meanwhile
+	job, enrichErr := wp.awaitEnrichment(ctx, job)
+	if enrichErr != nil && !errors.Is(enrichErr, ErrEnrichmentFailed) {
+		return job, false
+	}
+
+	// Skip jobs removed from the backlog after they were handed to a worker
+	if !wp.claimPending(job.ID) {
+		return job, false
+	}
+
+	// Feed the queue wait to starvation detection
+	wp.mu.RLock()
+	starvation := wp.starvation
+	wp.mu.RUnlock()
+	if !job.Created.IsZero() {
+		starvation.record(time.Since(job.Created))
+	}
+
+	// Jobs that waited past their expiry are reported without being executed;
+	// a redelivered job already started, so its work is not thrown away
+	if now := time.Now(); !job.redelivered && job.Expired(now) {
+		wp.tenants.dequeue(job.TenantID)
+		wp.deadlines.record(job.Class, job.Deadline, now)
+		wp.sendResult(Result[R]{
+			JobID:     job.ID,
+			Error:     ErrJobExpired,
+			Worker:    workerID,
+			Started:   now,
+			Completed: now,
+		})
+		return job, false
+	}
+
+	// Jobs that could not be enriched never reach the processor
+	if enrichErr != nil {
+		wp.tenants.dequeue(job.TenantID)
+		wp.recordFailure(job)
+		now := time.Now()
+		wp.sendResult(Result[R]{
+			JobID:     job.ID,
+			Error:     enrichErr,
+			Worker:    workerID,
+			Started:   now,
+			Completed: now,
+		})
+		return job, false
+	}
+
+	// Make sure the worker is initialized before anything is reserved for the job
+	resource, startErr := wp.resources.get(ctx, workerID)
+	if startErr != nil {
+		wp.tenants.dequeue(job.TenantID)
+		wp.recordFailure(job)
+		now := time.Now()
+		wp.sendResult(Result[R]{
+			JobID:     job.ID,
+			Error:     startErr,
+			Worker:    workerID,
+			Started:   now,
+			Completed: now,
+		})
+		return job, false
+	}
 
-	// Process with retries
-	for attempt := 0; attempt <= wp.config.MaxRetries; attempt++ {
-		// Create a context for this job processing
-		jobCtx := context.Background()
-		if wp.config.WorkerTimeout > 0 {
-			var cancel context.CancelFunc
-			jobCtx, cancel = context.WithTimeout(context.Background(), wp.config.WorkerTimeout)
-			defer cancel()
+	// Skip the job once its run or class budget is spent. A job is charged
+	// on its first delivery only, however often it yields or is redelivered.
+	cost := wp.jobCost(job)
+	charged := 0
+	if !job.redelivered {
+		if err := wp.budgets.reserve(job.Class, cost); err != nil {
+			wp.tenants.dequeue(job.TenantID)
+			wp.recordFailure(job)
+			now := time.Now()
+			wp.sendResult(Result[R]{
+				JobID:     job.ID,
+				Error:     err,
+				Worker:    workerID,
+				Started:   now,
+				Completed: now,
+			})
+			return job, false
 		}
+		charged = cost
+	}
+
+	// Wait until the job's class is below its concurrency cap, its cost fits
+	// under the pool's concurrent-cost cap, CPU throttling allows another job
+	// and its tenant drops below the in-flight quota
+	if err := wp.classSlots.acquire(ctx, job.Class); err != nil {
+		wp.budgets.refund(job.Class, charged)
+		return job, false
+	}
+	held, err := wp.costs.acquire(ctx, cost)
+	if err != nil {
+		wp.budgets.refund(job.Class, charged)
+		wp.classSlots.release(job.Class)
+		return job, false
+	}
+	slot, err := wp.throttle.acquire(ctx)
+	if err != nil {
+		wp.budgets.refund(job.Class, charged)
+		wp.classSlots.release(job.Class)
+		wp.costs.release(held)
+		return job, false
+	}
+	if err := wp.tenants.acquire(ctx, job.TenantID); err != nil {
+		wp.budgets.refund(job.Class, charged)
+		wp.classSlots.release(job.Class)
+		wp.costs.release(held)
+		wp.throttle.release(slot)
+		return job, false
+	}
 
-		result, err = wp.processor(jobCtx, job)
-		if err == nil {
+	// Deduplicate against the completion store
+	claim, seen, onceErr := wp.beginOnce(ctx, job)
+	if onceErr != nil && ctx.Err() != nil {
+		// The run ended while the job waited for its idempotency key
+		wp.budgets.refund(job.Class, charged)
+		wp.tenants.requeue(job.TenantID)
+		wp.classSlots.release(job.Class)
+		wp.costs.release(held)
+		wp.throttle.release(slot)
+		return job, false
+	}
+	if seen || onceErr != nil {
+		wp.budgets.refund(job.Class, charged)
+		wp.tenants.release(job.TenantID, onceErr)
+		wp.classSlots.release(job.Class)
+		wp.costs.release(held)
+		wp.throttle.release(slot)
+		if seen {
+			onceErr = ErrAlreadyCompleted
+		}
+		now := time.Now()
+		wp.sendResult(Result[R]{
+			JobID:     job.ID,
+			Error:     onceErr,
+			Worker:    workerID,
+			Started:   now,
+			Completed: now,
+		})
+		return job, false
+	}
+
+	startTime := time.Now()
+	slowDone := wp.watchSlow(workerID, job)
+	endBusy := wp.workers.begin(workerID, job.ID)
+	wp.jobWatch.publish(JobEvent[R]{JobID: job.ID, State: JobStarted, Worker: workerID})
+
+	var result R
+	var lost bool
+	var attemptErrors []error
+	var attemptDurations []time.Duration
+	var executionIDs []string
+	var yielded *YieldError
+	var cpuTime time.Duration
+
+	// Process with retries. Retries stop once the run stops dispatching, but
+	// an attempt in progress may finish within the straggler window.
+	meta := &ResultMeta{}
+	execCtx, endTask := wp.traceJob(wp.execContext(ctx), workerID, job)
+	execCtx = context.WithValue(execCtx, resultMetaKey{}, meta)
+	execCtx = context.WithValue(execCtx, resourceTokensKey{}, &wp.tokens)
+	if resource != nil {
+		execCtx = context.WithValue(execCtx, workerResourceKey{}, resource)
+	}
+	releaseRetry := func() {}
+	policy := wp.attemptPolicy(job.Priority)
+	for attempt := 0; attempt <= policy.maxRetries; attempt++ {
+		// Create a context for this job processing, bound to the run and
+		// watched for its deadline and heartbeats
+		attemptCtx, executionID := withExecution(wp.withTimeSlice(execCtx), job.ID, attempt+1, workerID)
+		executionIDs = append(executionIDs, executionID)
+		jobCtx, stop := newJobContext(attemptCtx, policy.timeout, policy.heartbeat, wp.config.VisibilityTimeout)
+
+		attemptStart := time.Now()
+		endRegion := wp.traceAttempt(jobCtx, attempt+1, executionID)
+		result, lost, err = wp.invoke(jobCtx, job)
+		endRegion()
+		cpuTime += time.Duration(jobCtx.cpu.Load())
+		if err != nil && errors.Is(context.Cause(jobCtx), ErrJobStuck) {
+			err = fmt.Errorf("%w: %w", ErrJobStuck, err)
+		}
+		stop()
+		releaseRetry()
+		attemptErrors = append(attemptErrors, err)
+		attemptDurations = append(attemptDurations, time.Since(attemptStart))
+		if errors.As(err, &yielded) {
+			break
+		}
+		wp.damper.observe(err != nil)
+		if err == nil || lost {
 			break
 		}
-		if attempt < wp.config.MaxRetries {
-			time.Sleep(time.Duration(attempt+1) * 100 * time.Millisecond)
+		if ctx.Err() != nil {
+			// The run was cancelled; report why instead of the processor's error
+			err = fmt.Errorf("job interrupted: %w (last error: %v)", cancellationError(ctx), err)
+			break
+		}
+		if attempt < policy.maxRetries {
+			wp.jobWatch.publish(JobEvent[R]{JobID: job.ID, State: JobRetrying, Worker: workerID, Attempt: attempt + 1, Err: err})
+			// Back off, giving up as soon as the run is stopped or times out
+			if sleepContext(ctx, wp.damper.backoff(backoffDelay(attempt, err, policy.backoff))) != nil {
+				err = fmt.Errorf("job interrupted: %w (last error: %v)", cancellationError(ctx), err)
+				break
+			}
+			// Defer the retry until any blackout that started meanwhile is
+			// over, and while damped until a retry slot frees up
+			if wp.awaitBlackout(ctx) != nil {
+				err = fmt.Errorf("job interrupted: %w (last error: %v)", cancellationError(ctx), err)
+				break
+			}
+			release, waitErr := wp.damper.acquire(ctx)
+			if waitErr != nil {
+				err = fmt.Errorf("job interrupted: %w (last error: %v)", cancellationError(ctx), err)
+				break
+			}
+			releaseRetry = release
 		}
 	}
 
+	endTask()
+	slowDone()
+	endBusy()
 	completed := time.Now()
 	duration := completed.Sub(startTime)
 
-	// Send result to channel
-	wp.results <- Result[R]{
+	// A job that yielded its time slice goes back on the queue to resume
+	// where it left off
+	if yielded != nil {
+		job.Continuation = yielded.Continuation
+		job.sliced = job.sliced.add(startTime, duration, cpuTime)
+		claim.release()
+		wp.tenants.requeue(job.TenantID)
+		wp.classSlots.release(job.Class)
+		wp.costs.release(held)
+		wp.throttle.release(slot)
+		wp.usage.record(job.OwnerKey(), completed, duration)
+		wp.recordCPU(job, cpuTime)
+		wp.budgets.settle(job.Class, duration, false)
+		job.redelivered = true
+		return job, wp.redeliver(job)
+	}
+
+	// A lost delivery, whether abandoned by the watchdog or ended by a
+	// panic, is redelivered until MaxRedeliveries is used up
+	if lost {
+		if limit := wp.redeliveryLimit(err); limit == 0 || job.Redeliveries < limit {
+			job.Redeliveries++
+			claim.release()
+			wp.tenants.release(job.TenantID, err)
+			wp.classSlots.release(job.Class)
+			wp.costs.release(held)
+			wp.throttle.release(slot)
+			wp.budgets.settle(job.Class, duration, false)
+			job.redelivered = true
+			return job, wp.redeliver(job)
+		}
+		err = fmt.Errorf("%w after %d redeliveries", err, job.Redeliveries)
+	}
+	err = claim.finish(err)
+
+	late := err == nil && ctx.Err() != nil
+	wp.tenants.release(job.TenantID, err)
+	wp.classSlots.release(job.Class)
+	wp.costs.release(held)
+	wp.throttle.release(slot)
+	wp.usage.record(job.OwnerKey(), completed, duration)
+	wp.recordCPU(job, cpuTime)
+	wp.deadlines.record(job.Class, job.Deadline, completed)
+	wp.budgets.settle(job.Class, duration, err != nil)
+
+	// The result covers every slice of a job that yielded, while the
+	// metrics above already counted the earlier ones
+	started, total, totalCPU := startTime, duration, cpuTime
+	if !job.sliced.started.IsZero() {
+		started = job.sliced.started
+		total += job.sliced.duration
+		totalCPU += job.sliced.cpu
+		job.sliced = slicedTime{}
+	}
+	if err != nil {
+		job.Attempts += len(attemptDurations)
+		wp.recordFailure(job)
+	} else {
+		wp.clearFailure(job.ID)
+		wp.checkLatency(job, total)
+	}
+
+	// Hand the result to the collector
+	labels, counters := meta.snapshot()
+	dispatch := dispatchFrom(ctx)
+	wp.sendResult(Result[R]{
 		JobID:     job.ID,
 		Data:      result,
 		Error:     err,
 		Worker:    workerID,
-		Started:   startTime,
+		Started:   started,
 		Completed: completed,
-		Duration:  duration,
-	}
+		Duration:  total,
+
+		Late:             late,
+		Redeliveries:     job.Redeliveries,
+		Attempts:         len(attemptDurations),
+		AttemptErrors:    attemptErrors,
+		AttemptDurations: attemptDurations,
+		ExecutionIDs:     executionIDs,
+
+		Labels:   labels,
+		Counters: counters,
+
+		Strategy:        dispatch.strategy,
+		Queue:           dispatch.queue,
+		Stolen:          dispatch.stolen,
+		DispatchAttempt: job.Redeliveries + 1,
+
+		CPUTime: totalCPU,
+	})
+	return job, false
 }
 
 // max returns the larger of two integers
@@ -667,8 +1851,9 @@ func (d *WorkStealingDeque[T]) Pop() (Job[T], bool) {
 
 // Steal removes and returns a job from the top of the deque (thief thread)
 func (d *WorkStealingDeque[T]) Steal() (Job[T], bool) {
-	d.mu.RLock()
-	defer d.mu.RUnlock()
+	// Stealing advances top, so it needs the write lock like Pop
+	d.mu.Lock()
+	defer d.mu.Unlock()
 
 	top := d.top
 	bottom := d.bottom
@@ -709,15 +1894,22 @@ func (d *WorkStealingDeque[T]) IsEmpty() bool {
 // PriorityQueue implements a priority queue with fair scheduling
 // Uses a binary heap with additional fairness mechanisms
 type PriorityQueue[T any] struct {
-	items    []Job[T]
+	items    []queuedJob[T]
+	seq      uint64 // Push counter breaking ties between equal Created timestamps
 	mu       sync.RWMutex
 	fairness map[int]int // Track job counts per priority to prevent starvation
 }
 
+// queuedJob is a job in a PriorityQueue tagged with its push sequence number
+type queuedJob[T any] struct {
+	job Job[T]
+	seq uint64
+}
+
 // NewPriorityQueue creates a new priority queue
 func NewPriorityQueue[T any]() *PriorityQueue[T] {
 	return &PriorityQueue[T]{
-		items:    make([]Job[T], 0),
+		items:    make([]queuedJob[T], 0),
 		fairness: make(map[int]int),
 	}
 }
@@ -731,7 +1923,8 @@ func (pq *PriorityQueue[T]) Push(job Job[T]) {
 	pq.fairness[job.Priority]++
 
 	// Add job to the end
-	pq.items = append(pq.items, job)
+	pq.seq++
+	pq.items = append(pq.items, queuedJob[T]{job: job, seq: pq.seq})
 
 	// Bubble up to maintain heap property
 	pq.bubbleUp(len(pq.items) - 1)
@@ -747,7 +1940,7 @@ func (pq *PriorityQueue[T]) Pop() (Job[T], bool) {
 	}
 
 	// Get the highest priority job
-	job := pq.items[0]
+	job := pq.items[0].job
 
 	// Update fairness tracking
 	pq.fairness[job.Priority]--
@@ -773,7 +1966,7 @@ func (pq *PriorityQueue[T]) Peek() (Job[T], bool) {
 		return Job[T]{}, false
 	}
 
-	return pq.items[0], true
+	return pq.items[0].job, true
 }
 
 // Size returns the number of jobs in the queue
@@ -783,11 +1976,67 @@ func (pq *PriorityQueue[T]) Size() int {
 	return len(pq.items)
 }
 
+// Len returns the number of jobs in the queue, as Size does
+func (pq *PriorityQueue[T]) Len() int {
+	return pq.Size()
+}
+
 // IsEmpty checks if the queue is empty
 func (pq *PriorityQueue[T]) IsEmpty() bool {
 	return pq.Size() == 0
 }
 
+// Update applies fn to every queued job matching the predicate and restores
+// heap order afterwards. It returns the number of jobs updated.
+func (pq *PriorityQueue[T]) Update(match func(Job[T]) bool, fn func(*Job[T])) int {
+	pq.mu.Lock()
+	defer pq.mu.Unlock()
+
+	updated := 0
+	for i := range pq.items {
+		job := &pq.items[i].job
+		if !match(*job) {
+			continue
+		}
+		pq.fairness[job.Priority]--
+		fn(job)
+		pq.fairness[job.Priority]++
+		updated++
+	}
+
+	if updated > 0 {
+		for i := len(pq.items)/2 - 1; i >= 0; i-- {
+			pq.bubbleDown(i)
+		}
+	}
+	return updated
+}
+
+// Remove deletes every queued job matching the predicate and returns them
+func (pq *PriorityQueue[T]) Remove(match func(Job[T]) bool) []Job[T] {
+	pq.mu.Lock()
+	defer pq.mu.Unlock()
+
+	var removed []Job[T]
+	kept := pq.items[:0]
+	for _, item := range pq.items {
+		if match(item.job) {
+			pq.fairness[item.job.Priority]--
+			removed = append(removed, item.job)
+			continue
+		}
+		kept = append(kept, item)
+	}
+	pq.items = kept
+
+	if len(removed) > 0 {
+		for i := len(pq.items)/2 - 1; i >= 0; i-- {
+			pq.bubbleDown(i)
+		}
+	}
+	return removed
+}
+
 // GetFairnessStats returns fairness statistics
 func (pq *PriorityQueue[T]) GetFairnessStats() map[int]int {
 	pq.mu.RLock()
@@ -844,8 +2093,8 @@ func (pq *PriorityQueue[T]) bubbleDown(index int) {
 // shouldSwap determines if two jobs should be swapped for heap ordering
 // Implements fair scheduling to prevent starvation
 func (pq *PriorityQueue[T]) shouldSwap(parent, child int) bool {
-	parentJob := pq.items[parent]
-	childJob := pq.items[child]
+	parentJob := pq.items[parent].job
+	childJob := pq.items[child].job
 
 	// Primary ordering: higher priority first
 	if parentJob.Priority != childJob.Priority {
@@ -853,7 +2102,12 @@ func (pq *PriorityQueue[T]) shouldSwap(parent, child int) bool {
 	}
 
 	// Secondary ordering: FIFO for same priority (fairness)
-	return parentJob.Created.After(childJob.Created)
+	if !parentJob.Created.Equal(childJob.Created) {
+		return parentJob.Created.After(childJob.Created)
+	}
+
+	// Equal timestamps fall back to push order
+	return pq.items[parent].seq > pq.items[child].seq
 }
 
 // GetMetrics returns a copy of the current metrics
@@ -865,25 +2119,66 @@ func (wp *WorkerPool[T, R]) GetMetrics() Metrics {
 		TotalJobs:       wp.metrics.TotalJobs,
 		ProcessedJobs:   wp.metrics.ProcessedJobs,
 		FailedJobs:      wp.metrics.FailedJobs,
+		ExpiredJobs:     wp.metrics.ExpiredJobs,
+		LateCompletions: wp.metrics.LateCompletions,
+		SkippedJobs:     wp.metrics.SkippedJobs,
 		TotalDuration:   wp.metrics.TotalDuration,
 		AverageDuration: wp.metrics.AverageDuration,
 		StartTime:       wp.metrics.StartTime,
 		EndTime:         wp.metrics.EndTime,
+		Tenants:         wp.tenants.snapshot(),
+		Stealing:        wp.steals.Load().snapshot(),
+
+		ErrorsReported:   wp.metrics.ErrorsReported,
+		ErrorsSuppressed: wp.metrics.ErrorsSuppressed,
+		FailureCauses:    maps.Clone(wp.metrics.FailureCauses),
+		Counters:         maps.Clone(wp.metrics.Counters),
+		StarvedJobs:      wp.metrics.StarvedJobs,
+		Adaptive:         wp.adaptive.stats(),
+
+		CPUTime:      wp.metrics.CPUTime,
+		ClassCPUTime: maps.Clone(wp.metrics.ClassCPUTime),
+
+		DroppedResults:   wp.metrics.DroppedResults,
+		DuplicateResults: wp.metrics.DuplicateResults,
 	}
 }
 
+// ending reports whether the run in progress has been stopped or timed out
+// and is winding down
+func (wp *WorkerPool[T, R]) ending() bool {
+	wp.ctxMu.RLock()
+	defer wp.ctxMu.RUnlock()
+	return wp.ctx == nil || wp.ctx.Err() != nil
+}
+
+// Name returns the pool name from its configuration
+func (wp *WorkerPool[T, R]) Name() string {
+	return wp.config.Name
+}
+
 // GetNumWorkers returns the number of workers in the pool
 func (wp *WorkerPool[T, R]) GetNumWorkers() int {
+	wp.mu.RLock()
+	defer wp.mu.RUnlock()
 	return wp.config.NumWorkers
 }
 
-// Stop cancels the worker pool context
+// Stop cancels the run in progress, if any. It does not wait for the run to
+// wind down and has no effect on later runs: the pool stays usable, and a
+// Run called after Stop waits for the stopped run to return before starting.
 func (wp *WorkerPool[T, R]) Stop() {
+	wp.StopWithCause(ErrPoolStopped)
+}
+
+// StopWithCause stops the run in progress like Stop, recording reason as the
+// cancellation cause reported by Run and by interrupted jobs
+func (wp *WorkerPool[T, R]) StopWithCause(reason error) {
 	wp.ctxMu.RLock()
 	cancel := wp.cancel
 	wp.ctxMu.RUnlock()
 
 	if cancel != nil {
-		cancel()
+		cancel(reason)
 	}
 }
@@ -53,7 +53,7 @@ func (ts *WorkerPoolTestSuite) TestWithProcessor() {
 	}
 
 	pool.WithProcessor(processor)
-	ts.NotNil(pool.processor)
+	ts.NotNil(pool.processorFunc())
 }
 
 func (ts *WorkerPoolTestSuite) TestAddJobs() {
@@ -242,6 +242,14 @@ func (ts *WorkerPoolTestSuite) TestRetryMechanism() {
 	ts.NoError(results[0].Error)
 	ts.Equal("HELLO", results[0].Data)
 	ts.Equal(3, attempts) // Should have retried twice
+
+	// Attempt history is recorded on the result
+	ts.Equal(3, results[0].Attempts)
+	ts.Len(results[0].AttemptErrors, 3)
+	ts.EqualError(results[0].AttemptErrors[0], "attempt 1 failed")
+	ts.EqualError(results[0].AttemptErrors[1], "attempt 2 failed")
+	ts.NoError(results[0].AttemptErrors[2])
+	ts.Len(results[0].AttemptDurations, 3)
 }
 
 func (ts *WorkerPoolTestSuite) TestContextCancellation() {
@@ -313,6 +321,79 @@ func (ts *WorkerPoolTestSuite) TestConcurrentAccess() {
 	ts.Equal(10, pool.metrics.TotalJobs)
 }
 
+func (ts *WorkerPoolTestSuite) TestConcurrentAddJobsAppends() {
+	pool := New[int, int]()
+	pool.WithProcessor(func(ctx context.Context, job Job[int]) (int, error) {
+		return job.Data, nil
+	})
+
+	// Producers batch jobs while a run is in progress and settings change
+	var wg sync.WaitGroup
+	for p := 0; p < 8; p++ {
+		wg.Add(1)
+		go func(p int) {
+			defer wg.Done()
+			var batch []Job[int]
+			for i := 0; i < 25; i++ {
+				batch = append(batch, Job[int]{ID: fmt.Sprintf("%d-%d", p, i), Data: i})
+			}
+			pool.AddJobs(batch[:10])
+			pool.AddJobs(batch[10:])
+		}(p)
+	}
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		pool.Run()
+	}()
+	go func() {
+		defer wg.Done()
+		pool.WithProcessor(func(ctx context.Context, job Job[int]) (int, error) {
+			return job.Data, nil
+		})
+		pool.WithEnricher(func(ctx context.Context, job Job[int]) (Job[int], error) {
+			return job, nil
+		}, EnrichmentOptions{})
+	}()
+	wg.Wait()
+	ts.Len(pool.jobs, 200)
+	ts.Equal(200, pool.GetMetrics().TotalJobs)
+
+	results, err := pool.Run()
+	ts.NoError(err)
+	ts.Len(results, 200)
+
+	pool.SetJobs([]Job[int]{{ID: "only"}})
+	ts.Len(pool.jobs, 1)
+	ts.Equal(1, pool.GetMetrics().TotalJobs)
+}
+
+func (ts *WorkerPoolTestSuite) TestRunsProcessTheWholeBatch() {
+	pool := New[int, int]()
+	pool.WithProcessor(func(ctx context.Context, job Job[int]) (int, error) {
+		return job.Data, nil
+	})
+
+	pool.AddJobs([]Job[int]{{ID: "1", Data: 1}})
+	results, err := pool.Run()
+	ts.NoError(err)
+	ts.Len(results, 1)
+
+	// Jobs stay in the batch, so the next run processes them again
+	pool.AddJobs([]Job[int]{{ID: "2", Data: 2}})
+	results, err = pool.Run()
+	ts.NoError(err)
+	ts.Len(results, 2)
+	ts.Equal(3, pool.GetMetrics().ProcessedJobs)
+
+	// SetJobs starts a new batch
+	pool.SetJobs([]Job[int]{{ID: "3", Data: 3}})
+	results, err = pool.Run()
+	ts.NoError(err)
+	ts.Require().Len(results, 1)
+	ts.Equal("3", results[0].JobID)
+}
+
 func (ts *WorkerPoolTestSuite) TestJobPriority() {
 	pool := New[string, string]()
 
@@ -0,0 +1,153 @@
+package workerpool
+
+import (
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// WorkerStats describes how busy one worker has been across runs. A worker
+// that is mostly idle while jobs are pending is starved by dispatch, limits
+// or dependencies; one that is never idle is overloaded.
+type WorkerStats struct {
+	ID       int           `json:"id"`
+	InFlight int           `json:"in_flight"` // Jobs the worker is executing now
+	Jobs     int           `json:"jobs"`      // Jobs the worker has executed
+	Busy     time.Duration `json:"busy_ns"`   // Time spent executing jobs, including retries
+	Idle     time.Duration `json:"idle_ns"`   // Run time spent not executing a job, e.g. waiting for work or a limit
+}
+
+// InFlightJob is a job executing right now, as listed by InFlight
+type InFlightJob struct {
+	JobID    string        `json:"job_id"`
+	WorkerID int           `json:"worker_id"`
+	Started  time.Time     `json:"started"`
+	Elapsed  time.Duration `json:"elapsed_ns"` // Time since Started, including retries and their backoff
+}
+
+// workerGauge is the live state of one worker
+type workerGauge struct {
+	inFlight atomic.Int32
+	jobs     atomic.Int64
+	busy     atomic.Int64 // Nanoseconds spent in finished jobs
+	since    atomic.Int64 // Unix nanoseconds the current job started; zero when idle
+	current  atomic.Pointer[InFlightJob]
+}
+
+// workerGauges tracks every worker and the time runs have been going
+type workerGauges struct {
+	gauges   []*workerGauge
+	runTime  time.Duration // Summed length of finished runs
+	runStart time.Time     // Start of the current run; zero between runs
+	mu       sync.Mutex
+}
+
+// get returns the gauge of a worker, creating it on first use
+func (g *workerGauges) get(id int) *workerGauge {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.growLocked(id + 1)
+	return g.gauges[id]
+}
+
+// growLocked makes sure there are gauges for n workers. Callers must hold g.mu.
+func (g *workerGauges) growLocked(n int) {
+	for len(g.gauges) < n {
+		g.gauges = append(g.gauges, &workerGauge{})
+	}
+}
+
+// begin marks a worker as executing a job and returns the function that
+// marks it finished
+func (g *workerGauges) begin(id int, jobID string) (end func()) {
+	gauge := g.get(id)
+	start := time.Now()
+	gauge.since.Store(start.UnixNano())
+	gauge.current.Store(&InFlightJob{JobID: jobID, WorkerID: id, Started: start})
+	gauge.inFlight.Add(1)
+	return func() {
+		gauge.busy.Add(int64(time.Since(start)))
+		gauge.jobs.Add(1)
+		gauge.inFlight.Add(-1)
+		gauge.current.Store(nil)
+		gauge.since.Store(0)
+	}
+}
+
+// startRun and endRun bracket a run of the given number of workers, the
+// time idle time is measured in
+func (g *workerGauges) startRun(workers int) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.growLocked(workers)
+	g.runStart = time.Now()
+}
+
+func (g *workerGauges) endRun() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.runTime += time.Since(g.runStart)
+	g.runStart = time.Time{}
+}
+
+// snapshot returns the stats of every worker as of now
+func (g *workerGauges) snapshot(now time.Time) []WorkerStats {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	runTime := g.runTime
+	if !g.runStart.IsZero() {
+		runTime += now.Sub(g.runStart)
+	}
+	stats := make([]WorkerStats, len(g.gauges))
+	for id, gauge := range g.gauges {
+		busy := time.Duration(gauge.busy.Load())
+		if since := gauge.since.Load(); since != 0 {
+			busy += now.Sub(time.Unix(0, since))
+		}
+		idle := runTime - busy
+		if idle < 0 {
+			idle = 0
+		}
+		stats[id] = WorkerStats{
+			ID:       id,
+			InFlight: int(gauge.inFlight.Load()),
+			Jobs:     int(gauge.jobs.Load()),
+			Busy:     busy,
+			Idle:     idle,
+		}
+	}
+	return stats
+}
+
+// inFlight returns the jobs executing as of now, longest running first
+func (g *workerGauges) inFlight(now time.Time) []InFlightJob {
+	g.mu.Lock()
+	gauges := append([]*workerGauge(nil), g.gauges...)
+	g.mu.Unlock()
+
+	var jobs []InFlightJob
+	for _, gauge := range gauges {
+		if current := gauge.current.Load(); current != nil {
+			job := *current
+			job.Elapsed = now.Sub(job.Started)
+			jobs = append(jobs, job)
+		}
+	}
+	sort.Slice(jobs, func(i, j int) bool { return jobs[i].Started.Before(jobs[j].Started) })
+	return jobs
+}
+
+// InFlight returns the jobs executing right now with the worker running each
+// and how long it has been running, longest running first. It is the place
+// to start when a run seems stuck.
+func (wp *WorkerPool[T, R]) InFlight() []InFlightJob {
+	return wp.workers.inFlight(time.Now())
+}
+
+// WorkerStats returns the in-flight jobs and busy and idle time of every
+// worker that has run a job, indexed by worker ID
+func (wp *WorkerPool[T, R]) WorkerStats() []WorkerStats {
+	return wp.workers.snapshot(time.Now())
+}
@@ -0,0 +1,95 @@
+package workerpool
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+func (ts *WorkerPoolTestSuite) TestWorkerStats() {
+	config := DefaultConfig()
+	config.NumWorkers = 2
+	config.Strategy = RoundRobin
+	pool := NewWithConfig[int, int](config)
+
+	release := make(chan struct{})
+	started := make(chan struct{})
+	pool.WithProcessor(func(ctx context.Context, job Job[int]) (int, error) {
+		if job.ID == "block" {
+			close(started)
+			<-release
+		}
+		time.Sleep(time.Duration(job.Data) * time.Millisecond)
+		return job.Data, nil
+	})
+	// Worker 0 gets the blocking job and three slow ones; worker 1 one quick one
+	jobs := []Job[int]{{ID: "block"}, {ID: "quick"}}
+	for i := 0; i < 3; i++ {
+		jobs = append(jobs, Job[int]{ID: fmt.Sprint("slow", i), Data: 10}, Job[int]{ID: fmt.Sprint("none", i)})
+	}
+	pool.AddJobs(jobs)
+
+	done := make(chan error)
+	go func() {
+		_, err := pool.Run()
+		done <- err
+	}()
+	<-started
+	ts.Eventually(func() bool {
+		stats := pool.WorkerStats()
+		return len(stats) == 2 && stats[0].InFlight == 1 && stats[1].Jobs == 4
+	}, 5*time.Second, time.Millisecond)
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	ts.NoError(<-done)
+
+	stats := pool.WorkerStats()
+	ts.Require().Len(stats, 2)
+	ts.Equal(0, stats[0].InFlight)
+	ts.Equal(4, stats[0].Jobs)
+	ts.Equal(4, stats[1].Jobs)
+	ts.GreaterOrEqual(stats[0].Busy, 50*time.Millisecond)
+	ts.Greater(stats[1].Idle, stats[0].Idle, "the quick worker waited on the slow one")
+	ts.Greater(stats[1].Idle, 20*time.Millisecond)
+
+	// Idle time stops accruing between runs
+	time.Sleep(10 * time.Millisecond)
+	ts.Equal(stats[1].Idle, pool.WorkerStats()[1].Idle)
+	ts.Len(pool.Snapshot().Workers, 2)
+}
+
+func (ts *WorkerPoolTestSuite) TestInFlight() {
+	config := DefaultConfig()
+	config.NumWorkers = 2
+	config.Strategy = RoundRobin
+	pool := NewWithConfig[int, int](config)
+
+	release := make(chan struct{})
+	pool.WithProcessor(func(ctx context.Context, job Job[int]) (int, error) {
+		if job.ID != "quick" {
+			<-release
+		}
+		return job.Data, nil
+	})
+	// Worker 0 finishes the quick job before it sticks
+	pool.AddJobs([]Job[int]{{ID: "quick"}, {ID: "stuck"}, {ID: "later"}})
+	ts.Empty(pool.InFlight())
+
+	done := make(chan error)
+	go func() {
+		_, err := pool.Run()
+		done <- err
+	}()
+	ts.Require().Eventually(func() bool { return len(pool.InFlight()) == 2 }, 5*time.Second, time.Millisecond)
+	time.Sleep(10 * time.Millisecond)
+
+	jobs := pool.InFlight()
+	ts.ElementsMatch([]string{"stuck", "later"}, []string{jobs[0].JobID, jobs[1].JobID})
+	ts.NotEqual(jobs[0].WorkerID, jobs[1].WorkerID)
+	ts.False(jobs[1].Started.Before(jobs[0].Started), "longest running first")
+	ts.GreaterOrEqual(jobs[0].Elapsed, 10*time.Millisecond)
+
+	close(release)
+	ts.NoError(<-done)
+	ts.Empty(pool.InFlight())
+}
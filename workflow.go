@@ -0,0 +1,345 @@
+package workerpool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// ErrBranchNotTaken is reported for the jobs of a workflow stage whose When
+// condition was false. Stages after it are skipped with ErrDependencyFailed.
+var ErrBranchNotTaken = errors.New("workflow branch not taken")
+
+// WorkflowState is the state of a workflow or one of its stages
+type WorkflowState int
+
+const (
+	WorkflowPending WorkflowState = iota
+	WorkflowRunning
+	WorkflowSucceeded
+	WorkflowFailed
+	WorkflowSkipped
+)
+
+// String returns the state's name
+func (s WorkflowState) String() string {
+	switch s {
+	case WorkflowPending:
+		return "pending"
+	case WorkflowRunning:
+		return "running"
+	case WorkflowSucceeded:
+		return "succeeded"
+	case WorkflowFailed:
+		return "failed"
+	case WorkflowSkipped:
+		return "skipped"
+	default:
+		return fmt.Sprintf("WorkflowState(%d)", int(s))
+	}
+}
+
+// StageStatus reports the progress of one workflow stage
+type StageStatus struct {
+	Name      string
+	State     WorkflowState
+	Jobs      int
+	Succeeded int
+	Failed    int
+	Skipped   int // Jobs skipped by a false When condition or a failed upstream stage
+}
+
+// WorkflowStatus reports the progress of a workflow and its stages in the
+// order they were added
+type WorkflowStatus struct {
+	State  WorkflowState
+	Stages []StageStatus
+}
+
+// Workflow runs named stages of jobs in dependency order on a single pool.
+// Each stage has its own processor and concurrency limit and may run only
+// when a condition on its upstream results holds:
+//
+//	wf := NewWorkflow[string, string]()
+//	wf.Stage("fetch", fetch).Jobs(jobs...).Concurrency(4)
+//	wf.Stage("parse", parse).Jobs(parseJobs...).After("fetch")
+//	wf.Stage("alert", alert).Jobs(alertJob).After("parse").When(anyFailed)
+//	results, err := wf.Run(DefaultConfig())
+//
+// A stage's jobs depend on every job of the stages it comes after, so a
+// failure upstream skips the stages below it, except those with a When
+// condition: they wait for their upstream stages to finish, whatever the
+// outcome, and the condition decides. Job IDs are prefixed with the stage
+// name, e.g. "fetch/page-1".
+type Workflow[T any, R any] struct {
+	stages []*Stage[T, R]
+	byName map[string]*Stage[T, R]
+	mu     sync.Mutex
+}
+
+// Stage is one step of a Workflow, configured through its chainable methods
+type Stage[T any, R any] struct {
+	name        string
+	processor   Processor[T, R]
+	jobs        []Job[T]
+	after       []string
+	when        func(upstream map[string][]Result[R]) bool
+	concurrency int
+
+	// Progress, guarded by workflow.mu
+	started   bool
+	decided   bool
+	taken     bool
+	results   []Result[R]
+	succeeded int
+	failed    int
+	skipped   int
+}
+
+// NewWorkflow creates an empty workflow
+func NewWorkflow[T any, R any]() *Workflow[T, R] {
+	return &Workflow[T, R]{byName: make(map[string]*Stage[T, R])}
+}
+
+// Stage adds a stage whose jobs are handled by processor
+func (w *Workflow[T, R]) Stage(name string, processor Processor[T, R]) *Stage[T, R] {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	s := &Stage[T, R]{name: name, processor: processor}
+	w.stages = append(w.stages, s)
+	if _, dup := w.byName[name]; !dup {
+		w.byName[name] = s
+	}
+	return s
+}
+
+// Jobs adds jobs to the stage
+func (s *Stage[T, R]) Jobs(jobs ...Job[T]) *Stage[T, R] {
+	s.jobs = append(s.jobs, jobs...)
+	return s
+}
+
+// After makes the stage wait for every job of the named stages to succeed,
+// or with a When condition to finish
+func (s *Stage[T, R]) After(stages ...string) *Stage[T, R] {
+	s.after = append(s.after, stages...)
+	return s
+}
+
+// When runs the stage only if cond returns true. cond is called once, when
+// the upstream stages have finished, with their results by stage name,
+// including failures, so a stage can branch on an upstream failure.
+func (s *Stage[T, R]) When(cond func(upstream map[string][]Result[R]) bool) *Stage[T, R] {
+	s.when = cond
+	return s
+}
+
+// Concurrency limits how many of the stage's jobs run at once; zero means
+// no limit beyond the pool's workers. The limit is a Config.ClassConcurrency
+// cap on the job class "workflow/<stage>", which the stage's jobs are given
+// in place of their own, so jobs held back have not started and their
+// timeouts are not running.
+func (s *Stage[T, R]) Concurrency(n int) *Stage[T, R] {
+	s.concurrency = n
+	return s
+}
+
+// Run executes the workflow on a pool created with config and returns the
+// results of every stage's jobs. It fails without running anything if a
+// stage name is repeated, empty or contains "/", or if a stage comes after
+// an unknown stage.
+func (w *Workflow[T, R]) Run(config Config) ([]Result[R], error) {
+	if err := w.validate(); err != nil {
+		return nil, err
+	}
+
+	w.mu.Lock()
+	config = config.clone()
+	stageOf := make(map[string]*Stage[T, R])
+	var jobs []Job[T]
+	for _, s := range w.stages {
+		s.started, s.decided, s.taken, s.results = false, false, false, nil
+		s.succeeded, s.failed, s.skipped = 0, 0, 0
+		class := ""
+		if s.concurrency > 0 {
+			class = "workflow/" + s.name
+			if config.ClassConcurrency == nil {
+				config.ClassConcurrency = make(map[string]int)
+			}
+			config.ClassConcurrency[class] = s.concurrency
+		}
+		deps := w.upstreamJobsLocked(s, make(map[string]bool))
+		for _, job := range s.jobs {
+			job.ID = s.name + "/" + job.ID
+			if class != "" {
+				job.Class = class
+			}
+			job = job.DependsOn(deps...)
+			stageOf[job.ID] = s
+			jobs = append(jobs, job)
+		}
+	}
+	w.mu.Unlock()
+
+	pool := NewWithConfig[T, R](config)
+	pool.WithProcessor(func(ctx context.Context, job Job[T]) (R, error) {
+		s := stageOf[job.ID]
+		w.mu.Lock()
+		s.started = true
+		w.mu.Unlock()
+		return s.processor(ctx, job)
+	})
+	pool.gate = func(job Job[T]) error {
+		return w.decide(stageOf[job.ID])
+	}
+	pool.gateAll = func(job Job[T]) bool {
+		return stageOf[job.ID].when != nil
+	}
+	pool.AddJobs(jobs)
+
+	var results []Result[R]
+	_, err := pool.run(context.Background(), func(result Result[R]) {
+		w.record(stageOf[result.JobID], result)
+		results = append(results, result)
+	})
+	return results, err
+}
+
+// Status reports the progress of the workflow. It is safe to call while
+// the workflow runs.
+func (w *Workflow[T, R]) Status() WorkflowStatus {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	var status WorkflowStatus
+	failed, started, finished := false, false, true
+	for _, s := range w.stages {
+		st := StageStatus{
+			Name:      s.name,
+			Jobs:      len(s.jobs),
+			Succeeded: s.succeeded,
+			Failed:    s.failed,
+			Skipped:   s.skipped,
+		}
+		switch {
+		case st.Succeeded+st.Failed+st.Skipped < st.Jobs && s.started:
+			st.State = WorkflowRunning
+		case st.Succeeded+st.Failed+st.Skipped < st.Jobs:
+			st.State = WorkflowPending
+		case st.Failed > 0:
+			st.State = WorkflowFailed
+		case st.Jobs > 0 && st.Skipped == st.Jobs:
+			st.State = WorkflowSkipped
+		default:
+			st.State = WorkflowSucceeded
+		}
+		status.Stages = append(status.Stages, st)
+
+		failed = failed || st.State == WorkflowFailed
+		started = started || st.State != WorkflowPending
+		finished = finished && st.State != WorkflowPending && st.State != WorkflowRunning
+	}
+
+	switch {
+	case failed:
+		status.State = WorkflowFailed
+	case finished && len(status.Stages) > 0:
+		status.State = WorkflowSucceeded
+	case started:
+		status.State = WorkflowRunning
+	default:
+		status.State = WorkflowPending
+	}
+	return status
+}
+
+// validate checks stage names and references
+func (w *Workflow[T, R]) validate() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	seen := make(map[string]bool)
+	for _, s := range w.stages {
+		switch {
+		case s.name == "" || strings.Contains(s.name, "/"):
+			return fmt.Errorf("workflow stage %q: name must be non-empty and contain no '/'", s.name)
+		case seen[s.name]:
+			return fmt.Errorf("workflow stage %q: duplicate name", s.name)
+		case s.processor == nil:
+			return fmt.Errorf("workflow stage %q: no processor", s.name)
+		}
+		seen[s.name] = true
+	}
+	for _, s := range w.stages {
+		for _, name := range s.after {
+			if !seen[name] {
+				return fmt.Errorf("workflow stage %q: unknown upstream stage %q", s.name, name)
+			}
+		}
+	}
+	return nil
+}
+
+// upstreamJobsLocked returns the job IDs stage s depends on. Upstream stages
+// without jobs are looked through so ordering still holds across them.
+func (w *Workflow[T, R]) upstreamJobsLocked(s *Stage[T, R], visiting map[string]bool) []string {
+	if visiting[s.name] {
+		return nil // A cycle; the jobs involved are skipped when the run finds it
+	}
+	visiting[s.name] = true
+	defer delete(visiting, s.name)
+
+	var ids []string
+	for _, name := range s.after {
+		up := w.byName[name]
+		if len(up.jobs) == 0 {
+			ids = append(ids, w.upstreamJobsLocked(up, visiting)...)
+			continue
+		}
+		for _, job := range up.jobs {
+			ids = append(ids, up.name+"/"+job.ID)
+		}
+	}
+	return ids
+}
+
+// decide evaluates the stage's When condition once its upstream stages have
+// finished, returning ErrBranchNotTaken if the stage must not run
+func (w *Workflow[T, R]) decide(s *Stage[T, R]) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if s.when == nil {
+		return nil
+	}
+	if !s.decided {
+		upstream := make(map[string][]Result[R], len(s.after))
+		for _, name := range s.after {
+			upstream[name] = append([]Result[R](nil), w.byName[name].results...)
+		}
+		s.decided, s.taken = true, s.when(upstream)
+	}
+	if !s.taken {
+		return ErrBranchNotTaken
+	}
+	return nil
+}
+
+// record counts a finished job of stage s
+func (w *Workflow[T, R]) record(s *Stage[T, R], result Result[R]) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	s.results = append(s.results, result)
+	switch {
+	case errors.Is(result.Error, ErrBranchNotTaken) || errors.Is(result.Error, ErrDependencyFailed):
+		s.skipped++
+	case result.Error != nil:
+		s.failed++
+	default:
+		s.succeeded++
+	}
+}
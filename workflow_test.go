@@ -0,0 +1,213 @@
+package workerpool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+func (ts *WorkerPoolTestSuite) TestWorkflowRunsStagesInOrder() {
+	var fetched atomic.Int32
+	var inFlight, peak atomic.Int32
+
+	wf := NewWorkflow[int, int]()
+	wf.Stage("fetch", func(ctx context.Context, job Job[int]) (int, error) {
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+		fetched.Add(1)
+		return job.Data * 10, nil
+	}).Jobs(Job[int]{ID: "a", Data: 1}, Job[int]{ID: "b", Data: 2}, Job[int]{ID: "c", Data: 3}).Concurrency(1)
+
+	wf.Stage("sum", func(ctx context.Context, job Job[int]) (int, error) {
+		// Runs only once every fetch job has finished
+		return int(fetched.Load()), nil
+	}).Jobs(Job[int]{ID: "total"}).After("fetch")
+
+	config := DefaultConfig()
+	config.NumWorkers = 4
+	results, err := wf.Run(config)
+	ts.NoError(err)
+	ts.Len(results, 4)
+	ts.Equal(int32(1), peak.Load())
+
+	for _, r := range results {
+		if r.JobID == "sum/total" {
+			ts.Equal(3, r.Data)
+		} else {
+			ts.True(strings.HasPrefix(r.JobID, "fetch/"), r.JobID)
+		}
+	}
+
+	status := wf.Status()
+	ts.Equal(WorkflowSucceeded, status.State)
+	ts.Equal([]StageStatus{
+		{Name: "fetch", State: WorkflowSucceeded, Jobs: 3, Succeeded: 3},
+		{Name: "sum", State: WorkflowSucceeded, Jobs: 1, Succeeded: 1},
+	}, status.Stages)
+}
+
+func (ts *WorkerPoolTestSuite) TestWorkflowConcurrencyHoldsJobsBeforeTheyStart() {
+	config := DefaultConfig()
+	config.NumWorkers = 4
+	config.MaxRetries = 0
+	config.WorkerTimeout = 30 * time.Millisecond
+
+	// Together the jobs take far longer than the timeout, which only covers
+	// the time each one runs
+	wf := NewWorkflow[int, int]()
+	wf.Stage("export", func(ctx context.Context, job Job[int]) (int, error) {
+		select {
+		case <-time.After(10 * time.Millisecond):
+			return job.Data, nil
+		case <-ctx.Done():
+			return 0, ctx.Err()
+		}
+	}).Jobs(Job[int]{ID: "a"}, Job[int]{ID: "b"}, Job[int]{ID: "c"}, Job[int]{ID: "d"}, Job[int]{ID: "e"}).Concurrency(1)
+
+	results, err := wf.Run(config)
+	ts.NoError(err)
+	ts.Len(results, 5)
+	for _, r := range results {
+		ts.NoError(r.Error, r.JobID)
+	}
+}
+
+func (ts *WorkerPoolTestSuite) TestWorkflowConditionalBranches() {
+	wf := NewWorkflow[string, string]()
+	wf.Stage("check", func(ctx context.Context, job Job[string]) (string, error) {
+		return "unhealthy", nil
+	}).Jobs(Job[string]{ID: "probe"})
+
+	isHealthy := func(upstream map[string][]Result[string]) bool {
+		return upstream["check"][0].Data == "healthy"
+	}
+	wf.Stage("deploy", func(ctx context.Context, job Job[string]) (string, error) {
+		return "deployed", nil
+	}).Jobs(Job[string]{ID: "rollout"}).After("check").When(isHealthy)
+	wf.Stage("verify", func(ctx context.Context, job Job[string]) (string, error) {
+		return "verified", nil
+	}).Jobs(Job[string]{ID: "smoke"}).After("deploy")
+	wf.Stage("page", func(ctx context.Context, job Job[string]) (string, error) {
+		return "paged", nil
+	}).Jobs(Job[string]{ID: "oncall"}).After("check").When(func(upstream map[string][]Result[string]) bool {
+		return !isHealthy(upstream)
+	})
+
+	results, err := wf.Run(DefaultConfig())
+	ts.NoError(err)
+
+	byID := make(map[string]Result[string])
+	for _, r := range results {
+		byID[r.JobID] = r
+	}
+	ts.ErrorIs(byID["deploy/rollout"].Error, ErrBranchNotTaken)
+	ts.ErrorIs(byID["verify/smoke"].Error, ErrDependencyFailed)
+	ts.Equal("paged", byID["page/oncall"].Data)
+
+	status := wf.Status()
+	ts.Equal(WorkflowSucceeded, status.State)
+	states := make(map[string]WorkflowState)
+	for _, st := range status.Stages {
+		states[st.Name] = st.State
+	}
+	ts.Equal(map[string]WorkflowState{
+		"check":  WorkflowSucceeded,
+		"deploy": WorkflowSkipped,
+		"verify": WorkflowSkipped,
+		"page":   WorkflowSucceeded,
+	}, states)
+}
+
+func (ts *WorkerPoolTestSuite) TestWorkflowFailureBranch() {
+	config := DefaultConfig()
+	config.NumWorkers = 2
+	config.MaxRetries = 0
+
+	wf := NewWorkflow[int, string]()
+	wf.Stage("parse", func(ctx context.Context, job Job[int]) (string, error) {
+		if job.Data == 0 {
+			return "", errors.New("bad input")
+		}
+		// Still running when the other parse job fails
+		time.Sleep(20 * time.Millisecond)
+		return "parsed", nil
+	}).Jobs(Job[int]{ID: "bad", Data: 0}, Job[int]{ID: "slow", Data: 1})
+
+	var seen int
+	anyFailed := func(upstream map[string][]Result[string]) bool {
+		seen = len(upstream["parse"])
+		for _, r := range upstream["parse"] {
+			if r.Error != nil {
+				return true
+			}
+		}
+		return false
+	}
+	wf.Stage("alert", func(ctx context.Context, job Job[int]) (string, error) {
+		return "alerted", nil
+	}).Jobs(Job[int]{ID: "oncall"}).After("parse").When(anyFailed)
+	wf.Stage("publish", func(ctx context.Context, job Job[int]) (string, error) {
+		return "published", nil
+	}).Jobs(Job[int]{ID: "site"}).After("parse")
+
+	results, err := wf.Run(config)
+	ts.NoError(err)
+
+	byID := make(map[string]Result[string])
+	for _, r := range results {
+		byID[r.JobID] = r
+	}
+	ts.Equal("alerted", byID["alert/oncall"].Data)
+	ts.Equal(2, seen, "the condition sees every upstream outcome")
+	ts.ErrorIs(byID["publish/site"].Error, ErrDependencyFailed)
+	ts.Equal(WorkflowSucceeded, wf.Status().Stages[1].State)
+}
+
+func (ts *WorkerPoolTestSuite) TestWorkflowFailureStatus() {
+	config := DefaultConfig()
+	config.MaxRetries = 0
+
+	wf := NewWorkflow[int, int]()
+	ts.Equal(WorkflowPending, wf.Status().State)
+	wf.Stage("load", func(ctx context.Context, job Job[int]) (int, error) {
+		return 0, errors.New("disk full")
+	}).Jobs(Job[int]{ID: "x"})
+	wf.Stage("index", func(ctx context.Context, job Job[int]) (int, error) {
+		return 1, nil
+	}).Jobs(Job[int]{ID: "y"}).After("load")
+
+	_, err := wf.Run(config)
+	ts.NoError(err)
+
+	status := wf.Status()
+	ts.Equal(WorkflowFailed, status.State)
+	ts.Equal(WorkflowFailed, status.Stages[0].State)
+	ts.Equal(WorkflowSkipped, status.Stages[1].State)
+	ts.Equal("failed", status.State.String())
+}
+
+func (ts *WorkerPoolTestSuite) TestWorkflowValidation() {
+	noop := func(ctx context.Context, job Job[int]) (int, error) { return 0, nil }
+
+	for i, build := range []func(*Workflow[int, int]){
+		func(wf *Workflow[int, int]) { wf.Stage("a", noop); wf.Stage("a", noop) },
+		func(wf *Workflow[int, int]) { wf.Stage("a/b", noop) },
+		func(wf *Workflow[int, int]) { wf.Stage("a", noop).After("missing") },
+		func(wf *Workflow[int, int]) { wf.Stage("a", nil) },
+	} {
+		wf := NewWorkflow[int, int]()
+		build(wf)
+		_, err := wf.Run(DefaultConfig())
+		ts.Error(err, fmt.Sprintf("case %d", i))
+	}
+}